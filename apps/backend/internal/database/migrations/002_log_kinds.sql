@@ -0,0 +1,42 @@
+---- tern migration up
+
+-- Add log kind with kind-specific structured fields
+-- Existing logs become plain notes
+ALTER TABLE asset_logs
+  ADD COLUMN kind TEXT NOT NULL DEFAULT 'note'
+    CHECK (kind IN ('note', 'change', 'incident', 'maintenance')),
+  -- incident fields
+  ADD COLUMN severity TEXT
+    CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+  ADD COLUMN started_at TIMESTAMPTZ,
+  ADD COLUMN resolved_at TIMESTAMPTZ,
+  ADD COLUMN root_cause TEXT,
+  -- change fields
+  ADD COLUMN planned BOOLEAN,
+  ADD COLUMN rollback_notes TEXT,
+  -- maintenance fields
+  ADD COLUMN duration_minutes INTEGER
+    CHECK (duration_minutes >= 0);
+
+-- Incidents cannot be resolved before they started
+ALTER TABLE asset_logs
+  ADD CONSTRAINT asset_logs_incident_window
+    CHECK (resolved_at IS NULL OR started_at IS NULL OR resolved_at >= started_at);
+
+-- Create composite index for filtering logs by kind
+CREATE INDEX idx_asset_logs_user_kind ON asset_logs(user_id, kind);
+
+---- tern migration down
+
+DROP INDEX IF EXISTS idx_asset_logs_user_kind;
+
+ALTER TABLE asset_logs
+  DROP CONSTRAINT IF EXISTS asset_logs_incident_window,
+  DROP COLUMN IF EXISTS duration_minutes,
+  DROP COLUMN IF EXISTS rollback_notes,
+  DROP COLUMN IF EXISTS planned,
+  DROP COLUMN IF EXISTS root_cause,
+  DROP COLUMN IF EXISTS resolved_at,
+  DROP COLUMN IF EXISTS started_at,
+  DROP COLUMN IF EXISTS severity,
+  DROP COLUMN IF EXISTS kind;
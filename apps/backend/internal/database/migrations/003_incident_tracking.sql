@@ -0,0 +1,43 @@
+---- tern migration up
+
+-- Add incident lifecycle state to incident logs
+ALTER TABLE asset_logs
+  ADD COLUMN incident_status TEXT
+    CHECK (incident_status IN ('open', 'mitigated', 'resolved')),
+  ADD COLUMN mitigated_at TIMESTAMPTZ;
+
+-- Backfill lifecycle state for existing incidents
+UPDATE asset_logs
+SET incident_status = CASE WHEN resolved_at IS NULL THEN 'open' ELSE 'resolved' END
+WHERE kind = 'incident';
+
+-- Create partial index for incident statistics over time
+CREATE INDEX idx_asset_logs_incidents ON asset_logs(user_id, started_at)
+  WHERE kind = 'incident';
+
+-- Create incident_timeline_entries table
+CREATE TABLE incident_timeline_entries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  log_id UUID NOT NULL REFERENCES asset_logs(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  status TEXT CHECK (status IN ('open', 'mitigated', 'resolved')),
+  note TEXT,
+  occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create index for reading an incident's timeline in order
+CREATE INDEX idx_incident_timeline_entries_log_id ON incident_timeline_entries(log_id, occurred_at);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_incident_timeline_entries_user_id ON incident_timeline_entries(user_id);
+
+---- tern migration down
+
+DROP TABLE IF EXISTS incident_timeline_entries CASCADE;
+
+DROP INDEX IF EXISTS idx_asset_logs_incidents;
+
+ALTER TABLE asset_logs
+  DROP COLUMN IF EXISTS mitigated_at,
+  DROP COLUMN IF EXISTS incident_status;
@@ -0,0 +1,37 @@
+---- tern migration up
+
+-- Create maintenance_tasks table
+CREATE TABLE maintenance_tasks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  title TEXT NOT NULL,
+  description TEXT,
+  recurrence TEXT NOT NULL,
+  starts_at TIMESTAMPTZ NOT NULL,
+  next_due_at TIMESTAMPTZ,
+  last_completed_at TIMESTAMPTZ,
+  last_reminded_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_maintenance_tasks_user_id ON maintenance_tasks(user_id);
+
+-- Create index on asset_id for efficient joins
+CREATE INDEX idx_maintenance_tasks_asset_id ON maintenance_tasks(asset_id);
+
+-- Create partial index for the overdue reminder scan
+CREATE INDEX idx_maintenance_tasks_next_due_at ON maintenance_tasks(next_due_at)
+  WHERE next_due_at IS NOT NULL;
+
+-- Create trigger to auto-update updated_at on maintenance_tasks table
+CREATE TRIGGER set_maintenance_tasks_timestamp
+  BEFORE UPDATE ON maintenance_tasks
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_set_timestamp();
+
+---- tern migration down
+
+DROP TABLE IF EXISTS maintenance_tasks CASCADE;
@@ -0,0 +1,86 @@
+---- tern migration up
+
+-- Create runbooks table
+CREATE TABLE runbooks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL,
+  title TEXT NOT NULL,
+  description TEXT,
+  asset_types TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_runbooks_user_id ON runbooks(user_id);
+
+-- Create GIN index for looking up runbooks by asset type
+CREATE INDEX idx_runbooks_asset_types ON runbooks USING GIN (asset_types);
+
+-- Create trigger to auto-update updated_at on runbooks table
+CREATE TRIGGER set_runbooks_timestamp
+  BEFORE UPDATE ON runbooks
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Create runbook_steps table
+CREATE TABLE runbook_steps (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  runbook_id UUID NOT NULL REFERENCES runbooks(id) ON DELETE CASCADE,
+  position INT NOT NULL CHECK (position > 0),
+  title TEXT NOT NULL,
+  body TEXT,
+  UNIQUE (runbook_id, position)
+);
+
+-- Create runbook_assets table for runbooks attached to individual assets
+CREATE TABLE runbook_assets (
+  runbook_id UUID NOT NULL REFERENCES runbooks(id) ON DELETE CASCADE,
+  asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+  PRIMARY KEY (runbook_id, asset_id)
+);
+
+-- Create index for looking up runbooks by asset
+CREATE INDEX idx_runbook_assets_asset_id ON runbook_assets(asset_id);
+
+-- Create runbook_runs table
+CREATE TABLE runbook_runs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  runbook_id UUID REFERENCES runbooks(id) ON DELETE SET NULL,
+  asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  title TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'in_progress'
+    CHECK (status IN ('in_progress', 'completed', 'aborted')),
+  notes TEXT,
+  log_id UUID REFERENCES asset_logs(id) ON DELETE SET NULL,
+  started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  finished_at TIMESTAMPTZ
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_runbook_runs_user_id ON runbook_runs(user_id);
+
+-- Create index for listing runs per asset
+CREATE INDEX idx_runbook_runs_asset_id ON runbook_runs(asset_id, started_at DESC);
+
+-- Create runbook_run_steps table (steps are copied from the runbook when a run starts)
+CREATE TABLE runbook_run_steps (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  run_id UUID NOT NULL REFERENCES runbook_runs(id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  title TEXT NOT NULL,
+  body TEXT,
+  checked BOOLEAN NOT NULL DEFAULT false,
+  checked_at TIMESTAMPTZ,
+  notes TEXT,
+  UNIQUE (run_id, position)
+);
+
+---- tern migration down
+
+DROP TABLE IF EXISTS runbook_run_steps CASCADE;
+DROP TABLE IF EXISTS runbook_runs CASCADE;
+DROP TABLE IF EXISTS runbook_assets CASCADE;
+DROP TABLE IF EXISTS runbook_steps CASCADE;
+DROP TABLE IF EXISTS runbooks CASCADE;
@@ -0,0 +1,30 @@
+---- tern migration up
+
+-- Create log_templates table
+CREATE TABLE log_templates (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT,
+  kind TEXT NOT NULL DEFAULT 'note'
+    CHECK (kind IN ('note', 'change', 'incident', 'maintenance')),
+  content TEXT NOT NULL,
+  tags TEXT[],
+  placeholders JSONB NOT NULL DEFAULT '[]',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, name)
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_log_templates_user_id ON log_templates(user_id);
+
+-- Create trigger to auto-update updated_at on log_templates table
+CREATE TRIGGER set_log_templates_timestamp
+  BEFORE UPDATE ON log_templates
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_set_timestamp();
+
+---- tern migration down
+
+DROP TABLE IF EXISTS log_templates CASCADE;
@@ -0,0 +1,21 @@
+---- tern migration up
+
+-- Link logs to additional assets. asset_logs.asset_id remains the primary asset;
+-- log_assets holds every other asset the log applies to.
+CREATE TABLE log_assets (
+  log_id UUID NOT NULL REFERENCES asset_logs(id) ON DELETE CASCADE,
+  asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (log_id, asset_id)
+);
+
+-- Index for listing the logs linked to an asset
+CREATE INDEX idx_log_assets_asset_id ON log_assets(asset_id);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_log_assets_user_id ON log_assets(user_id);
+
+---- tern migration down
+
+DROP TABLE IF EXISTS log_assets CASCADE;
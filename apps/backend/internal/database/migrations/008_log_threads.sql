@@ -0,0 +1,20 @@
+---- tern migration up
+
+-- Threads: a reply points at the log it follows up on and at the root of its thread.
+-- thread_root_id is NULL for root logs so a thread is read with one indexed lookup.
+-- Deleting a log deletes its replies.
+ALTER TABLE asset_logs
+  ADD COLUMN parent_log_id UUID REFERENCES asset_logs(id) ON DELETE CASCADE,
+  ADD COLUMN thread_root_id UUID REFERENCES asset_logs(id) ON DELETE CASCADE;
+
+-- Index for thread retrieval and reply counts
+CREATE INDEX idx_asset_logs_thread_root_id ON asset_logs(thread_root_id)
+  WHERE thread_root_id IS NOT NULL;
+
+---- tern migration down
+
+DROP INDEX IF EXISTS idx_asset_logs_thread_root_id;
+
+ALTER TABLE asset_logs
+  DROP COLUMN IF EXISTS thread_root_id,
+  DROP COLUMN IF EXISTS parent_log_id;
@@ -0,0 +1,15 @@
+---- tern migration up
+
+-- Add tags to assets (e.g. prod, critical, basement), normalised like log tags
+ALTER TABLE assets
+  ADD COLUMN tags TEXT[];
+
+-- Create partial GIN index on tags for tag filtering
+CREATE INDEX idx_assets_tags ON assets USING GIN (tags) WHERE tags IS NOT NULL;
+
+---- tern migration down
+
+DROP INDEX IF EXISTS idx_assets_tags;
+
+ALTER TABLE assets
+  DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,27 @@
+---- tern migration up
+
+-- Pinned logs and assets list first; favorites can be filtered on
+ALTER TABLE asset_logs
+  ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN favorite BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE assets
+  ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN favorite BOOLEAN NOT NULL DEFAULT false;
+
+-- Flags are set on few rows, so partial indexes stay small
+CREATE INDEX idx_asset_logs_favorite ON asset_logs (user_id) WHERE favorite;
+CREATE INDEX idx_assets_favorite ON assets (user_id) WHERE favorite;
+
+---- tern migration down
+
+DROP INDEX IF EXISTS idx_assets_favorite;
+DROP INDEX IF EXISTS idx_asset_logs_favorite;
+
+ALTER TABLE assets
+  DROP COLUMN IF EXISTS favorite,
+  DROP COLUMN IF EXISTS pinned;
+
+ALTER TABLE asset_logs
+  DROP COLUMN IF EXISTS favorite,
+  DROP COLUMN IF EXISTS pinned;
@@ -0,0 +1,30 @@
+---- tern migration up
+
+-- Create saved_views table: named asset or log list filters
+CREATE TABLE saved_views (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  description TEXT,
+  target TEXT NOT NULL CHECK (target IN ('assets', 'logs')),
+  asset_id UUID REFERENCES assets(id) ON DELETE CASCADE,
+  params JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, name),
+  -- Log views list the logs of one asset; asset views span all assets
+  CHECK ((target = 'logs') = (asset_id IS NOT NULL))
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_saved_views_user_id ON saved_views(user_id);
+
+-- Create trigger to auto-update updated_at on saved_views table
+CREATE TRIGGER set_saved_views_timestamp
+  BEFORE UPDATE ON saved_views
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_set_timestamp();
+
+---- tern migration down
+
+DROP TABLE IF EXISTS saved_views CASCADE;
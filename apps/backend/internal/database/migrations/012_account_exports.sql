@@ -0,0 +1,35 @@
+---- tern migration up
+
+-- Create account_exports table: account archives built in the background.
+-- The archive is kept in the database so any instance can serve the download.
+CREATE TABLE account_exports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending'
+    CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+  error TEXT,
+  archive BYTEA,
+  size_bytes BIGINT,
+  asset_count INTEGER,
+  log_count INTEGER,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ,
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_account_exports_user_id ON account_exports(user_id);
+
+-- Index for deleting expired exports
+CREATE INDEX idx_account_exports_expires_at ON account_exports(expires_at);
+
+-- Create trigger to auto-update updated_at on account_exports table
+CREATE TRIGGER set_account_exports_timestamp
+  BEFORE UPDATE ON account_exports
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_set_timestamp();
+
+---- tern migration down
+
+DROP TABLE IF EXISTS account_exports CASCADE;
@@ -0,0 +1,21 @@
+---- tern migration up
+
+-- Create api_tokens table: long-lived tokens for scripts calling the
+-- integration endpoints. Only a SHA-256 hash of each token is stored.
+CREATE TABLE api_tokens (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  token_hash TEXT NOT NULL UNIQUE,
+  token_prefix TEXT NOT NULL,
+  last_used_at TIMESTAMPTZ,
+  expires_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_api_tokens_user_id ON api_tokens(user_id);
+
+---- tern migration down
+
+DROP TABLE IF EXISTS api_tokens CASCADE;
@@ -0,0 +1,29 @@
+---- tern migration up
+
+-- Create asset_ports table: the network services an asset exposes, such as
+-- node_exporter on 9100/tcp. Read by the Prometheus service discovery endpoint.
+CREATE TABLE asset_ports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  port INTEGER NOT NULL CHECK (port BETWEEN 1 AND 65535),
+  protocol TEXT NOT NULL DEFAULT 'tcp' CHECK (protocol IN ('tcp', 'udp')),
+  service TEXT,
+  description TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (asset_id, port, protocol)
+);
+
+-- Create index on user_id for security and multi-tenancy
+CREATE INDEX idx_asset_ports_user_id ON asset_ports(user_id);
+
+-- Create trigger to auto-update updated_at on asset_ports table
+CREATE TRIGGER set_asset_ports_timestamp
+  BEFORE UPDATE ON asset_ports
+  FOR EACH ROW
+  EXECUTE FUNCTION trigger_set_timestamp();
+
+---- tern migration down
+
+DROP TABLE IF EXISTS asset_ports CASCADE;
@@ -0,0 +1,8 @@
+---- tern migration up
+
+-- Record when a port was last found open by a network scan
+ALTER TABLE asset_ports ADD COLUMN last_seen_at TIMESTAMPTZ;
+
+---- tern migration down
+
+ALTER TABLE asset_ports DROP COLUMN IF EXISTS last_seen_at;
@@ -0,0 +1,175 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for log activity over time.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// ActivityHandler handles HTTP requests for log activity aggregations.
+//
+// Routes:
+//   - GET /api/v1/activity/heatmap - Daily log counts over a year
+//   - GET /api/v1/assets/:id/activity/heatmap - Daily log counts over a year for one asset
+//   - GET /api/v1/activity/timeline - Log counts per interval, by tag or asset type
+//
+// All endpoints require authentication via the auth middleware.
+type ActivityHandler struct {
+	service *service.ActivityService
+}
+
+// NewActivityHandler creates a new ActivityHandler with the given ActivityService.
+func NewActivityHandler(service *service.ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		service: service,
+	}
+}
+
+// Heatmap handles GET /api/v1/activity/heatmap
+//
+// Returns the number of logs created on each day, for a contribution-style
+// heatmap. Days are calendar days in the requested time zone, and days
+// without logs are included with a count of zero.
+//
+// Query Parameters:
+//   - year: Calendar year to cover (default: the 365 days up to today)
+//   - tz: IANA time zone such as Europe/Berlin (default: UTC)
+//
+// Response:
+//   - 200 OK: Returns ActivityHeatmap
+//   - 400 Bad Request: Invalid year or time zone
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Response:
+//
+//	{
+//	  "tz": "Europe/Berlin",
+//	  "start": "2023-06-01",
+//	  "end": "2024-05-30",
+//	  "total": 412,
+//	  "max": 9,
+//	  "days": [{"date": "2023-06-01", "count": 0}, {"date": "2023-06-02", "count": 3}]
+//	}
+func (h *ActivityHandler) Heatmap(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.ActivityHeatmapQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.Heatmap(c.Request().Context(), userID, nil, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// AssetHeatmap handles GET /api/v1/assets/:id/activity/heatmap
+//
+// Same as Heatmap, counting only the logs whose primary or linked asset is :id.
+//
+// Response:
+//   - 200 OK: Returns ActivityHeatmap
+//   - 400 Bad Request: Invalid asset ID, year or time zone
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset not found or not owned by user
+func (h *ActivityHandler) AssetHeatmap(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate asset ID from URL parameter
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid asset id")
+	}
+
+	// Parse query parameters
+	var params model.ActivityHeatmapQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.Heatmap(c.Request().Context(), userID, &assetID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Timeline handles GET /api/v1/activity/timeline
+//
+// Returns the number of logs created in each interval between start and end,
+// broken down by tag or by the type of the log's primary asset. Intervals
+// start at local boundaries in the requested time zone; weeks start on Monday.
+// A log with several tags counts once per tag in groups and once in total.
+//
+// Query Parameters:
+//   - start: RFC3339 start of the window (default: 30 days before end)
+//   - end: RFC3339 end of the window, exclusive (default: now)
+//   - interval: hour, day, week or month (default: day)
+//   - group_by: tag or asset_type (default: tag)
+//   - asset_id: Only count logs for this asset (primary or linked)
+//   - tz: IANA time zone such as Europe/Berlin (default: UTC)
+//
+// Response:
+//   - 200 OK: Returns ActivityTimeline
+//   - 400 Bad Request: Invalid parameters or more than 1000 intervals
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset not found or not owned by user
+//
+// Example Response:
+//
+//	{
+//	  "start": "2024-05-01T00:00:00Z",
+//	  "end": "2024-05-31T00:00:00Z",
+//	  "interval": "week",
+//	  "group_by": "tag",
+//	  "tz": "UTC",
+//	  "buckets": [
+//	    {"start": "2024-04-29T00:00:00Z", "total": 6, "groups": {"zfs": 4, "backup": 2, "untagged": 1}}
+//	  ]
+//	}
+func (h *ActivityHandler) Timeline(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.ActivityTimelineQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.Timeline(c.Request().Context(), userID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
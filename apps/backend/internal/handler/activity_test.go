@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestActivityHandler_AssetHeatmap_InvalidID verifies 400 when asset ID is invalid
+func TestActivityHandler_AssetHeatmap_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewActivityHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/assets/invalid-uuid/activity/heatmap", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.AssetHeatmap(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestActivityHandler_Timeline_NoAuth verifies 401 when user is not authenticated
+func TestActivityHandler_Timeline_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewActivityHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/activity/timeline", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Timeline(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
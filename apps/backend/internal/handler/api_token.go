@@ -0,0 +1,122 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for API token operations.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/server"
+	"ark/internal/service"
+)
+
+// APITokenHandler handles HTTP requests for API tokens: long-lived tokens
+// that let scripts call the integration endpoints under /api/v1/integrations.
+// Tokens are sent as "Authorization: Bearer ark_..." and are not accepted by
+// any other endpoint.
+//
+// Routes:
+//   - GET    /api/v1/tokens     - List API tokens
+//   - POST   /api/v1/tokens     - Create API token
+//   - DELETE /api/v1/tokens/:id - Revoke API token
+//
+// All endpoints require authentication via the auth middleware.
+type APITokenHandler struct {
+	Handler
+	service *service.APITokenService
+}
+
+// NewAPITokenHandler creates a new APITokenHandler with the given server and APITokenService.
+func NewAPITokenHandler(s *server.Server, service *service.APITokenService) *APITokenHandler {
+	return &APITokenHandler{
+		Handler: NewHandler(s),
+		service: service,
+	}
+}
+
+// List handles GET /api/v1/tokens
+//
+// Response:
+//   - 200 OK: Returns APITokenListResponse, newest first. Tokens themselves are never listed.
+//   - 401 Unauthorized: Missing or invalid authentication
+func (h *APITokenHandler) List(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Create handles POST /api/v1/tokens
+//
+// Request Body (JSON):
+//   - name: What the token is for (required, max 100 chars)
+//   - expires_in_days: Days until the token stops working (optional, 1-3650; default: never)
+//
+// Response:
+//   - 201 Created: Returns CreatedAPIToken. The token is only ever shown here.
+//   - 400 Bad Request: Invalid body, or code API_TOKEN_LIMIT with 20 tokens already
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Response:
+//
+//	{"id": "550e8400-...", "user_id": "user_123", "name": "ansible on bastion",
+//	 "prefix": "ark_Q2x1c3", "created_at": "2024-06-01T12:00:00Z",
+//	 "token": "ark_Q2x1c3RlcnMgb2YgcmFuZG9tIGJ5dGVzIGdvIGhlcmU"}
+func (h *APITokenHandler) Create(c echo.Context) error {
+	return Handle(h.Handler, h.create, http.StatusCreated, &model.CreateAPITokenRequest{})(c)
+}
+
+func (h *APITokenHandler) create(c echo.Context, req *model.CreateAPITokenRequest) (*model.CreatedAPIToken, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	return h.service.Create(c.Request().Context(), userID, req)
+}
+
+// Delete handles DELETE /api/v1/tokens/:id
+//
+// Response:
+//   - 204 No Content: Token revoked; it stops working immediately
+//   - 400 Bad Request: Invalid token ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Token doesn't exist or belongs to another user
+func (h *APITokenHandler) Delete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate token ID from URL parameter
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid token id")
+	}
+
+	// Call service
+	err = h.service.Delete(c.Request().Context(), userID, tokenID)
+	if err != nil {
+		return err
+	}
+
+	// Return 204 No Content
+	return c.NoContent(http.StatusNoContent)
+}
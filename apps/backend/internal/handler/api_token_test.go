@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestAPITokenHandler_List_NoAuth verifies 401 when user is not authenticated
+func TestAPITokenHandler_List_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewAPITokenHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tokens", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.List(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestAPITokenHandler_Create_NoAuth verifies 401 when user is not authenticated
+func TestAPITokenHandler_Create_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewAPITokenHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tokens", strings.NewReader(`{"name": "ci"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Create(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestAPITokenHandler_Delete_InvalidID verifies 400 for a malformed token ID
+func TestAPITokenHandler_Delete_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewAPITokenHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/tokens/not-a-uuid", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+	c.SetParamNames("id")
+	c.SetParamValues("not-a-uuid")
+
+	// Act
+	err := handler.Delete(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
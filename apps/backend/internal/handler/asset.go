@@ -24,7 +24,9 @@ func NewAssetHandler(service *service.AssetService) *AssetHandler {
 }
 
 // List handles GET /api/v1/assets
-// Returns a paginated list of assets for the authenticated user
+// Returns a paginated list of assets for the authenticated user,
+// optionally filtered by tags (tag_mode=all by default, or any), by
+// favorite and by updated_within_days. Pinned assets always come first.
 func (h *AssetHandler) List(c echo.Context) error {
 	// Extract user_id from context (set by auth middleware)
 	userID, err := middleware.GetUserIDOrError(c)
@@ -52,7 +54,7 @@ func (h *AssetHandler) List(c echo.Context) error {
 }
 
 // Create handles POST /api/v1/assets
-// Creates a new asset for the authenticated user
+// Creates a new asset for the authenticated user, optionally pinned or favorite
 func (h *AssetHandler) Create(c echo.Context) error {
 	// Extract user_id from context
 	userID, err := middleware.GetUserIDOrError(c)
@@ -103,7 +105,8 @@ func (h *AssetHandler) GetByID(c echo.Context) error {
 }
 
 // Update handles PATCH /api/v1/assets/:id
-// Updates an existing asset for the authenticated user
+// Updates an existing asset for the authenticated user; pinned and favorite
+// are set the same way as the other fields
 func (h *AssetHandler) Update(c echo.Context) error {
 	// Extract user_id from context
 	userID, err := middleware.GetUserIDOrError(c)
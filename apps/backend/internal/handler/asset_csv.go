@@ -0,0 +1,183 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for asset CSV export and import.
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/server"
+	"ark/internal/service"
+)
+
+// AssetCSVHandler handles HTTP requests for moving asset inventories between
+// Ark and spreadsheets.
+//
+// Routes:
+//   - GET /api/v1/assets/csv - Export assets matching the list filters
+//   - POST /api/v1/assets/csv/preview - Read columns and suggest a mapping
+//   - POST /api/v1/assets/csv - Import assets, matched by name or hostname
+//
+// CSV layout:
+//
+//	id,name,type,hostname,tags,pinned,favorite,created_at,updated_at,metadata.<key>...
+//
+// Tags are comma-separated within their cell, and each metadata key is a column.
+//
+// All endpoints require authentication via the auth middleware.
+type AssetCSVHandler struct {
+	Handler
+	service *service.AssetCSVService
+}
+
+// NewAssetCSVHandler creates a new AssetCSVHandler with the given server and AssetCSVService.
+func NewAssetCSVHandler(s *server.Server, service *service.AssetCSVService) *AssetCSVHandler {
+	return &AssetCSVHandler{
+		Handler: NewHandler(s),
+		service: service,
+	}
+}
+
+// assetCSVFilename names a downloaded CSV after the current date
+func assetCSVFilename(now time.Time) string {
+	return "ark-assets-" + now.UTC().Format("20060102") + ".csv"
+}
+
+// Export handles GET /api/v1/assets/csv
+//
+// Query Parameters: the filters and sort of GET /api/v1/assets (type, search,
+// tags, tag_mode, favorite, updated_within_days, sort_by, sort_order).
+// limit and offset are ignored.
+//   - view_id: Export the assets of a saved asset view; its filters and sort
+//     replace the ones above
+//
+// Response:
+//   - 200 OK: text/csv attachment
+//   - 400 Bad Request: Invalid filters, a log view, or code CSV_TOO_LARGE over 5000 assets
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Saved view not found
+func (h *AssetCSVHandler) Export(c echo.Context) error {
+	return HandleFile(h.Handler, h.export, http.StatusOK, &model.AssetCSVExportRequest{}, assetCSVFilename(time.Now()), model.AssetCSVContentType)(c)
+}
+
+func (h *AssetCSVHandler) export(c echo.Context, req *model.AssetCSVExportRequest) ([]byte, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	return h.service.Export(c.Request().Context(), userID, req)
+}
+
+// Preview handles POST /api/v1/assets/csv/preview
+//
+// Reads a CSV uploaded as the multipart form field "file" (at most 10 MB)
+// without importing it. The suggested mapping assigns columns named like
+// asset fields to those fields, ignores id, created_at and updated_at, and
+// keeps every other column as a metadata key.
+//
+// Response:
+//   - 200 OK: Returns AssetCSVPreview
+//   - 400 Bad Request: Missing or malformed file
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Response:
+//
+//	{"columns": ["Name", "Host", "Rack"],
+//	 "mapping": {"Name": "name", "Host": "hostname", "Rack": "metadata.Rack"},
+//	 "rows": [["nas-01", "nas01.lan", "A2"]], "total_rows": 1}
+func (h *AssetCSVHandler) Preview(c echo.Context) error {
+	// Extract user_id from context
+	if _, err := middleware.GetUserIDOrError(c); err != nil {
+		return err
+	}
+
+	// Read the uploaded CSV
+	file, _, err := openUpload(c, "file", model.MaxAssetCSVBytes)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Call service
+	response, err := h.service.Preview(file)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Import handles POST /api/v1/assets/csv
+//
+// Imports a CSV uploaded as the multipart form field "file" (at most 10 MB,
+// 5000 rows). The optional form field "mapping" is a JSON object assigning
+// each column an asset field (name, type, hostname, tags, pinned, favorite),
+// a metadata key ("metadata.<key>") or "" to ignore it; without it the mapping
+// suggested by the preview is used. Empty cells leave fields unchanged, and
+// rows that change nothing are reported as "unchanged" without a write.
+//
+// Every row is validated before anything is written; errors name the row as
+// in a spreadsheet, e.g. {"field": "row 3: type", "error": "must be one of: ..."}.
+//
+// Query Parameters:
+//   - match: Field that finds the asset a row updates, ignoring case: name (default) or hostname.
+//     Rows that match no asset create one.
+//   - dry_run: Report what would change without writing (default: false)
+//
+// Response:
+//   - 200 OK: Returns AssetCSVImportResult
+//   - 400 Bad Request: Missing file, invalid mapping or invalid rows
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 413 Request Entity Too Large: File over 10 MB
+//
+// Example Response:
+//
+//	{"dry_run": false, "match": "name", "created": 1, "updated": 1, "unchanged": 1,
+//	 "rows": [{"row": 2, "action": "update", "id": "550e8400-...", "name": "nas-01"},
+//	          {"row": 3, "action": "unchanged", "id": "9b1deb4d-...", "name": "nas-02"},
+//	          {"row": 4, "action": "create", "id": "7c9e6679-...", "name": "pve-02"}]}
+func (h *AssetCSVHandler) Import(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters (Bind only reads them for GET and DELETE)
+	var params model.AssetCSVImportParams
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Read the uploaded CSV and its column mapping
+	file, _, err := openUpload(c, "file", model.MaxAssetCSVBytes)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var mapping map[string]string
+	if raw := c.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "mapping must be a JSON object of column names to fields")
+		}
+	}
+
+	// Call service
+	response, err := h.service.Import(c.Request().Context(), userID, file, mapping, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/middleware"
+)
+
+// TestAssetCSVHandler_Import_NoAuth verifies 401 when user is not authenticated
+func TestAssetCSVHandler_Import_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewAssetCSVHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/assets/csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Import(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestAssetCSVHandler_Preview_MissingFile verifies 400 when no CSV is uploaded
+func TestAssetCSVHandler_Preview_MissingFile(t *testing.T) {
+	// Arrange
+	handler := NewAssetCSVHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/assets/csv/preview", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.Preview(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestAssetCSVHandler_Export_InvalidFilter verifies the list filters are validated
+func TestAssetCSVHandler_Export_InvalidFilter(t *testing.T) {
+	// Arrange
+	handler := NewAssetCSVHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/assets/csv?tag_mode=some", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.Export(c)
+
+	// Assert
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr), "error should be *errs.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+}
@@ -0,0 +1,221 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for account exports.
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/server"
+	"ark/internal/service"
+)
+
+// ExportHandler handles HTTP requests for account exports: zip archives of
+// every asset and log, used for backups, migrations and data requests.
+//
+// Routes:
+//   - GET /api/v1/export - Download an archive built within the request
+//   - POST /api/v1/exports - Start a background export
+//   - GET /api/v1/exports - List exports
+//   - GET /api/v1/exports/:id - Get export status
+//   - GET /api/v1/exports/:id/download - Download a completed export
+//   - DELETE /api/v1/exports/:id - Delete an export
+//
+// Archive layout:
+//
+//	manifest.json
+//	assets/<name>-<id>/asset.json
+//	assets/<name>-<id>/asset.md
+//	assets/<name>-<id>/logs/<created_at>-<id>.md
+//
+// Markdown files carry every field as YAML front matter, including the
+// timeline of incident logs; a log's content is the body of its file.
+//
+// All endpoints require authentication via the auth middleware.
+type ExportHandler struct {
+	Handler
+	service *service.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler with the given server and ExportService.
+func NewExportHandler(s *server.Server, service *service.ExportService) *ExportHandler {
+	return &ExportHandler{
+		Handler: NewHandler(s),
+		service: service,
+	}
+}
+
+// exportFilename names a downloaded archive after the current date
+func exportFilename(now time.Time) string {
+	return "ark-export-" + now.UTC().Format("20060102") + ".zip"
+}
+
+// Export handles GET /api/v1/export
+//
+// Builds the user's archive within the request and downloads it. Accounts with
+// more than 5000 logs get 400 with code EXPORT_TOO_LARGE and must use
+// POST /api/v1/exports instead. Accounts with more than 10000 assets cannot
+// be exported at all.
+//
+// Query Parameters:
+//   - view_id: Only export the assets of a saved asset view, with their logs
+//
+// Response:
+//   - 200 OK: application/zip attachment
+//   - 400 Bad Request: Account too large for a direct export, or a log view
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Saved view not found
+func (h *ExportHandler) Export(c echo.Context) error {
+	return HandleFile(h.Handler, h.export, http.StatusOK, &model.ExportRequest{}, exportFilename(time.Now()), model.ExportContentType)(c)
+}
+
+func (h *ExportHandler) export(c echo.Context, req *model.ExportRequest) ([]byte, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	return h.service.Export(c.Request().Context(), userID, req.ViewID)
+}
+
+// Start handles POST /api/v1/exports
+//
+// Starts building the user's archive in the background. Poll the export until
+// its status is completed (or failed), then download it. Archives can be
+// downloaded for 7 days. Accounts with more than 100000 logs or 10000 assets
+// get 400 with code EXPORT_TOO_LARGE.
+//
+// Query Parameters:
+//   - view_id: Only export the assets of a saved asset view, with their logs
+//
+// Response:
+//   - 202 Accepted: Returns the pending AccountExport
+//   - 400 Bad Request: Account too large to export, or a log view
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Saved view not found
+//
+// Example Response:
+//
+//	{"id": "550e8400-...", "user_id": "user_123", "status": "pending",
+//	 "created_at": "2024-05-30T12:00:00Z", "updated_at": "2024-05-30T12:00:00Z", "expires_at": "2024-06-06T12:00:00Z"}
+func (h *ExportHandler) Start(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters (Bind only reads them for GET and DELETE)
+	var req model.ExportRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.Start(c.Request().Context(), userID, req.ViewID)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 202 Accepted
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// List handles GET /api/v1/exports
+// Returns the user's unexpired exports, newest first
+func (h *ExportHandler) List(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetByID handles GET /api/v1/exports/:id
+//
+// Returns an export with its status: pending, running, completed or failed.
+// Completed exports include their size and the number of assets and logs.
+func (h *ExportHandler) GetByID(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate export ID from URL parameter
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid export id")
+	}
+
+	// Call service
+	response, err := h.service.GetByID(c.Request().Context(), userID, exportID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Download handles GET /api/v1/exports/:id/download
+//
+// Response:
+//   - 200 OK: application/zip attachment
+//   - 400 Bad Request: Invalid export ID, or code EXPORT_NOT_READY if not completed
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Export not found, expired or not owned by user
+func (h *ExportHandler) Download(c echo.Context) error {
+	return HandleFile(h.Handler, h.download, http.StatusOK, &model.ExportDownloadRequest{}, exportFilename(time.Now()), model.ExportContentType)(c)
+}
+
+func (h *ExportHandler) download(c echo.Context, req *model.ExportDownloadRequest) ([]byte, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service (the ID was validated when binding)
+	return h.service.Download(c.Request().Context(), userID, uuid.MustParse(req.ID))
+}
+
+// Delete handles DELETE /api/v1/exports/:id
+// Deletes an export and its archive
+func (h *ExportHandler) Delete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate export ID from URL parameter
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid export id")
+	}
+
+	// Call service
+	if err := h.service.Delete(c.Request().Context(), userID, exportID); err != nil {
+		return err
+	}
+
+	// Return 204 No Content
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/middleware"
+)
+
+// TestExportHandler_Start_NoAuth verifies 401 when user is not authenticated
+func TestExportHandler_Start_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewExportHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/exports", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Start(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestExportHandler_GetByID_InvalidID verifies 400 when export ID is invalid
+func TestExportHandler_GetByID_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewExportHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/exports/invalid-uuid", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.GetByID(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestExportHandler_Download_InvalidID verifies the file handler validates the ID
+func TestExportHandler_Download_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewExportHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/exports/invalid-uuid/download", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.Download(c)
+
+	// Assert
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr), "error should be *errs.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+}
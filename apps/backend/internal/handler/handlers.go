@@ -6,17 +6,49 @@ import (
 )
 
 type Handlers struct {
-	Health  *HealthHandler
-	OpenAPI *OpenAPIHandler
-	Asset   *AssetHandler
-	Log     *LogHandler
+	Health      *HealthHandler
+	OpenAPI     *OpenAPIHandler
+	Asset       *AssetHandler
+	AssetCSV    *AssetCSVHandler
+	Log         *LogHandler
+	LogTemplate *LogTemplateHandler
+	Incident    *IncidentHandler
+	Maintenance *MaintenanceHandler
+	Runbook     *RunbookHandler
+	Tag         *TagHandler
+	SavedView   *SavedViewHandler
+	Report      *ReportHandler
+	Stats       *StatsHandler
+	Activity    *ActivityHandler
+	Export      *ExportHandler
+	Import      *ImportHandler
+	Inventory   *InventoryHandler
+	APIToken    *APITokenHandler
+	Integration *IntegrationHandler
+	Port        *PortHandler
 }
 
 func NewHandlers(s *server.Server, services *service.Services) *Handlers {
 	return &Handlers{
-		Health:  NewHealthHandler(s),
-		OpenAPI: NewOpenAPIHandler(s),
-		Asset:   NewAssetHandler(services.Asset),
-		Log:     NewLogHandler(services.Log),
+		Health:      NewHealthHandler(s),
+		OpenAPI:     NewOpenAPIHandler(s),
+		Asset:       NewAssetHandler(services.Asset),
+		AssetCSV:    NewAssetCSVHandler(s, services.AssetCSV),
+		Log:         NewLogHandler(services.Log),
+		LogTemplate: NewLogTemplateHandler(services.LogTemplate),
+		Incident:    NewIncidentHandler(services.Incident),
+		Maintenance: NewMaintenanceHandler(services.Maintenance),
+		Runbook:     NewRunbookHandler(services.Runbook),
+		Tag:         NewTagHandler(services.Tag),
+		SavedView:   NewSavedViewHandler(services.SavedView),
+		Report:      NewReportHandler(services.Report),
+		Stats:       NewStatsHandler(services.Stats),
+		Activity:    NewActivityHandler(services.Activity),
+		Export:      NewExportHandler(s, services.Export),
+		Import:      NewImportHandler(services.Import),
+		Inventory:   NewInventoryHandler(services.Inventory),
+		APIToken:    NewAPITokenHandler(s, services.APIToken),
+		Integration: NewIntegrationHandler(s, services.Integration),
+		Port:        NewPortHandler(services.Port),
 	}
 }
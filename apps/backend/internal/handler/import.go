@@ -0,0 +1,121 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for account imports.
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// uploadFormOverhead allows for the multipart framing around an uploaded file
+const uploadFormOverhead = 1 << 20
+
+// openUpload opens the file uploaded in a multipart form field, refusing
+// files over maxBytes with 413
+func openUpload(c echo.Context, field string, maxBytes int64) (multipart.File, int64, error) {
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, maxBytes+uploadFormOverhead)
+
+	tooLarge := echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("%s must be at most %d MB", field, maxBytes>>20))
+	header, err := c.FormFile(field)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, 0, tooLarge
+		}
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, field+" is required")
+	}
+	if header.Size > maxBytes {
+		return nil, 0, tooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, 0, err
+	}
+	return file, header.Size, nil
+}
+
+// ImportHandler handles HTTP requests for account imports: recreating assets
+// and logs from an archive written by an account export.
+//
+// Routes:
+//   - POST /api/v1/imports - Import an account archive
+//
+// All endpoints require authentication via the auth middleware.
+type ImportHandler struct {
+	service *service.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler with the given ImportService.
+func NewImportHandler(service *service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+	}
+}
+
+// Import handles POST /api/v1/imports
+//
+// Imports an account archive uploaded as the multipart form field "file" (at
+// most 100 MB). Assets and logs keep their archived IDs and timestamps; rows
+// whose ID belongs to another account are imported under a new ID. Incident
+// timelines come with their log and replace the timeline of an overwritten
+// incident. All rows are written in one transaction, so a failed import
+// changes nothing. Archives may hold at most 256 MB uncompressed.
+//
+// Query Parameters:
+//   - conflict: What to do with IDs already in the account (default: skip)
+//     skip keeps the existing row, overwrite replaces it with the archived one,
+//     duplicate imports the archived row under a new ID
+//   - dry_run: Report what would change without writing (default: false)
+//
+// Response:
+//   - 200 OK: Returns ImportResult with per-type counts and up to 1000 changes
+//   - 400 Bad Request: Missing file, invalid parameters or invalid archive
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 413 Request Entity Too Large: Archive over 100 MB
+//
+// Example Response:
+//
+//	{"dry_run": true, "conflict": "skip",
+//	 "assets": {"created": 1, "updated": 0, "skipped": 1, "new_ids": 0},
+//	 "logs": {"created": 12, "updated": 0, "skipped": 3, "new_ids": 0},
+//	 "changes": [{"type": "asset", "action": "create", "id": "550e8400-...", "name": "nas-01"}, ...],
+//	 "changes_truncated": false}
+func (h *ImportHandler) Import(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters (Bind only reads them for GET and DELETE)
+	var params model.ImportParams
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Read the uploaded archive
+	file, size, err := openUpload(c, "file", model.MaxImportBytes)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Call service
+	response, err := h.service.Import(c.Request().Context(), userID, file, size, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestImportHandler_Import_NoAuth verifies 401 when user is not authenticated
+func TestImportHandler_Import_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewImportHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/imports", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Import(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestImportHandler_Import_MissingFile verifies 400 when no archive is uploaded
+func TestImportHandler_Import_MissingFile(t *testing.T) {
+	// Arrange
+	handler := NewImportHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/imports?dry_run=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.Import(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
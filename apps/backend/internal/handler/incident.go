@@ -0,0 +1,161 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for incident lifecycle and statistics operations.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// IncidentHandler handles HTTP requests for incident tracking.
+// Incidents are asset logs of kind "incident"; this handler manages their
+// lifecycle (open → mitigated → resolved) and reports MTTR and availability.
+//
+// Routes:
+//   - GET  /api/v1/logs/:id/timeline  - Get incident with its timeline
+//   - POST /api/v1/logs/:id/timeline  - Add timeline entry / transition status
+//   - GET  /api/v1/stats/incidents    - Incident statistics over a window
+//
+// All endpoints require authentication via the auth middleware.
+type IncidentHandler struct {
+	service *service.IncidentService
+}
+
+// NewIncidentHandler creates a new IncidentHandler with the given IncidentService.
+func NewIncidentHandler(service *service.IncidentService) *IncidentHandler {
+	return &IncidentHandler{
+		service: service,
+	}
+}
+
+// GetTimeline handles GET /api/v1/logs/:id/timeline
+//
+// Returns the incident log together with its timeline entries in chronological order.
+//
+// Response:
+//   - 200 OK: Returns IncidentTimelineResponse
+//   - 400 Bad Request: Invalid log ID or the log is not an incident
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Log doesn't exist or belongs to another user
+func (h *IncidentHandler) GetTimeline(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate log ID from URL parameter
+	idParam := c.Param("id")
+	logID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid log id")
+	}
+
+	// Call service
+	response, err := h.service.GetTimeline(c.Request().Context(), userID, logID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// AddTimelineEntry handles POST /api/v1/logs/:id/timeline
+//
+// Adds a note to the incident timeline and optionally transitions its status.
+//
+// Request Body (JSON):
+//   - status: open, mitigated or resolved (optional)
+//   - note: Free-text update (optional, max 5000 chars)
+//   - occurred_at: When the update happened (optional, default: now)
+//
+// Transitions:
+//   - open → mitigated → resolved (mitigated may be skipped)
+//   - mitigated or resolved → open reopens the incident and clears its resolution
+//
+// Response:
+//   - 201 Created: Returns IncidentTimelineResponse with the updated incident
+//   - 400 Bad Request: Invalid body, not an incident, or transition not allowed
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Log doesn't exist or belongs to another user
+//
+// Example Request:
+//
+//	{"status": "mitigated", "note": "Failed over to secondary PSU"}
+func (h *IncidentHandler) AddTimelineEntry(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate log ID from URL parameter
+	idParam := c.Param("id")
+	logID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid log id")
+	}
+
+	// Parse request body
+	var req model.CreateTimelineEntryRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service (service validates the transition)
+	response, err := h.service.AddTimelineEntry(c.Request().Context(), userID, logID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 201 Created
+	return c.JSON(http.StatusCreated, response)
+}
+
+// Stats handles GET /api/v1/stats/incidents
+//
+// Computes incident statistics over a reporting window.
+//
+// Query Parameters:
+//   - start_date: Window start (default: 90 days before end_date)
+//   - end_date: Window end (default: now)
+//   - interval: Bucket size for over_time: day, week or month (default: week)
+//   - asset_id: Restrict to a single asset (optional)
+//   - type: Restrict to an asset type (optional)
+//   - min_severity: Only count incidents at or above this severity (optional)
+//
+// Response:
+//   - 200 OK: Returns IncidentStatsResponse with MTTR, availability,
+//     per-asset and per-type breakdowns and counts over time
+//   - 400 Bad Request: Invalid query parameters
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: asset_id doesn't exist or belongs to another user
+func (h *IncidentHandler) Stats(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.IncidentStatsQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service (service applies the default window)
+	response, err := h.service.Stats(c.Request().Context(), userID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
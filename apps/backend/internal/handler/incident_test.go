@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestIncidentHandler_GetTimeline_InvalidLogID verifies 400 when log ID is invalid
+func TestIncidentHandler_GetTimeline_InvalidLogID(t *testing.T) {
+	// Arrange
+	handler := NewIncidentHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/logs/invalid-uuid/timeline", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.GetTimeline(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestIncidentHandler_AddTimelineEntry_InvalidLogID verifies 400 when log ID is invalid
+func TestIncidentHandler_AddTimelineEntry_InvalidLogID(t *testing.T) {
+	// Arrange
+	handler := NewIncidentHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logs/invalid-uuid/timeline", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.AddTimelineEntry(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestIncidentHandler_Stats_NoAuth verifies an error when user_id missing
+func TestIncidentHandler_Stats_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewIncidentHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/incidents", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.Stats(c)
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for endpoints read by outside tools.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/server"
+	"ark/internal/service"
+)
+
+// IntegrationHandler handles HTTP requests from tools that read the inventory,
+// usually scripts running on the user's own machines.
+//
+// Routes:
+//   - GET /api/v1/integrations/ansible/inventory - Assets as an Ansible dynamic inventory
+//   - GET /api/v1/integrations/prometheus/sd      - Asset ports as Prometheus HTTP SD targets
+//   - GET /api/v1/integrations/ssh/config         - Assets as an ~/.ssh/config snippet
+//   - GET /api/v1/integrations/hosts              - Assets as an /etc/hosts block
+//
+// All endpoints accept an API token (see APITokenHandler) or a Clerk session.
+type IntegrationHandler struct {
+	Handler
+	service *service.IntegrationService
+}
+
+// NewIntegrationHandler creates a new IntegrationHandler with the given server and IntegrationService.
+func NewIntegrationHandler(s *server.Server, service *service.IntegrationService) *IntegrationHandler {
+	return &IntegrationHandler{
+		Handler: NewHandler(s),
+		service: service,
+	}
+}
+
+// AnsibleInventory handles GET /api/v1/integrations/ansible/inventory
+//
+// Returns assets in the JSON format of Ansible inventory scripts, so a script
+// as small as
+//
+//	#!/bin/sh
+//	curl -sf -H "Authorization: Bearer $ARK_TOKEN" https://ark.example.com/api/v1/integrations/ansible/inventory
+//
+// works with ansible -i. Hosts are named after their asset and grouped by
+// type (type_<type>) and tag (tag_<tag>), with other characters than letters,
+// digits and underscores replaced by underscores; assets with neither are
+// ungrouped. Metadata keys become host variables, together with ansible_host
+// (the asset's hostname), ark_id, ark_type and ark_tags.
+//
+// Query Parameters:
+//   - type: Only assets of this type (optional)
+//   - tags: Only assets with these tags (optional, repeatable)
+//   - tag_mode: "all" (default) requires every tag, "any" at least one
+//
+// Response:
+//   - 200 OK: Returns AnsibleInventory
+//   - 400 Bad Request: Invalid query parameters, or code INVENTORY_TOO_LARGE over 5000 assets
+//   - 401 Unauthorized: Missing, invalid or expired token
+//
+// Example Response:
+//
+//	{"_meta": {"hostvars": {"web01": {"ansible_host": "10.0.0.11", "http_port": 8080,
+//	                                  "ark_id": "550e8400-...", "ark_type": "server", "ark_tags": ["prod"]}}},
+//	 "all": {"children": ["tag_prod", "type_server"]},
+//	 "tag_prod": {"hosts": ["web01"]},
+//	 "type_server": {"hosts": ["web01"]}}
+func (h *IntegrationHandler) AnsibleInventory(c echo.Context) error {
+	return Handle(h.Handler, h.ansibleInventory, http.StatusOK, &model.AnsibleInventoryParams{})(c)
+}
+
+func (h *IntegrationHandler) ansibleInventory(c echo.Context, params *model.AnsibleInventoryParams) (*model.AnsibleInventory, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	return h.service.AnsibleInventory(c.Request().Context(), userID, params)
+}
+
+// PrometheusSD handles GET /api/v1/integrations/prometheus/sd
+//
+// Returns asset ports as targets in the format of Prometheus' http_sd_config,
+// so Prometheus can scrape what Ark knows about:
+//
+//	scrape_configs:
+//	  - job_name: node
+//	    http_sd_configs:
+//	      - url: https://ark.example.com/api/v1/integrations/prometheus/sd?service=node_exporter
+//	        authorization:
+//	          credentials: ark_...
+//
+// Each TCP port of an asset with a hostname becomes a target group of
+// hostname:port. Assets without a hostname are left out. Groups are labelled
+// with asset (the name), asset_type, tags (comma-separated with a comma on
+// both ends, as in ",prod,dns,") and service, plus __meta_ark_asset_id for
+// relabelling.
+//
+// Query Parameters:
+//   - type: Only assets of this type (optional)
+//   - tags: Only assets with these tags (optional, repeatable)
+//   - tag_mode: "all" (default) requires every tag, "any" at least one
+//   - service: Only ports of this service, ignoring case (optional)
+//
+// Response:
+//   - 200 OK: Returns a list of PrometheusTargetGroup
+//   - 400 Bad Request: Invalid query parameters, or code INVENTORY_TOO_LARGE over 5000 assets
+//   - 401 Unauthorized: Missing, invalid or expired token
+//
+// Example Response:
+//
+//	[{"targets": ["10.0.0.11:9100"],
+//	  "labels": {"asset": "web01", "asset_type": "server", "tags": ",prod,",
+//	             "service": "node_exporter", "__meta_ark_asset_id": "550e8400-..."}}]
+func (h *IntegrationHandler) PrometheusSD(c echo.Context) error {
+	return Handle(h.Handler, h.prometheusSD, http.StatusOK, &model.PrometheusSDParams{})(c)
+}
+
+func (h *IntegrationHandler) prometheusSD(c echo.Context, params *model.PrometheusSDParams) ([]model.PrometheusTargetGroup, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	return h.service.PrometheusTargets(c.Request().Context(), userID, params)
+}
+
+// SSHConfig handles GET /api/v1/integrations/ssh/config
+//
+// Returns a Host block for each asset with a hostname, aliased by the asset's
+// name with whitespace and pattern characters replaced by dashes. Asset
+// metadata overrides the connection: ssh_user (or ansible_user) sets User,
+// ssh_port (or ansible_port) sets Port and ssh_jump_host sets ProxyJump, where
+// the name of another asset refers to that asset's Host block. A cron job can
+// keep a workstation in sync with an Include of the downloaded file:
+//
+//	curl -sf -H "Authorization: Bearer $ARK_TOKEN" -o ~/.ssh/config.d/ark \
+//	  https://ark.example.com/api/v1/integrations/ssh/config
+//
+// Query Parameters:
+//   - type: Only assets of this type (optional)
+//   - tags: Only assets with these tags (optional, repeatable)
+//   - tag_mode: "all" (default) requires every tag, "any" at least one
+//
+// Response:
+//   - 200 OK: text/plain attachment
+//   - 400 Bad Request: Invalid query parameters, or code INVENTORY_TOO_LARGE over 5000 assets
+//   - 401 Unauthorized: Missing, invalid or expired token
+//
+// Example Response:
+//
+//	Host web01
+//	    HostName 10.0.0.11
+//	    User deploy
+//	    ProxyJump bastion
+func (h *IntegrationHandler) SSHConfig(c echo.Context) error {
+	return HandleFile(h.Handler, h.sshConfig, http.StatusOK, &model.GeneratedFileRequest{}, "ark-ssh-config", model.GeneratedFileContentType)(c)
+}
+
+func (h *IntegrationHandler) sshConfig(c echo.Context, req *model.GeneratedFileRequest) ([]byte, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	return h.service.SSHConfig(c.Request().Context(), userID, req)
+}
+
+// HostsFile handles GET /api/v1/integrations/hosts
+//
+// Returns an /etc/hosts block between "# BEGIN ark" and "# END ark" lines.
+// Assets are listed under their hostname when it is an IP address, or else
+// under their ip_address metadata key, and named after the asset and, when it
+// is a DNS name, the hostname. A cron job can swap the block in place:
+//
+//	curl -sf -H "Authorization: Bearer $ARK_TOKEN" -o /tmp/ark-hosts \
+//	  https://ark.example.com/api/v1/integrations/hosts &&
+//	  { sed '/^# BEGIN ark$/,/^# END ark$/d' /etc/hosts; cat /tmp/ark-hosts; } > /tmp/hosts &&
+//	  cp /tmp/hosts /etc/hosts
+//
+// Query Parameters: the same as SSHConfig.
+//
+// Response:
+//   - 200 OK: text/plain attachment
+//   - 400 Bad Request: Invalid query parameters, or code INVENTORY_TOO_LARGE over 5000 assets
+//   - 401 Unauthorized: Missing, invalid or expired token
+//
+// Example Response:
+//
+//	# BEGIN ark
+//	# Generated by Ark from the asset inventory; edits are overwritten.
+//	10.0.0.11	web01 web01.lan
+//	# END ark
+func (h *IntegrationHandler) HostsFile(c echo.Context) error {
+	return HandleFile(h.Handler, h.hostsFile, http.StatusOK, &model.GeneratedFileRequest{}, "ark-hosts", model.GeneratedFileContentType)(c)
+}
+
+func (h *IntegrationHandler) hostsFile(c echo.Context, req *model.GeneratedFileRequest) ([]byte, error) {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	return h.service.HostsFile(c.Request().Context(), userID, req)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/middleware"
+)
+
+// TestIntegrationHandler_AnsibleInventory_NoAuth verifies 401 when user is not authenticated
+func TestIntegrationHandler_AnsibleInventory_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewIntegrationHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/ansible/inventory", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.AnsibleInventory(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestIntegrationHandler_AnsibleInventory_InvalidTagMode verifies 400 for an unknown tag_mode
+func TestIntegrationHandler_AnsibleInventory_InvalidTagMode(t *testing.T) {
+	// Arrange
+	handler := NewIntegrationHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/ansible/inventory?tag_mode=some", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.AnsibleInventory(c)
+
+	// Assert
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr), "error should be *errs.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.NotEmpty(t, httpErr.Errors)
+}
+
+// TestIntegrationHandler_PrometheusSD_NoAuth verifies 401 when user is not authenticated
+func TestIntegrationHandler_PrometheusSD_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewIntegrationHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/prometheus/sd", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.PrometheusSD(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestIntegrationHandler_PrometheusSD_InvalidTagMode verifies 400 for an unknown tag_mode
+func TestIntegrationHandler_PrometheusSD_InvalidTagMode(t *testing.T) {
+	// Arrange
+	handler := NewIntegrationHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/prometheus/sd?tags=prod&tag_mode=some", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.PrometheusSD(c)
+
+	// Assert
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr), "error should be *errs.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.NotEmpty(t, httpErr.Errors)
+}
+
+// TestIntegrationHandler_SSHConfig_InvalidTagMode verifies the filters are validated
+func TestIntegrationHandler_SSHConfig_InvalidTagMode(t *testing.T) {
+	// Arrange
+	handler := NewIntegrationHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/ssh/config?tag_mode=some", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.SSHConfig(c)
+
+	// Assert
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr), "error should be *errs.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+}
+
+// TestIntegrationHandler_HostsFile_NoAuth verifies 401 when user is not authenticated
+func TestIntegrationHandler_HostsFile_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewIntegrationHandler(nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/hosts", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.HostsFile(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
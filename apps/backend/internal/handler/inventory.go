@@ -0,0 +1,282 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for importing assets from infrastructure files.
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// inventoryFilesField is the multipart form field inventory files are uploaded in
+const inventoryFilesField = "files"
+
+// readInventoryFiles reads the files uploaded in the "files" form field,
+// refusing more than MaxInventoryFiles files or any file over
+// MaxInventoryFileBytes
+func readInventoryFiles(c echo.Context) ([]model.InventoryFile, error) {
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, model.MaxInventoryFiles*(model.MaxInventoryFileBytes+uploadFormOverhead))
+
+	tooLarge := echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("each of %s must be at most %d MB", inventoryFilesField, model.MaxInventoryFileBytes>>20))
+	form, err := c.MultipartForm()
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, tooLarge
+		}
+		return nil, echo.NewHTTPError(http.StatusBadRequest, inventoryFilesField+" is required")
+	}
+
+	headers := form.File[inventoryFilesField]
+	if len(headers) == 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, inventoryFilesField+" is required")
+	}
+	if len(headers) > model.MaxInventoryFiles {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("at most %d %s may be uploaded at once", model.MaxInventoryFiles, inventoryFilesField))
+	}
+
+	files := make([]model.InventoryFile, 0, len(headers))
+	for _, header := range headers {
+		if header.Size > model.MaxInventoryFileBytes {
+			return nil, tooLarge
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, model.InventoryFile{Name: header.Filename, Data: data})
+	}
+
+	return files, nil
+}
+
+// InventoryHandler handles HTTP requests for importing assets from the files
+// that describe infrastructure, such as docker-compose.yml, Ansible inventories
+// or network scans.
+//
+// Routes:
+//   - POST /api/v1/inventory/compose - Import docker-compose services as containers
+//   - POST /api/v1/inventory/ansible - Import hosts from Ansible inventories
+//   - POST /api/v1/inventory/nmap    - Reconcile assets with nmap scan results
+//
+// Files are uploaded as the multipart form field "files", which may be repeated
+// (at most 20 files of 1 MB each). Imports never delete assets; assets imported
+// before are updated in place with a change log describing what differed.
+//
+// All endpoints require authentication via the auth middleware.
+type InventoryHandler struct {
+	service *service.InventoryService
+}
+
+// NewInventoryHandler creates a new InventoryHandler with the given InventoryService.
+func NewInventoryHandler(service *service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		service: service,
+	}
+}
+
+// ImportCompose handles POST /api/v1/inventory/compose
+//
+// Each service of the uploaded compose files becomes a container asset named
+// after its container_name, or "<project>-<service>". The project is the
+// file's top-level name, or else its file name without "docker-compose", as
+// in media.docker-compose.yml. Image, ports, volumes and networks are stored
+// in metadata along with host_asset_id, compose_project and compose_service,
+// which identify the container when the project is imported again. Change
+// logs are linked to the host asset.
+//
+// Query Parameters:
+//   - host_asset_id: Asset the containers run on (required)
+//   - dry_run: Report what would change without writing (default: false)
+//
+// Response:
+//   - 200 OK: Returns InventoryImportResult
+//   - 400 Bad Request: Missing files, invalid host_asset_id or invalid compose file
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Host asset doesn't exist or belongs to another user
+//   - 413 Request Entity Too Large: File over 1 MB
+//
+// Example Response:
+//
+//	{"dry_run": false, "created": 1, "updated": 1, "unchanged": 0,
+//	 "assets": [{"source": "media.yml: jellyfin", "action": "update", "id": "550e8400-...",
+//	             "name": "jellyfin", "changes": ["image: jellyfin/jellyfin:10.8 → jellyfin/jellyfin:10.9"]},
+//	            {"source": "media.yml: sonarr", "action": "create", "id": "7c9e6679-...", "name": "media-sonarr"}]}
+func (h *InventoryHandler) ImportCompose(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters (Bind only reads them for GET and DELETE)
+	var params model.ComposeImportParams
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+	hostAssetID, err := uuid.Parse(params.HostAssetID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid host_asset_id")
+	}
+
+	// Read the uploaded compose files
+	files, err := readInventoryFiles(c)
+	if err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.ImportCompose(c.Request().Context(), userID, hostAssetID, files, params.DryRun)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// ImportAnsible handles POST /api/v1/inventory/ansible
+//
+// Each host of the uploaded INI or YAML inventories (told apart by a .yml,
+// .yaml or .json extension) becomes an asset named after its inventory
+// hostname, matched to existing assets by name ignoring case. Host ranges such
+// as web[01:03] are expanded. Groups, including parent groups, become tags;
+// ansible_host becomes the hostname, or the inventory hostname without it;
+// other host variables go into metadata, except passwords. Group variables
+// are not imported.
+//
+// Query Parameters:
+//   - type: Asset type given to every host, e.g. server or vm (default: none)
+//   - dry_run: Preview the creates, updates and unchanged hosts without writing (default: false)
+//
+// Response:
+//   - 200 OK: Returns InventoryImportResult
+//   - 400 Bad Request: Missing files, invalid type or invalid inventory
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 413 Request Entity Too Large: File over 1 MB
+//
+// Example Response:
+//
+//	{"dry_run": true, "created": 1, "updated": 1, "unchanged": 1,
+//	 "assets": [{"source": "hosts.ini: web01.lan", "action": "update", "id": "550e8400-...",
+//	             "name": "web01.lan", "changes": ["hostname: 10.0.0.10 → 10.0.0.11", "tags: added prod"]},
+//	            {"source": "hosts.ini: web02.lan", "action": "create", "name": "web02.lan"},
+//	            {"source": "hosts.ini: db01.lan", "action": "unchanged", "id": "7c9e6679-...", "name": "db01.lan"}]}
+func (h *InventoryHandler) ImportAnsible(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters (Bind only reads them for GET and DELETE)
+	var params model.AnsibleImportParams
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Read the uploaded inventories
+	files, err := readInventoryFiles(c)
+	if err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.ImportAnsible(c.Request().Context(), userID, &params, files)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// ImportNmap handles POST /api/v1/inventory/nmap
+//
+// Reconciles the hosts of nmap XML output (nmap -oX) with existing assets,
+// matched by their mac_address metadata, then by IP address (the hostname or
+// ip_address metadata), then by hostname. Only hosts that were up are read,
+// and only open ports.
+//
+//   - Unknown hosts become new assets named after their reverse DNS name, or
+//     else their address, with their open ports.
+//   - Known assets gain their MAC address and newly open ports, and follow a
+//     changed IP address when their hostname was the old one. Every open port
+//     is marked seen at the scan's start time.
+//   - Assets whose address lies in the scanned networks but that no host
+//     matched are listed in not_seen. They are never changed.
+//
+// Every scan is reviewed: upload it with dry_run=true to get the proposed
+// changes and their plan, then upload it again without dry_run, passing the
+// plan and accept for each scanned address to import. Hosts not accepted are
+// reported as skipped. If the scan, scope or matching assets changed since the
+// dry run, nothing is written and the request fails with code PLAN_CHANGED.
+//
+// Query Parameters:
+//   - dry_run: Preview the reconciliation without writing (default: false)
+//   - scope: Networks the scan covered, as CIDRs or addresses, for not_seen (optional, repeatable;
+//     default: the targets on the nmap command line)
+//   - plan: The plan returned by the dry run (required without dry_run)
+//   - accept: Scanned addresses to import (required without dry_run, repeatable)
+//
+// Response:
+//   - 200 OK: Returns InventoryImportResult
+//   - 400 Bad Request: Missing files, plan or accept, invalid scope or accept, a file that is not
+//     nmap XML, or code PLAN_CHANGED
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 413 Request Entity Too Large: File over 1 MB
+//
+// Example Response:
+//
+//	{"dry_run": true, "created": 1, "updated": 1, "unchanged": 0,
+//	 "assets": [{"source": "lan.xml: 10.0.0.5", "action": "update", "id": "550e8400-...", "name": "nas",
+//	             "address": "10.0.0.5",
+//	             "changes": ["mac_address: (none) → 00:11:32:AA:BB:CC", "ports: added 445/tcp (microsoft-ds)"]},
+//	            {"source": "lan.xml: 10.0.0.23", "action": "create", "name": "printer.lan",
+//	             "address": "10.0.0.23", "changes": ["ports: 631/tcp (ipp)"]}],
+//	 "not_seen": [{"source": "10.0.0.9", "action": "not_seen", "id": "7c9e6679-...", "name": "old-pi"}],
+//	 "plan": "3f1c9a..."}
+func (h *InventoryHandler) ImportNmap(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters (Bind only reads them for GET and DELETE)
+	var params model.NmapImportParams
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Read the uploaded scans
+	files, err := readInventoryFiles(c)
+	if err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.ImportNmap(c.Request().Context(), userID, &params, files)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
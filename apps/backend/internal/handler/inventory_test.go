@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestInventoryHandler_ImportCompose_NoAuth verifies 401 when user is not authenticated
+func TestInventoryHandler_ImportCompose_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewInventoryHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inventory/compose", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.ImportCompose(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestInventoryHandler_ImportCompose_InvalidHostAssetID verifies 400 for a malformed host_asset_id
+func TestInventoryHandler_ImportCompose_InvalidHostAssetID(t *testing.T) {
+	// Arrange
+	handler := NewInventoryHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inventory/compose?host_asset_id=not-a-uuid", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.ImportCompose(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Equal(t, "invalid host_asset_id", httpErr.Message)
+}
+
+// TestInventoryHandler_ImportCompose_MissingFiles verifies 400 when no files are uploaded
+func TestInventoryHandler_ImportCompose_MissingFiles(t *testing.T) {
+	// Arrange
+	handler := NewInventoryHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inventory/compose?host_asset_id=550e8400-e29b-41d4-a716-446655440000", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.ImportCompose(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Equal(t, "files is required", httpErr.Message)
+}
+
+// TestInventoryHandler_ImportAnsible_NoAuth verifies 401 when user is not authenticated
+func TestInventoryHandler_ImportAnsible_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewInventoryHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inventory/ansible", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.ImportAnsible(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestInventoryHandler_ImportAnsible_MissingFiles verifies 400 when no files are uploaded
+func TestInventoryHandler_ImportAnsible_MissingFiles(t *testing.T) {
+	// Arrange
+	handler := NewInventoryHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inventory/ansible?dry_run=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.ImportAnsible(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestInventoryHandler_ImportNmap_NoAuth verifies 401 when user is not authenticated
+func TestInventoryHandler_ImportNmap_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewInventoryHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inventory/nmap", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.ImportNmap(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestInventoryHandler_ImportNmap_MissingFiles verifies 400 when no scan is uploaded
+func TestInventoryHandler_ImportNmap_MissingFiles(t *testing.T) {
+	// Arrange
+	handler := NewInventoryHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inventory/nmap?dry_run=true&scope=10.0.0.0/24", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.ImportNmap(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
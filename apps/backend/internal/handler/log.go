@@ -11,6 +11,7 @@ import (
 	"ark/internal/middleware"
 	"ark/internal/model"
 	"ark/internal/service"
+	"ark/internal/validation"
 )
 
 // LogHandler handles HTTP requests for asset log operations.
@@ -18,9 +19,10 @@ import (
 // associated with homelab assets.
 //
 // Routes:
-//   - POST   /api/v1/assets/:id/logs  - Create log for asset (nested)
+//   - POST   /api/v1/assets/:id/logs  - Create log for asset (nested, optionally ?template=<id>)
 //   - GET    /api/v1/assets/:id/logs  - List logs for asset (nested)
 //   - GET    /api/v1/logs/:id          - Get log by ID (flat)
+//   - GET    /api/v1/logs/:id/thread   - Get the thread a log belongs to (flat)
 //   - PATCH  /api/v1/logs/:id          - Update log (flat)
 //   - DELETE /api/v1/logs/:id          - Delete log (flat)
 //
@@ -40,7 +42,8 @@ func NewLogHandler(service *service.LogService) *LogHandler {
 // ListByAsset handles GET /api/v1/assets/:id/logs
 //
 // Returns a paginated list of logs for the specified asset. This is a nested route
-// that requires the asset ID in the URL path.
+// that requires the asset ID in the URL path. Logs linked to the asset are included
+// alongside logs whose primary asset it is. Pinned logs always come first.
 //
 // Authentication: Required (user_id from context)
 //
@@ -50,12 +53,21 @@ func NewLogHandler(service *service.LogService) *LogHandler {
 // Query Parameters:
 //   - limit:  Maximum number of logs to return (default: 50, max: 200)
 //   - offset: Number of logs to skip for pagination (default: 0)
-//   - tags:   Filter by tags (optional, can specify multiple)
+//   - tags:   Filter by tags, log must have all of them (optional, can specify multiple)
+//   - tags_all: Log must have all of these tags (optional, same as tags)
+//   - tags_any: Log must have at least one of these tags (optional)
+//   - tags_none: Log must have none of these tags (optional)
+//   - kind:   Filter by log kind: note, change, incident, maintenance (optional)
 //   - search: Search in log content (optional)
 //   - start_date: Filter logs created after this date (optional)
 //   - end_date: Filter logs created before this date (optional)
+//   - created_within_days: Filter logs created in the last N days (optional, 1-3650)
 //   - sort_by: Field to sort by (default: "created_at")
 //   - sort_order: Sort direction "asc" or "desc" (default: "desc")
+//   - collapse_threads: List each matching thread once as its root log, with
+//     reply_count and last_activity_at (optional, default false)
+//   - render: Set to "html" to render each log's content (optional, see GetByID)
+//   - favorite: Only favorite (true) or non-favorite (false) logs (optional)
 //
 // Response:
 //   - 200 OK: Returns LogListResponse with logs array and pagination metadata
@@ -74,6 +86,7 @@ func NewLogHandler(service *service.LogService) *LogHandler {
 //	    {
 //	      "id": "660e8400-e29b-41d4-a716-446655440000",
 //	      "asset_id": "550e8400-e29b-41d4-a716-446655440000",
+//	      "kind": "note",
 //	      "content": "Fixed nginx by restarting service",
 //	      "tags": ["nginx", "fix"],
 //	      "created_at": "2024-03-15T14:30:00Z",
@@ -100,8 +113,8 @@ func (h *LogHandler) ListByAsset(c echo.Context) error {
 
 	// Parse query parameters
 	var params model.LogQueryParams
-	if err := c.Bind(&params); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	if err := validation.BindAndValidate(c, &params); err != nil {
+		return err
 	}
 
 	// Set defaults for pagination (logs use 50 as default)
@@ -130,6 +143,27 @@ func (h *LogHandler) ListByAsset(c echo.Context) error {
 // Request Body (JSON):
 //   - content: Log content/description (required, 2-10000 chars)
 //   - tags: Array of tag strings (optional, max 20 tags, each max 50 chars)
+//   - kind: note (default), change, incident or maintenance (optional)
+//   - severity, started_at, resolved_at, root_cause: incident fields (severity required)
+//   - planned, rollback_notes: change fields
+//   - duration_minutes: maintenance field
+//   - linked_asset_ids: Other assets the log applies to (optional, max 100)
+//   - parent_log_id: Log of the same asset this entry follows up on, making it a reply (optional)
+//   - pinned: Always list the log first for its asset (optional, default false)
+//   - favorite: Mark the log as a favorite (optional, default false)
+//   - values: Placeholder values when creating from a template (see below)
+//
+// Query Parameters:
+//   - template: UUID of a log template to render (optional)
+//
+// Template Handling:
+//   - The template content is rendered with values and replaces content
+//   - Each value is checked against its placeholder type; missing values use the default
+//   - The template's kind applies unless kind is set; its tags are added to tags
+//
+// Kind Handling:
+//   - Fields that belong to a different kind are rejected with 400
+//   - Incidents default started_at to the time of creation
 //
 // Tags Handling:
 //   - Service layer processes tags: trim, lowercase, deduplicate
@@ -140,10 +174,10 @@ func (h *LogHandler) ListByAsset(c echo.Context) error {
 //   - 201 Created: Returns LogResponse with created log including ID and timestamps
 //   - 400 Bad Request: Invalid asset ID, JSON format, or validation error
 //   - 401 Unauthorized: Missing or invalid authentication
-//   - 404 Not Found: Asset doesn't exist or belongs to another user
+//   - 404 Not Found: Asset or a linked asset doesn't exist or belongs to another user
 //
 // Security:
-//   - Service layer verifies asset ownership before creating log
+//   - Service layer verifies ownership of the asset and every linked asset before creating log
 //   - User can only create logs for their own assets
 //
 // Example Request:
@@ -184,6 +218,21 @@ func (h *LogHandler) Create(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
+	// Render from a template when requested
+	if templateParam := c.QueryParam("template"); templateParam != "" {
+		templateID, err := uuid.Parse(templateParam)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid template id")
+		}
+
+		response, err := h.service.CreateFromTemplate(c.Request().Context(), userID, assetID, templateID, &req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, response)
+	}
+
 	// Call service (service verifies asset ownership and processes tags)
 	response, err := h.service.Create(c.Request().Context(), userID, assetID, &req)
 	if err != nil {
@@ -204,9 +253,13 @@ func (h *LogHandler) Create(c echo.Context) error {
 // URL Parameters:
 //   - id: UUID of the log (required)
 //
+// Query Parameters:
+//   - render: Set to "html" to add content_html, the content as sanitized
+//     HTML, and code_blocks, its code blocks with language (optional)
+//
 // Response:
 //   - 200 OK: Returns LogResponse with the requested log
-//   - 400 Bad Request: Invalid log ID format
+//   - 400 Bad Request: Invalid log ID format or render mode
 //   - 401 Unauthorized: Missing or invalid authentication
 //   - 404 Not Found: Log doesn't exist or belongs to another user
 //
@@ -227,8 +280,74 @@ func (h *LogHandler) GetByID(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid log id")
 	}
 
+	// Parse query parameters
+	var params model.LogRenderParams
+	if err := validation.BindAndValidate(c, &params); err != nil {
+		return err
+	}
+
 	// Call service
-	response, err := h.service.GetByID(c.Request().Context(), userID, logID)
+	response, err := h.service.GetByID(c.Request().Context(), userID, logID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetThread handles GET /api/v1/logs/:id/thread
+//
+// Returns the thread a log belongs to. The ID may be the root or any reply;
+// the response always starts from the root.
+//
+// Authentication: Required (user_id from context)
+//
+// URL Parameters:
+//   - id: UUID of any log in the thread (required)
+//
+// Query Parameters:
+//   - render: Set to "html" to render every log in the thread (optional, see GetByID)
+//
+// Response:
+//   - 200 OK: Returns LogThreadResponse with root, replies (oldest first),
+//     reply_count and last_activity_at
+//   - 400 Bad Request: Invalid log ID format or render mode
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Log doesn't exist or belongs to another user
+//
+// Example Response:
+//
+//	{
+//	  "root": {"id": "660e8400-e29b-41d4-a716-446655440000", "content": "Pool degraded", ...},
+//	  "replies": [
+//	    {"id": "770e8400-e29b-41d4-a716-446655440000", "parent_log_id": "660e8400-...", "thread_root_id": "660e8400-...", "content": "Replaced sdc", ...}
+//	  ],
+//	  "reply_count": 1,
+//	  "last_activity_at": "2024-03-16T09:10:00Z"
+//	}
+func (h *LogHandler) GetThread(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate log ID from URL parameter
+	idParam := c.Param("id")
+	logID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid log id")
+	}
+
+	// Parse query parameters
+	var params model.LogRenderParams
+	if err := validation.BindAndValidate(c, &params); err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.GetThread(c.Request().Context(), userID, logID, &params)
 	if err != nil {
 		return err
 	}
@@ -250,12 +369,19 @@ func (h *LogHandler) GetByID(c echo.Context) error {
 // Request Body (JSON):
 //   - content: New log content (optional, 2-10000 chars)
 //   - tags: New tags array (optional, max 20 tags, each max 50 chars)
+//   - kind and kind-specific fields (optional, same rules as Create)
+//   - linked_asset_ids: Replaces the linked assets (optional)
+//   - pinned, favorite: Set or clear the flags (optional)
 //
 // PATCH Semantics:
 //   - Omitted fields: Not updated (keep existing value)
 //   - Provided fields: Updated to new value
 //   - tags = []: Clear all tags
 //   - tags = null or omitted: Keep existing tags
+//   - Changing kind clears the fields that belonged to the previous kind
+//   - resolved_at of an existing incident is rejected; resolve it with
+//     POST /api/v1/logs/:id/timeline instead
+//   - linked_asset_ids = []: Unlink all assets except the primary one
 //
 // Tags Handling:
 //   - Service layer processes tags: trim, lowercase, deduplicate
@@ -266,7 +392,7 @@ func (h *LogHandler) GetByID(c echo.Context) error {
 //   - 200 OK: Returns LogResponse with updated log
 //   - 400 Bad Request: Invalid log ID, JSON format, or validation error
 //   - 401 Unauthorized: Missing or invalid authentication
-//   - 404 Not Found: Log doesn't exist or belongs to another user
+//   - 404 Not Found: Log or a linked asset doesn't exist or belongs to another user
 //
 // Example Requests:
 //
@@ -318,7 +444,7 @@ func (h *LogHandler) Update(c echo.Context) error {
 // Delete handles DELETE /api/v1/logs/:id
 //
 // Deletes a log entry. This operation is idempotent - deleting an already-deleted
-// log returns 404.
+// log returns 404. Replies to the log are deleted with it.
 //
 // Authentication: Required (user_id from context)
 //
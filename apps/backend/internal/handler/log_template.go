@@ -0,0 +1,265 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for log template operations.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// LogTemplateHandler handles HTTP requests for log templates.
+// A template is a saved log layout with typed {{name}} placeholders and default
+// tags; logs are created from it with POST /api/v1/assets/:id/logs?template=<id>.
+//
+// Routes:
+//   - GET    /api/v1/log-templates            - List templates
+//   - POST   /api/v1/log-templates            - Create template
+//   - GET    /api/v1/log-templates/:id        - Get template
+//   - PATCH  /api/v1/log-templates/:id        - Update template
+//   - DELETE /api/v1/log-templates/:id        - Delete template
+//   - POST   /api/v1/log-templates/:id/render - Preview template with values
+//
+// All endpoints require authentication via the auth middleware.
+type LogTemplateHandler struct {
+	service *service.LogTemplateService
+}
+
+// NewLogTemplateHandler creates a new LogTemplateHandler with the given LogTemplateService.
+func NewLogTemplateHandler(service *service.LogTemplateService) *LogTemplateHandler {
+	return &LogTemplateHandler{
+		service: service,
+	}
+}
+
+// List handles GET /api/v1/log-templates
+//
+// Response:
+//   - 200 OK: Returns LogTemplateListResponse ordered by name
+//   - 401 Unauthorized: Missing or invalid authentication
+func (h *LogTemplateHandler) List(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetByID handles GET /api/v1/log-templates/:id
+//
+// Response:
+//   - 200 OK: Returns LogTemplate
+//   - 400 Bad Request: Invalid template ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Template doesn't exist or belongs to another user
+func (h *LogTemplateHandler) GetByID(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate template ID from URL parameter
+	idParam := c.Param("id")
+	templateID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid log template id")
+	}
+
+	// Call service
+	response, err := h.service.GetByID(c.Request().Context(), userID, templateID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Create handles POST /api/v1/log-templates
+//
+// Request Body (JSON):
+//   - name: Template name, unique per user (required, max 100 chars)
+//   - description: What the template is for (optional)
+//   - kind: Kind of logs created from it (default: note)
+//   - content: Log content with {{name}} placeholders (required, 2-10000 chars)
+//   - tags: Default tags added to every log (optional)
+//   - placeholders: Declarations with name, type (string, int, number, bool, date),
+//     required, default and description (optional, max 50)
+//
+// Every placeholder used in content must be declared.
+//
+// Response:
+//   - 201 Created: Returns LogTemplate
+//   - 400 Bad Request: Invalid body, undeclared placeholder or duplicate name
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Request:
+//
+//	{
+//	  "name": "Disk replacement",
+//	  "kind": "maintenance",
+//	  "content": "Replaced disk in bay {{bay}}, new serial {{disk_serial}}",
+//	  "tags": ["disk", "hardware"],
+//	  "placeholders": [
+//	    {"name": "bay", "type": "int", "required": true},
+//	    {"name": "disk_serial", "type": "string", "required": true}
+//	  ]
+//	}
+func (h *LogTemplateHandler) Create(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req model.CreateLogTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Create(c.Request().Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 201 Created
+	return c.JSON(http.StatusCreated, response)
+}
+
+// Update handles PATCH /api/v1/log-templates/:id
+//
+// Request Body (JSON): Any subset of the create fields. Placeholders and tags
+// are replaced as a whole when set.
+//
+// Response:
+//   - 200 OK: Returns the updated LogTemplate
+//   - 400 Bad Request: Invalid template ID or body
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Template doesn't exist or belongs to another user
+func (h *LogTemplateHandler) Update(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate template ID from URL parameter
+	idParam := c.Param("id")
+	templateID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid log template id")
+	}
+
+	// Parse request body
+	var req model.UpdateLogTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Update(c.Request().Context(), userID, templateID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Delete handles DELETE /api/v1/log-templates/:id
+//
+// Logs created from the template are kept.
+//
+// Response:
+//   - 204 No Content: Template successfully deleted
+//   - 400 Bad Request: Invalid template ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Template doesn't exist or belongs to another user
+func (h *LogTemplateHandler) Delete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate template ID from URL parameter
+	idParam := c.Param("id")
+	templateID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid log template id")
+	}
+
+	// Call service
+	err = h.service.Delete(c.Request().Context(), userID, templateID)
+	if err != nil {
+		return err
+	}
+
+	// Return 204 No Content
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Render handles POST /api/v1/log-templates/:id/render
+//
+// Previews the content a log would get from the template without creating it.
+//
+// Request Body (JSON):
+//   - values: Placeholder values keyed by name (optional)
+//
+// Response:
+//   - 200 OK: Returns RenderLogTemplateResponse with content, kind and tags
+//   - 400 Bad Request: Invalid template ID, body or values
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Template doesn't exist or belongs to another user
+//
+// Example Request:
+//
+//	{
+//	  "values": {"bay": 3, "disk_serial": "WD-WX12345"}
+//	}
+func (h *LogTemplateHandler) Render(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate template ID from URL parameter
+	idParam := c.Param("id")
+	templateID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid log template id")
+	}
+
+	// Parse request body
+	var req model.RenderLogTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Render(c.Request().Context(), userID, templateID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
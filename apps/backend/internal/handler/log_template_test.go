@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestLogTemplateHandler_GetByID_InvalidID verifies 400 when template ID is invalid
+func TestLogTemplateHandler_GetByID_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewLogTemplateHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/log-templates/invalid-uuid", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.GetByID(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestLogTemplateHandler_Render_NoAuth verifies 401 when user is not authenticated
+func TestLogTemplateHandler_Render_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewLogTemplateHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/log-templates/550e8400-e29b-41d4-a716-446655440000/render", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("550e8400-e29b-41d4-a716-446655440000")
+
+	// Act
+	err := handler.Render(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestLogHandler_Create_InvalidTemplateID verifies 400 when the template query parameter is invalid
+func TestLogHandler_Create_InvalidTemplateID(t *testing.T) {
+	// Arrange
+	handler := NewLogHandler(nil)
+
+	e := echo.New()
+	body := `{"values": {"disk_serial": "WD-123"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/assets/550e8400-e29b-41d4-a716-446655440000/logs?template=nope", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("550e8400-e29b-41d4-a716-446655440000")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.Create(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Equal(t, "invalid template id", httpErr.Message)
+}
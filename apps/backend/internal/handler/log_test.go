@@ -87,3 +87,26 @@ func TestLogHandler_Constructor(t *testing.T) {
 	assert.NotNil(t, handler)
 	assert.IsType(t, &LogHandler{}, handler)
 }
+
+// TestLogHandler_GetThread_InvalidID verifies 400 when log ID is invalid
+func TestLogHandler_GetThread_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewLogHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/logs/invalid-uuid/thread", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.GetThread(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
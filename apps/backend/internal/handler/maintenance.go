@@ -0,0 +1,311 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for recurring maintenance task operations.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// MaintenanceHandler handles HTTP requests for recurring maintenance tasks.
+// Tasks belong to an asset and recur on an RRULE schedule; completing a task
+// records a maintenance log on the asset and schedules the next occurrence.
+//
+// Routes:
+//   - GET    /api/v1/assets/:id/maintenance-tasks   - List tasks for an asset
+//   - POST   /api/v1/assets/:id/maintenance-tasks   - Create task for an asset
+//   - GET    /api/v1/maintenance-tasks              - List all tasks by due date
+//   - GET    /api/v1/maintenance-tasks/:id          - Get single task
+//   - PATCH  /api/v1/maintenance-tasks/:id          - Update task
+//   - DELETE /api/v1/maintenance-tasks/:id          - Delete task
+//   - POST   /api/v1/maintenance-tasks/:id/complete - Complete task
+//
+// All endpoints require authentication via the auth middleware.
+type MaintenanceHandler struct {
+	service *service.MaintenanceService
+}
+
+// NewMaintenanceHandler creates a new MaintenanceHandler with the given MaintenanceService.
+func NewMaintenanceHandler(service *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		service: service,
+	}
+}
+
+// ListByAsset handles GET /api/v1/assets/:id/maintenance-tasks
+//
+// Query Parameters:
+//   - overdue: true for tasks due now or earlier, false for the rest (optional)
+//   - due_by: Only tasks due on or before this time (optional)
+//
+// Response:
+//   - 200 OK: Returns MaintenanceTaskListResponse ordered by next due date
+//   - 400 Bad Request: Invalid asset ID or query parameters
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset doesn't exist or belongs to another user
+func (h *MaintenanceHandler) ListByAsset(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate asset ID from URL parameter
+	idParam := c.Param("id")
+	assetID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid asset id")
+	}
+
+	// Parse query parameters
+	var params model.MaintenanceTaskQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.ListByAsset(c.Request().Context(), userID, assetID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// List handles GET /api/v1/maintenance-tasks
+//
+// Lists maintenance tasks across all of the user's assets, soonest due first.
+// Accepts the same query parameters as ListByAsset.
+//
+// Response:
+//   - 200 OK: Returns MaintenanceTaskListResponse
+//   - 400 Bad Request: Invalid query parameters
+//   - 401 Unauthorized: Missing or invalid authentication
+func (h *MaintenanceHandler) List(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.MaintenanceTaskQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.List(c.Request().Context(), userID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Create handles POST /api/v1/assets/:id/maintenance-tasks
+//
+// Request Body (JSON):
+//   - title: Task title (required, max 200 chars)
+//   - description: Task description (optional, max 5000 chars)
+//   - recurrence: RRULE subset, e.g. "FREQ=MONTHLY" or "FREQ=YEARLY;INTERVAL=3" (required)
+//     Supported parts: FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, UNTIL
+//   - starts_at: First due date (optional, default: now)
+//
+// Response:
+//   - 201 Created: Returns MaintenanceTaskResponse
+//   - 400 Bad Request: Invalid asset ID, body or recurrence rule
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset doesn't exist or belongs to another user
+//
+// Example Request:
+//
+//	{"title": "Scrub ZFS pool", "recurrence": "FREQ=MONTHLY", "starts_at": "2025-02-01T02:00:00Z"}
+func (h *MaintenanceHandler) Create(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate asset ID from URL parameter
+	idParam := c.Param("id")
+	assetID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid asset id")
+	}
+
+	// Parse request body
+	var req model.CreateMaintenanceTaskRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service (service validates the recurrence rule)
+	response, err := h.service.Create(c.Request().Context(), userID, assetID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 201 Created
+	return c.JSON(http.StatusCreated, response)
+}
+
+// GetByID handles GET /api/v1/maintenance-tasks/:id
+//
+// Response:
+//   - 200 OK: Returns MaintenanceTaskResponse
+//   - 400 Bad Request: Invalid task ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Task doesn't exist or belongs to another user
+func (h *MaintenanceHandler) GetByID(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate task ID from URL parameter
+	idParam := c.Param("id")
+	taskID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid maintenance task id")
+	}
+
+	// Call service
+	response, err := h.service.GetByID(c.Request().Context(), userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Update handles PATCH /api/v1/maintenance-tasks/:id
+//
+// All fields are optional. Changing recurrence or starts_at recomputes the
+// next due date from the new schedule and the last completion.
+//
+// Response:
+//   - 200 OK: Returns updated MaintenanceTaskResponse
+//   - 400 Bad Request: Invalid task ID, body or recurrence rule
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Task doesn't exist or belongs to another user
+func (h *MaintenanceHandler) Update(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate task ID from URL parameter
+	idParam := c.Param("id")
+	taskID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid maintenance task id")
+	}
+
+	// Parse request body
+	var req model.UpdateMaintenanceTaskRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Update(c.Request().Context(), userID, taskID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Delete handles DELETE /api/v1/maintenance-tasks/:id
+//
+// Logs recorded by past completions are kept.
+//
+// Response:
+//   - 204 No Content: Task successfully deleted
+//   - 400 Bad Request: Invalid task ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Task doesn't exist or belongs to another user
+func (h *MaintenanceHandler) Delete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate task ID from URL parameter
+	idParam := c.Param("id")
+	taskID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid maintenance task id")
+	}
+
+	// Call service
+	err = h.service.Delete(c.Request().Context(), userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	// Return 204 No Content
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Complete handles POST /api/v1/maintenance-tasks/:id/complete
+//
+// Records a maintenance log on the task's asset and advances the task to its
+// next occurrence. The task keeps its cadence: a monthly task due on the 1st
+// that is completed on the 10th is next due on the 1st of the following month.
+//
+// Request Body (JSON, all optional):
+//   - notes: Appended to the log content (max 10000 chars)
+//   - completed_at: When the work was done (default: now, must not be in the future)
+//   - duration_minutes: Time spent, stored on the maintenance log
+//   - tags: Tags for the log
+//
+// Response:
+//   - 200 OK: Returns CompleteMaintenanceTaskResponse with the task and the new log
+//   - 400 Bad Request: Invalid task ID or body
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Task doesn't exist or belongs to another user
+func (h *MaintenanceHandler) Complete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate task ID from URL parameter
+	idParam := c.Param("id")
+	taskID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid maintenance task id")
+	}
+
+	// Parse request body
+	var req model.CompleteMaintenanceTaskRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Complete(c.Request().Context(), userID, taskID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestMaintenanceHandler_Create_InvalidAssetID verifies 400 when asset ID is invalid
+func TestMaintenanceHandler_Create_InvalidAssetID(t *testing.T) {
+	// Arrange
+	handler := NewMaintenanceHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/assets/invalid-uuid/maintenance-tasks", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.Create(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestMaintenanceHandler_Complete_InvalidTaskID verifies 400 when task ID is invalid
+func TestMaintenanceHandler_Complete_InvalidTaskID(t *testing.T) {
+	// Arrange
+	handler := NewMaintenanceHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/maintenance-tasks/invalid-uuid/complete", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.Complete(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestMaintenanceHandler_List_NoAuth verifies an error when user_id missing
+func TestMaintenanceHandler_List_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewMaintenanceHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/maintenance-tasks", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.List(c)
+
+	// Assert
+	assert.Error(t, err)
+}
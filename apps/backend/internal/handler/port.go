@@ -0,0 +1,183 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for asset port operations.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// PortHandler handles HTTP requests for the network ports assets expose, such
+// as node_exporter on 9100/tcp. Ports feed Prometheus service discovery.
+//
+// Routes:
+//   - GET    /api/v1/assets/:id/ports - List ports of an asset
+//   - POST   /api/v1/assets/:id/ports - Add port to an asset
+//   - PATCH  /api/v1/ports/:id        - Update port
+//   - DELETE /api/v1/ports/:id        - Delete port
+//
+// All endpoints require authentication via the auth middleware.
+type PortHandler struct {
+	service *service.PortService
+}
+
+// NewPortHandler creates a new PortHandler with the given PortService.
+func NewPortHandler(service *service.PortService) *PortHandler {
+	return &PortHandler{
+		service: service,
+	}
+}
+
+// ListByAsset handles GET /api/v1/assets/:id/ports
+//
+// Response:
+//   - 200 OK: Returns AssetPortListResponse ordered by port and protocol
+//   - 400 Bad Request: Invalid asset ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset doesn't exist or belongs to another user
+func (h *PortHandler) ListByAsset(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate asset ID from URL parameter
+	idParam := c.Param("id")
+	assetID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid asset id")
+	}
+
+	// Call service
+	response, err := h.service.ListByAsset(c.Request().Context(), userID, assetID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Create handles POST /api/v1/assets/:id/ports
+//
+// Request Body (JSON):
+//   - port: Port number (required, 1-65535)
+//   - protocol: "tcp" or "udp" (optional, default: tcp)
+//   - service: What listens on the port, e.g. node_exporter (optional, max 100 chars)
+//   - description: Free-form notes (optional, max 1000 chars)
+//
+// Response:
+//   - 201 Created: Returns AssetPort
+//   - 400 Bad Request: Invalid asset ID or body, or the asset already has the port
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset doesn't exist or belongs to another user
+//
+// Example Request:
+//
+//	{"port": 9100, "service": "node_exporter"}
+func (h *PortHandler) Create(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate asset ID from URL parameter
+	idParam := c.Param("id")
+	assetID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid asset id")
+	}
+
+	// Parse request body
+	var req model.CreateAssetPortRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Create(c.Request().Context(), userID, assetID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 201 Created
+	return c.JSON(http.StatusCreated, response)
+}
+
+// Update handles PATCH /api/v1/ports/:id
+//
+// All fields are optional; an empty service clears it.
+//
+// Response:
+//   - 200 OK: Returns updated AssetPort
+//   - 400 Bad Request: Invalid port ID or body, or the asset already has the port
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Port doesn't exist or belongs to another user
+func (h *PortHandler) Update(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate port ID from URL parameter
+	idParam := c.Param("id")
+	portID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid port id")
+	}
+
+	// Parse request body
+	var req model.UpdateAssetPortRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Update(c.Request().Context(), userID, portID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Delete handles DELETE /api/v1/ports/:id
+//
+// Response:
+//   - 204 No Content: Port successfully deleted
+//   - 400 Bad Request: Invalid port ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Port doesn't exist or belongs to another user
+func (h *PortHandler) Delete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate port ID from URL parameter
+	idParam := c.Param("id")
+	portID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid port id")
+	}
+
+	// Call service
+	err = h.service.Delete(c.Request().Context(), userID, portID)
+	if err != nil {
+		return err
+	}
+
+	// Return 204 No Content
+	return c.NoContent(http.StatusNoContent)
+}
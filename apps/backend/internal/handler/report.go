@@ -0,0 +1,80 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for reports.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// ReportHandler handles HTTP requests for reports over the user's inventory.
+//
+// Routes:
+//   - GET /api/v1/reports/stale-assets - Assets with no recent activity
+//
+// All endpoints require authentication via the auth middleware.
+type ReportHandler struct {
+	service *service.ReportService
+}
+
+// NewReportHandler creates a new ReportHandler with the given ReportService.
+func NewReportHandler(service *service.ReportService) *ReportHandler {
+	return &ReportHandler{
+		service: service,
+	}
+}
+
+// StaleAssets handles GET /api/v1/reports/stale-assets
+//
+// Lists assets with no logs and no updates in the last N days, stalest first.
+// Logs linked to an asset count as activity on it. The same report is emailed
+// weekly, with the default period, to users who have stale assets.
+//
+// Query Parameters:
+//   - days: Inactivity period in days (default: 90, max: 3650)
+//   - limit: Maximum number of assets to return (default: 100, max: 1000)
+//   - view_id: Only report the assets of a saved asset view
+//
+// Response:
+//   - 200 OK: Returns StaleAssetReport with the assets and the total number stale
+//   - 400 Bad Request: Invalid query parameters, or a log view
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Saved view not found
+//
+// Example Response:
+//
+//	{
+//	  "days": 90,
+//	  "assets": [
+//	    {"id": "550e8400-...", "name": "old-nas", "type": "nas", "updated_at": "2023-11-02T10:00:00Z",
+//	     "last_log_at": "2024-01-15T09:30:00Z", "last_activity_at": "2024-01-15T09:30:00Z", "days_stale": 214}
+//	  ],
+//	  "total": 1
+//	}
+func (h *ReportHandler) StaleAssets(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.StaleAssetQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.StaleAssets(c.Request().Context(), userID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,449 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for runbook and runbook run operations.
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// RunbookHandler handles HTTP requests for runbooks and their execution.
+// A runbook is a reusable markdown procedure made of ordered steps. Running it
+// against an asset creates a run whose steps are checked off one by one; finishing
+// or aborting the run records a single consolidated maintenance log on the asset.
+//
+// Routes:
+//   - GET    /api/v1/runbooks                         - List runbooks
+//   - POST   /api/v1/runbooks                         - Create runbook
+//   - GET    /api/v1/runbooks/:id                     - Get runbook with steps
+//   - PATCH  /api/v1/runbooks/:id                     - Update runbook
+//   - DELETE /api/v1/runbooks/:id                     - Delete runbook
+//   - POST   /api/v1/runbooks/:id/runs                - Start a run against an asset
+//   - GET    /api/v1/assets/:id/runbooks              - List runbooks applicable to an asset
+//   - GET    /api/v1/runbook-runs/:id                 - Get run with step state
+//   - PATCH  /api/v1/runbook-runs/:id/steps/:position - Check off a step / add notes
+//   - POST   /api/v1/runbook-runs/:id/finish          - Finish run and record log
+//   - POST   /api/v1/runbook-runs/:id/abort           - Abort run and record log
+//
+// All endpoints require authentication via the auth middleware.
+type RunbookHandler struct {
+	service *service.RunbookService
+}
+
+// NewRunbookHandler creates a new RunbookHandler with the given RunbookService.
+func NewRunbookHandler(service *service.RunbookService) *RunbookHandler {
+	return &RunbookHandler{
+		service: service,
+	}
+}
+
+// List handles GET /api/v1/runbooks
+//
+// Query Parameters:
+//   - asset_type: Only runbooks attached to this asset type (optional)
+//   - search: Case-insensitive title search (optional)
+//
+// Response:
+//   - 200 OK: Returns RunbookListResponse (runbooks without steps)
+//   - 400 Bad Request: Invalid query parameters
+//   - 401 Unauthorized: Missing or invalid authentication
+func (h *RunbookHandler) List(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.RunbookQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.List(c.Request().Context(), userID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// ListForAsset handles GET /api/v1/assets/:id/runbooks
+//
+// Returns runbooks attached to the asset, to its type, or to nothing (general runbooks).
+//
+// Response:
+//   - 200 OK: Returns RunbookListResponse
+//   - 400 Bad Request: Invalid asset ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset doesn't exist or belongs to another user
+func (h *RunbookHandler) ListForAsset(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate asset ID from URL parameter
+	idParam := c.Param("id")
+	assetID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid asset id")
+	}
+
+	// Call service
+	response, err := h.service.ListForAsset(c.Request().Context(), userID, assetID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Create handles POST /api/v1/runbooks
+//
+// Request Body (JSON):
+//   - title: Runbook title (required, max 200 chars)
+//   - description: Markdown description (optional)
+//   - asset_types: Asset types the runbook applies to (optional)
+//   - asset_ids: Individual assets the runbook applies to (optional)
+//   - steps: Ordered steps, each with title (required) and markdown body (1-100 steps)
+//
+// A runbook with neither asset_types nor asset_ids applies to every asset.
+//
+// Response:
+//   - 201 Created: Returns Runbook with steps
+//   - 400 Bad Request: Invalid body or steps
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: An asset in asset_ids doesn't exist or belongs to another user
+//
+// Example Request:
+//
+//	{
+//	  "title": "Replace failed disk",
+//	  "asset_types": ["nas"],
+//	  "steps": [
+//	    {"title": "Identify the failed disk", "body": "`zpool status -v`"},
+//	    {"title": "Swap the disk"},
+//	    {"title": "Resilver", "body": "`zpool replace tank <old> <new>`"}
+//	  ]
+//	}
+func (h *RunbookHandler) Create(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req model.CreateRunbookRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Create(c.Request().Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 201 Created
+	return c.JSON(http.StatusCreated, response)
+}
+
+// GetByID handles GET /api/v1/runbooks/:id
+//
+// Response:
+//   - 200 OK: Returns Runbook with steps
+//   - 400 Bad Request: Invalid runbook ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Runbook doesn't exist or belongs to another user
+func (h *RunbookHandler) GetByID(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate runbook ID from URL parameter
+	idParam := c.Param("id")
+	runbookID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid runbook id")
+	}
+
+	// Call service
+	response, err := h.service.GetByID(c.Request().Context(), userID, runbookID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Update handles PATCH /api/v1/runbooks/:id
+//
+// All fields are optional. steps, asset_types and asset_ids replace the existing
+// values when present. Runs already in progress keep the steps they started with.
+//
+// Response:
+//   - 200 OK: Returns updated Runbook with steps
+//   - 400 Bad Request: Invalid runbook ID, body or steps
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Runbook or an attached asset doesn't exist or belongs to another user
+func (h *RunbookHandler) Update(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate runbook ID from URL parameter
+	idParam := c.Param("id")
+	runbookID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid runbook id")
+	}
+
+	// Parse request body
+	var req model.UpdateRunbookRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Update(c.Request().Context(), userID, runbookID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Delete handles DELETE /api/v1/runbooks/:id
+//
+// Past runs and their logs are kept.
+//
+// Response:
+//   - 204 No Content: Runbook successfully deleted
+//   - 400 Bad Request: Invalid runbook ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Runbook doesn't exist or belongs to another user
+func (h *RunbookHandler) Delete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate runbook ID from URL parameter
+	idParam := c.Param("id")
+	runbookID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid runbook id")
+	}
+
+	// Call service
+	err = h.service.Delete(c.Request().Context(), userID, runbookID)
+	if err != nil {
+		return err
+	}
+
+	// Return 204 No Content
+	return c.NoContent(http.StatusNoContent)
+}
+
+// StartRun handles POST /api/v1/runbooks/:id/runs
+//
+// Request Body (JSON):
+//   - asset_id: Asset to run the runbook against (required)
+//
+// Response:
+//   - 201 Created: Returns RunbookRun with unchecked steps
+//   - 400 Bad Request: Invalid runbook ID or body, or the runbook doesn't apply to the asset
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Runbook or asset doesn't exist or belongs to another user
+func (h *RunbookHandler) StartRun(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate runbook ID from URL parameter
+	idParam := c.Param("id")
+	runbookID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid runbook id")
+	}
+
+	// Parse request body
+	var req model.StartRunbookRunRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.StartRun(c.Request().Context(), userID, runbookID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 201 Created
+	return c.JSON(http.StatusCreated, response)
+}
+
+// GetRun handles GET /api/v1/runbook-runs/:id
+//
+// Response:
+//   - 200 OK: Returns RunbookRun with step state
+//   - 400 Bad Request: Invalid run ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Run doesn't exist or belongs to another user
+func (h *RunbookHandler) GetRun(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate run ID from URL parameter
+	idParam := c.Param("id")
+	runID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid runbook run id")
+	}
+
+	// Call service
+	response, err := h.service.GetRun(c.Request().Context(), userID, runID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateRunStep handles PATCH /api/v1/runbook-runs/:id/steps/:position
+//
+// Request Body (JSON, at least one field):
+//   - checked: Check or uncheck the step
+//   - notes: Notes for the step (max 5000 chars)
+//
+// Response:
+//   - 200 OK: Returns updated RunbookRun
+//   - 400 Bad Request: Invalid run ID, position or body, or the run is finished
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Run or step doesn't exist or belongs to another user
+func (h *RunbookHandler) UpdateRunStep(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate run ID from URL parameter
+	idParam := c.Param("id")
+	runID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid runbook run id")
+	}
+
+	// Parse and validate step position from URL parameter
+	position, err := strconv.Atoi(c.Param("position"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid step position")
+	}
+
+	// Parse request body
+	var req model.UpdateRunbookRunStepRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.UpdateRunStep(c.Request().Context(), userID, runID, position, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// FinishRun handles POST /api/v1/runbook-runs/:id/finish
+//
+// Completes the run and records a single maintenance log on the asset containing
+// the checklist of steps and their notes. Unchecked steps are kept in the log.
+//
+// Request Body (JSON, optional):
+//   - notes: Summary appended to the log (max 5000 chars)
+//
+// Response:
+//   - 200 OK: Returns CloseRunbookRunResponse with the run and the new log
+//   - 400 Bad Request: Invalid run ID or body, or the run is already finished
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Run doesn't exist or belongs to another user
+func (h *RunbookHandler) FinishRun(c echo.Context) error {
+	return h.closeRun(c, h.service.Finish)
+}
+
+// AbortRun handles POST /api/v1/runbook-runs/:id/abort
+//
+// Stops the run early and records what was done as a single maintenance log.
+//
+// Request Body (JSON, optional):
+//   - notes: Reason for aborting (max 5000 chars)
+//
+// Response:
+//   - 200 OK: Returns CloseRunbookRunResponse with the run and the new log
+//   - 400 Bad Request: Invalid run ID or body, or the run is already finished
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Run doesn't exist or belongs to another user
+func (h *RunbookHandler) AbortRun(c echo.Context) error {
+	return h.closeRun(c, h.service.Abort)
+}
+
+// closeRun is shared by FinishRun and AbortRun, which differ only in the service call
+func (h *RunbookHandler) closeRun(c echo.Context, closeFn func(ctx context.Context, userID string, runID uuid.UUID, req *model.CloseRunbookRunRequest) (*model.CloseRunbookRunResponse, error)) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate run ID from URL parameter
+	idParam := c.Param("id")
+	runID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid runbook run id")
+	}
+
+	// Parse request body
+	var req model.CloseRunbookRunRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := closeFn(c.Request().Context(), userID, runID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
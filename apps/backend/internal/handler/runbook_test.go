@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/middleware"
+)
+
+// TestRunbookHandler_GetByID_InvalidID verifies 400 when runbook ID is invalid
+func TestRunbookHandler_GetByID_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewRunbookHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runbooks/invalid-uuid", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.GetByID(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestRunbookHandler_UpdateRunStep_InvalidPosition verifies 400 when step position is not a number
+func TestRunbookHandler_UpdateRunStep_InvalidPosition(t *testing.T) {
+	// Arrange
+	handler := NewRunbookHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/runbook-runs/550e8400-e29b-41d4-a716-446655440000/steps/first", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id", "position")
+	c.SetParamValues("550e8400-e29b-41d4-a716-446655440000", "first")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.UpdateRunStep(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// TestRunbookHandler_FinishRun_InvalidRunID verifies 400 when run ID is invalid
+func TestRunbookHandler_FinishRun_InvalidRunID(t *testing.T) {
+	// Arrange
+	handler := NewRunbookHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runbook-runs/invalid-uuid/finish", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid-uuid")
+	c.Set(middleware.UserIDKey, "user-123")
+
+	// Act
+	err := handler.FinishRun(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
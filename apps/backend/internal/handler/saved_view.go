@@ -0,0 +1,251 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for saved view operations.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// SavedViewHandler handles HTTP requests for saved views.
+// A saved view is a named set of asset or log list filters; running it returns
+// the same response as the list endpoint with those filters.
+//
+// Routes:
+//   - GET    /api/v1/views             - List saved views
+//   - POST   /api/v1/views             - Create saved view
+//   - GET    /api/v1/views/:id         - Get saved view
+//   - PATCH  /api/v1/views/:id         - Update saved view
+//   - DELETE /api/v1/views/:id         - Delete saved view
+//   - GET    /api/v1/views/:id/results - Run saved view
+//
+// All endpoints require authentication via the auth middleware.
+type SavedViewHandler struct {
+	service *service.SavedViewService
+}
+
+// NewSavedViewHandler creates a new SavedViewHandler with the given SavedViewService.
+func NewSavedViewHandler(service *service.SavedViewService) *SavedViewHandler {
+	return &SavedViewHandler{
+		service: service,
+	}
+}
+
+// List handles GET /api/v1/views
+//
+// Response:
+//   - 200 OK: Returns SavedViewListResponse ordered by name
+//   - 401 Unauthorized: Missing or invalid authentication
+func (h *SavedViewHandler) List(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Call service
+	response, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetByID handles GET /api/v1/views/:id
+//
+// Response:
+//   - 200 OK: Returns SavedView
+//   - 400 Bad Request: Invalid view ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: View doesn't exist or belongs to another user
+func (h *SavedViewHandler) GetByID(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate view ID from URL parameter
+	idParam := c.Param("id")
+	viewID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid view id")
+	}
+
+	// Call service
+	response, err := h.service.GetByID(c.Request().Context(), userID, viewID)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Create handles POST /api/v1/views
+//
+// Request Body (JSON):
+//   - name: View name, unique per user (required, max 100 chars)
+//   - description: What the view shows (optional)
+//   - target: "assets" or "logs" (required)
+//   - asset_id: Asset whose logs a log view lists (required for log views only)
+//   - params: Filters keyed like the query parameters of GET /api/v1/assets or
+//     GET /api/v1/assets/:id/logs (optional). Unknown keys are rejected.
+//
+// Response:
+//   - 201 Created: Returns SavedView
+//   - 400 Bad Request: Invalid body or params, or duplicate name
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: Asset doesn't exist or belongs to another user
+//
+// Example Request:
+//
+//	{
+//	  "name": "Recently touched prod VMs",
+//	  "target": "assets",
+//	  "params": {"type": "vm", "tags": ["prod"], "updated_within_days": 30, "sort_by": "updated_at"}
+//	}
+func (h *SavedViewHandler) Create(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req model.CreateSavedViewRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Create(c.Request().Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response with 201 Created
+	return c.JSON(http.StatusCreated, response)
+}
+
+// Update handles PATCH /api/v1/views/:id
+//
+// Request Body (JSON): Any of name, description, asset_id and params. The
+// target cannot change; params are replaced as a whole when set.
+//
+// Response:
+//   - 200 OK: Returns the updated SavedView
+//   - 400 Bad Request: Invalid view ID, body or params
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: View or asset doesn't exist or belongs to another user
+func (h *SavedViewHandler) Update(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate view ID from URL parameter
+	idParam := c.Param("id")
+	viewID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid view id")
+	}
+
+	// Parse request body
+	var req model.UpdateSavedViewRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Update(c.Request().Context(), userID, viewID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Delete handles DELETE /api/v1/views/:id
+//
+// Response:
+//   - 204 No Content: View successfully deleted
+//   - 400 Bad Request: Invalid view ID
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: View doesn't exist or belongs to another user
+func (h *SavedViewHandler) Delete(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate view ID from URL parameter
+	idParam := c.Param("id")
+	viewID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid view id")
+	}
+
+	// Call service
+	err = h.service.Delete(c.Request().Context(), userID, viewID)
+	if err != nil {
+		return err
+	}
+
+	// Return 204 No Content
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Run handles GET /api/v1/views/:id/results
+//
+// Runs the view's filters through the list endpoint of its target.
+//
+// Query Parameters:
+//   - limit: Page size, overriding the view's limit (optional)
+//   - offset: Number of results to skip, overriding the view's offset (optional)
+//
+// Response:
+//   - 200 OK: Returns AssetListResponse for asset views or LogListResponse for log views
+//   - 400 Bad Request: Invalid view ID or query parameters
+//   - 401 Unauthorized: Missing or invalid authentication
+//   - 404 Not Found: View doesn't exist or belongs to another user
+func (h *SavedViewHandler) Run(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse and validate view ID from URL parameter
+	idParam := c.Param("id")
+	viewID, err := uuid.Parse(idParam)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid view id")
+	}
+
+	// Parse query parameters
+	var params model.RunSavedViewParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.Run(c.Request().Context(), userID, viewID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
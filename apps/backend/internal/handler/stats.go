@@ -0,0 +1,88 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for the dashboard statistics.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// StatsHandler handles HTTP requests for the dashboard statistics.
+//
+// Routes:
+//   - GET /api/v1/stats - Dashboard statistics
+//
+// All endpoints require authentication via the auth middleware.
+type StatsHandler struct {
+	service *service.StatsService
+}
+
+// NewStatsHandler creates a new StatsHandler with the given StatsService.
+func NewStatsHandler(service *service.StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// Get handles GET /api/v1/stats
+//
+// Returns the user's dashboard statistics, computed in SQL and cached per user
+// for up to ten minutes. Any write to the user's assets, logs or tags drops the
+// cache, so the statistics reflect it on the next request.
+//
+// Asset statuses are derived: "incident" for assets with an unresolved incident,
+// "stale" for assets with no logs or updates in 90 days, "active" otherwise.
+// Assets without a type are counted under "none". Storage is the stored size of
+// the user's asset and log rows.
+//
+// Query Parameters:
+//   - days: Window for logs_per_day and most_active_assets (default: 30, max: 365)
+//   - limit: Length of top_tags and most_active_assets (default: 10, max: 50)
+//
+// Response:
+//   - 200 OK: Returns DashboardStats
+//   - 400 Bad Request: Invalid query parameters
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Response:
+//
+//	{
+//	  "days": 30,
+//	  "assets": {
+//	    "total": 12,
+//	    "by_type": [{"key": "vm", "count": 7}, {"key": "server", "count": 3}, {"key": "none", "count": 2}],
+//	    "by_status": [{"key": "active", "count": 10}, {"key": "stale", "count": 1}, {"key": "incident", "count": 1}]
+//	  },
+//	  "logs_per_day": [{"date": "2024-05-01", "count": 4}, {"date": "2024-05-02", "count": 0}],
+//	  "top_tags": [{"name": "zfs", "count": 18, "log_count": 16, "asset_count": 2, "last_used_at": "2024-05-30T10:00:00Z"}],
+//	  "most_active_assets": [{"id": "550e8400-...", "name": "nas-01", "type": "nas", "log_count": 9, "last_log_at": "2024-05-30T10:00:00Z"}],
+//	  "storage": {"asset_bytes": 5120, "log_bytes": 81920, "total_bytes": 87040},
+//	  "generated_at": "2024-05-30T12:00:00Z"
+//	}
+func (h *StatsHandler) Get(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.StatsQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.Get(c.Request().Context(), userID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
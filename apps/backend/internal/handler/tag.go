@@ -0,0 +1,164 @@
+// Package handler provides HTTP request handlers for the Ark API.
+// This file contains handlers for tag vocabulary operations.
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/middleware"
+	"ark/internal/model"
+	"ark/internal/service"
+)
+
+// TagHandler handles HTTP requests for the tag vocabulary. Tags live on logs,
+// assets and log templates; these endpoints list them and rewrite them in bulk.
+//
+// Routes:
+//   - GET  /api/v1/tags        - List tags with usage counts
+//   - POST /api/v1/tags/rename - Rename a tag everywhere
+//   - POST /api/v1/tags/merge  - Merge several tags into one everywhere
+//
+// All endpoints require authentication via the auth middleware.
+type TagHandler struct {
+	service *service.TagService
+}
+
+// NewTagHandler creates a new TagHandler with the given TagService.
+func NewTagHandler(service *service.TagService) *TagHandler {
+	return &TagHandler{
+		service: service,
+	}
+}
+
+// List handles GET /api/v1/tags
+//
+// Query Parameters:
+//   - prefix: Only tags starting with this prefix, for autocomplete (optional)
+//   - limit: Maximum number of tags to return (default: 100, max: 1000)
+//
+// Response:
+//   - 200 OK: Returns TagListResponse ordered by count, most used first, with
+//     separate log and asset counts showing where each tag is used
+//   - 400 Bad Request: Invalid query parameters
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Response:
+//
+//	{
+//	  "tags": [
+//	    {"name": "nginx", "count": 42, "log_count": 40, "asset_count": 2, "last_used_at": "2024-03-15T14:30:00Z"},
+//	    {"name": "ngnix", "count": 3, "log_count": 3, "asset_count": 0, "last_used_at": "2024-01-02T09:00:00Z"}
+//	  ],
+//	  "total": 2
+//	}
+func (h *TagHandler) List(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse query parameters
+	var params model.TagQueryParams
+	if err := c.Bind(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid query parameters")
+	}
+
+	// Call service
+	response, err := h.service.List(c.Request().Context(), userID, &params)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Rename handles POST /api/v1/tags/rename
+//
+// Rewrites the tag on all of the user's logs, assets and log templates in one
+// transaction. Renaming onto a tag that is already in use merges the two.
+//
+// Request Body (JSON):
+//   - from: Tag to rename (required)
+//   - to: New tag name (required, max 50 chars)
+//
+// Response:
+//   - 200 OK: Returns TagOperationResponse with the number of logs, assets and templates updated
+//   - 400 Bad Request: Invalid body, or from and to are the same tag
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Request:
+//
+//	{
+//	  "from": "ngnix",
+//	  "to": "nginx"
+//	}
+func (h *TagHandler) Rename(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req model.RenameTagRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Rename(c.Request().Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
+
+// Merge handles POST /api/v1/tags/merge
+//
+// Replaces every source tag with the target on all of the user's logs, assets
+// and log templates in one transaction. A log or asset carrying several sources
+// ends up with the target once.
+//
+// Request Body (JSON):
+//   - sources: Tags to merge (required, 1-50 tags)
+//   - target: Tag to merge into (required, max 50 chars)
+//
+// Response:
+//   - 200 OK: Returns TagOperationResponse with the number of logs, assets and templates updated
+//   - 400 Bad Request: Invalid body, or no source other than the target
+//   - 401 Unauthorized: Missing or invalid authentication
+//
+// Example Request:
+//
+//	{
+//	  "sources": ["k8s", "kube"],
+//	  "target": "kubernetes"
+//	}
+func (h *TagHandler) Merge(c echo.Context) error {
+	// Extract user_id from context
+	userID, err := middleware.GetUserIDOrError(c)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req model.MergeTagsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Call service
+	response, err := h.service.Merge(c.Request().Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	// Return response
+	return c.JSON(http.StatusOK, response)
+}
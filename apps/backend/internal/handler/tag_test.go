@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTagHandler_List_NoAuth verifies 401 when user_id missing
+func TestTagHandler_List_NoAuth(t *testing.T) {
+	// Arrange
+	handler := NewTagHandler(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tags?prefix=ng", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Act
+	err := handler.List(c)
+
+	// Assert
+	assert.Error(t, err)
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok, "error should be *echo.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+// TestTagHandler_Constructor verifies NewTagHandler works correctly
+func TestTagHandler_Constructor(t *testing.T) {
+	handler := NewTagHandler(nil)
+
+	assert.NotNil(t, handler)
+	assert.IsType(t, &TagHandler{}, handler)
+}
@@ -1,5 +1,10 @@
 package email
 
+import (
+	"fmt"
+	"time"
+)
+
 func (c *Client) SendWelcomeEmail(to, firstName string) error {
 	data := map[string]string{
 		"UserFirstName": firstName,
@@ -12,3 +17,60 @@ func (c *Client) SendWelcomeEmail(to, firstName string) error {
 		data,
 	)
 }
+
+// MaintenanceReminderItem is one overdue task listed in a maintenance reminder email
+type MaintenanceReminderItem struct {
+	Title     string    `json:"title"`
+	AssetName string    `json:"asset_name"`
+	DueAt     time.Time `json:"due_at"`
+}
+
+func (c *Client) SendMaintenanceReminderEmail(to string, tasks []MaintenanceReminderItem) error {
+	subject := "1 maintenance task is due"
+	if len(tasks) != 1 {
+		subject = fmt.Sprintf("%d maintenance tasks are due", len(tasks))
+	}
+
+	data := map[string]any{
+		"TaskCount": len(tasks),
+		"Tasks":     tasks,
+	}
+
+	return c.SendEmail(
+		to,
+		subject,
+		TemplateMaintenanceReminder,
+		data,
+	)
+}
+
+// StaleAssetItem is one asset listed in a stale asset report email
+type StaleAssetItem struct {
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	DaysStale      int       `json:"days_stale"`
+}
+
+// SendStaleAssetsEmail sends the weekly stale asset report. total is the number
+// of stale assets, of which assets lists the stalest.
+func (c *Client) SendStaleAssetsEmail(to string, days int, total int, assets []StaleAssetItem) error {
+	subject := "1 asset has gone stale"
+	if total != 1 {
+		subject = fmt.Sprintf("%d assets have gone stale", total)
+	}
+
+	data := map[string]any{
+		"Days":   days,
+		"Total":  total,
+		"More":   total - len(assets),
+		"Assets": assets,
+	}
+
+	return c.SendEmail(
+		to,
+		subject,
+		TemplateStaleAssets,
+		data,
+	)
+}
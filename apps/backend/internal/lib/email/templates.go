@@ -3,5 +3,7 @@ package email
 type Template string
 
 const (
-	TemplateWelcome Template = "welcome"
+	TemplateWelcome             Template = "welcome"
+	TemplateMaintenanceReminder Template = "maintenance_reminder"
+	TemplateStaleAssets         Template = "stale_assets"
 )
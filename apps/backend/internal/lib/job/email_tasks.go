@@ -5,10 +5,14 @@ import (
 	"time"
 
 	"github.com/hibiken/asynq"
+
+	"ark/internal/lib/email"
 )
 
 const (
-	TaskWelcome = "email:welcome"
+	TaskWelcome             = "email:welcome"
+	TaskMaintenanceReminder = "email:maintenance_reminder"
+	TaskStaleAssetsReport   = "email:stale_assets"
 )
 
 type WelcomeEmailPayload struct {
@@ -30,3 +34,47 @@ func NewWelcomeEmailTask(to, firstName string) (*asynq.Task, error) {
 		asynq.Queue("default"),
 		asynq.Timeout(30*time.Second)), nil
 }
+
+type MaintenanceReminderPayload struct {
+	To    string                          `json:"to"`
+	Tasks []email.MaintenanceReminderItem `json:"tasks"`
+}
+
+func NewMaintenanceReminderTask(to string, tasks []email.MaintenanceReminderItem) (*asynq.Task, error) {
+	payload, err := json.Marshal(MaintenanceReminderPayload{
+		To:    to,
+		Tasks: tasks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskMaintenanceReminder, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(30*time.Second)), nil
+}
+
+type StaleAssetsReportPayload struct {
+	To     string                 `json:"to"`
+	Days   int                    `json:"days"`
+	Total  int                    `json:"total"`
+	Assets []email.StaleAssetItem `json:"assets"`
+}
+
+func NewStaleAssetsReportTask(to string, days, total int, assets []email.StaleAssetItem) (*asynq.Task, error) {
+	payload, err := json.Marshal(StaleAssetsReportPayload{
+		To:     to,
+		Days:   days,
+		Total:  total,
+		Assets: assets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskStaleAssetsReport, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(30*time.Second)), nil
+}
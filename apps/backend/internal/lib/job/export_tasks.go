@@ -0,0 +1,49 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	// TaskAccountExport builds one account archive. Its handler is registered
+	// by the export service.
+	TaskAccountExport = "export:account"
+
+	// TaskExportCleanup deletes expired account archives. Its handler is
+	// registered by the export service.
+	TaskExportCleanup = "export:cleanup"
+)
+
+type AccountExportPayload struct {
+	ExportID string `json:"export_id"`
+	UserID   string `json:"user_id"`
+
+	// ViewID is the saved asset view the export is limited to, if any
+	ViewID string `json:"view_id,omitempty"`
+}
+
+func NewAccountExportTask(exportID, userID, viewID string) (*asynq.Task, error) {
+	payload, err := json.Marshal(AccountExportPayload{
+		ExportID: exportID,
+		UserID:   userID,
+		ViewID:   viewID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskAccountExport, payload,
+		asynq.MaxRetry(2),
+		asynq.Queue("low"),
+		asynq.Timeout(30*time.Minute)), nil
+}
+
+func NewExportCleanupTask() *asynq.Task {
+	return asynq.NewTask(TaskExportCleanup, nil,
+		asynq.MaxRetry(1),
+		asynq.Queue("low"),
+		asynq.Timeout(5*time.Minute))
+}
@@ -47,3 +47,69 @@ func (j *JobService) handleWelcomeEmailTask(ctx context.Context, t *asynq.Task)
 		Msg("Successfully sent welcome email")
 	return nil
 }
+
+func (j *JobService) handleMaintenanceReminderTask(ctx context.Context, t *asynq.Task) error {
+	var p MaintenanceReminderPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal maintenance reminder payload: %w", err)
+	}
+
+	j.logger.Info().
+		Str("type", "maintenance_reminder").
+		Str("to", p.To).
+		Int("tasks", len(p.Tasks)).
+		Msg("Processing maintenance reminder email task")
+
+	err := emailClient.SendMaintenanceReminderEmail(
+		p.To,
+		p.Tasks,
+	)
+	if err != nil {
+		j.logger.Error().
+			Str("type", "maintenance_reminder").
+			Str("to", p.To).
+			Err(err).
+			Msg("Failed to send maintenance reminder email")
+		return err
+	}
+
+	j.logger.Info().
+		Str("type", "maintenance_reminder").
+		Str("to", p.To).
+		Msg("Successfully sent maintenance reminder email")
+	return nil
+}
+
+func (j *JobService) handleStaleAssetsReportTask(ctx context.Context, t *asynq.Task) error {
+	var p StaleAssetsReportPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal stale assets report payload: %w", err)
+	}
+
+	j.logger.Info().
+		Str("type", "stale_assets").
+		Str("to", p.To).
+		Int("assets", p.Total).
+		Msg("Processing stale assets report email task")
+
+	err := emailClient.SendStaleAssetsEmail(
+		p.To,
+		p.Days,
+		p.Total,
+		p.Assets,
+	)
+	if err != nil {
+		j.logger.Error().
+			Str("type", "stale_assets").
+			Str("to", p.To).
+			Err(err).
+			Msg("Failed to send stale assets report email")
+		return err
+	}
+
+	j.logger.Info().
+		Str("type", "stale_assets").
+		Str("to", p.To).
+		Msg("Successfully sent stale assets report email")
+	return nil
+}
@@ -1,15 +1,19 @@
 package job
 
 import (
+	"context"
+
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog"
 	"ark/internal/config"
 )
 
 type JobService struct {
-	Client *asynq.Client
-	server *asynq.Server
-	logger *zerolog.Logger
+	Client    *asynq.Client
+	server    *asynq.Server
+	scheduler *asynq.Scheduler
+	mux       *asynq.ServeMux
+	logger    *zerolog.Logger
 }
 
 func NewJobService(logger *zerolog.Logger, cfg *config.Config) *JobService {
@@ -31,20 +35,47 @@ func NewJobService(logger *zerolog.Logger, cfg *config.Config) *JobService {
 		},
 	)
 
+	// Periodic tasks are enqueued by the scheduler and processed by the server above
+	scheduler := asynq.NewScheduler(
+		asynq.RedisClientOpt{Addr: redisAddr},
+		&asynq.SchedulerOpts{},
+	)
+
 	return &JobService{
-		Client: client,
-		server: server,
-		logger: logger,
+		Client:    client,
+		server:    server,
+		scheduler: scheduler,
+		mux:       asynq.NewServeMux(),
+		logger:    logger,
 	}
 }
 
+// HandleFunc registers a task handler. Services register their own tasks after
+// construction; the mux is safe to extend while the server is running.
+func (j *JobService) HandleFunc(pattern string, handler func(context.Context, *asynq.Task) error) {
+	j.mux.HandleFunc(pattern, handler)
+}
+
+// Schedule enqueues task on the given cron spec (e.g. "0 * * * *" or "@every 1h").
+// Every instance runs a scheduler, so periodic tasks should use asynq.Unique
+// to avoid being processed once per instance.
+func (j *JobService) Schedule(cronspec string, task *asynq.Task, opts ...asynq.Option) (string, error) {
+	return j.scheduler.Register(cronspec, task, opts...)
+}
+
 func (j *JobService) Start() error {
 	// Register task handlers
-	mux := asynq.NewServeMux()
-	mux.HandleFunc(TaskWelcome, j.handleWelcomeEmailTask)
+	j.mux.HandleFunc(TaskWelcome, j.handleWelcomeEmailTask)
+	j.mux.HandleFunc(TaskMaintenanceReminder, j.handleMaintenanceReminderTask)
+	j.mux.HandleFunc(TaskStaleAssetsReport, j.handleStaleAssetsReportTask)
 
 	j.logger.Info().Msg("Starting background job server")
-	if err := j.server.Start(mux); err != nil {
+	if err := j.server.Start(j.mux); err != nil {
+		return err
+	}
+
+	j.logger.Info().Msg("Starting background job scheduler")
+	if err := j.scheduler.Start(); err != nil {
 		return err
 	}
 
@@ -53,6 +84,7 @@ func (j *JobService) Start() error {
 
 func (j *JobService) Stop() {
 	j.logger.Info().Msg("Stopping background job server")
+	j.scheduler.Shutdown()
 	j.server.Shutdown()
 	j.Client.Close()
 }
@@ -0,0 +1,20 @@
+package job
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	// TaskMaintenanceScan finds overdue maintenance tasks and enqueues reminder emails.
+	// Its handler is registered by the maintenance service.
+	TaskMaintenanceScan = "maintenance:scan"
+)
+
+func NewMaintenanceScanTask() *asynq.Task {
+	return asynq.NewTask(TaskMaintenanceScan, nil,
+		asynq.MaxRetry(1),
+		asynq.Queue("low"),
+		asynq.Timeout(5*time.Minute))
+}
@@ -0,0 +1,20 @@
+package job
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	// TaskStaleAssetScan finds users with stale assets and enqueues their weekly
+	// report emails. Its handler is registered by the report service.
+	TaskStaleAssetScan = "report:stale_assets_scan"
+)
+
+func NewStaleAssetScanTask() *asynq.Task {
+	return asynq.NewTask(TaskStaleAssetScan, nil,
+		asynq.MaxRetry(1),
+		asynq.Queue("low"),
+		asynq.Timeout(10*time.Minute))
+}
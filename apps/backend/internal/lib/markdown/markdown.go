@@ -0,0 +1,94 @@
+// Package markdown renders log content written in markdown to sanitized HTML.
+//
+// Content is parsed as CommonMark with the GitHub extensions (tables,
+// strikethrough, autolinks and task lists). Raw HTML in the source is dropped
+// and the rendered output goes through a bluemonday policy, so the result is
+// safe to embed in shared pages. Fenced and indented code blocks are also
+// returned separately with their language so callers can reuse them.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// CodeBlock is a code block extracted from markdown source. Language is the
+// info string of a fenced block and empty for indented blocks.
+type CodeBlock struct {
+	Language string
+	Code     string
+}
+
+// Rendered is the result of rendering markdown source
+type Rendered struct {
+	HTML       string
+	CodeBlocks []CodeBlock
+}
+
+var (
+	md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// policy is the UGC policy plus the language-* classes goldmark puts on
+	// fenced code so clients can highlight it
+	policy = func() *bluemonday.Policy {
+		p := bluemonday.UGCPolicy()
+		p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+		return p
+	}()
+)
+
+// Render converts markdown source to sanitized HTML and extracts its code blocks
+func Render(source string) (*Rendered, error) {
+	src := []byte(source)
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	blocks := make([]CodeBlock, 0)
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch block := n.(type) {
+		case *ast.FencedCodeBlock:
+			blocks = append(blocks, CodeBlock{
+				Language: string(block.Language(src)),
+				Code:     codeLines(block, src),
+			})
+		case *ast.CodeBlock:
+			blocks = append(blocks, CodeBlock{Code: codeLines(block, src)})
+		}
+
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk markdown: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := md.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, fmt.Errorf("render markdown: %w", err)
+	}
+
+	return &Rendered{
+		HTML:       policy.Sanitize(buf.String()),
+		CodeBlocks: blocks,
+	}, nil
+}
+
+// codeLines joins the raw lines of a code block
+func codeLines(n ast.Node, src []byte) string {
+	var buf bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		buf.Write(line.Value(src))
+	}
+	return buf.String()
+}
@@ -0,0 +1,82 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender_Basic(t *testing.T) {
+	got, err := Render("# Disk swap\n\nReplaced **sdc** in bay 3.")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	for _, want := range []string{"<h1", "Disk swap</h1>", "<strong>sdc</strong>"} {
+		if !strings.Contains(got.HTML, want) {
+			t.Errorf("HTML %q does not contain %q", got.HTML, want)
+		}
+	}
+	if len(got.CodeBlocks) != 0 {
+		t.Errorf("CodeBlocks = %v, want none", got.CodeBlocks)
+	}
+}
+
+func TestRender_Table(t *testing.T) {
+	got, err := Render("| disk | serial |\n| --- | --- |\n| sdc | WD-123 |\n")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	for _, want := range []string{"<table>", "<th>disk</th>", "<td>WD-123</td>"} {
+		if !strings.Contains(got.HTML, want) {
+			t.Errorf("HTML %q does not contain %q", got.HTML, want)
+		}
+	}
+}
+
+func TestRender_CodeBlocks(t *testing.T) {
+	source := "Ran:\n\n```bash\nzpool replace tank sdc\nzpool status\n```\n\n    indented\n"
+
+	got, err := Render(source)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if len(got.CodeBlocks) != 2 {
+		t.Fatalf("CodeBlocks = %v, want 2 blocks", got.CodeBlocks)
+	}
+	if got.CodeBlocks[0].Language != "bash" || got.CodeBlocks[0].Code != "zpool replace tank sdc\nzpool status\n" {
+		t.Errorf("CodeBlocks[0] = %+v", got.CodeBlocks[0])
+	}
+	if got.CodeBlocks[1].Language != "" || got.CodeBlocks[1].Code != "indented\n" {
+		t.Errorf("CodeBlocks[1] = %+v", got.CodeBlocks[1])
+	}
+	if !strings.Contains(got.HTML, `<code class="language-bash">`) {
+		t.Errorf("HTML %q should keep the language class", got.HTML)
+	}
+}
+
+func TestRender_Sanitizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		blocked string
+	}{
+		{name: "script tag", source: "hi <script>alert(1)</script>", blocked: "<script"},
+		{name: "event handler", source: `<img src="x" onerror="alert(1)">`, blocked: "onerror"},
+		{name: "javascript link", source: "[click](javascript:alert(1))", blocked: "javascript:"},
+		{name: "code class", source: "<code class=\"evil\">x</code>", blocked: "evil"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Render(tc.source)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if strings.Contains(got.HTML, tc.blocked) {
+				t.Errorf("HTML %q should not contain %q", got.HTML, tc.blocked)
+			}
+		})
+	}
+}
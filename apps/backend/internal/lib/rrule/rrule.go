@@ -0,0 +1,173 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for
+// scheduling maintenance tasks: FREQ, INTERVAL and UNTIL.
+//
+// Examples:
+//
+//	FREQ=MONTHLY                  every month
+//	FREQ=WEEKLY;INTERVAL=2        every other week
+//	FREQ=YEARLY;INTERVAL=3        every three years
+//	FREQ=DAILY;UNTIL=20261231     daily until the end of 2026
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequencies
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+// MaxInterval bounds INTERVAL so that advancing a date can never overflow
+const MaxInterval = 1000
+
+// Rule is a parsed recurrence rule
+type Rule struct {
+	Freq     string
+	Interval int
+	Until    *time.Time
+}
+
+// Parse parses a recurrence rule such as "FREQ=WEEKLY;INTERVAL=2".
+// An optional "RRULE:" prefix is accepted. Unsupported parts are rejected
+// rather than silently ignored so a rule never means less than it says.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RRULE:"), "rrule:")
+	if s == "" {
+		return nil, fmt.Errorf("empty recurrence rule")
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate rule part %s", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			freq := strings.ToUpper(value)
+			switch freq {
+			case Daily, Weekly, Monthly, Yearly:
+				rule.Freq = freq
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > MaxInterval {
+				return nil, fmt.Errorf("INTERVAL must be between 1 and %d", MaxInterval)
+			}
+			rule.Interval = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+
+	return rule, nil
+}
+
+// parseUntil accepts the RFC 5545 DATE and UTC DATE-TIME forms.
+// A bare date includes the whole day.
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q (expected YYYYMMDD or YYYYMMDDTHHMMSSZ)", value)
+}
+
+// String returns the canonical form of the rule
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// occurrence returns the k-th occurrence after anchor (k=0 is anchor itself).
+// Monthly and yearly rules clamp to the end of shorter months, so a task due
+// on Jan 31 is next due on Feb 28 and then Mar 31 rather than drifting.
+func (r *Rule) occurrence(anchor time.Time, k int) time.Time {
+	switch r.Freq {
+	case Daily:
+		return anchor.AddDate(0, 0, k*r.Interval)
+	case Weekly:
+		return anchor.AddDate(0, 0, 7*k*r.Interval)
+	case Monthly:
+		return addMonthsClamped(anchor, k*r.Interval)
+	default:
+		return addMonthsClamped(anchor, 12*k*r.Interval)
+	}
+}
+
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfTarget := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfTarget.AddDate(0, 0, day-1)
+}
+
+// Next returns the first occurrence strictly after `after`, counted from anchor
+// so the task keeps its cadence (a monthly task due on the 1st stays on the 1st
+// even when completed late). It returns false once UNTIL has passed.
+func (r *Rule) Next(anchor, after time.Time) (time.Time, bool) {
+	k := 1
+
+	// Skip ahead for long-overdue daily and weekly rules instead of counting every day
+	if r.Freq == Daily || r.Freq == Weekly {
+		periodDays := r.Interval
+		if r.Freq == Weekly {
+			periodDays *= 7
+		}
+		if elapsed := int(after.Sub(anchor).Hours()/24) / periodDays; elapsed > k {
+			k = elapsed
+		}
+	}
+
+	next := r.occurrence(anchor, k)
+	for !next.After(after) {
+		k++
+		next = r.occurrence(anchor, k)
+	}
+
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
@@ -0,0 +1,124 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		freq     string
+		interval int
+		until    bool
+	}{
+		{input: "FREQ=MONTHLY", freq: Monthly, interval: 1},
+		{input: "RRULE:FREQ=WEEKLY;INTERVAL=2", freq: Weekly, interval: 2},
+		{input: "freq=yearly;interval=3", freq: Yearly, interval: 3},
+		{input: "FREQ=DAILY;UNTIL=20261231", freq: Daily, interval: 1, until: true},
+		{input: "FREQ=DAILY;UNTIL=20261231T120000Z", freq: Daily, interval: 1, until: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rule, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.freq, rule.Freq)
+			assert.Equal(t, tt.interval, rule.Interval)
+			assert.Equal(t, tt.until, rule.Until != nil)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=abc",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	rule, err := Parse("interval=2;freq=weekly")
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2", rule.String())
+
+	rule, err = Parse("FREQ=MONTHLY")
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=MONTHLY", rule.String())
+}
+
+func TestRule_Next(t *testing.T) {
+	anchor := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		rule  string
+		after time.Time
+		want  time.Time
+	}{
+		{name: "completed on time", rule: "FREQ=MONTHLY", after: anchor, want: time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{name: "completed late keeps cadence", rule: "FREQ=MONTHLY", after: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), want: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)},
+		{name: "completed early", rule: "FREQ=WEEKLY", after: anchor.Add(-48 * time.Hour), want: anchor.AddDate(0, 0, 7)},
+		{name: "every other week", rule: "FREQ=WEEKLY;INTERVAL=2", after: anchor.AddDate(0, 0, 15), want: anchor.AddDate(0, 0, 28)},
+		{name: "long overdue daily", rule: "FREQ=DAILY", after: time.Date(2030, 6, 15, 12, 0, 0, 0, time.UTC), want: time.Date(2030, 6, 16, 9, 0, 0, 0, time.UTC)},
+		{name: "every three years", rule: "FREQ=YEARLY;INTERVAL=3", after: anchor, want: time.Date(2028, 1, 1, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			require.NoError(t, err)
+
+			next, ok := rule.Next(anchor, tt.after)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func TestRule_Next_ClampsToMonthEnd(t *testing.T) {
+	rule, err := Parse("FREQ=MONTHLY")
+	require.NoError(t, err)
+
+	anchor := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(anchor, anchor)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), next)
+
+	// The following occurrence returns to the 31st instead of drifting to the 28th
+	next, ok = rule.Next(anchor, next)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), next)
+}
+
+func TestRule_Next_Until(t *testing.T) {
+	rule, err := Parse("FREQ=MONTHLY;UNTIL=20250315")
+	require.NoError(t, err)
+
+	anchor := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(anchor, anchor.AddDate(0, 1, 0))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), next)
+
+	_, ok = rule.Next(anchor, next)
+	assert.False(t, ok, "no occurrence after UNTIL")
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"ark/internal/lib/jwt"
+	"ark/internal/model"
+	"ark/internal/server"
+)
+
+// APITokenAuthenticator resolves an API token to the user it belongs to
+type APITokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (string, error)
+}
+
+// APITokenMiddleware authenticates scripts by the long-lived API tokens users
+// create, as an alternative to Clerk sessions on the routes that accept them.
+type APITokenMiddleware struct {
+	server *server.Server
+	auth   *AuthMiddleware
+	tokens APITokenAuthenticator
+}
+
+func NewAPITokenMiddleware(s *server.Server, auth *AuthMiddleware, tokens APITokenAuthenticator) *APITokenMiddleware {
+	return &APITokenMiddleware{
+		server: s,
+		auth:   auth,
+		tokens: tokens,
+	}
+}
+
+// TokenOrSessionAuth accepts an API token or a Clerk session. Bearer tokens
+// starting with the API token prefix are checked against stored tokens and
+// set user_id in the context; anything else goes through ClerkAuthMiddleware
+// and RequireAuth.
+func (m *APITokenMiddleware) TokenOrSessionAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	session := m.auth.ClerkAuthMiddleware(m.auth.RequireAuth(next))
+
+	return func(c echo.Context) error {
+		token, err := jwt.ExtractBearerToken(c.Request().Header.Get("Authorization"))
+		if err != nil || !strings.HasPrefix(token, model.APITokenPrefix) {
+			return session(c)
+		}
+
+		start := time.Now()
+		requestID := GetRequestID(c)
+
+		userID, err := m.tokens.Authenticate(c.Request().Context(), token)
+		if err != nil {
+			m.server.Logger.Debug().
+				Err(err).
+				Str("function", "TokenOrSessionAuth").
+				Str("request_id", requestID).
+				Dur("duration", time.Since(start)).
+				Msg("API token authentication failed")
+			return err
+		}
+
+		c.Set(UserIDKey, userID)
+
+		m.server.Logger.Info().
+			Str("function", "TokenOrSessionAuth").
+			Str("user_id", userID).
+			Str("request_id", requestID).
+			Dur("duration", time.Since(start)).
+			Msg("user authenticated with API token")
+
+		return next(c)
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+)
+
+// fakeTokens accepts a single API token
+type fakeTokens struct {
+	token  string
+	userID string
+}
+
+func (f *fakeTokens) Authenticate(ctx context.Context, token string) (string, error) {
+	if token != f.token {
+		return "", errs.NewUnauthorizedError("Invalid or expired token", false)
+	}
+	return f.userID, nil
+}
+
+func newTestAPITokenMiddleware() *APITokenMiddleware {
+	testServer := createTestServer()
+	return NewAPITokenMiddleware(testServer, NewAuthMiddleware(testServer), &fakeTokens{token: "ark_valid", userID: "user_123"})
+}
+
+func TestTokenOrSessionAuth_ValidToken(t *testing.T) {
+	m := newTestAPITokenMiddleware()
+	c := createTestContext("Bearer ark_valid")
+
+	var userID string
+	handler := m.TokenOrSessionAuth(func(c echo.Context) error {
+		userID = GetUserID(c)
+		return c.String(http.StatusOK, "success")
+	})
+
+	err := handler(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user_123", userID)
+}
+
+func TestTokenOrSessionAuth_InvalidToken(t *testing.T) {
+	m := newTestAPITokenMiddleware()
+	c := createTestContext("Bearer ark_revoked")
+
+	handler := m.TokenOrSessionAuth(func(c echo.Context) error {
+		t.Fatal("handler should not run")
+		return nil
+	})
+
+	err := handler(c)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok, "Error should be an errs.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Status)
+}
+
+func TestTokenOrSessionAuth_FallsBackToSession(t *testing.T) {
+	m := newTestAPITokenMiddleware()
+
+	// Without an API token the request goes through ClerkAuthMiddleware,
+	// which rejects a missing header
+	c := createTestContext("")
+
+	handler := m.TokenOrSessionAuth(func(c echo.Context) error {
+		t.Fatal("handler should not run")
+		return nil
+	})
+
+	err := handler(c)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok, "Error should be an errs.HTTPError")
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Status)
+	assert.Equal(t, "Missing authorization header", httpErr.Message)
+}
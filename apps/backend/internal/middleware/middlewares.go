@@ -11,6 +11,9 @@ type Middlewares struct {
 	ContextEnhancer *ContextEnhancer
 	Tracing         *TracingMiddleware
 	RateLimit       *RateLimitMiddleware
+
+	// APIToken needs the API token service, so the router sets it
+	APIToken *APITokenMiddleware
 }
 
 func NewMiddlewares(s *server.Server) *Middlewares {
@@ -0,0 +1,122 @@
+package model
+
+import "time"
+
+// Activity timeline intervals
+const (
+	ActivityIntervalHour  = "hour"
+	ActivityIntervalDay   = "day"
+	ActivityIntervalWeek  = "week"
+	ActivityIntervalMonth = "month"
+)
+
+// Activity timeline breakdowns
+const (
+	ActivityGroupByTag       = "tag"
+	ActivityGroupByAssetType = "asset_type"
+)
+
+// ActivityGroupUntagged is the timeline group for logs without tags
+const ActivityGroupUntagged = "untagged"
+
+const (
+	// DefaultActivityTimeZone is used when no tz is given
+	DefaultActivityTimeZone = "UTC"
+	// HeatmapDays is the number of daily buckets in a rolling heatmap
+	HeatmapDays = 365
+	// DefaultActivityTimelineWindow is the timeline window used when no start is given
+	DefaultActivityTimelineWindow = 30 * 24 * time.Hour
+	// MaxActivityBuckets caps the number of intervals returned by the timeline
+	MaxActivityBuckets = 1000
+)
+
+// IsValidActivityInterval checks if the given interval is a valid timeline interval
+func IsValidActivityInterval(interval string) bool {
+	switch interval {
+	case ActivityIntervalHour, ActivityIntervalDay, ActivityIntervalWeek, ActivityIntervalMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// ActivityHeatmapQueryParams represents query parameters for the activity heatmap.
+// Without a year the heatmap covers the HeatmapDays days up to today.
+type ActivityHeatmapQueryParams struct {
+	Year *int   `query:"year" validate:"omitempty,min=1970,max=9999"`
+	TZ   string `query:"tz" validate:"omitempty,max=64"`
+}
+
+// SetDefaults sets default values for ActivityHeatmapQueryParams
+func (q *ActivityHeatmapQueryParams) SetDefaults() {
+	if q.TZ == "" {
+		q.TZ = DefaultActivityTimeZone
+	}
+}
+
+// ActivityHeatmap is the DTO for the activity heatmap: the number of logs
+// created on each day from Start to End inclusive, in the requested time zone
+type ActivityHeatmap struct {
+	TZ    string       `json:"tz"`
+	Start string       `json:"start"`
+	End   string       `json:"end"`
+	Total int64        `json:"total"`
+	Max   int64        `json:"max"`
+	Days  []DailyCount `json:"days"`
+}
+
+// ActivityTimelineQueryParams represents query parameters for the activity timeline
+type ActivityTimelineQueryParams struct {
+	Start    *time.Time `query:"start"`
+	End      *time.Time `query:"end"`
+	Interval string     `query:"interval" validate:"omitempty,oneof=hour day week month"`
+	GroupBy  string     `query:"group_by" validate:"omitempty,oneof=tag asset_type"`
+	AssetID  *string    `query:"asset_id" validate:"omitempty,uuid"`
+	TZ       string     `query:"tz" validate:"omitempty,max=64"`
+}
+
+// SetDefaults sets default values for ActivityTimelineQueryParams
+func (q *ActivityTimelineQueryParams) SetDefaults(now time.Time) {
+	if q.End == nil {
+		end := now
+		q.End = &end
+	}
+	if q.Start == nil {
+		start := q.End.Add(-DefaultActivityTimelineWindow)
+		q.Start = &start
+	}
+	if q.Interval == "" {
+		q.Interval = ActivityIntervalDay
+	}
+	if q.GroupBy == "" {
+		q.GroupBy = ActivityGroupByTag
+	}
+	if q.TZ == "" {
+		q.TZ = DefaultActivityTimeZone
+	}
+}
+
+// ActivityBucket counts the logs created within one interval. Groups breaks the
+// count down by tag or asset type; a log with several tags counts once per tag.
+type ActivityBucket struct {
+	Start  time.Time        `json:"start"`
+	Total  int64            `json:"total"`
+	Groups map[string]int64 `json:"groups"`
+}
+
+// ActivityTimeline is the DTO for the activity timeline
+type ActivityTimeline struct {
+	Start    time.Time        `json:"start"`
+	End      time.Time        `json:"end"`
+	Interval string           `json:"interval"`
+	GroupBy  string           `json:"group_by"`
+	TZ       string           `json:"tz"`
+	Buckets  []ActivityBucket `json:"buckets"`
+}
+
+// ActivityGroupCount is the number of logs for one group within one interval
+type ActivityGroupCount struct {
+	Start time.Time `json:"start"`
+	Key   string    `json:"key"`
+	Count int64     `json:"count"`
+}
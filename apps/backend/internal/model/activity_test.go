@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// Test 1: TestIsValidActivityInterval
+func TestIsValidActivityInterval(t *testing.T) {
+	for _, interval := range []string{ActivityIntervalHour, ActivityIntervalDay, ActivityIntervalWeek, ActivityIntervalMonth} {
+		if !IsValidActivityInterval(interval) {
+			t.Errorf("Expected %q to be a valid interval", interval)
+		}
+	}
+	if IsValidActivityInterval("year") {
+		t.Error("Expected year to be an invalid interval")
+	}
+}
+
+// Test 2: TestActivityTimelineQueryParams_SetDefaults
+func TestActivityTimelineQueryParams_SetDefaults(t *testing.T) {
+	now := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+
+	params := ActivityTimelineQueryParams{}
+	params.SetDefaults(now)
+
+	if !params.End.Equal(now) {
+		t.Errorf("Expected end %v, got %v", now, *params.End)
+	}
+	if !params.Start.Equal(now.Add(-DefaultActivityTimelineWindow)) {
+		t.Errorf("Expected start 30 days before end, got %v", *params.Start)
+	}
+	if params.Interval != ActivityIntervalDay || params.GroupBy != ActivityGroupByTag || params.TZ != DefaultActivityTimeZone {
+		t.Errorf("Unexpected defaults: %+v", params)
+	}
+}
+
+// Test 3: TestActivityHeatmapQueryParams_SetDefaults
+func TestActivityHeatmapQueryParams_SetDefaults(t *testing.T) {
+	params := ActivityHeatmapQueryParams{}
+	params.SetDefaults()
+	if params.TZ != DefaultActivityTimeZone || params.Year != nil {
+		t.Errorf("Unexpected defaults: %+v", params)
+	}
+
+	params = ActivityHeatmapQueryParams{TZ: "Europe/Berlin"}
+	params.SetDefaults()
+	if params.TZ != "Europe/Berlin" {
+		t.Errorf("Expected tz to stay Europe/Berlin, got %q", params.TZ)
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ark/internal/validation"
+)
+
+const (
+	// APITokenPrefix starts every API token, telling them apart from session JWTs
+	APITokenPrefix = "ark_"
+	// MaxAPITokens is the most API tokens a user may hold
+	MaxAPITokens = 20
+	// MaxAPITokenExpiryDays is the longest lifetime an API token may be given
+	MaxAPITokenExpiryDays = 3650
+)
+
+// APIToken is a long-lived token for scripts calling the integration
+// endpoints. The token itself is only returned when it is created; Prefix
+// holds its first characters so it can be recognized later.
+type APIToken struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"token_prefix"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// CreateAPITokenRequest is the DTO for creating an API token. Tokens without
+// ExpiresInDays never expire.
+type CreateAPITokenRequest struct {
+	Name          string `json:"name" validate:"required,max=100"`
+	ExpiresInDays *int   `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=3650"`
+}
+
+// Validate implements validation.Validatable. Name is trimmed first so a
+// blank name counts as missing.
+func (r *CreateAPITokenRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	return validation.Struct(r)
+}
+
+// CreatedAPIToken is the response to creating an API token, the only one
+// that includes the token
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// APITokenListResponse is the DTO for lists of API tokens
+type APITokenListResponse struct {
+	Tokens []APIToken `json:"tokens"`
+	Total  int        `json:"total"`
+}
+
+// NewAPITokenListResponse converts a slice of APIToken to APITokenListResponse DTO
+func NewAPITokenListResponse(tokens []*APIToken) *APITokenListResponse {
+	items := make([]APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		items = append(items, *token)
+	}
+
+	return &APITokenListResponse{
+		Tokens: items,
+		Total:  len(items),
+	}
+}
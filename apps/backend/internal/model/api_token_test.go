@@ -0,0 +1,91 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Test 1: TestCreateAPITokenRequest_Validation
+func TestCreateAPITokenRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	req := CreateAPITokenRequest{Name: "ansible on bastion"}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	days := 0
+	req.ExpiresInDays = &days
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for zero expiry days")
+	}
+}
+
+// Test 2: TestCreateAPITokenRequest_Validate
+func TestCreateAPITokenRequest_Validate(t *testing.T) {
+	days := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		req     CreateAPITokenRequest
+		wantErr bool
+	}{
+		{"valid", CreateAPITokenRequest{Name: " ci ", ExpiresInDays: days(30)}, false},
+		{"blank name", CreateAPITokenRequest{Name: "  "}, true},
+		{"long name", CreateAPITokenRequest{Name: strings.Repeat("x", 101)}, true},
+		{"zero days", CreateAPITokenRequest{Name: "ci", ExpiresInDays: days(0)}, true},
+		{"too many days", CreateAPITokenRequest{Name: "ci", ExpiresInDays: days(MaxAPITokenExpiryDays + 1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected valid request, got error: %v", err)
+			}
+		})
+	}
+
+	req := CreateAPITokenRequest{Name: " ci "}
+	if err := req.Validate(); err != nil || req.Name != "ci" {
+		t.Errorf("Expected trimmed name, got %q (%v)", req.Name, err)
+	}
+}
+
+// Test 3: TestCreatedAPIToken_JSON
+func TestCreatedAPIToken_JSON(t *testing.T) {
+	created := CreatedAPIToken{
+		APIToken: APIToken{ID: uuid.New(), Name: "ci", Prefix: "ark_abcdef"},
+		Token:    "ark_abcdefghij",
+	}
+
+	data, err := json.Marshal(created)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, key := range []string{`"name":"ci"`, `"prefix":"ark_abcdef"`, `"token":"ark_abcdefghij"`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("Expected %s in %s", key, data)
+		}
+	}
+}
+
+// Test 4: TestNewAPITokenListResponse
+func TestNewAPITokenListResponse(t *testing.T) {
+	resp := NewAPITokenListResponse(nil)
+	if resp.Tokens == nil || resp.Total != 0 {
+		t.Errorf("Expected empty non-nil list, got %+v", resp)
+	}
+
+	resp = NewAPITokenListResponse([]*APIToken{{Name: "a"}, {Name: "b"}})
+	if resp.Total != 2 || resp.Tokens[1].Name != "b" {
+		t.Errorf("Expected two tokens in order, got %+v", resp)
+	}
+}
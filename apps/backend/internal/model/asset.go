@@ -34,7 +34,10 @@ type Asset struct {
 	Name      string          `json:"name" db:"name"`
 	Type      *string         `json:"type,omitempty" db:"type"`
 	Hostname  *string         `json:"hostname,omitempty" db:"hostname"`
+	Tags      []string        `json:"tags,omitempty" db:"tags"`
 	Metadata  json.RawMessage `json:"metadata,omitempty" db:"metadata"`
+	Pinned    bool            `json:"pinned" db:"pinned"`
+	Favorite  bool            `json:"favorite" db:"favorite"`
 	CreatedAt time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
 }
@@ -44,7 +47,10 @@ type CreateAssetRequest struct {
 	Name     string           `json:"name" validate:"required,max=100"`
 	Type     *string          `json:"type,omitempty" validate:"omitempty,max=50"`
 	Hostname *string          `json:"hostname,omitempty" validate:"omitempty,max=255"`
+	Tags     []string         `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
 	Metadata *json.RawMessage `json:"metadata,omitempty"`
+	Pinned   bool             `json:"pinned,omitempty"`
+	Favorite bool             `json:"favorite,omitempty"`
 }
 
 // UpdateAssetRequest is the DTO for updating an existing asset
@@ -52,7 +58,10 @@ type UpdateAssetRequest struct {
 	Name     *string          `json:"name,omitempty" validate:"omitempty,max=100"`
 	Type     *string          `json:"type,omitempty" validate:"omitempty,max=50"`
 	Hostname *string          `json:"hostname,omitempty" validate:"omitempty,max=255"`
+	Tags     *[]string        `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
 	Metadata *json.RawMessage `json:"metadata,omitempty"`
+	Pinned   *bool            `json:"pinned,omitempty"`
+	Favorite *bool            `json:"favorite,omitempty"`
 }
 
 // AssetResponse is the DTO for single asset responses
@@ -62,7 +71,10 @@ type AssetResponse struct {
 	Name      string          `json:"name"`
 	Type      *string         `json:"type,omitempty"`
 	Hostname  *string         `json:"hostname,omitempty"`
+	Tags      []string        `json:"tags,omitempty"`
 	Metadata  json.RawMessage `json:"metadata,omitempty"`
+	Pinned    bool            `json:"pinned"`
+	Favorite  bool            `json:"favorite"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
@@ -79,7 +91,10 @@ func NewAssetResponse(asset *Asset) *AssetResponse {
 		Name:      asset.Name,
 		Type:      asset.Type,
 		Hostname:  asset.Hostname,
+		Tags:      asset.Tags,
 		Metadata:  asset.Metadata,
+		Pinned:    asset.Pinned,
+		Favorite:  asset.Favorite,
 		CreatedAt: asset.CreatedAt,
 		UpdatedAt: asset.UpdatedAt,
 	}
@@ -112,14 +127,31 @@ func NewAssetListResponse(assets []*Asset, total int64, limit, offset int) *Asse
 
 // AssetQueryParams represents query parameters for listing assets
 type AssetQueryParams struct {
-	Limit     int     `query:"limit" validate:"omitempty,min=1,max=100"`
-	Offset    int     `query:"offset" validate:"omitempty,min=0"`
-	Type      *string `query:"type" validate:"omitempty,max=50"`
-	Search    *string `query:"search" validate:"omitempty,max=100"`
-	SortBy    string  `query:"sort_by" validate:"omitempty,oneof=name created_at updated_at"`
-	SortOrder string  `query:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Limit     int     `query:"limit" json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+	Offset    int     `query:"offset" json:"offset,omitempty" validate:"omitempty,min=0"`
+	Type      *string `query:"type" json:"type,omitempty" validate:"omitempty,max=50"`
+	Search    *string `query:"search" json:"search,omitempty" validate:"omitempty,max=100"`
+	SortBy    string  `query:"sort_by" json:"sort_by,omitempty" validate:"omitempty,oneof=name created_at updated_at"`
+	SortOrder string  `query:"sort_order" json:"sort_order,omitempty" validate:"omitempty,oneof=asc desc"`
+
+	// Tags filters by tag; TagMode "all" (default) requires every tag, "any" at least one
+	Tags    []string `query:"tags" json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	TagMode string   `query:"tag_mode" json:"tag_mode,omitempty" validate:"omitempty,oneof=any all"`
+
+	// Favorite filters on the favorite flag when set; pinned assets always list first
+	Favorite *bool `query:"favorite" json:"favorite,omitempty"`
+
+	// UpdatedWithinDays keeps assets updated in the last N days; being relative,
+	// it stays meaningful in saved views
+	UpdatedWithinDays *int `query:"updated_within_days" json:"updated_within_days,omitempty" validate:"omitempty,min=1,max=3650"`
 }
 
+// Tag filter modes
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
 // SetDefaults sets default values for AssetQueryParams
 func (q *AssetQueryParams) SetDefaults() {
 	if q.Limit == 0 {
@@ -137,4 +169,7 @@ func (q *AssetQueryParams) SetDefaults() {
 	if q.SortOrder == "" {
 		q.SortOrder = "desc"
 	}
+	if q.TagMode == "" {
+		q.TagMode = TagModeAll
+	}
 }
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ark/internal/validation"
+)
+
+// Asset CSV columns. Metadata keys are flattened into one column each,
+// named AssetCSVMetadataPrefix followed by the key.
+const (
+	AssetCSVFieldID        = "id"
+	AssetCSVFieldName      = "name"
+	AssetCSVFieldType      = "type"
+	AssetCSVFieldHostname  = "hostname"
+	AssetCSVFieldTags      = "tags"
+	AssetCSVFieldPinned    = "pinned"
+	AssetCSVFieldFavorite  = "favorite"
+	AssetCSVFieldCreatedAt = "created_at"
+	AssetCSVFieldUpdatedAt = "updated_at"
+
+	AssetCSVMetadataPrefix = "metadata."
+)
+
+// IsAssetCSVImportField reports whether a CSV column can be imported into
+// the given field: an asset field or a metadata key
+func IsAssetCSVImportField(field string) bool {
+	switch field {
+	case AssetCSVFieldName, AssetCSVFieldType, AssetCSVFieldHostname, AssetCSVFieldTags, AssetCSVFieldPinned, AssetCSVFieldFavorite:
+		return true
+	default:
+		key := strings.TrimPrefix(field, AssetCSVMetadataPrefix)
+		return key != field && key != "" && len(key) <= 100
+	}
+}
+
+// Asset CSV import match fields: rows update the asset with the same name or
+// hostname (ignoring case) and create an asset otherwise
+const (
+	AssetCSVMatchName     = AssetCSVFieldName
+	AssetCSVMatchHostname = AssetCSVFieldHostname
+)
+
+const (
+	// AssetCSVContentType is the media type of asset CSV files
+	AssetCSVContentType = "text/csv; charset=utf-8"
+
+	// MaxAssetCSVBytes is the largest CSV file accepted for import
+	MaxAssetCSVBytes = 10 << 20
+	// MaxAssetCSVRows is the most assets exported or imported in one CSV
+	MaxAssetCSVRows = 5000
+	// AssetCSVPreviewRows is the number of sample rows in a CSV preview
+	AssetCSVPreviewRows = 5
+)
+
+// AssetCSVExportRequest selects the assets to export with the asset list
+// filters, or with the filters of the saved asset view ViewID, which replace
+// them; limit and offset are ignored
+type AssetCSVExportRequest struct {
+	AssetQueryParams
+	ViewID *uuid.UUID `query:"view_id"`
+}
+
+// Validate implements validation.Validatable
+func (r *AssetCSVExportRequest) Validate() error {
+	return validation.Struct(r)
+}
+
+// AssetCSVPreview describes an uploaded CSV for the column-mapping step.
+// Mapping suggests a field for every column; an empty field ignores the column.
+type AssetCSVPreview struct {
+	Columns   []string          `json:"columns"`
+	Mapping   map[string]string `json:"mapping"`
+	Rows      [][]string        `json:"rows"`
+	TotalRows int               `json:"total_rows"`
+}
+
+// AssetCSVImportParams are the query parameters of an asset CSV import
+type AssetCSVImportParams struct {
+	Match  string `query:"match"`
+	DryRun bool   `query:"dry_run"`
+}
+
+// SetDefaults matches rows by name unless another field is given
+func (p *AssetCSVImportParams) SetDefaults() {
+	if p.Match == "" {
+		p.Match = AssetCSVMatchName
+	}
+}
+
+// Asset CSV row actions
+const (
+	AssetCSVActionCreate    = "create"
+	AssetCSVActionUpdate    = "update"
+	AssetCSVActionUnchanged = "unchanged"
+)
+
+// AssetCSVRowResult is what an import does, or would do, with one CSV row.
+// Rows are numbered as in a spreadsheet: the header is row 1. ID is nil for
+// assets a dry run would create.
+type AssetCSVRowResult struct {
+	Row    int        `json:"row"`
+	Action string     `json:"action"`
+	ID     *uuid.UUID `json:"id,omitempty"`
+	Name   string     `json:"name"`
+}
+
+// AssetCSVImportResult reports the outcome of an asset CSV import
+type AssetCSVImportResult struct {
+	DryRun    bool                `json:"dry_run"`
+	Match     string              `json:"match"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Unchanged int                 `json:"unchanged"`
+	Rows      []AssetCSVRowResult `json:"rows"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "testing"
+
+// Test 1: TestIsAssetCSVImportField
+func TestIsAssetCSVImportField(t *testing.T) {
+	for _, field := range []string{AssetCSVFieldName, AssetCSVFieldHostname, AssetCSVFieldTags, "metadata.rack"} {
+		if !IsAssetCSVImportField(field) {
+			t.Errorf("Expected %q to be importable", field)
+		}
+	}
+	for _, field := range []string{AssetCSVFieldID, AssetCSVFieldCreatedAt, "metadata.", "rack"} {
+		if IsAssetCSVImportField(field) {
+			t.Errorf("Expected %q not to be importable", field)
+		}
+	}
+}
+
+// Test 2: TestAssetCSVImportParams_SetDefaults
+func TestAssetCSVImportParams_SetDefaults(t *testing.T) {
+	params := AssetCSVImportParams{}
+	params.SetDefaults()
+	if params.Match != AssetCSVMatchName {
+		t.Errorf("Expected default match %q, got %q", AssetCSVMatchName, params.Match)
+	}
+}
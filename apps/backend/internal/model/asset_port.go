@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Port protocols
+const (
+	PortProtocolTCP = "tcp"
+	PortProtocolUDP = "udp"
+)
+
+// IsValidPortProtocol checks if the given protocol is a valid port protocol
+func IsValidPortProtocol(p string) bool {
+	switch p {
+	case PortProtocolTCP, PortProtocolUDP:
+		return true
+	default:
+		return false
+	}
+}
+
+// AssetPort is a network service an asset exposes, e.g. node_exporter on
+// 9100/tcp. Service names what listens on the port; Prometheus service
+// discovery lists each port as a target. LastSeenAt is when a network scan
+// import last found the port open.
+type AssetPort struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	AssetID     uuid.UUID  `json:"asset_id" db:"asset_id"`
+	UserID      string     `json:"user_id" db:"user_id"`
+	Port        int        `json:"port" db:"port"`
+	Protocol    string     `json:"protocol" db:"protocol"`
+	Service     *string    `json:"service,omitempty" db:"service"`
+	Description *string    `json:"description,omitempty" db:"description"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty" db:"last_seen_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateAssetPortRequest is the DTO for adding a port to an asset.
+// Protocol defaults to tcp.
+type CreateAssetPortRequest struct {
+	Port        int     `json:"port" validate:"required,min=1,max=65535"`
+	Protocol    string  `json:"protocol,omitempty" validate:"omitempty,oneof=tcp udp"`
+	Service     *string `json:"service,omitempty" validate:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// UpdateAssetPortRequest is the DTO for updating an asset port
+type UpdateAssetPortRequest struct {
+	Port        *int    `json:"port,omitempty" validate:"omitempty,min=1,max=65535"`
+	Protocol    *string `json:"protocol,omitempty" validate:"omitempty,oneof=tcp udp"`
+	Service     *string `json:"service,omitempty" validate:"omitempty,max=100"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// AssetPortListResponse is the DTO for lists of asset ports
+type AssetPortListResponse struct {
+	Ports []AssetPort `json:"ports"`
+	Total int         `json:"total"`
+}
+
+// NewAssetPortListResponse converts a slice of AssetPort to AssetPortListResponse DTO
+func NewAssetPortListResponse(ports []*AssetPort) *AssetPortListResponse {
+	items := make([]AssetPort, 0, len(ports))
+	for _, port := range ports {
+		items = append(items, *port)
+	}
+
+	return &AssetPortListResponse{
+		Ports: items,
+		Total: len(items),
+	}
+}
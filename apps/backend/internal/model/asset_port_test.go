@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Test 1: TestIsValidPortProtocol
+func TestIsValidPortProtocol(t *testing.T) {
+	for _, protocol := range []string{PortProtocolTCP, PortProtocolUDP} {
+		if !IsValidPortProtocol(protocol) {
+			t.Errorf("Expected %q to be a valid protocol", protocol)
+		}
+	}
+	if IsValidPortProtocol("sctp") {
+		t.Error("Expected sctp to be an invalid protocol")
+	}
+}
+
+// Test 2: TestCreateAssetPortRequest_Validation
+func TestCreateAssetPortRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	req := CreateAssetPortRequest{Port: 9100}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	req.Port = 70000
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for port over 65535")
+	}
+
+	req.Port = 53
+	req.Protocol = "icmp"
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for invalid protocol")
+	}
+}
+
+// Test 3: TestNewAssetPortListResponse
+func TestNewAssetPortListResponse(t *testing.T) {
+	resp := NewAssetPortListResponse(nil)
+	if resp.Ports == nil || resp.Total != 0 {
+		t.Errorf("Expected empty non-nil list, got %+v", resp)
+	}
+
+	resp = NewAssetPortListResponse([]*AssetPort{{Port: 22}, {Port: 9100}})
+	if resp.Total != 2 || resp.Ports[1].Port != 9100 {
+		t.Errorf("Expected 2 ports, got %+v", resp)
+	}
+}
@@ -1142,3 +1142,61 @@ func TestAssetQueryParams_Validation_ValidParams(t *testing.T) {
 		t.Errorf("Expected validation to pass for valid params, got error: %v", err)
 	}
 }
+
+// ========== Asset Tag Tests ==========
+
+// Test 49: TestAssetQueryParams_SetDefaults_TagMode
+func TestAssetQueryParams_SetDefaults_TagMode(t *testing.T) {
+	params := AssetQueryParams{}
+	params.SetDefaults()
+	if params.TagMode != TagModeAll {
+		t.Errorf("Expected default tag_mode %q, got %q", TagModeAll, params.TagMode)
+	}
+
+	params = AssetQueryParams{TagMode: TagModeAny}
+	params.SetDefaults()
+	if params.TagMode != TagModeAny {
+		t.Errorf("Expected tag_mode %q to be kept, got %q", TagModeAny, params.TagMode)
+	}
+}
+
+// Test 50: TestAssetQueryParams_Validation_TagMode
+func TestAssetQueryParams_Validation_TagMode(t *testing.T) {
+	validate := validator.New()
+
+	params := AssetQueryParams{Tags: []string{"prod", "critical"}, TagMode: TagModeAny}
+	if err := validate.Struct(params); err != nil {
+		t.Errorf("Expected valid tag filter, got error: %v", err)
+	}
+
+	params.TagMode = "none"
+	if err := validate.Struct(params); err == nil {
+		t.Error("Expected validation error for invalid tag_mode")
+	}
+}
+
+// Test 51: TestNewAssetResponse_Tags
+func TestNewAssetResponse_Tags(t *testing.T) {
+	resp := NewAssetResponse(&Asset{ID: uuid.New(), Name: "nas-01", Tags: []string{"prod", "basement"}})
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"tags":["prod","basement"]`) {
+		t.Errorf("JSON should contain tags, got %s", jsonData)
+	}
+}
+
+// Test 52: TestNewAssetResponse_PinnedFavorite
+func TestNewAssetResponse_PinnedFavorite(t *testing.T) {
+	resp := NewAssetResponse(&Asset{ID: uuid.New(), Name: "ups-01", Pinned: true})
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"pinned":true,"favorite":false`) {
+		t.Errorf("JSON should always contain pinned and favorite, got %s", jsonData)
+	}
+}
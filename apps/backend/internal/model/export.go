@@ -0,0 +1,92 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"ark/internal/validation"
+)
+
+// Export statuses
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+const (
+	// ExportArchiveFormat identifies Ark account archives in their manifest
+	ExportArchiveFormat = "ark-export"
+	// ExportArchiveVersion is the archive layout version written by exports
+	ExportArchiveVersion = 2
+
+	// MaxSyncExportLogs is the largest account, in logs, exported within a
+	// request; larger accounts are exported by a background job
+	MaxSyncExportLogs = 5000
+	// MaxExportLogs is the largest account, in logs, exported at all
+	MaxExportLogs = 100000
+	// MaxExportAssets is the largest account, in assets, exported at all
+	MaxExportAssets = 10000
+
+	// ExportRetention is how long a finished export can be downloaded
+	ExportRetention = 7 * 24 * time.Hour
+)
+
+// ExportContentType is the media type of account archives
+const ExportContentType = "application/zip"
+
+// AccountExport is an account archive built in the background.
+// The archive itself is only loaded for downloads.
+type AccountExport struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      string     `json:"user_id" db:"user_id"`
+	Status      string     `json:"status" db:"status"`
+	Error       *string    `json:"error,omitempty" db:"error"`
+	SizeBytes   *int64     `json:"size_bytes,omitempty" db:"size_bytes"`
+	AssetCount  *int       `json:"asset_count,omitempty" db:"asset_count"`
+	LogCount    *int       `json:"log_count,omitempty" db:"log_count"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+}
+
+// ExportListResponse is the DTO for the user's exports, newest first
+type ExportListResponse struct {
+	Exports []*AccountExport `json:"exports"`
+	Total   int              `json:"total"`
+}
+
+// NewExportListResponse converts a slice of AccountExport to ExportListResponse DTO
+func NewExportListResponse(exports []*AccountExport) *ExportListResponse {
+	if exports == nil {
+		exports = []*AccountExport{}
+	}
+	return &ExportListResponse{
+		Exports: exports,
+		Total:   len(exports),
+	}
+}
+
+// ExportRequest selects what an account export includes: every asset, or the
+// assets of the saved asset view ViewID, with their logs
+type ExportRequest struct {
+	ViewID *uuid.UUID `query:"view_id"`
+}
+
+// Validate implements validation.Validatable
+func (r *ExportRequest) Validate() error {
+	return nil
+}
+
+// ExportDownloadRequest identifies a finished export to download
+type ExportDownloadRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+// Validate implements validation.Validatable
+func (r *ExportDownloadRequest) Validate() error {
+	return validation.Struct(r)
+}
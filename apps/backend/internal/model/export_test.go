@@ -0,0 +1,24 @@
+package model
+
+import "testing"
+
+// Test 1: TestExportDownloadRequest_Validate
+func TestExportDownloadRequest_Validate(t *testing.T) {
+	req := ExportDownloadRequest{ID: "550e8400-e29b-41d4-a716-446655440000"}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	req.ID = "not-a-uuid"
+	if err := req.Validate(); err == nil {
+		t.Error("Expected validation error for invalid id")
+	}
+}
+
+// Test 2: TestNewExportListResponse
+func TestNewExportListResponse(t *testing.T) {
+	resp := NewExportListResponse(nil)
+	if resp.Exports == nil || resp.Total != 0 {
+		t.Errorf("Expected empty non-nil export list, got %+v", resp)
+	}
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// Import conflict policies decide what happens to an archived asset or log
+// whose ID already exists in the account
+const (
+	// ImportConflictSkip keeps the existing row
+	ImportConflictSkip = "skip"
+	// ImportConflictOverwrite replaces the existing row with the archived one
+	ImportConflictOverwrite = "overwrite"
+	// ImportConflictDuplicate imports the archived row under a new ID
+	ImportConflictDuplicate = "duplicate"
+)
+
+// IsValidImportConflict checks if the given policy is a valid import conflict policy
+func IsValidImportConflict(policy string) bool {
+	switch policy {
+	case ImportConflictSkip, ImportConflictOverwrite, ImportConflictDuplicate:
+		return true
+	default:
+		return false
+	}
+}
+
+// Import change actions
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+)
+
+// Import change types
+const (
+	ImportTypeAsset = "asset"
+	ImportTypeLog   = "log"
+)
+
+const (
+	// MaxImportBytes is the largest archive accepted for import
+	MaxImportBytes = 100 << 20
+
+	// MaxImportChanges is the number of changes listed in an import result;
+	// the summary counts always cover every row
+	MaxImportChanges = 1000
+)
+
+// ImportParams are the query parameters of an account import
+type ImportParams struct {
+	Conflict string `query:"conflict"`
+	DryRun   bool   `query:"dry_run"`
+}
+
+// SetDefaults fills in the default conflict policy
+func (p *ImportParams) SetDefaults() {
+	if p.Conflict == "" {
+		p.Conflict = ImportConflictSkip
+	}
+}
+
+// ImportChange is what an import does, or would do, with one archived row.
+// NewID is set when the row is imported under a different ID.
+type ImportChange struct {
+	Type   string     `json:"type"`
+	Action string     `json:"action"`
+	ID     uuid.UUID  `json:"id"`
+	NewID  *uuid.UUID `json:"new_id,omitempty"`
+	Name   string     `json:"name,omitempty"`
+}
+
+// ImportEntitySummary counts the changes for one type of row. NewIDs counts
+// created rows that could not keep their archived ID.
+type ImportEntitySummary struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	NewIDs  int `json:"new_ids"`
+}
+
+// ImportResult reports the outcome of an import, or the plan of a dry run
+type ImportResult struct {
+	DryRun           bool                `json:"dry_run"`
+	Conflict         string              `json:"conflict"`
+	Assets           ImportEntitySummary `json:"assets"`
+	Logs             ImportEntitySummary `json:"logs"`
+	Changes          []ImportChange      `json:"changes"`
+	ChangesTruncated bool                `json:"changes_truncated"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "testing"
+
+// Test 1: TestIsValidImportConflict
+func TestIsValidImportConflict(t *testing.T) {
+	for _, policy := range []string{ImportConflictSkip, ImportConflictOverwrite, ImportConflictDuplicate} {
+		if !IsValidImportConflict(policy) {
+			t.Errorf("Expected %q to be a valid conflict policy", policy)
+		}
+	}
+	if IsValidImportConflict("merge") {
+		t.Error("Expected merge to be an invalid conflict policy")
+	}
+}
+
+// Test 2: TestImportParams_SetDefaults
+func TestImportParams_SetDefaults(t *testing.T) {
+	params := ImportParams{}
+	params.SetDefaults()
+	if params.Conflict != ImportConflictSkip {
+		t.Errorf("Expected default conflict %q, got %q", ImportConflictSkip, params.Conflict)
+	}
+
+	params = ImportParams{Conflict: ImportConflictOverwrite}
+	params.SetDefaults()
+	if params.Conflict != ImportConflictOverwrite {
+		t.Errorf("Expected conflict to be kept, got %q", params.Conflict)
+	}
+}
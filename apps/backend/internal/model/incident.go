@@ -0,0 +1,176 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Incident Statuses
+const (
+	IncidentStatusOpen      = "open"
+	IncidentStatusMitigated = "mitigated"
+	IncidentStatusResolved  = "resolved"
+)
+
+// IsValidIncidentStatus checks if the given status is a valid incident status
+func IsValidIncidentStatus(s string) bool {
+	switch s {
+	case IncidentStatusOpen, IncidentStatusMitigated, IncidentStatusResolved:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransitionIncident reports whether an incident may move from one status to another.
+// Incidents move forward open → mitigated → resolved (mitigation may be skipped),
+// and a mitigated or resolved incident may be reopened.
+func CanTransitionIncident(from, to string) bool {
+	switch from {
+	case IncidentStatusOpen:
+		return to == IncidentStatusMitigated || to == IncidentStatusResolved
+	case IncidentStatusMitigated:
+		return to == IncidentStatusResolved || to == IncidentStatusOpen
+	case IncidentStatusResolved:
+		return to == IncidentStatusOpen
+	default:
+		return false
+	}
+}
+
+// InitialIncidentStatus returns the status a new incident starts in
+func InitialIncidentStatus(resolvedAt *time.Time) string {
+	if resolvedAt != nil {
+		return IncidentStatusResolved
+	}
+	return IncidentStatusOpen
+}
+
+// SeverityRank orders severities from low (1) to critical (4); unknown severities rank 0
+func SeverityRank(s string) int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// IncidentTimelineEntry records a status change or note on an incident log
+type IncidentTimelineEntry struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	LogID      uuid.UUID `json:"log_id" db:"log_id"`
+	UserID     string    `json:"user_id" db:"user_id"`
+	Status     *string   `json:"status,omitempty" db:"status"`
+	Note       *string   `json:"note,omitempty" db:"note"`
+	OccurredAt time.Time `json:"occurred_at" db:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateTimelineEntryRequest is the DTO for adding a timeline entry to an incident.
+// Setting Status transitions the incident; at least one of Status or Note is required.
+type CreateTimelineEntryRequest struct {
+	Status     *string    `json:"status,omitempty" validate:"omitempty,oneof=open mitigated resolved"`
+	Note       *string    `json:"note,omitempty" validate:"omitempty,min=1,max=5000"`
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+}
+
+// IncidentTimelineResponse is the DTO for an incident together with its timeline
+type IncidentTimelineResponse struct {
+	Incident *LogResponse            `json:"incident"`
+	Entries  []IncidentTimelineEntry `json:"entries"`
+}
+
+// IncidentRecord is an incident log joined with its asset, used for statistics
+type IncidentRecord struct {
+	LogID      uuid.UUID
+	AssetID    uuid.UUID
+	AssetName  string
+	AssetType  *string
+	Severity   *string
+	Status     string
+	StartedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// Stats Intervals
+const (
+	StatsIntervalDay   = "day"
+	StatsIntervalWeek  = "week"
+	StatsIntervalMonth = "month"
+)
+
+// IncidentStatsQueryParams represents query parameters for incident statistics
+type IncidentStatsQueryParams struct {
+	StartDate   *time.Time `query:"start_date"`
+	EndDate     *time.Time `query:"end_date"`
+	Interval    string     `query:"interval" validate:"omitempty,oneof=day week month"`
+	AssetID     *string    `query:"asset_id" validate:"omitempty,uuid"`
+	Type        *string    `query:"type" validate:"omitempty,max=50"`
+	MinSeverity *string    `query:"min_severity" validate:"omitempty,oneof=low medium high critical"`
+}
+
+// DefaultIncidentStatsWindow is the reporting window used when no start_date is given
+const DefaultIncidentStatsWindow = 90 * 24 * time.Hour
+
+// SetDefaults sets default values for IncidentStatsQueryParams
+func (q *IncidentStatsQueryParams) SetDefaults(now time.Time) {
+	if q.EndDate == nil {
+		end := now
+		q.EndDate = &end
+	}
+	if q.StartDate == nil {
+		start := q.EndDate.Add(-DefaultIncidentStatsWindow)
+		q.StartDate = &start
+	}
+	if q.Interval == "" {
+		q.Interval = StatsIntervalWeek
+	}
+}
+
+// AssetIncidentStats summarises incidents for a single asset
+type AssetIncidentStats struct {
+	AssetID             uuid.UUID `json:"asset_id"`
+	AssetName           string    `json:"asset_name"`
+	AssetType           *string   `json:"asset_type,omitempty"`
+	IncidentCount       int       `json:"incident_count"`
+	ResolvedCount       int       `json:"resolved_count"`
+	MTTRSeconds         *float64  `json:"mttr_seconds,omitempty"`
+	DowntimeSeconds     float64   `json:"downtime_seconds"`
+	AvailabilityPercent float64   `json:"availability_percent"`
+}
+
+// TypeIncidentStats summarises incidents for an asset type
+type TypeIncidentStats struct {
+	AssetType     string   `json:"asset_type"`
+	IncidentCount int      `json:"incident_count"`
+	MTTRSeconds   *float64 `json:"mttr_seconds,omitempty"`
+}
+
+// IncidentBucket counts incidents started within one interval
+type IncidentBucket struct {
+	Start  time.Time      `json:"start"`
+	Total  int            `json:"total"`
+	ByType map[string]int `json:"by_type"`
+}
+
+// IncidentStatsResponse is the DTO for incident statistics over a reporting window
+type IncidentStatsResponse struct {
+	StartDate           time.Time            `json:"start_date"`
+	EndDate             time.Time            `json:"end_date"`
+	Interval            string               `json:"interval"`
+	TotalIncidents      int                  `json:"total_incidents"`
+	OpenIncidents       int                  `json:"open_incidents"`
+	MTTRSeconds         *float64             `json:"mttr_seconds,omitempty"`
+	AvailabilityPercent float64              `json:"availability_percent"`
+	ByAsset             []AssetIncidentStats `json:"by_asset"`
+	ByType              []TypeIncidentStats  `json:"by_type"`
+	OverTime            []IncidentBucket     `json:"over_time"`
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Test 1: TestCanTransitionIncident
+func TestCanTransitionIncident(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{IncidentStatusOpen, IncidentStatusMitigated, true},
+		{IncidentStatusOpen, IncidentStatusResolved, true},
+		{IncidentStatusOpen, IncidentStatusOpen, false},
+		{IncidentStatusMitigated, IncidentStatusResolved, true},
+		{IncidentStatusMitigated, IncidentStatusOpen, true},
+		{IncidentStatusMitigated, IncidentStatusMitigated, false},
+		{IncidentStatusResolved, IncidentStatusOpen, true},
+		{IncidentStatusResolved, IncidentStatusMitigated, false},
+		{"unknown", IncidentStatusOpen, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransitionIncident(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionIncident(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+// Test 2: TestInitialIncidentStatus
+func TestInitialIncidentStatus(t *testing.T) {
+	if got := InitialIncidentStatus(nil); got != IncidentStatusOpen {
+		t.Errorf("Expected open for unresolved incident, got %q", got)
+	}
+
+	now := time.Now()
+	if got := InitialIncidentStatus(&now); got != IncidentStatusResolved {
+		t.Errorf("Expected resolved for resolved incident, got %q", got)
+	}
+}
+
+// Test 3: TestSeverityRank
+func TestSeverityRank(t *testing.T) {
+	if !(SeverityRank(SeverityLow) < SeverityRank(SeverityMedium) &&
+		SeverityRank(SeverityMedium) < SeverityRank(SeverityHigh) &&
+		SeverityRank(SeverityHigh) < SeverityRank(SeverityCritical)) {
+		t.Error("Expected severities to rank low < medium < high < critical")
+	}
+	if SeverityRank("bogus") != 0 {
+		t.Error("Expected unknown severity to rank 0")
+	}
+}
+
+// Test 4: TestIncidentStatsQueryParams_SetDefaults
+func TestIncidentStatsQueryParams_SetDefaults(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	params := IncidentStatsQueryParams{}
+	params.SetDefaults(now)
+
+	if params.EndDate == nil || !params.EndDate.Equal(now) {
+		t.Errorf("Expected end_date to default to now, got %v", params.EndDate)
+	}
+	if params.StartDate == nil || !params.StartDate.Equal(now.Add(-DefaultIncidentStatsWindow)) {
+		t.Errorf("Expected start_date to default to 90 days before end, got %v", params.StartDate)
+	}
+	if params.Interval != StatsIntervalWeek {
+		t.Errorf("Expected interval to default to week, got %q", params.Interval)
+	}
+}
+
+// Test 5: TestIncidentStatsQueryParams_SetDefaults_StartRelativeToEnd
+func TestIncidentStatsQueryParams_SetDefaults_StartRelativeToEnd(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	params := IncidentStatsQueryParams{EndDate: &end, Interval: StatsIntervalMonth}
+	params.SetDefaults(now)
+
+	if !params.StartDate.Equal(end.Add(-DefaultIncidentStatsWindow)) {
+		t.Errorf("Expected start_date relative to end_date, got %v", params.StartDate)
+	}
+	if params.Interval != StatsIntervalMonth {
+		t.Errorf("Expected explicit interval to be kept, got %q", params.Interval)
+	}
+}
+
+// Test 6: TestCreateTimelineEntryRequest_Validation
+func TestCreateTimelineEntryRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	status := IncidentStatusMitigated
+	req := CreateTimelineEntryRequest{Status: &status}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	bad := "closed"
+	req.Status = &bad
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for invalid status")
+	}
+}
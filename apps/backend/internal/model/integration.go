@@ -0,0 +1,103 @@
+package model
+
+import (
+	"encoding/json"
+
+	"ark/internal/validation"
+)
+
+// MaxAnsibleInventoryHosts is the most assets a dynamic inventory may list
+const MaxAnsibleInventoryHosts = 5000
+
+// AnsibleInventoryParams narrow the assets a dynamic inventory lists, like
+// the filters of GET /api/v1/assets
+type AnsibleInventoryParams struct {
+	Type    *string  `query:"type"`
+	Tags    []string `query:"tags"`
+	TagMode string   `query:"tag_mode" validate:"omitempty,oneof=any all"`
+}
+
+// Validate implements validation.Validatable
+func (p *AnsibleInventoryParams) Validate() error {
+	return validation.Struct(p)
+}
+
+// AnsibleInventoryGroup is a group of a dynamic inventory
+type AnsibleInventoryGroup struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Children []string `json:"children,omitempty"`
+}
+
+// AnsibleInventory is an inventory in the JSON format Ansible reads from
+// inventory scripts: groups by name next to "_meta", which holds the
+// variables of every host so Ansible needn't ask for them one by one.
+type AnsibleInventory struct {
+	Groups   map[string]*AnsibleInventoryGroup
+	HostVars map[string]map[string]any
+}
+
+// MarshalJSON writes the inventory as
+//
+//	{"_meta": {"hostvars": {...}}, "all": {"children": [...]}, "<group>": {"hosts": [...]}}
+func (inv *AnsibleInventory) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(inv.Groups)+1)
+	for name, group := range inv.Groups {
+		out[name] = group
+	}
+	out["_meta"] = map[string]any{"hostvars": inv.HostVars}
+	return json.Marshal(out)
+}
+
+// MaxPrometheusSDAssets is the most assets Prometheus service discovery may list
+const MaxPrometheusSDAssets = 5000
+
+// PrometheusSDParams narrow the targets Prometheus service discovery returns,
+// so each scrape job can pull its own set. Service matches the service of a
+// port, ignoring case.
+type PrometheusSDParams struct {
+	Type    *string  `query:"type"`
+	Tags    []string `query:"tags"`
+	TagMode string   `query:"tag_mode" validate:"omitempty,oneof=any all"`
+	Service *string  `query:"service"`
+}
+
+// Validate implements validation.Validatable
+func (p *PrometheusSDParams) Validate() error {
+	return validation.Struct(p)
+}
+
+// PrometheusTargetGroup is one entry of the list Prometheus reads from an
+// http_sd_config endpoint
+type PrometheusTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+const (
+	// MaxGeneratedFileHosts is the most assets an SSH config or hosts file may list
+	MaxGeneratedFileHosts = 5000
+	// GeneratedFileContentType is the media type of SSH configs and hosts files
+	GeneratedFileContentType = "text/plain; charset=utf-8"
+)
+
+// Asset metadata keys read when rendering an SSH config. Each falls back to
+// the Ansible host variable of the same meaning, so hosts imported from an
+// Ansible inventory keep their connection settings.
+const (
+	SSHMetadataUser     = "ssh_user"
+	SSHMetadataPort     = "ssh_port"
+	SSHMetadataJumpHost = "ssh_jump_host"
+)
+
+// GeneratedFileRequest selects the assets rendered into an SSH config or
+// hosts file, with the filters of the dynamic inventory
+type GeneratedFileRequest struct {
+	Type    *string  `query:"type"`
+	Tags    []string `query:"tags"`
+	TagMode string   `query:"tag_mode" validate:"omitempty,oneof=any all"`
+}
+
+// Validate implements validation.Validatable
+func (r *GeneratedFileRequest) Validate() error {
+	return validation.Struct(r)
+}
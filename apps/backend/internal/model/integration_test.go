@@ -0,0 +1,27 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Test 1: TestAnsibleInventory_MarshalJSON
+func TestAnsibleInventory_MarshalJSON(t *testing.T) {
+	inv := &AnsibleInventory{
+		Groups: map[string]*AnsibleInventoryGroup{
+			"all":      {Children: []string{"tag_prod"}},
+			"tag_prod": {Hosts: []string{"web01"}},
+		},
+		HostVars: map[string]map[string]any{"web01": {"ansible_host": "10.0.0.11"}},
+	}
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := `{"_meta":{"hostvars":{"web01":{"ansible_host":"10.0.0.11"}}},"all":{"children":["tag_prod"]},"tag_prod":{"hosts":["web01"]}}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxInventoryFiles is the most files accepted in one inventory import
+	MaxInventoryFiles = 20
+	// MaxInventoryFileBytes is the largest inventory file accepted
+	MaxInventoryFileBytes = 1 << 20
+	// MaxInventoryAssets is the most assets one inventory import may describe
+	MaxInventoryAssets = 1000
+)
+
+// InventoryFile is an uploaded inventory file such as a docker-compose.yml or
+// an Ansible inventory
+type InventoryFile struct {
+	Name string
+	Data []byte
+}
+
+// Inventory import actions
+const (
+	InventoryActionCreate    = "create"
+	InventoryActionUpdate    = "update"
+	InventoryActionUnchanged = "unchanged"
+	InventoryActionNotSeen   = "not_seen"
+	InventoryActionSkipped   = "skipped"
+)
+
+// Docker Compose metadata keys. Containers are identified by their host
+// asset, project and service, so re-imports update them in place.
+const (
+	ComposeMetadataHostAssetID = "host_asset_id"
+	ComposeMetadataProject     = "compose_project"
+	ComposeMetadataService     = "compose_service"
+)
+
+// Network metadata keys. Network scan imports write them; the hosts file
+// generator takes an asset's address from ip_address when its hostname is a
+// DNS name.
+const (
+	MetadataIPAddress  = "ip_address"
+	MetadataMACAddress = "mac_address"
+	MetadataMACVendor  = "mac_vendor"
+)
+
+// ComposeImportParams are the query parameters of a docker-compose import
+type ComposeImportParams struct {
+	HostAssetID string `query:"host_asset_id"`
+	DryRun      bool   `query:"dry_run"`
+}
+
+// AnsibleImportParams are the query parameters of an Ansible inventory import.
+// Type, when set, is given to every imported host.
+type AnsibleImportParams struct {
+	Type   string `query:"type"`
+	DryRun bool   `query:"dry_run"`
+}
+
+// NmapImportParams are the query parameters of an nmap scan import. Scope
+// lists the networks the scan covered, as CIDRs or addresses, to find assets
+// the scan did not see; without it the targets on the nmap command line are
+// used.
+//
+// A scan is always reviewed with a dry run first. Applying it requires the
+// Plan the dry run returned and Accept, the scanned addresses to import;
+// other hosts are skipped.
+type NmapImportParams struct {
+	DryRun bool     `query:"dry_run"`
+	Scope  []string `query:"scope"`
+	Plan   string   `query:"plan"`
+	Accept []string `query:"accept"`
+}
+
+// InventoryAssetResult is what an inventory import does, or would do, with one
+// asset. Changes describe the differences an update writes, one per field.
+// ID is nil for assets a dry run would create. Address is the scanned address
+// of a network scan host, as passed to accept.
+type InventoryAssetResult struct {
+	Source  string     `json:"source"`
+	Action  string     `json:"action"`
+	ID      *uuid.UUID `json:"id,omitempty"`
+	Name    string     `json:"name"`
+	Address string     `json:"address,omitempty"`
+	Changes []string   `json:"changes,omitempty"`
+}
+
+// InventoryImportResult reports the outcome of an inventory import, or what a
+// dry run would do. NotSeen lists assets a network scan should have found but
+// did not; they are reported only, never changed. Plan fingerprints what a
+// network scan import would do, and Skipped counts the scanned hosts an
+// apply did not accept.
+type InventoryImportResult struct {
+	DryRun    bool                   `json:"dry_run"`
+	Created   int                    `json:"created"`
+	Updated   int                    `json:"updated"`
+	Unchanged int                    `json:"unchanged"`
+	Skipped   int                    `json:"skipped,omitempty"`
+	Assets    []InventoryAssetResult `json:"assets"`
+	NotSeen   []InventoryAssetResult `json:"not_seen,omitempty"`
+	Plan      string                 `json:"plan,omitempty"`
+}
@@ -4,40 +4,188 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"ark/internal/validation"
+)
+
+// Log Kinds
+const (
+	LogKindNote        = "note"
+	LogKindChange      = "change"
+	LogKindIncident    = "incident"
+	LogKindMaintenance = "maintenance"
+)
+
+// IsValidLogKind checks if the given kind is a valid log kind
+func IsValidLogKind(k string) bool {
+	switch k {
+	case LogKindNote, LogKindChange, LogKindIncident, LogKindMaintenance:
+		return true
+	default:
+		return false
+	}
+}
+
+// Log render modes
+const (
+	LogRenderHTML = "html"
+)
+
+// Incident Severities
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
 )
 
-// AssetLog represents a configuration change or troubleshooting log for an asset
+// IsValidSeverity checks if the given severity is a valid incident severity
+func IsValidSeverity(s string) bool {
+	switch s {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// AssetLog represents a configuration change or troubleshooting log for an asset.
+// Kind-specific fields are only populated for the matching kind:
+//   - incident:    Severity, StartedAt, ResolvedAt, RootCause, IncidentStatus, MitigatedAt
+//   - change:      Planned, RollbackNotes
+//   - maintenance: DurationMinutes
 type AssetLog struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	AssetID   uuid.UUID `json:"asset_id" db:"asset_id"`
-	UserID    string    `json:"user_id" db:"user_id"`
-	Content   string    `json:"content" db:"content"`
-	Tags      []string  `json:"tags,omitempty" db:"tags"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID              uuid.UUID  `json:"id" db:"id"`
+	AssetID         uuid.UUID  `json:"asset_id" db:"asset_id"`
+	UserID          string     `json:"user_id" db:"user_id"`
+	Kind            string     `json:"kind" db:"kind"`
+	Content         string     `json:"content" db:"content"`
+	Tags            []string   `json:"tags,omitempty" db:"tags"`
+	Severity        *string    `json:"severity,omitempty" db:"severity"`
+	StartedAt       *time.Time `json:"started_at,omitempty" db:"started_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+	RootCause       *string    `json:"root_cause,omitempty" db:"root_cause"`
+	IncidentStatus  *string    `json:"incident_status,omitempty" db:"incident_status"`
+	MitigatedAt     *time.Time `json:"mitigated_at,omitempty" db:"mitigated_at"`
+	Planned         *bool      `json:"planned,omitempty" db:"planned"`
+	RollbackNotes   *string    `json:"rollback_notes,omitempty" db:"rollback_notes"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty" db:"duration_minutes"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+
+	// LinkedAssetIDs are the other assets the log applies to, besides the primary AssetID
+	LinkedAssetIDs []uuid.UUID `json:"linked_asset_ids" db:"-"`
+
+	// Thread fields: ParentLogID is the log this one follows up on and ThreadRootID
+	// the first log of the thread; both are nil for a root log
+	ParentLogID  *uuid.UUID `json:"parent_log_id,omitempty" db:"parent_log_id"`
+	ThreadRootID *uuid.UUID `json:"thread_root_id,omitempty" db:"thread_root_id"`
+
+	// Pinned logs always list first for their asset; Favorite is a filterable bookmark
+	Pinned   bool `json:"pinned" db:"pinned"`
+	Favorite bool `json:"favorite" db:"favorite"`
+
+	// Thread summary, only set when threads are collapsed in a listing
+	ReplyCount     *int       `json:"reply_count,omitempty" db:"-"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty" db:"-"`
 }
 
-// CreateLogRequest is the DTO for creating a new log entry
+// CreateLogRequest is the DTO for creating a new log entry.
+// Kind defaults to "note" when omitted; kind-specific fields are validated by LogService.
 type CreateLogRequest struct {
-	Content string   `json:"content" validate:"required,min=2,max=10000"`
-	Tags    []string `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	Kind            string     `json:"kind,omitempty" validate:"omitempty,oneof=note change incident maintenance"`
+	Content         string     `json:"content" validate:"required,min=2,max=10000"`
+	Tags            []string   `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	Severity        *string    `json:"severity,omitempty" validate:"omitempty,oneof=low medium high critical"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	RootCause       *string    `json:"root_cause,omitempty" validate:"omitempty,max=5000"`
+	Planned         *bool      `json:"planned,omitempty"`
+	RollbackNotes   *string    `json:"rollback_notes,omitempty" validate:"omitempty,max=5000"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty" validate:"omitempty,min=0"`
+
+	// LinkedAssetIDs links the log to more assets than the one it is created on
+	LinkedAssetIDs []uuid.UUID `json:"linked_asset_ids,omitempty" validate:"omitempty,max=100"`
+
+	// ParentLogID makes the log a reply to an existing log
+	ParentLogID *uuid.UUID `json:"parent_log_id,omitempty"`
+
+	Pinned   bool `json:"pinned,omitempty"`
+	Favorite bool `json:"favorite,omitempty"`
+
+	// Values fills the placeholders when the log is created from a template;
+	// the rendered template then replaces Content.
+	Values map[string]any `json:"values,omitempty"`
 }
 
 // UpdateLogRequest is the DTO for updating an existing log entry
 type UpdateLogRequest struct {
-	Content *string   `json:"content,omitempty" validate:"omitempty,min=2,max=10000"`
-	Tags    *[]string `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	Kind            *string    `json:"kind,omitempty" validate:"omitempty,oneof=note change incident maintenance"`
+	Content         *string    `json:"content,omitempty" validate:"omitempty,min=2,max=10000"`
+	Tags            *[]string  `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	Severity        *string    `json:"severity,omitempty" validate:"omitempty,oneof=low medium high critical"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	RootCause       *string    `json:"root_cause,omitempty" validate:"omitempty,max=5000"`
+	Planned         *bool      `json:"planned,omitempty"`
+	RollbackNotes   *string    `json:"rollback_notes,omitempty" validate:"omitempty,max=5000"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty" validate:"omitempty,min=0"`
+
+	// LinkedAssetIDs replaces the linked assets when set; the primary asset never changes
+	LinkedAssetIDs *[]uuid.UUID `json:"linked_asset_ids,omitempty" validate:"omitempty,max=100"`
+
+	Pinned   *bool `json:"pinned,omitempty"`
+	Favorite *bool `json:"favorite,omitempty"`
 }
 
 // LogResponse is the DTO for single log responses
 type LogResponse struct {
-	ID        uuid.UUID `json:"id"`
-	AssetID   uuid.UUID `json:"asset_id"`
-	UserID    string    `json:"user_id"`
-	Content   string    `json:"content"`
-	Tags      []string  `json:"tags,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	AssetID         uuid.UUID  `json:"asset_id"`
+	UserID          string     `json:"user_id"`
+	Kind            string     `json:"kind"`
+	Content         string     `json:"content"`
+	Tags            []string   `json:"tags,omitempty"`
+	Severity        *string    `json:"severity,omitempty"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	RootCause       *string    `json:"root_cause,omitempty"`
+	IncidentStatus  *string    `json:"incident_status,omitempty"`
+	MitigatedAt     *time.Time `json:"mitigated_at,omitempty"`
+	Planned         *bool      `json:"planned,omitempty"`
+	RollbackNotes   *string    `json:"rollback_notes,omitempty"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	LinkedAssetIDs []uuid.UUID `json:"linked_asset_ids"`
+	ParentLogID    *uuid.UUID  `json:"parent_log_id,omitempty"`
+	ThreadRootID   *uuid.UUID  `json:"thread_root_id,omitempty"`
+	ReplyCount     *int        `json:"reply_count,omitempty"`
+	LastActivityAt *time.Time  `json:"last_activity_at,omitempty"`
+	Pinned         bool        `json:"pinned"`
+	Favorite       bool        `json:"favorite"`
+
+	// Set only when the log is requested with ?render=html
+	ContentHTML *string     `json:"content_html,omitempty"`
+	CodeBlocks  []CodeBlock `json:"code_blocks,omitempty"`
+}
+
+// CodeBlock is a code block extracted from log content. Language is the info
+// string of a fenced block and empty for indented blocks.
+type CodeBlock struct {
+	Language string `json:"language"`
+	Code     string `json:"code"`
+}
+
+// LogRenderParams holds the render mode for endpoints returning logs
+type LogRenderParams struct {
+	Render *string `query:"render" validate:"omitempty,oneof=html"`
+}
+
+// Validate implements validation.Validatable
+func (p *LogRenderParams) Validate() error {
+	return validation.Struct(p)
 }
 
 // NewLogResponse converts an AssetLog domain model to LogResponse DTO
@@ -47,13 +195,58 @@ func NewLogResponse(log *AssetLog) *LogResponse {
 	}
 
 	return &LogResponse{
-		ID:        log.ID,
-		AssetID:   log.AssetID,
-		UserID:    log.UserID,
-		Content:   log.Content,
-		Tags:      log.Tags,
-		CreatedAt: log.CreatedAt,
-		UpdatedAt: log.UpdatedAt,
+		ID:              log.ID,
+		AssetID:         log.AssetID,
+		UserID:          log.UserID,
+		Kind:            log.Kind,
+		Content:         log.Content,
+		Tags:            log.Tags,
+		Severity:        log.Severity,
+		StartedAt:       log.StartedAt,
+		ResolvedAt:      log.ResolvedAt,
+		RootCause:       log.RootCause,
+		IncidentStatus:  log.IncidentStatus,
+		MitigatedAt:     log.MitigatedAt,
+		Planned:         log.Planned,
+		RollbackNotes:   log.RollbackNotes,
+		DurationMinutes: log.DurationMinutes,
+		LinkedAssetIDs:  log.LinkedAssetIDs,
+		ParentLogID:     log.ParentLogID,
+		ThreadRootID:    log.ThreadRootID,
+		ReplyCount:      log.ReplyCount,
+		LastActivityAt:  log.LastActivityAt,
+		Pinned:          log.Pinned,
+		Favorite:        log.Favorite,
+		CreatedAt:       log.CreatedAt,
+		UpdatedAt:       log.UpdatedAt,
+	}
+}
+
+// LogThreadResponse is the DTO for a log thread: the root log and its replies in
+// chronological order. Replies carry parent_log_id so clients can nest them.
+type LogThreadResponse struct {
+	Root           LogResponse   `json:"root"`
+	Replies        []LogResponse `json:"replies"`
+	ReplyCount     int           `json:"reply_count"`
+	LastActivityAt time.Time     `json:"last_activity_at"`
+}
+
+// NewLogThreadResponse builds a LogThreadResponse from a root log and its replies
+func NewLogThreadResponse(root *AssetLog, replies []*AssetLog) *LogThreadResponse {
+	lastActivity := root.UpdatedAt
+	responses := make([]LogResponse, 0, len(replies))
+	for _, reply := range replies {
+		responses = append(responses, *NewLogResponse(reply))
+		if reply.UpdatedAt.After(lastActivity) {
+			lastActivity = reply.UpdatedAt
+		}
+	}
+
+	return &LogThreadResponse{
+		Root:           *NewLogResponse(root),
+		Replies:        responses,
+		ReplyCount:     len(responses),
+		LastActivityAt: lastActivity,
 	}
 }
 
@@ -84,14 +277,37 @@ func NewLogListResponse(logs []*AssetLog, total int64, limit, offset int) *LogLi
 
 // LogQueryParams represents query parameters for listing logs
 type LogQueryParams struct {
-	Limit     int        `query:"limit" validate:"omitempty,min=1,max=200"`
-	Offset    int        `query:"offset" validate:"omitempty,min=0"`
-	Tags      []string   `query:"tags" validate:"omitempty,dive,max=50"`
-	Search    *string    `query:"search" validate:"omitempty,max=100"`
-	StartDate *time.Time `query:"start_date"`
-	EndDate   *time.Time `query:"end_date"`
-	SortBy    string     `query:"sort_by" validate:"omitempty,oneof=created_at updated_at"`
-	SortOrder string     `query:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Limit     int        `query:"limit" json:"limit,omitempty" validate:"omitempty,min=1,max=200"`
+	Offset    int        `query:"offset" json:"offset,omitempty" validate:"omitempty,min=0"`
+	Tags      []string   `query:"tags" json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	TagsAny   []string   `query:"tags_any" json:"tags_any,omitempty" validate:"omitempty,dive,max=50"`
+	TagsAll   []string   `query:"tags_all" json:"tags_all,omitempty" validate:"omitempty,dive,max=50"`
+	TagsNone  []string   `query:"tags_none" json:"tags_none,omitempty" validate:"omitempty,dive,max=50"`
+	Kind      *string    `query:"kind" json:"kind,omitempty" validate:"omitempty,oneof=note change incident maintenance"`
+	Search    *string    `query:"search" json:"search,omitempty" validate:"omitempty,max=100"`
+	StartDate *time.Time `query:"start_date" json:"start_date,omitempty"`
+	EndDate   *time.Time `query:"end_date" json:"end_date,omitempty"`
+	SortBy    string     `query:"sort_by" json:"sort_by,omitempty" validate:"omitempty,oneof=created_at updated_at"`
+	SortOrder string     `query:"sort_order" json:"sort_order,omitempty" validate:"omitempty,oneof=asc desc"`
+
+	// CollapseThreads lists each matching thread once, as its root log with
+	// reply count and last activity
+	CollapseThreads bool `query:"collapse_threads" json:"collapse_threads,omitempty"`
+
+	// Favorite filters on the favorite flag when set
+	Favorite *bool `query:"favorite" json:"favorite,omitempty"`
+
+	// CreatedWithinDays keeps logs created in the last N days; being relative,
+	// it stays meaningful in saved views
+	CreatedWithinDays *int `query:"created_within_days" json:"created_within_days,omitempty" validate:"omitempty,min=1,max=3650"`
+
+	// Render adds content_html and code_blocks to each log when set to "html"
+	Render *string `query:"render" json:"render,omitempty" validate:"omitempty,oneof=html"`
+}
+
+// Validate implements validation.Validatable
+func (q *LogQueryParams) Validate() error {
+	return validation.Struct(q)
 }
 
 // SetDefaults sets default values for LogQueryParams
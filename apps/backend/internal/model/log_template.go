@@ -0,0 +1,106 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Placeholder Types
+const (
+	PlaceholderTypeString = "string"
+	PlaceholderTypeInt    = "int"
+	PlaceholderTypeNumber = "number"
+	PlaceholderTypeBool   = "bool"
+	PlaceholderTypeDate   = "date"
+)
+
+// IsValidPlaceholderType checks if the given type is a valid placeholder type
+func IsValidPlaceholderType(t string) bool {
+	switch t {
+	case PlaceholderTypeString, PlaceholderTypeInt, PlaceholderTypeNumber, PlaceholderTypeBool, PlaceholderTypeDate:
+		return true
+	default:
+		return false
+	}
+}
+
+// TemplatePlaceholder declares a typed {{name}} placeholder used in a log template's content.
+// Default is used when no value is supplied; a required placeholder without a
+// default must be given a value.
+type TemplatePlaceholder struct {
+	Name        string  `json:"name" validate:"required,max=50"`
+	Type        string  `json:"type" validate:"required,oneof=string int number bool date"`
+	Required    bool    `json:"required"`
+	Default     *string `json:"default,omitempty" validate:"omitempty,max=1000"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+// LogTemplate is a saved log layout with placeholders, e.g. a disk replacement
+// log with {{disk_serial}} and {{bay}}. Logs created from a template get the
+// rendered content, the template's kind and its default tags.
+type LogTemplate struct {
+	ID           uuid.UUID             `json:"id" db:"id"`
+	UserID       string                `json:"user_id" db:"user_id"`
+	Name         string                `json:"name" db:"name"`
+	Description  *string               `json:"description,omitempty" db:"description"`
+	Kind         string                `json:"kind" db:"kind"`
+	Content      string                `json:"content" db:"content"`
+	Tags         []string              `json:"tags,omitempty" db:"tags"`
+	Placeholders []TemplatePlaceholder `json:"placeholders" db:"placeholders"`
+	CreatedAt    time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// CreateLogTemplateRequest is the DTO for creating a log template
+type CreateLogTemplateRequest struct {
+	Name         string                `json:"name" validate:"required,max=100"`
+	Description  *string               `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Kind         string                `json:"kind,omitempty" validate:"omitempty,oneof=note change incident maintenance"`
+	Content      string                `json:"content" validate:"required,min=2,max=10000"`
+	Tags         []string              `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	Placeholders []TemplatePlaceholder `json:"placeholders,omitempty" validate:"omitempty,max=50,dive"`
+}
+
+// UpdateLogTemplateRequest is the DTO for updating a log template.
+// Content and Placeholders are validated together, so changing either
+// re-checks that every placeholder in the content is declared.
+type UpdateLogTemplateRequest struct {
+	Name         *string                `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description  *string                `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Kind         *string                `json:"kind,omitempty" validate:"omitempty,oneof=note change incident maintenance"`
+	Content      *string                `json:"content,omitempty" validate:"omitempty,min=2,max=10000"`
+	Tags         *[]string              `json:"tags,omitempty"`
+	Placeholders *[]TemplatePlaceholder `json:"placeholders,omitempty"`
+}
+
+// RenderLogTemplateRequest is the DTO for previewing a template with values
+type RenderLogTemplateRequest struct {
+	Values map[string]any `json:"values,omitempty"`
+}
+
+// RenderLogTemplateResponse is the DTO for a rendered template preview
+type RenderLogTemplateResponse struct {
+	Content string   `json:"content"`
+	Kind    string   `json:"kind"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// LogTemplateListResponse is the DTO for lists of log templates
+type LogTemplateListResponse struct {
+	Templates []LogTemplate `json:"templates"`
+	Total     int           `json:"total"`
+}
+
+// NewLogTemplateListResponse converts a slice of LogTemplate to LogTemplateListResponse DTO
+func NewLogTemplateListResponse(templates []*LogTemplate) *LogTemplateListResponse {
+	items := make([]LogTemplate, 0, len(templates))
+	for _, template := range templates {
+		items = append(items, *template)
+	}
+
+	return &LogTemplateListResponse{
+		Templates: items,
+		Total:     len(items),
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Test 1: TestIsValidPlaceholderType
+func TestIsValidPlaceholderType(t *testing.T) {
+	for _, typ := range []string{"string", "int", "number", "bool", "date"} {
+		if !IsValidPlaceholderType(typ) {
+			t.Errorf("IsValidPlaceholderType(%q) = false, want true", typ)
+		}
+	}
+	for _, typ := range []string{"", "uuid", "String"} {
+		if IsValidPlaceholderType(typ) {
+			t.Errorf("IsValidPlaceholderType(%q) = true, want false", typ)
+		}
+	}
+}
+
+// Test 2: TestCreateLogTemplateRequest_Validation
+func TestCreateLogTemplateRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	req := CreateLogTemplateRequest{
+		Name:    "Disk replacement",
+		Kind:    LogKindMaintenance,
+		Content: "Replaced disk {{disk_serial}}",
+		Placeholders: []TemplatePlaceholder{
+			{Name: "disk_serial", Type: PlaceholderTypeString, Required: true},
+		},
+	}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("valid request failed validation: %v", err)
+	}
+
+	req.Placeholders[0].Type = "uuid"
+	if err := validate.Struct(req); err == nil {
+		t.Error("expected validation error for unknown placeholder type")
+	}
+
+	req.Placeholders[0].Type = PlaceholderTypeString
+	req.Kind = "journal"
+	if err := validate.Struct(req); err == nil {
+		t.Error("expected validation error for unknown kind")
+	}
+}
+
+// Test 3: TestNewLogTemplateListResponse
+func TestNewLogTemplateListResponse(t *testing.T) {
+	response := NewLogTemplateListResponse(nil)
+	if response.Templates == nil || response.Total != 0 {
+		t.Errorf("empty list: got %+v", response)
+	}
+
+	response = NewLogTemplateListResponse([]*LogTemplate{{Name: "a"}, {Name: "b"}})
+	if response.Total != 2 || response.Templates[1].Name != "b" {
+		t.Errorf("got %+v", response)
+	}
+}
@@ -1301,3 +1301,292 @@ func TestLogQueryParams_Validation_ValidParams(t *testing.T) {
 		t.Errorf("Expected validation to pass for valid params, got error: %v", err)
 	}
 }
+
+// ========== Log Kind Tests ==========
+
+// Test 57: TestIsValidLogKind
+func TestIsValidLogKind(t *testing.T) {
+	valid := []string{LogKindNote, LogKindChange, LogKindIncident, LogKindMaintenance}
+	for _, kind := range valid {
+		if !IsValidLogKind(kind) {
+			t.Errorf("Expected %q to be a valid log kind", kind)
+		}
+	}
+
+	invalid := []string{"", "Note", "outage", "change "}
+	for _, kind := range invalid {
+		if IsValidLogKind(kind) {
+			t.Errorf("Expected %q to be an invalid log kind", kind)
+		}
+	}
+}
+
+// Test 58: TestIsValidSeverity
+func TestIsValidSeverity(t *testing.T) {
+	for _, severity := range []string{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical} {
+		if !IsValidSeverity(severity) {
+			t.Errorf("Expected %q to be a valid severity", severity)
+		}
+	}
+	if IsValidSeverity("urgent") {
+		t.Error("Expected 'urgent' to be an invalid severity")
+	}
+}
+
+// Test 59: TestCreateLogRequest_Validation_InvalidKind
+func TestCreateLogRequest_Validation_InvalidKind(t *testing.T) {
+	validate := validator.New()
+	req := CreateLogRequest{
+		Kind:    "outage",
+		Content: "Power went out",
+	}
+
+	err := validate.Struct(req)
+	if err == nil {
+		t.Fatal("Expected validation error for invalid kind")
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok || validationErrors[0].Field() != "Kind" || validationErrors[0].Tag() != "oneof" {
+		t.Errorf("Expected oneof error on Kind, got %v", err)
+	}
+}
+
+// Test 60: TestCreateLogRequest_Validation_IncidentFields
+func TestCreateLogRequest_Validation_IncidentFields(t *testing.T) {
+	validate := validator.New()
+	severity := SeverityHigh
+	started := time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC)
+	req := CreateLogRequest{
+		Kind:      LogKindIncident,
+		Content:   "NAS unreachable after power loss",
+		Severity:  &severity,
+		StartedAt: &started,
+	}
+
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected incident request to be valid, got error: %v", err)
+	}
+
+	badSeverity := "urgent"
+	req.Severity = &badSeverity
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for invalid severity")
+	}
+}
+
+// Test 61: TestNewLogResponse_KindFields
+func TestNewLogResponse_KindFields(t *testing.T) {
+	severity := SeverityCritical
+	rootCause := "Failed PSU"
+	started := time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC)
+	resolved := started.Add(90 * time.Minute)
+	log := &AssetLog{
+		ID:         uuid.New(),
+		AssetID:    uuid.New(),
+		UserID:     "user_123",
+		Kind:       LogKindIncident,
+		Content:    "Server down",
+		Severity:   &severity,
+		StartedAt:  &started,
+		ResolvedAt: &resolved,
+		RootCause:  &rootCause,
+	}
+
+	resp := NewLogResponse(log)
+
+	if resp.Kind != LogKindIncident {
+		t.Errorf("Expected kind %q, got %q", LogKindIncident, resp.Kind)
+	}
+	if resp.Severity == nil || *resp.Severity != severity {
+		t.Error("Expected severity to be copied")
+	}
+	if resp.StartedAt == nil || !resp.StartedAt.Equal(started) {
+		t.Error("Expected started_at to be copied")
+	}
+	if resp.ResolvedAt == nil || !resp.ResolvedAt.Equal(resolved) {
+		t.Error("Expected resolved_at to be copied")
+	}
+	if resp.RootCause == nil || *resp.RootCause != rootCause {
+		t.Error("Expected root_cause to be copied")
+	}
+}
+
+// Test 62: TestLogResponse_MarshalJSON_OmitsOtherKindFields
+func TestLogResponse_MarshalJSON_OmitsOtherKindFields(t *testing.T) {
+	duration := 45
+	resp := NewLogResponse(&AssetLog{
+		ID:              uuid.New(),
+		AssetID:         uuid.New(),
+		Kind:            LogKindMaintenance,
+		Content:         "Scrubbed ZFS pool",
+		DurationMinutes: &duration,
+	})
+
+	jsonData, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+
+	jsonStr := string(jsonData)
+	if !strings.Contains(jsonStr, `"kind":"maintenance"`) {
+		t.Error("JSON should contain kind")
+	}
+	if !strings.Contains(jsonStr, `"duration_minutes":45`) {
+		t.Error("JSON should contain duration_minutes")
+	}
+	for _, field := range []string{"severity", "started_at", "resolved_at", "root_cause", "planned", "rollback_notes"} {
+		if strings.Contains(jsonStr, `"`+field+`"`) {
+			t.Errorf("JSON should omit unset field %q", field)
+		}
+	}
+}
+
+// Test 63: TestLogQueryParams_Validation_Kind
+func TestLogQueryParams_Validation_Kind(t *testing.T) {
+	validate := validator.New()
+
+	kind := LogKindChange
+	params := LogQueryParams{Limit: 50, Kind: &kind}
+	if err := validate.Struct(params); err != nil {
+		t.Errorf("Expected valid kind filter, got error: %v", err)
+	}
+
+	bad := "outage"
+	params.Kind = &bad
+	if err := validate.Struct(params); err == nil {
+		t.Error("Expected validation error for invalid kind filter")
+	}
+}
+
+// Test 64: TestNewLogResponse_LinkedAssetIDs
+func TestNewLogResponse_LinkedAssetIDs(t *testing.T) {
+	linked := []uuid.UUID{uuid.New(), uuid.New()}
+	resp := NewLogResponse(&AssetLog{
+		ID:             uuid.New(),
+		AssetID:        uuid.New(),
+		Kind:           LogKindChange,
+		Content:        "Upgraded switch firmware to 7.2",
+		LinkedAssetIDs: linked,
+	})
+
+	if len(resp.LinkedAssetIDs) != 2 || resp.LinkedAssetIDs[1] != linked[1] {
+		t.Errorf("Expected linked_asset_ids to be copied, got %v", resp.LinkedAssetIDs)
+	}
+
+	jsonData, err := json.Marshal(NewLogResponse(&AssetLog{LinkedAssetIDs: []uuid.UUID{}}))
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"linked_asset_ids":[]`) {
+		t.Errorf("JSON should contain an empty linked_asset_ids array, got %s", jsonData)
+	}
+}
+
+// Test 65: TestNewLogThreadResponse
+func TestNewLogThreadResponse(t *testing.T) {
+	created := time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC)
+	root := &AssetLog{ID: uuid.New(), Content: "Pool degraded", CreatedAt: created, UpdatedAt: created.Add(time.Hour)}
+	first := &AssetLog{ID: uuid.New(), ParentLogID: &root.ID, ThreadRootID: &root.ID, UpdatedAt: created.Add(24 * time.Hour)}
+	second := &AssetLog{ID: uuid.New(), ParentLogID: &first.ID, ThreadRootID: &root.ID, UpdatedAt: created.Add(2 * time.Hour)}
+
+	resp := NewLogThreadResponse(root, []*AssetLog{first, second})
+
+	if resp.Root.ID != root.ID {
+		t.Errorf("Expected root %v, got %v", root.ID, resp.Root.ID)
+	}
+	if resp.ReplyCount != 2 || len(resp.Replies) != 2 {
+		t.Errorf("Expected 2 replies, got %d", resp.ReplyCount)
+	}
+	if resp.Replies[1].ParentLogID == nil || *resp.Replies[1].ParentLogID != first.ID {
+		t.Error("Expected nested reply to keep its parent_log_id")
+	}
+	if !resp.LastActivityAt.Equal(first.UpdatedAt) {
+		t.Errorf("Expected last activity %v, got %v", first.UpdatedAt, resp.LastActivityAt)
+	}
+
+	empty := NewLogThreadResponse(root, nil)
+	if empty.Replies == nil || empty.ReplyCount != 0 || !empty.LastActivityAt.Equal(root.UpdatedAt) {
+		t.Errorf("Unexpected empty thread response: %+v", empty)
+	}
+}
+
+// Test 66: TestLogQueryParams_Validation_TagFilters
+func TestLogQueryParams_Validation_TagFilters(t *testing.T) {
+	validate := validator.New()
+
+	params := LogQueryParams{
+		Limit:    50,
+		TagsAny:  []string{"zfs", "btrfs"},
+		TagsAll:  []string{"storage"},
+		TagsNone: []string{"resolved"},
+	}
+	if err := validate.Struct(params); err != nil {
+		t.Errorf("Expected valid tag filters, got error: %v", err)
+	}
+
+	params.TagsNone = []string{strings.Repeat("a", 51)}
+	if err := validate.Struct(params); err == nil {
+		t.Error("Expected validation error for tags_none entry over 50 chars")
+	}
+}
+
+// Test 67: TestLogResponse_JSON_Rendered
+func TestLogResponse_JSON_Rendered(t *testing.T) {
+	response := NewLogResponse(&AssetLog{Content: "`x`"})
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if strings.Contains(string(data), "content_html") || strings.Contains(string(data), "code_blocks") {
+		t.Errorf("JSON should omit render fields when not rendered: %s", data)
+	}
+
+	html := "<p><code>x</code></p>"
+	response.ContentHTML = &html
+	response.CodeBlocks = []CodeBlock{{Language: "bash", Code: "ls\n"}}
+	data, err = json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"code_blocks":[{"language":"bash","code":"ls\n"}]`) {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+}
+
+// Test 68: TestNewLogResponse_PinnedFavorite
+func TestNewLogResponse_PinnedFavorite(t *testing.T) {
+	response := NewLogResponse(&AssetLog{Content: "UPS shutdown setup", Pinned: true, Favorite: true})
+
+	if !response.Pinned || !response.Favorite {
+		t.Errorf("Expected pinned and favorite to be copied, got %+v", response)
+	}
+
+	data, err := json.Marshal(NewLogResponse(&AssetLog{}))
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"pinned":false,"favorite":false`) {
+		t.Errorf("JSON should always contain pinned and favorite, got %s", data)
+	}
+}
+
+// Test 69: TestLogRenderParams_Validate
+func TestLogRenderParams_Validate(t *testing.T) {
+	html := LogRenderHTML
+	if err := (&LogRenderParams{Render: &html}).Validate(); err != nil {
+		t.Errorf("Expected html to be valid, got error: %v", err)
+	}
+	if err := (&LogRenderParams{}).Validate(); err != nil {
+		t.Errorf("Expected no render to be valid, got error: %v", err)
+	}
+
+	pdf := "pdf"
+	if err := (&LogRenderParams{Render: &pdf}).Validate(); err == nil {
+		t.Error("Expected validation error for render=pdf")
+	}
+	if err := (&LogQueryParams{Render: &pdf}).Validate(); err == nil {
+		t.Error("Expected validation error for render=pdf in list query")
+	}
+}
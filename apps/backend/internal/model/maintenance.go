@@ -0,0 +1,136 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceTask is a recurring maintenance job on an asset, e.g. "scrub ZFS pool monthly".
+// Recurrence is an RRULE subset (FREQ, INTERVAL, UNTIL) counted from StartsAt.
+// NextDueAt is nil once the rule's UNTIL has passed and the task is finished.
+type MaintenanceTask struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	AssetID         uuid.UUID  `json:"asset_id" db:"asset_id"`
+	UserID          string     `json:"user_id" db:"user_id"`
+	Title           string     `json:"title" db:"title"`
+	Description     *string    `json:"description,omitempty" db:"description"`
+	Recurrence      string     `json:"recurrence" db:"recurrence"`
+	StartsAt        time.Time  `json:"starts_at" db:"starts_at"`
+	NextDueAt       *time.Time `json:"next_due_at,omitempty" db:"next_due_at"`
+	LastCompletedAt *time.Time `json:"last_completed_at,omitempty" db:"last_completed_at"`
+	LastRemindedAt  *time.Time `json:"last_reminded_at,omitempty" db:"last_reminded_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsOverdue reports whether the task is due at or before now
+func (t *MaintenanceTask) IsOverdue(now time.Time) bool {
+	return t.NextDueAt != nil && !t.NextDueAt.After(now)
+}
+
+// CreateMaintenanceTaskRequest is the DTO for creating a maintenance task.
+// StartsAt is the first due date and defaults to now.
+type CreateMaintenanceTaskRequest struct {
+	Title       string     `json:"title" validate:"required,max=200"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,max=5000"`
+	Recurrence  string     `json:"recurrence" validate:"required,max=200"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+}
+
+// UpdateMaintenanceTaskRequest is the DTO for updating a maintenance task.
+// Changing Recurrence or StartsAt recomputes the next due date.
+type UpdateMaintenanceTaskRequest struct {
+	Title       *string    `json:"title,omitempty" validate:"omitempty,max=200"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,max=5000"`
+	Recurrence  *string    `json:"recurrence,omitempty" validate:"omitempty,max=200"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+}
+
+// CompleteMaintenanceTaskRequest is the DTO for marking a maintenance task done.
+// Completing a task records a maintenance log on the asset.
+type CompleteMaintenanceTaskRequest struct {
+	Notes           *string    `json:"notes,omitempty" validate:"omitempty,max=10000"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty" validate:"omitempty,min=0"`
+	Tags            []string   `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+}
+
+// MaintenanceTaskQueryParams represents query parameters for listing maintenance tasks
+type MaintenanceTaskQueryParams struct {
+	Overdue *bool      `query:"overdue"`
+	DueBy   *time.Time `query:"due_by"`
+}
+
+// MaintenanceTaskResponse is the DTO for single maintenance task responses
+type MaintenanceTaskResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	AssetID         uuid.UUID  `json:"asset_id"`
+	UserID          string     `json:"user_id"`
+	Title           string     `json:"title"`
+	Description     *string    `json:"description,omitempty"`
+	Recurrence      string     `json:"recurrence"`
+	StartsAt        time.Time  `json:"starts_at"`
+	NextDueAt       *time.Time `json:"next_due_at,omitempty"`
+	LastCompletedAt *time.Time `json:"last_completed_at,omitempty"`
+	Overdue         bool       `json:"overdue"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// NewMaintenanceTaskResponse converts a MaintenanceTask domain model to MaintenanceTaskResponse DTO
+func NewMaintenanceTaskResponse(task *MaintenanceTask, now time.Time) *MaintenanceTaskResponse {
+	if task == nil {
+		return nil
+	}
+
+	return &MaintenanceTaskResponse{
+		ID:              task.ID,
+		AssetID:         task.AssetID,
+		UserID:          task.UserID,
+		Title:           task.Title,
+		Description:     task.Description,
+		Recurrence:      task.Recurrence,
+		StartsAt:        task.StartsAt,
+		NextDueAt:       task.NextDueAt,
+		LastCompletedAt: task.LastCompletedAt,
+		Overdue:         task.IsOverdue(now),
+		CreatedAt:       task.CreatedAt,
+		UpdatedAt:       task.UpdatedAt,
+	}
+}
+
+// MaintenanceTaskListResponse is the DTO for lists of maintenance tasks
+type MaintenanceTaskListResponse struct {
+	Tasks []MaintenanceTaskResponse `json:"tasks"`
+	Total int                       `json:"total"`
+}
+
+// NewMaintenanceTaskListResponse converts a slice of MaintenanceTask to MaintenanceTaskListResponse DTO
+func NewMaintenanceTaskListResponse(tasks []*MaintenanceTask, now time.Time) *MaintenanceTaskListResponse {
+	responses := make([]MaintenanceTaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		responses = append(responses, *NewMaintenanceTaskResponse(task, now))
+	}
+
+	return &MaintenanceTaskListResponse{
+		Tasks: responses,
+		Total: len(responses),
+	}
+}
+
+// CompleteMaintenanceTaskResponse is the DTO returned after completing a task:
+// the advanced task and the maintenance log that was recorded
+type CompleteMaintenanceTaskResponse struct {
+	Task *MaintenanceTaskResponse `json:"task"`
+	Log  *LogResponse             `json:"log"`
+}
+
+// MaintenanceReminder is an overdue task joined with its asset, used by the reminder scan
+type MaintenanceReminder struct {
+	TaskID    uuid.UUID
+	UserID    string
+	Title     string
+	AssetName string
+	NextDueAt time.Time
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Test 1: TestMaintenanceTask_IsOverdue
+func TestMaintenanceTask_IsOverdue(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		nextDueAt *time.Time
+		want      bool
+	}{
+		{"due in the past", &past, true},
+		{"due exactly now", &now, true},
+		{"due in the future", &future, false},
+		{"finished task", nil, false},
+	}
+
+	for _, tt := range tests {
+		task := &MaintenanceTask{NextDueAt: tt.nextDueAt}
+		if got := task.IsOverdue(now); got != tt.want {
+			t.Errorf("%s: IsOverdue() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Test 2: TestCreateMaintenanceTaskRequest_Validation
+func TestCreateMaintenanceTaskRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	req := CreateMaintenanceTaskRequest{Title: "Scrub ZFS pool", Recurrence: "FREQ=MONTHLY"}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	req.Title = ""
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for missing title")
+	}
+
+	req.Title = strings.Repeat("a", 201)
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for title over 200 chars")
+	}
+
+	req = CreateMaintenanceTaskRequest{Title: "Rotate backups"}
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for missing recurrence")
+	}
+}
+
+// Test 3: TestCompleteMaintenanceTaskRequest_Validation
+func TestCompleteMaintenanceTaskRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	duration := 30
+	req := CompleteMaintenanceTaskRequest{DurationMinutes: &duration, Tags: []string{"zfs"}}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	negative := -1
+	req.DurationMinutes = &negative
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for negative duration")
+	}
+}
+
+// Test 4: TestNewMaintenanceTaskResponse
+func TestNewMaintenanceTaskResponse(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	due := now.Add(-24 * time.Hour)
+
+	task := &MaintenanceTask{
+		ID:         uuid.New(),
+		AssetID:    uuid.New(),
+		UserID:     "user_123",
+		Title:      "Replace UPS battery",
+		Recurrence: "FREQ=YEARLY;INTERVAL=3",
+		StartsAt:   due,
+		NextDueAt:  &due,
+	}
+
+	response := NewMaintenanceTaskResponse(task, now)
+	if response.Title != task.Title || response.Recurrence != task.Recurrence {
+		t.Error("Expected response to copy task fields")
+	}
+	if !response.Overdue {
+		t.Error("Expected response to be marked overdue")
+	}
+
+	if NewMaintenanceTaskResponse(nil, now) != nil {
+		t.Error("Expected nil response for nil task")
+	}
+}
+
+// Test 5: TestNewMaintenanceTaskListResponse_Empty
+func TestNewMaintenanceTaskListResponse_Empty(t *testing.T) {
+	response := NewMaintenanceTaskListResponse(nil, time.Now())
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"tasks":[]`) {
+		t.Errorf("Expected empty tasks array, got %s", jsonData)
+	}
+	if response.Total != 0 {
+		t.Errorf("Expected total 0, got %d", response.Total)
+	}
+}
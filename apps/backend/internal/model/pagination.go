@@ -11,6 +11,11 @@ const (
 	DefaultLogLimit = 50
 	// MaxLogLimit is the maximum number of logs that can be requested per page
 	MaxLogLimit = 200
+
+	// DefaultTagLimit is the default number of tags returned
+	DefaultTagLimit = 100
+	// MaxTagLimit is the maximum number of tags that can be requested
+	MaxTagLimit = 1000
 )
 
 // PaginationParams represents query parameters for pagination
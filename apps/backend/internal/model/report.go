@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultStaleAssetDays is the inactivity period after which an asset is stale
+	DefaultStaleAssetDays = 90
+	// MaxStaleAssetDays bounds the inactivity period of the stale asset report
+	MaxStaleAssetDays = 3650
+
+	// DefaultStaleAssetLimit is the default number of stale assets returned
+	DefaultStaleAssetLimit = 100
+	// MaxStaleAssetLimit is the maximum number of stale assets that can be requested
+	MaxStaleAssetLimit = 1000
+)
+
+// StaleAssetQueryParams represents query parameters for the stale asset report
+type StaleAssetQueryParams struct {
+	Days  int `query:"days" validate:"omitempty,min=1,max=3650"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=1000"`
+
+	// ViewID restricts the report to the assets of a saved asset view
+	ViewID *uuid.UUID `query:"view_id"`
+}
+
+// SetDefaults sets default values for StaleAssetQueryParams
+func (q *StaleAssetQueryParams) SetDefaults() {
+	if q.Days == 0 {
+		q.Days = DefaultStaleAssetDays
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultStaleAssetLimit
+	}
+	if q.Limit > MaxStaleAssetLimit {
+		q.Limit = MaxStaleAssetLimit
+	}
+}
+
+// StaleAsset is an asset with no logs and no updates for the report period.
+// LastActivityAt is the later of UpdatedAt and LastLogAt.
+type StaleAsset struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	Type           *string    `json:"type,omitempty"`
+	Hostname       *string    `json:"hostname,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	LastLogAt      *time.Time `json:"last_log_at,omitempty"`
+	LastActivityAt time.Time  `json:"last_activity_at"`
+	DaysStale      int        `json:"days_stale"`
+}
+
+// StaleAssetReport is the DTO for the stale asset report, stalest first
+type StaleAssetReport struct {
+	Days   int          `json:"days"`
+	Assets []StaleAsset `json:"assets"`
+	Total  int64        `json:"total"`
+}
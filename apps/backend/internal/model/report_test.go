@@ -0,0 +1,21 @@
+package model
+
+import "testing"
+
+// Test 1: TestStaleAssetQueryParams_SetDefaults
+func TestStaleAssetQueryParams_SetDefaults(t *testing.T) {
+	params := StaleAssetQueryParams{}
+	params.SetDefaults()
+	if params.Days != DefaultStaleAssetDays || params.Limit != DefaultStaleAssetLimit {
+		t.Errorf("Expected defaults %d/%d, got %+v", DefaultStaleAssetDays, DefaultStaleAssetLimit, params)
+	}
+
+	params = StaleAssetQueryParams{Days: 30, Limit: 5000}
+	params.SetDefaults()
+	if params.Days != 30 {
+		t.Errorf("Expected days to stay 30, got %d", params.Days)
+	}
+	if params.Limit != MaxStaleAssetLimit {
+		t.Errorf("Expected limit capped at %d, got %d", MaxStaleAssetLimit, params.Limit)
+	}
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Runbook Run Statuses
+const (
+	RunbookRunStatusInProgress = "in_progress"
+	RunbookRunStatusCompleted  = "completed"
+	RunbookRunStatusAborted    = "aborted"
+)
+
+// MaxRunbookSteps limits the number of steps in a single runbook
+const MaxRunbookSteps = 100
+
+// Runbook is a reusable markdown procedure made of ordered steps.
+// A runbook applies to assets whose type is in AssetTypes or whose ID is in AssetIDs;
+// a runbook with neither applies to every asset.
+type Runbook struct {
+	ID          uuid.UUID     `json:"id" db:"id"`
+	UserID      string        `json:"user_id" db:"user_id"`
+	Title       string        `json:"title" db:"title"`
+	Description *string       `json:"description,omitempty" db:"description"`
+	AssetTypes  []string      `json:"asset_types" db:"asset_types"`
+	AssetIDs    []uuid.UUID   `json:"asset_ids" db:"-"`
+	StepCount   int           `json:"step_count" db:"-"`
+	Steps       []RunbookStep `json:"steps,omitempty" db:"-"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// AppliesTo reports whether the runbook can be run against the asset
+func (r *Runbook) AppliesTo(asset *Asset) bool {
+	if len(r.AssetTypes) == 0 && len(r.AssetIDs) == 0 {
+		return true
+	}
+	for _, id := range r.AssetIDs {
+		if id == asset.ID {
+			return true
+		}
+	}
+	if asset.Type != nil {
+		for _, t := range r.AssetTypes {
+			if t == *asset.Type {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RunbookStep is one step of a runbook. Position is 1-based.
+type RunbookStep struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	RunbookID uuid.UUID `json:"runbook_id" db:"runbook_id"`
+	Position  int       `json:"position" db:"position"`
+	Title     string    `json:"title" db:"title"`
+	Body      *string   `json:"body,omitempty" db:"body"`
+}
+
+// RunbookStepInput is a step in create and update requests; order is given by array position
+type RunbookStepInput struct {
+	Title string  `json:"title" validate:"required,max=200"`
+	Body  *string `json:"body,omitempty" validate:"omitempty,max=10000"`
+}
+
+// CreateRunbookRequest is the DTO for creating a runbook
+type CreateRunbookRequest struct {
+	Title       string             `json:"title" validate:"required,max=200"`
+	Description *string            `json:"description,omitempty" validate:"omitempty,max=50000"`
+	AssetTypes  []string           `json:"asset_types,omitempty" validate:"omitempty,dive,max=50"`
+	AssetIDs    []uuid.UUID        `json:"asset_ids,omitempty"`
+	Steps       []RunbookStepInput `json:"steps" validate:"required,min=1,max=100,dive"`
+}
+
+// UpdateRunbookRequest is the DTO for updating a runbook.
+// Steps, AssetTypes and AssetIDs replace the existing values when set.
+type UpdateRunbookRequest struct {
+	Title       *string             `json:"title,omitempty" validate:"omitempty,max=200"`
+	Description *string             `json:"description,omitempty" validate:"omitempty,max=50000"`
+	AssetTypes  *[]string           `json:"asset_types,omitempty"`
+	AssetIDs    *[]uuid.UUID        `json:"asset_ids,omitempty"`
+	Steps       *[]RunbookStepInput `json:"steps,omitempty"`
+}
+
+// RunbookQueryParams represents query parameters for listing runbooks
+type RunbookQueryParams struct {
+	AssetType *string `query:"asset_type" validate:"omitempty,max=50"`
+	Search    *string `query:"search" validate:"omitempty,max=100"`
+}
+
+// RunbookListResponse is the DTO for lists of runbooks (without steps)
+type RunbookListResponse struct {
+	Runbooks []Runbook `json:"runbooks"`
+	Total    int       `json:"total"`
+}
+
+// NewRunbookListResponse converts a slice of Runbook to RunbookListResponse DTO
+func NewRunbookListResponse(runbooks []*Runbook) *RunbookListResponse {
+	items := make([]Runbook, 0, len(runbooks))
+	for _, runbook := range runbooks {
+		items = append(items, *runbook)
+	}
+
+	return &RunbookListResponse{
+		Runbooks: items,
+		Total:    len(items),
+	}
+}
+
+// RunbookRun is one execution of a runbook against an asset.
+// Title and steps are copied from the runbook when the run starts, so editing
+// or deleting the runbook never changes a run in progress or its history.
+type RunbookRun struct {
+	ID         uuid.UUID        `json:"id" db:"id"`
+	RunbookID  *uuid.UUID       `json:"runbook_id,omitempty" db:"runbook_id"`
+	AssetID    uuid.UUID        `json:"asset_id" db:"asset_id"`
+	UserID     string           `json:"user_id" db:"user_id"`
+	Title      string           `json:"title" db:"title"`
+	Status     string           `json:"status" db:"status"`
+	Notes      *string          `json:"notes,omitempty" db:"notes"`
+	LogID      *uuid.UUID       `json:"log_id,omitempty" db:"log_id"`
+	StartedAt  time.Time        `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty" db:"finished_at"`
+	Steps      []RunbookRunStep `json:"steps" db:"-"`
+}
+
+// RunbookRunStep is the state of one step within a run
+type RunbookRunStep struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	RunID     uuid.UUID  `json:"run_id" db:"run_id"`
+	Position  int        `json:"position" db:"position"`
+	Title     string     `json:"title" db:"title"`
+	Body      *string    `json:"body,omitempty" db:"body"`
+	Checked   bool       `json:"checked" db:"checked"`
+	CheckedAt *time.Time `json:"checked_at,omitempty" db:"checked_at"`
+	Notes     *string    `json:"notes,omitempty" db:"notes"`
+}
+
+// StartRunbookRunRequest is the DTO for starting a runbook run
+type StartRunbookRunRequest struct {
+	AssetID uuid.UUID `json:"asset_id" validate:"required"`
+}
+
+// UpdateRunbookRunStepRequest is the DTO for checking off a step and/or adding notes
+type UpdateRunbookRunStepRequest struct {
+	Checked *bool   `json:"checked,omitempty"`
+	Notes   *string `json:"notes,omitempty" validate:"omitempty,max=5000"`
+}
+
+// CloseRunbookRunRequest is the DTO for finishing or aborting a run.
+// Notes are a summary when finishing and the reason when aborting.
+type CloseRunbookRunRequest struct {
+	Notes *string `json:"notes,omitempty" validate:"omitempty,max=5000"`
+}
+
+// CloseRunbookRunResponse is the DTO returned when a run is finished or aborted:
+// the closed run and the consolidated log recorded on the asset
+type CloseRunbookRunResponse struct {
+	Run *RunbookRun  `json:"run"`
+	Log *LogResponse `json:"log"`
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Test 1: TestRunbook_AppliesTo
+func TestRunbook_AppliesTo(t *testing.T) {
+	nasType := "nas"
+	vmType := "vm"
+	nas := &Asset{ID: uuid.New(), Type: &nasType}
+	vm := &Asset{ID: uuid.New(), Type: &vmType}
+	untyped := &Asset{ID: uuid.New()}
+
+	general := &Runbook{}
+	byType := &Runbook{AssetTypes: []string{"nas", "server"}}
+	byAsset := &Runbook{AssetIDs: []uuid.UUID{vm.ID}}
+
+	tests := []struct {
+		name    string
+		runbook *Runbook
+		asset   *Asset
+		want    bool
+	}{
+		{"general runbook applies to typed asset", general, nas, true},
+		{"general runbook applies to untyped asset", general, untyped, true},
+		{"type match", byType, nas, true},
+		{"type mismatch", byType, vm, false},
+		{"untyped asset never matches type", byType, untyped, false},
+		{"asset match", byAsset, vm, true},
+		{"asset mismatch", byAsset, nas, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.runbook.AppliesTo(tt.asset); got != tt.want {
+			t.Errorf("%s: AppliesTo() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Test 2: TestCreateRunbookRequest_Validation
+func TestCreateRunbookRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	req := CreateRunbookRequest{
+		Title: "Upgrade Proxmox node",
+		Steps: []RunbookStepInput{{Title: "Migrate VMs off the node"}, {Title: "apt full-upgrade"}},
+	}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	req.Steps = nil
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for missing steps")
+	}
+
+	req.Steps = []RunbookStepInput{{Title: ""}}
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for step without title")
+	}
+}
+
+// Test 3: TestNewRunbookListResponse_Empty
+func TestNewRunbookListResponse_Empty(t *testing.T) {
+	response := NewRunbookListResponse(nil)
+
+	if response.Runbooks == nil {
+		t.Error("Expected non-nil runbooks slice")
+	}
+	if response.Total != 0 {
+		t.Errorf("Expected total 0, got %d", response.Total)
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Saved view targets: the list endpoint a view runs against
+const (
+	SavedViewTargetAssets = "assets"
+	SavedViewTargetLogs   = "logs"
+)
+
+// IsValidSavedViewTarget checks if the given target is a valid saved view target
+func IsValidSavedViewTarget(t string) bool {
+	switch t {
+	case SavedViewTargetAssets, SavedViewTargetLogs:
+		return true
+	default:
+		return false
+	}
+}
+
+// SavedView is a named set of list filters stored server-side.
+// Params holds AssetQueryParams or LogQueryParams, depending on Target, as a
+// JSON object keyed like the query string. Log views list the logs of AssetID.
+type SavedView struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	UserID      string          `json:"user_id" db:"user_id"`
+	Name        string          `json:"name" db:"name"`
+	Description *string         `json:"description,omitempty" db:"description"`
+	Target      string          `json:"target" db:"target"`
+	AssetID     *uuid.UUID      `json:"asset_id,omitempty" db:"asset_id"`
+	Params      json.RawMessage `json:"params" db:"params"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CreateSavedViewRequest is the DTO for creating a saved view
+type CreateSavedViewRequest struct {
+	Name        string          `json:"name" validate:"required,max=100"`
+	Description *string         `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Target      string          `json:"target" validate:"required,oneof=assets logs"`
+	AssetID     *uuid.UUID      `json:"asset_id,omitempty"`
+	Params      json.RawMessage `json:"params,omitempty"`
+}
+
+// UpdateSavedViewRequest is the DTO for updating a saved view.
+// The target cannot change; Params is replaced as a whole when set.
+type UpdateSavedViewRequest struct {
+	Name        *string          `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string          `json:"description,omitempty" validate:"omitempty,max=1000"`
+	AssetID     *uuid.UUID       `json:"asset_id,omitempty"`
+	Params      *json.RawMessage `json:"params,omitempty"`
+}
+
+// RunSavedViewParams pages through the results of a saved view. Zero values
+// keep the limit and offset stored in the view.
+type RunSavedViewParams struct {
+	Limit  int `query:"limit" validate:"omitempty,min=1"`
+	Offset int `query:"offset" validate:"omitempty,min=0"`
+}
+
+// SavedViewListResponse is the DTO for lists of saved views
+type SavedViewListResponse struct {
+	Views []SavedView `json:"views"`
+	Total int         `json:"total"`
+}
+
+// NewSavedViewListResponse converts a slice of SavedView to SavedViewListResponse DTO
+func NewSavedViewListResponse(views []*SavedView) *SavedViewListResponse {
+	items := make([]SavedView, 0, len(views))
+	for _, view := range views {
+		items = append(items, *view)
+	}
+
+	return &SavedViewListResponse{
+		Views: items,
+		Total: len(items),
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Test 1: TestIsValidSavedViewTarget
+func TestIsValidSavedViewTarget(t *testing.T) {
+	for _, target := range []string{SavedViewTargetAssets, SavedViewTargetLogs} {
+		if !IsValidSavedViewTarget(target) {
+			t.Errorf("Expected %q to be a valid target", target)
+		}
+	}
+	if IsValidSavedViewTarget("incidents") {
+		t.Error("Expected incidents to be an invalid target")
+	}
+}
+
+// Test 2: TestCreateSavedViewRequest_Validation
+func TestCreateSavedViewRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	req := CreateSavedViewRequest{Name: "Prod VMs", Target: SavedViewTargetAssets}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	req.Target = "runbooks"
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for invalid target")
+	}
+}
+
+// Test 3: TestNewSavedViewListResponse
+func TestNewSavedViewListResponse(t *testing.T) {
+	resp := NewSavedViewListResponse(nil)
+	if resp.Views == nil || resp.Total != 0 {
+		t.Errorf("Expected empty non-nil view list, got %+v", resp)
+	}
+
+	resp = NewSavedViewListResponse([]*SavedView{{ID: uuid.New(), Name: "Prod VMs"}})
+	if resp.Total != 1 || resp.Views[0].Name != "Prod VMs" {
+		t.Errorf("Unexpected view list: %+v", resp)
+	}
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultStatsDays is the default window for daily log counts and activity
+	DefaultStatsDays = 30
+	// MaxStatsDays is the longest window the dashboard statistics cover
+	MaxStatsDays = 365
+
+	// DefaultStatsLimit is the default length of the top tag and active asset lists
+	DefaultStatsLimit = 10
+	// MaxStatsLimit is the maximum length of the top tag and active asset lists
+	MaxStatsLimit = 50
+)
+
+// Asset statuses used by the dashboard statistics.
+// They are derived from activity; assets have no stored status.
+const (
+	AssetStatusActive   = "active"   // activity within DefaultStaleAssetDays
+	AssetStatusStale    = "stale"    // no logs or updates in DefaultStaleAssetDays
+	AssetStatusIncident = "incident" // has an incident that is not resolved
+)
+
+// AssetTypeNone is the count bucket for assets without a type
+const AssetTypeNone = "none"
+
+// StatsQueryParams represents query parameters for the dashboard statistics
+type StatsQueryParams struct {
+	Days  int `query:"days" validate:"omitempty,min=1,max=365"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// SetDefaults sets default values for StatsQueryParams
+func (q *StatsQueryParams) SetDefaults() {
+	if q.Days == 0 {
+		q.Days = DefaultStatsDays
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultStatsLimit
+	}
+	if q.Limit > MaxStatsLimit {
+		q.Limit = MaxStatsLimit
+	}
+}
+
+// CountBucket is the number of rows sharing a key
+type CountBucket struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+// AssetCountStats counts the user's assets by type and by derived status
+type AssetCountStats struct {
+	Total    int64         `json:"total"`
+	ByType   []CountBucket `json:"by_type"`
+	ByStatus []CountBucket `json:"by_status"`
+}
+
+// DailyCount is the number of logs created on a UTC date (YYYY-MM-DD)
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// ActiveAsset is an asset ranked by the number of logs in the stats window.
+// Logs linked to an asset count for it as well.
+type ActiveAsset struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Type      *string   `json:"type,omitempty"`
+	LogCount  int64     `json:"log_count"`
+	LastLogAt time.Time `json:"last_log_at"`
+}
+
+// StorageStats is the on-disk size of the user's assets and logs in bytes
+type StorageStats struct {
+	AssetBytes int64 `json:"asset_bytes"`
+	LogBytes   int64 `json:"log_bytes"`
+	TotalBytes int64 `json:"total_bytes"`
+}
+
+// DashboardStats is the DTO for the dashboard statistics.
+// GeneratedAt is when the statistics were computed; they may be served from cache.
+type DashboardStats struct {
+	Days             int             `json:"days"`
+	Assets           AssetCountStats `json:"assets"`
+	LogsPerDay       []DailyCount    `json:"logs_per_day"`
+	TopTags          []Tag           `json:"top_tags"`
+	MostActiveAssets []ActiveAsset   `json:"most_active_assets"`
+	Storage          StorageStats    `json:"storage"`
+	GeneratedAt      time.Time       `json:"generated_at"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "testing"
+
+// Test 1: TestStatsQueryParams_SetDefaults
+func TestStatsQueryParams_SetDefaults(t *testing.T) {
+	params := StatsQueryParams{}
+	params.SetDefaults()
+	if params.Days != DefaultStatsDays || params.Limit != DefaultStatsLimit {
+		t.Errorf("Expected defaults %d/%d, got %+v", DefaultStatsDays, DefaultStatsLimit, params)
+	}
+
+	params = StatsQueryParams{Days: 7, Limit: 500}
+	params.SetDefaults()
+	if params.Days != 7 {
+		t.Errorf("Expected days to stay 7, got %d", params.Days)
+	}
+	if params.Limit != MaxStatsLimit {
+		t.Errorf("Expected limit capped at %d, got %d", MaxStatsLimit, params.Limit)
+	}
+}
@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+// MaxMergeSourceTags is the maximum number of tags merged in one request
+const MaxMergeSourceTags = 50
+
+// Tag is a tag in the user's vocabulary with where, how often and how recently it is used.
+// Count is LogCount plus AssetCount.
+type Tag struct {
+	Name       string    `json:"name"`
+	Count      int64     `json:"count"`
+	LogCount   int64     `json:"log_count"`
+	AssetCount int64     `json:"asset_count"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// TagQueryParams represents query parameters for listing tags.
+// Prefix supports autocomplete; results are ordered by usage.
+type TagQueryParams struct {
+	Prefix *string `query:"prefix" validate:"omitempty,max=50"`
+	Limit  int     `query:"limit" validate:"omitempty,min=1,max=1000"`
+}
+
+// SetDefaults sets default values for TagQueryParams
+func (q *TagQueryParams) SetDefaults() {
+	if q.Limit <= 0 {
+		q.Limit = DefaultTagLimit
+	}
+	if q.Limit > MaxTagLimit {
+		q.Limit = MaxTagLimit
+	}
+}
+
+// TagListResponse is the DTO for tag lists
+type TagListResponse struct {
+	Tags  []Tag `json:"tags"`
+	Total int   `json:"total"`
+}
+
+// NewTagListResponse converts a slice of Tag to TagListResponse DTO
+func NewTagListResponse(tags []Tag) *TagListResponse {
+	if tags == nil {
+		tags = []Tag{}
+	}
+	return &TagListResponse{
+		Tags:  tags,
+		Total: len(tags),
+	}
+}
+
+// RenameTagRequest is the DTO for renaming a tag. Renaming onto an existing
+// tag merges the two.
+type RenameTagRequest struct {
+	From string `json:"from" validate:"required,max=50"`
+	To   string `json:"to" validate:"required,max=50"`
+}
+
+// MergeTagsRequest is the DTO for merging several tags into one
+type MergeTagsRequest struct {
+	Sources []string `json:"sources" validate:"required,min=1,max=50,dive,max=50"`
+	Target  string   `json:"target" validate:"required,max=50"`
+}
+
+// TagOperationResponse is the DTO for the result of a rename or merge
+type TagOperationResponse struct {
+	Tag              string `json:"tag"`
+	LogsUpdated      int64  `json:"logs_updated"`
+	AssetsUpdated    int64  `json:"assets_updated"`
+	TemplatesUpdated int64  `json:"templates_updated"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Test 1: TestTagQueryParams_SetDefaults
+func TestTagQueryParams_SetDefaults(t *testing.T) {
+	params := TagQueryParams{}
+	params.SetDefaults()
+	if params.Limit != DefaultTagLimit {
+		t.Errorf("Expected default limit %d, got %d", DefaultTagLimit, params.Limit)
+	}
+
+	params = TagQueryParams{Limit: MaxTagLimit + 1}
+	params.SetDefaults()
+	if params.Limit != MaxTagLimit {
+		t.Errorf("Expected limit capped at %d, got %d", MaxTagLimit, params.Limit)
+	}
+}
+
+// Test 2: TestMergeTagsRequest_Validation
+func TestMergeTagsRequest_Validation(t *testing.T) {
+	validate := validator.New()
+
+	req := MergeTagsRequest{Sources: []string{"k8s", "kube"}, Target: "kubernetes"}
+	if err := validate.Struct(req); err != nil {
+		t.Errorf("Expected valid request, got error: %v", err)
+	}
+
+	req.Sources = nil
+	if err := validate.Struct(req); err == nil {
+		t.Error("Expected validation error for missing sources")
+	}
+}
+
+// Test 3: TestNewTagListResponse
+func TestNewTagListResponse(t *testing.T) {
+	resp := NewTagListResponse(nil)
+	if resp.Tags == nil || resp.Total != 0 {
+		t.Errorf("Expected empty non-nil tag list, got %+v", resp)
+	}
+}
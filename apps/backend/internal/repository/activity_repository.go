@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/model"
+)
+
+// ActivityRepository buckets log activity over time with date_trunc in a
+// given time zone. All methods enforce user isolation. Callers validate the
+// interval and time zone; both are passed to Postgres as parameters.
+type ActivityRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewActivityRepository creates a new ActivityRepository with the given database pool.
+func NewActivityRepository(db *pgxpool.Pool) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// activityLogFilter selects the user's logs created in [@start, @end), and with
+// an asset the logs whose primary or linked asset it is
+func activityLogFilter(args pgx.NamedArgs, userID string, start, end time.Time, assetID *uuid.UUID) string {
+	args["userID"] = userID
+	args["start"] = start
+	args["end"] = end
+
+	filter := "l.user_id = @userID AND l.created_at >= @start AND l.created_at < @end"
+	if assetID != nil {
+		args["assetID"] = *assetID
+		filter += " AND (l.asset_id = @assetID OR EXISTS (SELECT 1 FROM log_assets la WHERE la.log_id = l.id AND la.asset_id = @assetID))"
+	}
+	return filter
+}
+
+// CountByInterval counts the logs created in each interval from start to end.
+// Every interval is returned, oldest first, including those without logs.
+// Bucket starts are the local interval starts in tz.
+func (r *ActivityRepository) CountByInterval(ctx context.Context, userID string, start, end time.Time, interval, tz string, assetID *uuid.UUID) ([]model.ActivityBucket, error) {
+	args := pgx.NamedArgs{
+		"interval": interval,
+		"tz":       tz,
+	}
+	filter := activityLogFilter(args, userID, start, end, assetID)
+
+	query := fmt.Sprintf(`
+		SELECT b.bucket AT TIME ZONE @tz::text, count(l.id)
+		FROM generate_series(
+			date_trunc(@interval::text, @start::timestamptz AT TIME ZONE @tz::text),
+			date_trunc(@interval::text, (@end::timestamptz - interval '1 microsecond') AT TIME ZONE @tz::text),
+			('1 ' || @interval::text)::interval
+		) AS b(bucket)
+		LEFT JOIN asset_logs l
+			ON %s
+			AND date_trunc(@interval::text, l.created_at AT TIME ZONE @tz::text) = b.bucket
+		GROUP BY b.bucket
+		ORDER BY b.bucket ASC
+	`, filter)
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("count logs by interval: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := make([]model.ActivityBucket, 0)
+	for rows.Next() {
+		var bucket model.ActivityBucket
+		if err := rows.Scan(&bucket.Start, &bucket.Total); err != nil {
+			return nil, fmt.Errorf("scan activity bucket: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate activity buckets: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// CountByGroup counts the logs created in each interval from start to end by
+// tag or by the type of their primary asset. Only non-zero counts are returned.
+// Untagged logs are counted under model.ActivityGroupUntagged and assets without
+// a type under model.AssetTypeNone.
+func (r *ActivityRepository) CountByGroup(ctx context.Context, userID string, start, end time.Time, interval, tz, groupBy string, assetID *uuid.UUID) ([]model.ActivityGroupCount, error) {
+	args := pgx.NamedArgs{
+		"interval": interval,
+		"tz":       tz,
+	}
+	filter := activityLogFilter(args, userID, start, end, assetID)
+
+	var source, key string
+	switch groupBy {
+	case model.ActivityGroupByAssetType:
+		source = "JOIN assets a ON a.id = l.asset_id"
+		key = "COALESCE(a.type, @none)"
+		args["none"] = model.AssetTypeNone
+	default:
+		source = "LEFT JOIN LATERAL unnest(l.tags) AS t(tag) ON true"
+		key = "COALESCE(t.tag, @untagged)"
+		args["untagged"] = model.ActivityGroupUntagged
+	}
+
+	query := fmt.Sprintf(`
+		SELECT date_trunc(@interval::text, l.created_at AT TIME ZONE @tz::text) AT TIME ZONE @tz::text AS bucket,
+			%s AS key, count(*)
+		FROM asset_logs l
+		%s
+		WHERE %s
+		GROUP BY 1, 2
+		ORDER BY 1 ASC, 3 DESC, 2 ASC
+	`, key, source, filter)
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("count logs by group: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]model.ActivityGroupCount, 0)
+	for rows.Next() {
+		var count model.ActivityGroupCount
+		if err := rows.Scan(&count.Start, &count.Key, &count.Count); err != nil {
+			return nil, fmt.Errorf("scan activity group count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate activity group counts: %w", err)
+	}
+
+	return counts, nil
+}
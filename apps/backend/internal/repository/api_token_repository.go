@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// APITokenRepository provides data access for the api_tokens table.
+// All methods except Authenticate enforce user isolation; Authenticate is
+// how a token's user is found.
+type APITokenRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAPITokenRepository creates a new APITokenRepository with the given database pool.
+func NewAPITokenRepository(db *pgxpool.Pool) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// apiTokenColumns is the column list scanned by scanAPIToken
+const apiTokenColumns = `id, user_id, name, token_prefix, last_used_at, expires_at, created_at`
+
+// scanAPIToken scans a row selected with apiTokenColumns
+func scanAPIToken(row rowScanner) (*model.APIToken, error) {
+	var token model.APIToken
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.Prefix,
+		&token.LastUsedAt,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Create stores a token by its hash, unless the user already holds
+// MaxAPITokens tokens
+func (r *APITokenRepository) Create(ctx context.Context, userID, name, tokenHash, tokenPrefix string, expiresAt *time.Time) (*model.APIToken, error) {
+	query := `
+		INSERT INTO api_tokens (user_id, name, token_hash, token_prefix, expires_at)
+		SELECT @userID, @name, @tokenHash, @tokenPrefix, @expiresAt
+		WHERE (SELECT count(*) FROM api_tokens WHERE user_id = @userID) < @maxTokens
+		RETURNING ` + apiTokenColumns
+
+	args := pgx.NamedArgs{
+		"userID":      userID,
+		"name":        name,
+		"tokenHash":   tokenHash,
+		"tokenPrefix": tokenPrefix,
+		"expiresAt":   expiresAt,
+		"maxTokens":   model.MaxAPITokens,
+	}
+
+	token, err := scanAPIToken(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "API_TOKEN_LIMIT"
+			return nil, errs.NewBadRequestError(
+				fmt.Sprintf("at most %d API tokens are allowed, delete one first", model.MaxAPITokens),
+				false, &code, nil, nil)
+		}
+		return nil, fmt.Errorf("create api token: %w", err)
+	}
+
+	return token, nil
+}
+
+// List returns the user's tokens, newest first
+func (r *APITokenRepository) List(ctx context.Context, userID string) ([]*model.APIToken, error) {
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE user_id = @userID
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID})
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]*model.APIToken, 0)
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Delete revokes a token
+func (r *APITokenRepository) Delete(ctx context.Context, userID string, tokenID uuid.UUID) error {
+	query := `
+		DELETE FROM api_tokens
+		WHERE id = @tokenID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"tokenID": tokenID,
+		"userID":  userID,
+	}
+
+	result, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("delete api token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errs.NewNotFoundError("api token not found", false, nil)
+	}
+
+	return nil
+}
+
+// Authenticate finds the unexpired token with the given hash and records its
+// use. It returns nil if there is none.
+func (r *APITokenRepository) Authenticate(ctx context.Context, tokenHash string) (*model.APIToken, error) {
+	query := `
+		UPDATE api_tokens
+		SET last_used_at = now()
+		WHERE token_hash = @tokenHash AND (expires_at IS NULL OR expires_at > now())
+		RETURNING ` + apiTokenColumns
+
+	token, err := scanAPIToken(r.db.QueryRow(ctx, query, pgx.NamedArgs{"tokenHash": tokenHash}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("authenticate api token: %w", err)
+	}
+
+	return token, nil
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -24,12 +26,43 @@ func NewAssetRepository(db *pgxpool.Pool) *AssetRepository {
 	return &AssetRepository{db: db}
 }
 
+// assetColumns is the column list selected for every Asset query.
+// It must stay in sync with scanAsset.
+const assetColumns = `id, user_id, name, type, hostname, tags, metadata, pinned, favorite, created_at, updated_at`
+
+// scanAsset scans a row selected with assetColumns into an Asset
+func scanAsset(row rowScanner) (*model.Asset, error) {
+	var asset model.Asset
+	err := row.Scan(
+		&asset.ID,
+		&asset.UserID,
+		&asset.Name,
+		&asset.Type,     // pointer - handles NULL
+		&asset.Hostname, // pointer - handles NULL
+		&asset.Tags,     // pgx handles []string ↔ text[] automatically
+		&asset.Metadata, // json.RawMessage - handles NULL
+		&asset.Pinned,
+		&asset.Favorite,
+		&asset.CreatedAt,
+		&asset.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
 // GetByID retrieves a single asset by ID for the specified user.
 // Returns NotFoundError if the asset doesn't exist or belongs to another user.
 // This dual-key lookup (id AND user_id) prevents unauthorized access.
 func (r *AssetRepository) GetByID(ctx context.Context, userID string, assetID uuid.UUID) (*model.Asset, error) {
+	return getAsset(ctx, r.db, userID, assetID)
+}
+
+// getAsset retrieves a single asset with q, which may be a transaction
+func getAsset(ctx context.Context, q querier, userID string, assetID uuid.UUID) (*model.Asset, error) {
 	query := `
-		SELECT id, user_id, name, type, hostname, metadata, created_at, updated_at
+		SELECT ` + assetColumns + `
 		FROM assets
 		WHERE id = @assetID AND user_id = @userID
 	`
@@ -39,18 +72,7 @@ func (r *AssetRepository) GetByID(ctx context.Context, userID string, assetID uu
 		"userID":  userID,
 	}
 
-	var asset model.Asset
-	err := r.db.QueryRow(ctx, query, args).Scan(
-		&asset.ID,
-		&asset.UserID,
-		&asset.Name,
-		&asset.Type,     // pointer - handles NULL
-		&asset.Hostname, // pointer - handles NULL
-		&asset.Metadata, // json.RawMessage - handles NULL
-		&asset.CreatedAt,
-		&asset.UpdatedAt,
-	)
-
+	asset, err := scanAsset(q.QueryRow(ctx, query, args))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.NewNotFoundError("asset not found", false, nil)
@@ -58,7 +80,7 @@ func (r *AssetRepository) GetByID(ctx context.Context, userID string, assetID uu
 		return nil, fmt.Errorf("get asset by id: %w", err)
 	}
 
-	return &asset, nil
+	return asset, nil
 }
 
 // buildAssetWhereClause builds dynamic WHERE clause for List/Count with filters
@@ -76,9 +98,38 @@ func buildAssetWhereClause(params *model.AssetQueryParams, args pgx.NamedArgs) s
 		args["search"] = searchPattern
 	}
 
+	// Tags filter: all specified tags (default) or any of them
+	if len(params.Tags) > 0 {
+		if params.TagMode == model.TagModeAny {
+			clauses = append(clauses, "tags && @tags::text[]")
+		} else {
+			clauses = append(clauses, "tags @> @tags::text[]")
+		}
+		args["tags"] = params.Tags
+	}
+
+	if params.Favorite != nil {
+		clauses = append(clauses, "favorite = @favorite")
+		args["favorite"] = *params.Favorite
+	}
+
+	if params.UpdatedWithinDays != nil {
+		clauses = append(clauses, "updated_at >= now() - make_interval(days => @updatedWithinDays)")
+		args["updatedWithinDays"] = *params.UpdatedWithinDays
+	}
+
 	return "WHERE " + strings.Join(clauses, " AND ")
 }
 
+// assetFilterClause restricts column, an asset ID, to the user's assets that
+// match the list filters. A nil filter matches every asset.
+func assetFilterClause(column string, filter *model.AssetQueryParams, args pgx.NamedArgs) string {
+	if filter == nil {
+		return ""
+	}
+	return fmt.Sprintf("AND %s IN (SELECT id FROM assets %s)", column, buildAssetWhereClause(filter, args))
+}
+
 // validateAssetSortBy prevents SQL injection by validating sort column
 func validateAssetSortBy(sortBy string) error {
 	allowed := map[string]bool{
@@ -118,14 +169,15 @@ func (r *AssetRepository) List(ctx context.Context, userID string, params *model
 	}
 	whereClause := buildAssetWhereClause(params, args)
 
-	// Build complete query with ORDER BY and LIMIT/OFFSET
+	// Build complete query with ORDER BY and LIMIT/OFFSET; pinned assets come
+	// first whatever the sort
 	query := fmt.Sprintf(`
-		SELECT id, user_id, name, type, hostname, metadata, created_at, updated_at
+		SELECT %s
 		FROM assets
 		%s
-		ORDER BY %s %s
+		ORDER BY pinned DESC, %s %s
 		LIMIT @limit OFFSET @offset
-	`, whereClause, params.SortBy, params.SortOrder)
+	`, assetColumns, whereClause, params.SortBy, params.SortOrder)
 
 	rows, err := r.db.Query(ctx, query, args)
 	if err != nil {
@@ -135,21 +187,11 @@ func (r *AssetRepository) List(ctx context.Context, userID string, params *model
 
 	assets := make([]*model.Asset, 0)
 	for rows.Next() {
-		var asset model.Asset
-		err := rows.Scan(
-			&asset.ID,
-			&asset.UserID,
-			&asset.Name,
-			&asset.Type,
-			&asset.Hostname,
-			&asset.Metadata,
-			&asset.CreatedAt,
-			&asset.UpdatedAt,
-		)
+		asset, err := scanAsset(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scan asset: %w", err)
 		}
-		assets = append(assets, &asset)
+		assets = append(assets, asset)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -182,39 +224,70 @@ func (r *AssetRepository) Count(ctx context.Context, userID string, params *mode
 	return count, nil
 }
 
+// VerifyOwned checks in one query that every asset ID belongs to the user.
+// Returns NotFoundError if any of them doesn't exist or belongs to another
+// user. Duplicate IDs are allowed.
+func (r *AssetRepository) VerifyOwned(ctx context.Context, userID string, assetIDs []uuid.UUID) error {
+	distinct := make(map[uuid.UUID]bool, len(assetIDs))
+	for _, id := range assetIDs {
+		distinct[id] = true
+	}
+	if len(distinct) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM assets
+		WHERE user_id = @userID AND id = ANY(@assetIDs::uuid[])
+	`
+
+	args := pgx.NamedArgs{
+		"userID":   userID,
+		"assetIDs": assetIDs,
+	}
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, args).Scan(&count); err != nil {
+		return fmt.Errorf("verify assets: %w", err)
+	}
+
+	if count != len(distinct) {
+		return errs.NewNotFoundError("asset not found", false, nil)
+	}
+
+	return nil
+}
+
 // Create inserts a new asset for a user
 func (r *AssetRepository) Create(ctx context.Context, userID string, req *model.CreateAssetRequest) (*model.Asset, error) {
+	return insertAsset(ctx, r.db, userID, req)
+}
+
+// insertAsset inserts an asset with q, so it can run inside a transaction
+func insertAsset(ctx context.Context, q querier, userID string, req *model.CreateAssetRequest) (*model.Asset, error) {
 	query := `
-		INSERT INTO assets (user_id, name, type, hostname, metadata)
-		VALUES (@userID, @name, @type, @hostname, @metadata)
-		RETURNING id, user_id, name, type, hostname, metadata, created_at, updated_at
-	`
+		INSERT INTO assets (user_id, name, type, hostname, tags, metadata, pinned, favorite)
+		VALUES (@userID, @name, @type, @hostname, @tags, @metadata, @pinned, @favorite)
+		RETURNING ` + assetColumns
 
 	args := pgx.NamedArgs{
 		"userID":   userID,
 		"name":     req.Name,
 		"type":     req.Type,     // nil becomes NULL
 		"hostname": req.Hostname, // nil becomes NULL
+		"tags":     req.Tags,     // nil becomes NULL
 		"metadata": req.Metadata, // nil becomes NULL
+		"pinned":   req.Pinned,
+		"favorite": req.Favorite,
 	}
 
-	var asset model.Asset
-	err := r.db.QueryRow(ctx, query, args).Scan(
-		&asset.ID,
-		&asset.UserID,
-		&asset.Name,
-		&asset.Type,
-		&asset.Hostname,
-		&asset.Metadata,
-		&asset.CreatedAt,
-		&asset.UpdatedAt,
-	)
-
+	asset, err := scanAsset(q.QueryRow(ctx, query, args))
 	if err != nil {
 		return nil, fmt.Errorf("create asset: %w", err)
 	}
 
-	return &asset, nil
+	return asset, nil
 }
 
 // buildAssetUpdateSetClause builds dynamic SET clause for Update
@@ -236,16 +309,36 @@ func buildAssetUpdateSetClause(req *model.UpdateAssetRequest, args pgx.NamedArgs
 		args["hostname"] = *req.Hostname
 	}
 
+	if req.Tags != nil {
+		setClauses = append(setClauses, "tags = @tags")
+		args["tags"] = *req.Tags
+	}
+
 	if req.Metadata != nil {
 		setClauses = append(setClauses, "metadata = @metadata")
 		args["metadata"] = *req.Metadata
 	}
 
+	if req.Pinned != nil {
+		setClauses = append(setClauses, "pinned = @pinned")
+		args["pinned"] = *req.Pinned
+	}
+
+	if req.Favorite != nil {
+		setClauses = append(setClauses, "favorite = @favorite")
+		args["favorite"] = *req.Favorite
+	}
+
 	return strings.Join(setClauses, ", ")
 }
 
 // Update modifies an existing asset (only non-nil fields are updated)
 func (r *AssetRepository) Update(ctx context.Context, userID string, assetID uuid.UUID, req *model.UpdateAssetRequest) (*model.Asset, error) {
+	return updateAsset(ctx, r.db, userID, assetID, req)
+}
+
+// updateAsset updates an asset with q, so it can run inside a transaction
+func updateAsset(ctx context.Context, q querier, userID string, assetID uuid.UUID, req *model.UpdateAssetRequest) (*model.Asset, error) {
 	// Build SET clause dynamically based on non-nil fields
 	args := pgx.NamedArgs{
 		"assetID": assetID,
@@ -257,21 +350,10 @@ func (r *AssetRepository) Update(ctx context.Context, userID string, assetID uui
 		UPDATE assets
 		SET %s
 		WHERE id = @assetID AND user_id = @userID
-		RETURNING id, user_id, name, type, hostname, metadata, created_at, updated_at
-	`, setClause)
-
-	var asset model.Asset
-	err := r.db.QueryRow(ctx, query, args).Scan(
-		&asset.ID,
-		&asset.UserID,
-		&asset.Name,
-		&asset.Type,
-		&asset.Hostname,
-		&asset.Metadata,
-		&asset.CreatedAt,
-		&asset.UpdatedAt,
-	)
+		RETURNING %s
+	`, setClause, assetColumns)
 
+	asset, err := scanAsset(q.QueryRow(ctx, query, args))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.NewNotFoundError("asset not found", false, nil)
@@ -279,7 +361,7 @@ func (r *AssetRepository) Update(ctx context.Context, userID string, assetID uui
 		return nil, fmt.Errorf("update asset: %w", err)
 	}
 
-	return &asset, nil
+	return asset, nil
 }
 
 // Delete removes an asset for a user
@@ -305,3 +387,170 @@ func (r *AssetRepository) Delete(ctx context.Context, userID string, assetID uui
 
 	return nil
 }
+
+// FindByField returns the user's assets whose name or hostname equals one of
+// values, ignoring case
+func (r *AssetRepository) FindByField(ctx context.Context, userID, field string, values []string) ([]*model.Asset, error) {
+	if field != "name" && field != "hostname" {
+		return nil, fmt.Errorf("invalid asset match field: %s", field)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM assets
+		WHERE user_id = @userID AND lower(%s) = ANY(@values::text[])
+		ORDER BY created_at ASC, id ASC
+	`, assetColumns, field)
+
+	lowered := make([]string, 0, len(values))
+	for _, value := range values {
+		lowered = append(lowered, strings.ToLower(value))
+	}
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID, "values": lowered})
+	if err != nil {
+		return nil, fmt.Errorf("find assets by %s: %w", field, err)
+	}
+	defer rows.Close()
+
+	assets := make([]*model.Asset, 0)
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// FindByMetadata returns the user's assets whose metadata contains every key
+// and value of filter, oldest first
+func (r *AssetRepository) FindByMetadata(ctx context.Context, userID string, filter map[string]any) ([]*model.Asset, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("encode metadata filter: %w", err)
+	}
+
+	query := `
+		SELECT ` + assetColumns + `
+		FROM assets
+		WHERE user_id = @userID AND metadata @> @filter::jsonb
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID, "filter": string(data)})
+	if err != nil {
+		return nil, fmt.Errorf("find assets by metadata: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]*model.Asset, 0)
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// FindWithAddress returns the user's assets that have a hostname or any of
+// the given metadata keys, oldest first
+func (r *AssetRepository) FindWithAddress(ctx context.Context, userID string, metadataKeys []string) ([]*model.Asset, error) {
+	query := `
+		SELECT ` + assetColumns + `
+		FROM assets
+		WHERE user_id = @userID AND (hostname <> '' OR metadata ?| @keys::text[])
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID, "keys": metadataKeys})
+	if err != nil {
+		return nil, fmt.Errorf("find assets with address: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]*model.Asset, 0)
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// AssetWrite is one asset to create, or to update when ID is set. Log, if
+// set, is written on the asset after it, and Ports are recorded as seen open
+// at PortsSeenAt. A write with an ID but no Update only writes the log and
+// ports.
+type AssetWrite struct {
+	ID          uuid.UUID
+	Create      *model.CreateAssetRequest
+	Update      *model.UpdateAssetRequest
+	Log         *model.CreateLogRequest
+	Ports       []*model.CreateAssetPortRequest
+	PortsSeenAt time.Time
+}
+
+// BulkWrite creates and updates assets, with their logs, in a single
+// transaction and returns them in the order of writes. If any write fails,
+// nothing is written.
+func (r *AssetRepository) BulkWrite(ctx context.Context, userID string, writes []AssetWrite) ([]*model.Asset, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin bulk asset transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	assets := make([]*model.Asset, 0, len(writes))
+	for _, write := range writes {
+		var asset *model.Asset
+		switch {
+		case write.Update != nil:
+			asset, err = updateAsset(ctx, tx, userID, write.ID, write.Update)
+		case write.Create != nil:
+			asset, err = insertAsset(ctx, tx, userID, write.Create)
+		default:
+			asset, err = getAsset(ctx, tx, userID, write.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if write.Log != nil {
+			if _, err := insertLog(ctx, tx, userID, asset.ID, write.Log); err != nil {
+				return nil, err
+			}
+		}
+		if len(write.Ports) > 0 {
+			if err := upsertPorts(ctx, tx, userID, asset.ID, write.Ports, write.PortsSeenAt); err != nil {
+				return nil, err
+			}
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit bulk asset transaction: %w", err)
+	}
+
+	return assets, nil
+}
@@ -446,7 +446,7 @@ func TestAssetRepository_Create_AllFields(t *testing.T) {
 		Name:     "Production Server",
 		Type:     testingPkg.Ptr("server"),
 		Hostname: testingPkg.Ptr("prod.example.com"),
-		Metadata: json.RawMessage(`{"cpu": "16 cores"}`),
+		Metadata: testingPkg.Ptr(json.RawMessage(`{"cpu": "16 cores"}`)),
 	}
 
 	asset, err := repo.Create(ctx, userID, req)
@@ -547,7 +547,7 @@ func TestAssetRepository_Update_AllFields(t *testing.T) {
 		Name:     testingPkg.Ptr("Updated"),
 		Type:     testingPkg.Ptr("vm"),
 		Hostname: testingPkg.Ptr("new.host.com"),
-		Metadata: json.RawMessage(`{"updated": true}`),
+		Metadata: testingPkg.Ptr(json.RawMessage(`{"updated": true}`)),
 	}
 
 	asset, err := repo.Update(ctx, userID, assetID, updateReq)
@@ -690,3 +690,33 @@ func TestAssetRepository_Delete_WrongUser(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, asset)
 }
+
+// ========== VerifyOwned Tests ==========
+
+// Test 27: TestAssetRepository_VerifyOwned
+func TestAssetRepository_VerifyOwned(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewAssetRepository(testDB.Pool)
+
+	aliceID, otherAliceID, bobID := uuid.New(), uuid.New(), uuid.New()
+	_, err := testDB.Pool.Exec(ctx, `
+		INSERT INTO assets (id, user_id, name)
+		VALUES ($1, 'alice', 'nas-01'), ($2, 'alice', 'pve-01'), ($3, 'bob', 'router')
+	`, aliceID, otherAliceID, bobID)
+	require.NoError(t, err)
+
+	// Owned assets, including duplicates, pass
+	assert.NoError(t, repo.VerifyOwned(ctx, "alice", []uuid.UUID{aliceID, otherAliceID, aliceID}))
+	assert.NoError(t, repo.VerifyOwned(ctx, "alice", nil))
+
+	// Another user's asset or an unknown ID is not found
+	for _, ids := range [][]uuid.UUID{{aliceID, bobID}, {uuid.New()}} {
+		err = repo.VerifyOwned(ctx, "alice", ids)
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, 404, httpErr.Status)
+	}
+}
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// ExportRepository reads a user's whole account for archiving and stores the
+// archives built in the background in the account_exports table.
+// All per-user methods enforce user isolation.
+type ExportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewExportRepository creates a new ExportRepository with the given database pool.
+func NewExportRepository(db *pgxpool.Pool) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+// exportColumns is the column list scanned by scanExport. The archive is
+// left out; only GetArchive reads it.
+const exportColumns = `id, user_id, status, error, size_bytes, asset_count, log_count,
+		created_at, updated_at, completed_at, expires_at`
+
+// scanExport scans a row selected with exportColumns
+func scanExport(row rowScanner) (*model.AccountExport, error) {
+	var export model.AccountExport
+	err := row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.Error,
+		&export.SizeBytes,
+		&export.AssetCount,
+		&export.LogCount,
+		&export.CreatedAt,
+		&export.UpdatedAt,
+		&export.CompletedAt,
+		&export.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ListAssets returns the user's assets that match filter, by name. A nil
+// filter returns every asset.
+func (r *ExportRepository) ListAssets(ctx context.Context, userID string, filter *model.AssetQueryParams) ([]*model.Asset, error) {
+	args := pgx.NamedArgs{"userID": userID}
+	query := `
+		SELECT ` + assetColumns + `
+		FROM assets
+		WHERE user_id = @userID ` + assetFilterClause("id", filter, args) + `
+		ORDER BY name ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list assets for export: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]*model.Asset, 0)
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan asset for export: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate assets for export: %w", err)
+	}
+
+	return assets, nil
+}
+
+// CountAssets returns the number of the user's assets that match filter
+func (r *ExportRepository) CountAssets(ctx context.Context, userID string, filter *model.AssetQueryParams) (int64, error) {
+	args := pgx.NamedArgs{"userID": userID}
+	query := `SELECT count(*) FROM assets WHERE user_id = @userID ` + assetFilterClause("id", filter, args)
+
+	var count int64
+	err := r.db.QueryRow(ctx, query, args).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count assets for export: %w", err)
+	}
+	return count, nil
+}
+
+// CountLogs returns the number of logs the user has across the assets that
+// match filter
+func (r *ExportRepository) CountLogs(ctx context.Context, userID string, filter *model.AssetQueryParams) (int64, error) {
+	args := pgx.NamedArgs{"userID": userID}
+	query := `SELECT count(*) FROM asset_logs WHERE user_id = @userID ` + assetFilterClause("asset_id", filter, args)
+
+	var count int64
+	err := r.db.QueryRow(ctx, query, args).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count logs for export: %w", err)
+	}
+	return count, nil
+}
+
+// ListTimelines returns the timeline entries of the user's incidents on the
+// assets that match filter by log, in chronological order
+func (r *ExportRepository) ListTimelines(ctx context.Context, userID string, filter *model.AssetQueryParams) (map[uuid.UUID][]model.IncidentTimelineEntry, error) {
+	args := pgx.NamedArgs{"userID": userID}
+	logFilter := ""
+	if filter != nil {
+		logFilter = `AND log_id IN (
+			SELECT id FROM asset_logs WHERE user_id = @userID ` + assetFilterClause("asset_id", filter, args) + `
+		)`
+	}
+
+	query := `
+		SELECT id, log_id, user_id, status, note, occurred_at, created_at
+		FROM incident_timeline_entries
+		WHERE user_id = @userID ` + logFilter + `
+		ORDER BY log_id ASC, occurred_at ASC, created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list timelines for export: %w", err)
+	}
+	defer rows.Close()
+
+	timelines := make(map[uuid.UUID][]model.IncidentTimelineEntry)
+	for rows.Next() {
+		var entry model.IncidentTimelineEntry
+		err := rows.Scan(&entry.ID, &entry.LogID, &entry.UserID, &entry.Status, &entry.Note, &entry.OccurredAt, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan timeline entry for export: %w", err)
+		}
+		timelines[entry.LogID] = append(timelines[entry.LogID], entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate timelines for export: %w", err)
+	}
+
+	return timelines, nil
+}
+
+// ForEachLog calls fn with each of the user's logs on the assets that match
+// filter, grouped by asset and oldest first within an asset. Logs are streamed
+// so large accounts are never held in memory at once; an error from fn stops
+// the iteration and is returned.
+func (r *ExportRepository) ForEachLog(ctx context.Context, userID string, filter *model.AssetQueryParams, fn func(*model.AssetLog) error) error {
+	args := pgx.NamedArgs{"userID": userID}
+	query := `
+		SELECT ` + logColumns + `
+		FROM asset_logs
+		WHERE user_id = @userID ` + assetFilterClause("asset_id", filter, args) + `
+		ORDER BY asset_id ASC, created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("list logs for export: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		log, err := scanLog(rows)
+		if err != nil {
+			return fmt.Errorf("scan log for export: %w", err)
+		}
+		if err := fn(log); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate logs for export: %w", err)
+	}
+
+	return nil
+}
+
+// Create records a pending export that expires at expiresAt
+func (r *ExportRepository) Create(ctx context.Context, userID string, expiresAt time.Time) (*model.AccountExport, error) {
+	query := `
+		INSERT INTO account_exports (user_id, expires_at)
+		VALUES (@userID, @expiresAt)
+		RETURNING ` + exportColumns
+
+	export, err := scanExport(r.db.QueryRow(ctx, query, pgx.NamedArgs{
+		"userID":    userID,
+		"expiresAt": expiresAt,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("create export: %w", err)
+	}
+	return export, nil
+}
+
+func (r *ExportRepository) GetByID(ctx context.Context, userID string, exportID uuid.UUID) (*model.AccountExport, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM account_exports
+		WHERE id = @exportID AND user_id = @userID
+	`
+
+	export, err := scanExport(r.db.QueryRow(ctx, query, pgx.NamedArgs{
+		"exportID": exportID,
+		"userID":   userID,
+	}))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("export not found", false, nil)
+		}
+		return nil, fmt.Errorf("get export: %w", err)
+	}
+	return export, nil
+}
+
+// List returns the user's unexpired exports, newest first
+func (r *ExportRepository) List(ctx context.Context, userID string) ([]*model.AccountExport, error) {
+	query := `
+		SELECT ` + exportColumns + `
+		FROM account_exports
+		WHERE user_id = @userID AND expires_at > now()
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID})
+	if err != nil {
+		return nil, fmt.Errorf("list exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := make([]*model.AccountExport, 0)
+	for rows.Next() {
+		export, err := scanExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// GetArchive returns the archive of a completed, unexpired export
+func (r *ExportRepository) GetArchive(ctx context.Context, userID string, exportID uuid.UUID) ([]byte, error) {
+	query := `
+		SELECT archive
+		FROM account_exports
+		WHERE id = @exportID AND user_id = @userID
+			AND status = @completed AND expires_at > now()
+	`
+
+	var archive []byte
+	err := r.db.QueryRow(ctx, query, pgx.NamedArgs{
+		"exportID":  exportID,
+		"userID":    userID,
+		"completed": model.ExportStatusCompleted,
+	}).Scan(&archive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("export not found", false, nil)
+		}
+		return nil, fmt.Errorf("get export archive: %w", err)
+	}
+	return archive, nil
+}
+
+// MarkRunning moves a pending export to running. A running export stays
+// running so that a retried job can pick it up again. It reports false when
+// the export no longer exists or has already finished.
+func (r *ExportRepository) MarkRunning(ctx context.Context, userID string, exportID uuid.UUID) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE account_exports
+		SET status = @running
+		WHERE id = @exportID AND user_id = @userID AND status IN (@pending, @running)
+	`, pgx.NamedArgs{
+		"exportID": exportID,
+		"userID":   userID,
+		"running":  model.ExportStatusRunning,
+		"pending":  model.ExportStatusPending,
+	})
+	if err != nil {
+		return false, fmt.Errorf("start export: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Complete stores the archive of a running export
+func (r *ExportRepository) Complete(ctx context.Context, userID string, exportID uuid.UUID, archive []byte, assetCount, logCount int) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE account_exports
+		SET status = @completed, archive = @archive, size_bytes = @sizeBytes,
+			asset_count = @assetCount, log_count = @logCount, completed_at = now()
+		WHERE id = @exportID AND user_id = @userID
+	`, pgx.NamedArgs{
+		"exportID":   exportID,
+		"userID":     userID,
+		"completed":  model.ExportStatusCompleted,
+		"archive":    archive,
+		"sizeBytes":  len(archive),
+		"assetCount": assetCount,
+		"logCount":   logCount,
+	})
+	if err != nil {
+		return fmt.Errorf("complete export: %w", err)
+	}
+	return nil
+}
+
+// Fail marks an export as failed with a message for the user
+func (r *ExportRepository) Fail(ctx context.Context, userID string, exportID uuid.UUID, message string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE account_exports
+		SET status = @failed, error = @message, completed_at = now()
+		WHERE id = @exportID AND user_id = @userID
+	`, pgx.NamedArgs{
+		"exportID": exportID,
+		"userID":   userID,
+		"failed":   model.ExportStatusFailed,
+		"message":  message,
+	})
+	if err != nil {
+		return fmt.Errorf("fail export: %w", err)
+	}
+	return nil
+}
+
+func (r *ExportRepository) Delete(ctx context.Context, userID string, exportID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM account_exports
+		WHERE id = @exportID AND user_id = @userID
+	`, pgx.NamedArgs{
+		"exportID": exportID,
+		"userID":   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete export: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.NewNotFoundError("export not found", false, nil)
+	}
+	return nil
+}
+
+// DeleteExpired removes every user's expired exports and returns how many
+// were removed. Used by the daily cleanup job.
+func (r *ExportRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM account_exports WHERE expires_at <= now()`)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired exports: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"ark/internal/model"
+	testingPkg "ark/internal/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertExportAsset inserts an asset with one incident log and its timeline
+func insertExportAsset(t *testing.T, testDB *testingPkg.TestDB, userID, name, assetType string) (uuid.UUID, uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+
+	assetID, logID := uuid.New(), uuid.New()
+	_, err := testDB.Pool.Exec(ctx, `INSERT INTO assets (id, user_id, name, type) VALUES ($1, $2, $3, $4)`, assetID, userID, name, assetType)
+	require.NoError(t, err)
+
+	_, err = testDB.Pool.Exec(ctx, `
+		INSERT INTO asset_logs (id, asset_id, user_id, kind, content, severity)
+		VALUES ($1, $2, $3, 'incident', 'Down', 'high')
+	`, logID, assetID, userID)
+	require.NoError(t, err)
+
+	_, err = testDB.Pool.Exec(ctx, `
+		INSERT INTO incident_timeline_entries (log_id, user_id, status) VALUES ($1, $2, 'open')
+	`, logID, userID)
+	require.NoError(t, err)
+
+	return assetID, logID
+}
+
+// ========== Filter Tests ==========
+
+// Test 1: TestExportRepository_NilFilterExportsEverything
+func TestExportRepository_NilFilterExportsEverything(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewExportRepository(testDB.Pool)
+
+	insertExportAsset(t, testDB, "alice", "nas-01", "nas")
+	insertExportAsset(t, testDB, "alice", "switch-01", "switch")
+	insertExportAsset(t, testDB, "bob", "router", "router")
+
+	assets, err := repo.ListAssets(ctx, "alice", nil)
+	require.NoError(t, err)
+	assert.Len(t, assets, 2)
+
+	logs, err := repo.CountLogs(ctx, "alice", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), logs)
+
+	timelines, err := repo.ListTimelines(ctx, "alice", nil)
+	require.NoError(t, err)
+	assert.Len(t, timelines, 2)
+}
+
+// Test 2: TestExportRepository_FilterLimitsAssetsLogsAndTimelines
+func TestExportRepository_FilterLimitsAssetsLogsAndTimelines(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewExportRepository(testDB.Pool)
+
+	nasID, nasLogID := insertExportAsset(t, testDB, "alice", "nas-01", "nas")
+	insertExportAsset(t, testDB, "alice", "switch-01", "switch")
+	insertExportAsset(t, testDB, "bob", "bob-nas", "nas")
+
+	filter := &model.AssetQueryParams{Type: testingPkg.Ptr("nas")}
+
+	assets, err := repo.ListAssets(ctx, "alice", filter)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	assert.Equal(t, nasID, assets[0].ID)
+
+	count, err := repo.CountAssets(ctx, "alice", filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = repo.CountLogs(ctx, "alice", filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	var logIDs []uuid.UUID
+	err = repo.ForEachLog(ctx, "alice", filter, func(log *model.AssetLog) error {
+		logIDs = append(logIDs, log.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{nasLogID}, logIDs)
+
+	timelines, err := repo.ListTimelines(ctx, "alice", filter)
+	require.NoError(t, err)
+	require.Len(t, timelines, 1)
+	assert.Len(t, timelines[nasLogID], 1)
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// ImportRepository writes account archives back into the assets, asset_logs,
+// log_assets and incident_timeline_entries tables. All methods enforce user isolation.
+type ImportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewImportRepository creates a new ImportRepository with the given database pool.
+func NewImportRepository(db *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// ImportOwnership tells, for each archived ID found in the database, whether
+// the row belongs to the importing user. IDs that are not in the map are free.
+type ImportOwnership struct {
+	Assets map[uuid.UUID]bool
+	Logs   map[uuid.UUID]bool
+}
+
+// ImportRows are the rows an import writes. Rows marked in Overwrite replace
+// the user's existing row with the same ID; all other rows are inserted.
+// Timeline entries belong to logs in Logs and replace their existing
+// timelines; they are inserted under new IDs.
+type ImportRows struct {
+	Assets    []*model.Asset
+	Logs      []*model.AssetLog
+	Timeline  []model.IncidentTimelineEntry
+	Overwrite map[uuid.UUID]bool
+}
+
+// importConflictError is returned when rows appear or disappear between
+// planning an import and applying it
+func importConflictError() error {
+	code := "IMPORT_CONFLICT"
+	return errs.NewBadRequestError("account changed during import, please retry", false, &code, nil, nil)
+}
+
+// existingOwners returns which of the given IDs exist in table and whether
+// they belong to the user
+func existingOwners(ctx context.Context, q querier, table, userID string, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	owners := make(map[uuid.UUID]bool)
+	if len(ids) == 0 {
+		return owners, nil
+	}
+
+	query := fmt.Sprintf(`SELECT id, user_id = @userID FROM %s WHERE id = ANY(@ids::uuid[])`, table)
+	rows, err := q.Query(ctx, query, pgx.NamedArgs{"userID": userID, "ids": ids})
+	if err != nil {
+		return nil, fmt.Errorf("find existing %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var owned bool
+		if err := rows.Scan(&id, &owned); err != nil {
+			return nil, fmt.Errorf("scan existing %s: %w", table, err)
+		}
+		owners[id] = owned
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate existing %s: %w", table, err)
+	}
+
+	return owners, nil
+}
+
+// ExistingIDs looks up which archived asset and log IDs are already taken
+func (r *ImportRepository) ExistingIDs(ctx context.Context, userID string, assetIDs, logIDs []uuid.UUID) (*ImportOwnership, error) {
+	assets, err := existingOwners(ctx, r.db, "assets", userID, assetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := existingOwners(ctx, r.db, "asset_logs", userID, logIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportOwnership{Assets: assets, Logs: logs}, nil
+}
+
+// Apply writes the rows in a single transaction. Rows are bulk loaded with
+// COPY into temporary tables and moved into place with one INSERT and one
+// UPDATE per table, so original IDs and timestamps are kept; the updated_at
+// trigger still stamps overwritten rows. If any row was taken or removed since
+// the import was planned, nothing is written.
+func (r *ImportRepository) Apply(ctx context.Context, userID string, rows *ImportRows) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin import transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := importAssets(ctx, tx, userID, rows); err != nil {
+		return err
+	}
+
+	if err := importLogs(ctx, tx, userID, rows); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit import transaction: %w", err)
+	}
+
+	return nil
+}
+
+// countOverwrites counts the rows marked for overwriting
+func countOverwrites[T any](overwrite map[uuid.UUID]bool, rows []T, id func(T) uuid.UUID) int {
+	count := 0
+	for _, row := range rows {
+		if overwrite[id(row)] {
+			count++
+		}
+	}
+	return count
+}
+
+// moveImportRows runs the INSERT of new rows and the UPDATE of overwritten
+// rows from a temporary table, checking that every row was written
+func moveImportRows(ctx context.Context, tx pgx.Tx, table, userID, insertQuery, updateQuery string, creates, updates int) error {
+	result, err := tx.Exec(ctx, insertQuery)
+	if err != nil {
+		return fmt.Errorf("insert import %s: %w", table, err)
+	}
+	if result.RowsAffected() != int64(creates) {
+		return importConflictError()
+	}
+
+	result, err = tx.Exec(ctx, updateQuery, pgx.NamedArgs{"userID": userID})
+	if err != nil {
+		return fmt.Errorf("update import %s: %w", table, err)
+	}
+	if result.RowsAffected() != int64(updates) {
+		return importConflictError()
+	}
+
+	return nil
+}
+
+func importAssets(ctx context.Context, tx pgx.Tx, userID string, rows *ImportRows) error {
+	if len(rows.Assets) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		CREATE TEMP TABLE import_assets (LIKE assets, overwrite BOOLEAN NOT NULL)
+		ON COMMIT DROP
+	`)
+	if err != nil {
+		return fmt.Errorf("create import assets table: %w", err)
+	}
+
+	updates := countOverwrites(rows.Overwrite, rows.Assets, func(a *model.Asset) uuid.UUID { return a.ID })
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"import_assets"},
+		[]string{"id", "user_id", "name", "type", "hostname", "tags", "metadata", "pinned", "favorite", "created_at", "updated_at", "overwrite"},
+		pgx.CopyFromSlice(len(rows.Assets), func(i int) ([]any, error) {
+			a := rows.Assets[i]
+			var metadata any
+			if len(a.Metadata) > 0 {
+				metadata = string(a.Metadata)
+			}
+			return []any{a.ID, userID, a.Name, a.Type, a.Hostname, a.Tags, metadata, a.Pinned, a.Favorite, a.CreatedAt, a.UpdatedAt, rows.Overwrite[a.ID]}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("copy import assets: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO assets (id, user_id, name, type, hostname, tags, metadata, pinned, favorite, created_at, updated_at)
+		SELECT id, user_id, name, type, hostname, tags, metadata, pinned, favorite, created_at, updated_at
+		FROM import_assets
+		WHERE NOT overwrite
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	updateQuery := `
+		UPDATE assets a
+		SET name = i.name, type = i.type, hostname = i.hostname, tags = i.tags, metadata = i.metadata,
+			pinned = i.pinned, favorite = i.favorite, created_at = i.created_at
+		FROM import_assets i
+		WHERE a.id = i.id AND a.user_id = @userID AND i.overwrite
+	`
+
+	return moveImportRows(ctx, tx, "assets", userID, insertQuery, updateQuery, len(rows.Assets)-updates, updates)
+}
+
+func importLogs(ctx context.Context, tx pgx.Tx, userID string, rows *ImportRows) error {
+	if len(rows.Logs) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		CREATE TEMP TABLE import_logs (LIKE asset_logs, overwrite BOOLEAN NOT NULL)
+		ON COMMIT DROP
+	`)
+	if err != nil {
+		return fmt.Errorf("create import logs table: %w", err)
+	}
+
+	updates := countOverwrites(rows.Overwrite, rows.Logs, func(l *model.AssetLog) uuid.UUID { return l.ID })
+	logIDs := make([]uuid.UUID, 0, len(rows.Logs))
+	var links [][]any
+	for _, l := range rows.Logs {
+		logIDs = append(logIDs, l.ID)
+		for _, assetID := range l.LinkedAssetIDs {
+			links = append(links, []any{l.ID, assetID, userID, l.CreatedAt})
+		}
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"import_logs"},
+		[]string{
+			"id", "asset_id", "user_id", "kind", "content", "tags",
+			"severity", "started_at", "resolved_at", "root_cause",
+			"incident_status", "mitigated_at",
+			"planned", "rollback_notes", "duration_minutes",
+			"created_at", "updated_at",
+			"parent_log_id", "thread_root_id", "pinned", "favorite", "overwrite",
+		},
+		pgx.CopyFromSlice(len(rows.Logs), func(i int) ([]any, error) {
+			l := rows.Logs[i]
+			return []any{
+				l.ID, l.AssetID, userID, l.Kind, l.Content, l.Tags,
+				l.Severity, l.StartedAt, l.ResolvedAt, l.RootCause,
+				l.IncidentStatus, l.MitigatedAt,
+				l.Planned, l.RollbackNotes, l.DurationMinutes,
+				l.CreatedAt, l.UpdatedAt,
+				l.ParentLogID, l.ThreadRootID, l.Pinned, l.Favorite, rows.Overwrite[l.ID],
+			}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("copy import logs: %w", err)
+	}
+
+	// Foreign keys are checked at the end of each statement, so replies may
+	// be inserted together with the logs they follow up on
+	insertQuery := `
+		INSERT INTO asset_logs (
+			id, asset_id, user_id, kind, content, tags,
+			severity, started_at, resolved_at, root_cause,
+			incident_status, mitigated_at,
+			planned, rollback_notes, duration_minutes,
+			created_at, updated_at,
+			parent_log_id, thread_root_id, pinned, favorite
+		)
+		SELECT
+			id, asset_id, user_id, kind, content, tags,
+			severity, started_at, resolved_at, root_cause,
+			incident_status, mitigated_at,
+			planned, rollback_notes, duration_minutes,
+			created_at, updated_at,
+			parent_log_id, thread_root_id, pinned, favorite
+		FROM import_logs
+		WHERE NOT overwrite
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	updateQuery := `
+		UPDATE asset_logs l
+		SET asset_id = i.asset_id, kind = i.kind, content = i.content, tags = i.tags,
+			severity = i.severity, started_at = i.started_at, resolved_at = i.resolved_at, root_cause = i.root_cause,
+			incident_status = i.incident_status, mitigated_at = i.mitigated_at,
+			planned = i.planned, rollback_notes = i.rollback_notes, duration_minutes = i.duration_minutes,
+			created_at = i.created_at,
+			parent_log_id = i.parent_log_id, thread_root_id = i.thread_root_id,
+			pinned = i.pinned, favorite = i.favorite
+		FROM import_logs i
+		WHERE l.id = i.id AND l.user_id = @userID AND i.overwrite
+	`
+
+	if err := moveImportRows(ctx, tx, "logs", userID, insertQuery, updateQuery, len(rows.Logs)-updates, updates); err != nil {
+		return err
+	}
+
+	// Overwritten logs take the archived linked assets
+	_, err = tx.Exec(ctx, `DELETE FROM log_assets WHERE user_id = @userID AND log_id = ANY(@logIDs::uuid[])`, pgx.NamedArgs{
+		"userID": userID,
+		"logIDs": logIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("clear import log assets: %w", err)
+	}
+
+	if len(links) > 0 {
+		_, err = tx.CopyFrom(ctx,
+			pgx.Identifier{"log_assets"},
+			[]string{"log_id", "asset_id", "user_id", "created_at"},
+			pgx.CopyFromRows(links),
+		)
+		if err != nil {
+			return fmt.Errorf("copy import log assets: %w", err)
+		}
+	}
+
+	// Overwritten incidents take the archived timeline
+	_, err = tx.Exec(ctx, `DELETE FROM incident_timeline_entries WHERE user_id = @userID AND log_id = ANY(@logIDs::uuid[])`, pgx.NamedArgs{
+		"userID": userID,
+		"logIDs": logIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("clear import timelines: %w", err)
+	}
+
+	if len(rows.Timeline) > 0 {
+		_, err = tx.CopyFrom(ctx,
+			pgx.Identifier{"incident_timeline_entries"},
+			[]string{"log_id", "user_id", "status", "note", "occurred_at", "created_at"},
+			pgx.CopyFromSlice(len(rows.Timeline), func(i int) ([]any, error) {
+				e := rows.Timeline[i]
+				return []any{e.LogID, userID, e.Status, e.Note, e.OccurredAt, e.CreatedAt}, nil
+			}),
+		)
+		if err != nil {
+			return fmt.Errorf("copy import timelines: %w", err)
+		}
+	}
+
+	return nil
+}
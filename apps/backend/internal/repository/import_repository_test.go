@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	testingPkg "ark/internal/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importTestRows is an asset with an incident log linked to a second asset
+func importTestRows(created time.Time) (*ImportRows, uuid.UUID, uuid.UUID, uuid.UUID) {
+	assetID, linkedID, logID := uuid.New(), uuid.New(), uuid.New()
+	rows := &ImportRows{
+		Assets: []*model.Asset{
+			{ID: assetID, Name: "nas-01", Tags: []string{"storage"}, CreatedAt: created, UpdatedAt: created},
+			{ID: linkedID, Name: "switch-01", CreatedAt: created, UpdatedAt: created},
+		},
+		Logs: []*model.AssetLog{{
+			ID:             logID,
+			AssetID:        assetID,
+			Kind:           model.LogKindIncident,
+			Content:        "Pool degraded",
+			Severity:       testingPkg.Ptr(model.SeverityHigh),
+			LinkedAssetIDs: []uuid.UUID{linkedID},
+			CreatedAt:      created,
+			UpdatedAt:      created,
+		}},
+		Timeline: []model.IncidentTimelineEntry{
+			{LogID: logID, Status: testingPkg.Ptr(model.IncidentStatusOpen), OccurredAt: created, CreatedAt: created},
+		},
+		Overwrite: map[uuid.UUID]bool{},
+	}
+	return rows, assetID, linkedID, logID
+}
+
+// countRows counts the user's rows of a table
+func countRows(t *testing.T, testDB *testingPkg.TestDB, table, userID string) int {
+	t.Helper()
+
+	var count int
+	err := testDB.Pool.QueryRow(context.Background(), "SELECT count(*) FROM "+table+" WHERE user_id = $1", userID).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+// ========== ExistingIDs Tests ==========
+
+// Test 1: TestImportRepository_ExistingIDs_Ownership
+func TestImportRepository_ExistingIDs_Ownership(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewImportRepository(testDB.Pool)
+
+	aliceID, bobID := uuid.New(), uuid.New()
+	_, err := testDB.Pool.Exec(ctx, `
+		INSERT INTO assets (id, user_id, name) VALUES ($1, 'alice', 'nas-01'), ($2, 'bob', 'router')
+	`, aliceID, bobID)
+	require.NoError(t, err)
+
+	freeID := uuid.New()
+	owners, err := repo.ExistingIDs(ctx, "alice", []uuid.UUID{aliceID, bobID, freeID}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{aliceID: true, bobID: false}, owners.Assets)
+	assert.Empty(t, owners.Logs)
+}
+
+// ========== Apply Tests ==========
+
+// Test 2: TestImportRepository_Apply_KeepsIDsAndTimestamps
+func TestImportRepository_Apply_KeepsIDsAndTimestamps(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewImportRepository(testDB.Pool)
+
+	created := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+	rows, assetID, linkedID, logID := importTestRows(created)
+
+	err := repo.Apply(ctx, "alice", rows)
+	require.NoError(t, err)
+
+	asset, err := NewAssetRepository(testDB.Pool).GetByID(ctx, "alice", assetID)
+	require.NoError(t, err)
+	assert.Equal(t, "nas-01", asset.Name)
+	assert.True(t, created.Equal(asset.CreatedAt), "created_at should be kept")
+
+	var kind string
+	var createdAt time.Time
+	err = testDB.Pool.QueryRow(ctx, `SELECT kind, created_at FROM asset_logs WHERE id = $1 AND user_id = 'alice'`, logID).Scan(&kind, &createdAt)
+	require.NoError(t, err)
+	assert.Equal(t, model.LogKindIncident, kind)
+	assert.True(t, created.Equal(createdAt))
+
+	var linked uuid.UUID
+	err = testDB.Pool.QueryRow(ctx, `SELECT asset_id FROM log_assets WHERE log_id = $1`, logID).Scan(&linked)
+	require.NoError(t, err)
+	assert.Equal(t, linkedID, linked)
+
+	timeline, err := NewIncidentRepository(testDB.Pool).ListTimeline(ctx, "alice", logID)
+	require.NoError(t, err)
+	require.Len(t, timeline, 1)
+	assert.Equal(t, model.IncidentStatusOpen, *timeline[0].Status)
+}
+
+// Test 3: TestImportRepository_Apply_Overwrite
+func TestImportRepository_Apply_Overwrite(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewImportRepository(testDB.Pool)
+
+	created := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+	rows, assetID, _, logID := importTestRows(created)
+	require.NoError(t, repo.Apply(ctx, "alice", rows))
+
+	// The archive is imported again with changes, replacing the rows
+	rows.Assets = rows.Assets[:1]
+	rows.Assets[0].Name = "nas-01-renamed"
+	rows.Logs[0].Content = "Pool degraded, disk 3 replaced"
+	rows.Logs[0].LinkedAssetIDs = nil
+	rows.Timeline = []model.IncidentTimelineEntry{
+		{LogID: logID, Note: testingPkg.Ptr("Paged on-call"), OccurredAt: created, CreatedAt: created},
+		{LogID: logID, Status: testingPkg.Ptr(model.IncidentStatusResolved), OccurredAt: created.Add(time.Hour), CreatedAt: created.Add(time.Hour)},
+	}
+	rows.Overwrite = map[uuid.UUID]bool{assetID: true, logID: true}
+
+	err := repo.Apply(ctx, "alice", rows)
+	require.NoError(t, err)
+
+	asset, err := NewAssetRepository(testDB.Pool).GetByID(ctx, "alice", assetID)
+	require.NoError(t, err)
+	assert.Equal(t, "nas-01-renamed", asset.Name)
+
+	var content string
+	err = testDB.Pool.QueryRow(ctx, `SELECT content FROM asset_logs WHERE id = $1`, logID).Scan(&content)
+	require.NoError(t, err)
+	assert.Equal(t, "Pool degraded, disk 3 replaced", content)
+
+	assert.Equal(t, 0, countRows(t, testDB, "log_assets", "alice"), "links should be replaced")
+
+	timeline, err := NewIncidentRepository(testDB.Pool).ListTimeline(ctx, "alice", logID)
+	require.NoError(t, err)
+	require.Len(t, timeline, 2, "the timeline should be replaced, not appended to")
+	assert.Equal(t, "Paged on-call", *timeline[0].Note)
+	assert.Equal(t, model.IncidentStatusResolved, *timeline[1].Status)
+}
+
+// Test 4: TestImportRepository_Apply_ConflictWritesNothing
+func TestImportRepository_Apply_ConflictWritesNothing(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewImportRepository(testDB.Pool)
+
+	created := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+	rows, _, linkedID, _ := importTestRows(created)
+
+	// The second asset was created after the import was planned
+	_, err := testDB.Pool.Exec(ctx, `INSERT INTO assets (id, user_id, name) VALUES ($1, 'alice', 'switch-01')`, linkedID)
+	require.NoError(t, err)
+
+	err = repo.Apply(ctx, "alice", rows)
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, "IMPORT_CONFLICT", httpErr.Code)
+	assert.Equal(t, 1, countRows(t, testDB, "assets", "alice"), "the transaction should be rolled back")
+	assert.Equal(t, 0, countRows(t, testDB, "asset_logs", "alice"))
+	assert.Equal(t, 0, countRows(t, testDB, "incident_timeline_entries", "alice"))
+}
+
+// Test 5: TestImportRepository_Apply_OverwriteDeletedRow
+func TestImportRepository_Apply_OverwriteDeletedRow(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewImportRepository(testDB.Pool)
+
+	created := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+	rows, assetID, _, _ := importTestRows(created)
+
+	// The asset to overwrite was deleted after the import was planned
+	rows.Overwrite[assetID] = true
+
+	err := repo.Apply(ctx, "alice", rows)
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, "IMPORT_CONFLICT", httpErr.Code)
+	assert.Equal(t, 0, countRows(t, testDB, "assets", "alice"))
+}
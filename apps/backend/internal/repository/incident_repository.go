@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// IncidentRepository provides data access for the incident lifecycle:
+// the incident_timeline_entries table and incident rows of asset_logs.
+// All methods enforce user isolation.
+type IncidentRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewIncidentRepository creates a new IncidentRepository with the given database pool.
+func NewIncidentRepository(db *pgxpool.Pool) *IncidentRepository {
+	return &IncidentRepository{db: db}
+}
+
+// ListTimeline returns the timeline entries of an incident in chronological order
+func (r *IncidentRepository) ListTimeline(ctx context.Context, userID string, logID uuid.UUID) ([]model.IncidentTimelineEntry, error) {
+	query := `
+		SELECT id, log_id, user_id, status, note, occurred_at, created_at
+		FROM incident_timeline_entries
+		WHERE log_id = @logID AND user_id = @userID
+		ORDER BY occurred_at ASC, created_at ASC
+	`
+
+	args := pgx.NamedArgs{
+		"logID":  logID,
+		"userID": userID,
+	}
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list incident timeline: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]model.IncidentTimelineEntry, 0)
+	for rows.Next() {
+		var entry model.IncidentTimelineEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.LogID,
+			&entry.UserID,
+			&entry.Status,
+			&entry.Note,
+			&entry.OccurredAt,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan incident timeline entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate incident timeline: %w", err)
+	}
+
+	return entries, nil
+}
+
+// AddTimelineEntry records a timeline entry and, when the entry carries a status,
+// moves the incident to that status in the same transaction.
+// Transition rules are enforced by the service layer.
+func (r *IncidentRepository) AddTimelineEntry(ctx context.Context, userID string, logID uuid.UUID, req *model.CreateTimelineEntryRequest, occurredAt time.Time) (*model.IncidentTimelineEntry, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin timeline transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"logID":      logID,
+		"userID":     userID,
+		"status":     req.Status,
+		"note":       req.Note,
+		"occurredAt": occurredAt,
+	}
+
+	if req.Status != nil {
+		var setClause string
+		switch *req.Status {
+		case model.IncidentStatusMitigated:
+			setClause = "mitigated_at = @occurredAt"
+		case model.IncidentStatusResolved:
+			setClause = "resolved_at = @occurredAt"
+		case model.IncidentStatusOpen:
+			// Reopening discards the previous mitigation and resolution
+			setClause = "mitigated_at = NULL, resolved_at = NULL"
+		}
+
+		query := fmt.Sprintf(`
+			UPDATE asset_logs
+			SET incident_status = @status, %s
+			WHERE id = @logID AND user_id = @userID AND kind = 'incident'
+		`, setClause)
+
+		result, err := tx.Exec(ctx, query, args)
+		if err != nil {
+			return nil, fmt.Errorf("transition incident: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return nil, errs.NewNotFoundError("incident not found", false, nil)
+		}
+	}
+
+	query := `
+		INSERT INTO incident_timeline_entries (log_id, user_id, status, note, occurred_at)
+		VALUES (@logID, @userID, @status, @note, @occurredAt)
+		RETURNING id, log_id, user_id, status, note, occurred_at, created_at
+	`
+
+	var entry model.IncidentTimelineEntry
+	err = tx.QueryRow(ctx, query, args).Scan(
+		&entry.ID,
+		&entry.LogID,
+		&entry.UserID,
+		&entry.Status,
+		&entry.Note,
+		&entry.OccurredAt,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create incident timeline entry: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit timeline transaction: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// ListIncidents returns every incident that overlaps the [start, end] window,
+// joined with its asset. Open incidents overlap until they are resolved.
+// With assetID, incidents linked to that asset count too and are attributed
+// to it rather than to their primary asset; assetType filters on the asset
+// each incident is attributed to.
+func (r *IncidentRepository) ListIncidents(ctx context.Context, userID string, start, end time.Time, assetID *uuid.UUID, assetType *string) ([]model.IncidentRecord, error) {
+	clauses := []string{
+		"l.user_id = @userID",
+		"l.kind = 'incident'",
+		"l.started_at <= @end",
+		"(l.resolved_at IS NULL OR l.resolved_at >= @start)",
+	}
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"start":  start,
+		"end":    end,
+	}
+
+	assetJoin := "a.id = l.asset_id"
+	if assetID != nil {
+		assetJoin = "a.id = @assetID"
+		clauses = append(clauses, "(l.asset_id = @assetID OR EXISTS (SELECT 1 FROM log_assets la WHERE la.log_id = l.id AND la.asset_id = @assetID))")
+		args["assetID"] = *assetID
+	}
+
+	if assetType != nil {
+		clauses = append(clauses, "a.type = @type")
+		args["type"] = *assetType
+	}
+
+	query := fmt.Sprintf(`
+		SELECT l.id, a.id, a.name, a.type, l.severity,
+			COALESCE(l.incident_status, 'open'), l.started_at, l.resolved_at
+		FROM asset_logs l
+		JOIN assets a ON %s AND a.user_id = l.user_id
+		WHERE %s
+		ORDER BY l.started_at ASC
+	`, assetJoin, strings.Join(clauses, " AND "))
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list incidents: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := make([]model.IncidentRecord, 0)
+	for rows.Next() {
+		var incident model.IncidentRecord
+		err := rows.Scan(
+			&incident.LogID,
+			&incident.AssetID,
+			&incident.AssetName,
+			&incident.AssetType,
+			&incident.Severity,
+			&incident.Status,
+			&incident.StartedAt,
+			&incident.ResolvedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate incidents: %w", err)
+	}
+
+	return incidents, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ark/internal/model"
+	testingPkg "ark/internal/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ========== ListIncidents Tests ==========
+
+// Test 1: TestIncidentRepository_ListIncidents_LinkedAsset
+func TestIncidentRepository_ListIncidents_LinkedAsset(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	logRepo := NewLogRepository(testDB.Pool)
+	repo := NewIncidentRepository(testDB.Pool)
+
+	userID := "test-user-incidents"
+	routerID := uuid.New()
+	nasID := uuid.New()
+	_, err := testDB.Pool.Exec(ctx, `
+		INSERT INTO assets (id, user_id, name, type)
+		VALUES ($1, $3, 'router', 'network'), ($2, $3, 'nas', 'storage')
+	`, routerID, nasID, userID)
+	require.NoError(t, err)
+
+	// The incident is logged on the router and linked to the NAS
+	startedAt := time.Now().Add(-2 * time.Hour)
+	incident, err := logRepo.Create(ctx, userID, routerID, &model.CreateLogRequest{
+		Kind:           model.LogKindIncident,
+		Content:        "Uplink down, NAS unreachable",
+		Severity:       testingPkg.Ptr(model.SeverityHigh),
+		StartedAt:      &startedAt,
+		LinkedAssetIDs: []uuid.UUID{nasID},
+	})
+	require.NoError(t, err)
+
+	start := startedAt.Add(-time.Hour)
+	end := time.Now()
+
+	// Filtered by the linked asset, the incident is attributed to it
+	incidents, err := repo.ListIncidents(ctx, userID, start, end, &nasID, nil)
+	require.NoError(t, err)
+	require.Len(t, incidents, 1)
+	assert.Equal(t, incident.ID, incidents[0].LogID)
+	assert.Equal(t, nasID, incidents[0].AssetID)
+	assert.Equal(t, "nas", incidents[0].AssetName)
+	assert.Equal(t, "storage", *incidents[0].AssetType)
+
+	// The type filter applies to the attributed asset
+	incidents, err = repo.ListIncidents(ctx, userID, start, end, &nasID, testingPkg.Ptr("network"))
+	require.NoError(t, err)
+	assert.Empty(t, incidents)
+
+	// Unfiltered, the incident belongs to its primary asset
+	incidents, err = repo.ListIncidents(ctx, userID, start, end, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, incidents, 1)
+	assert.Equal(t, routerID, incidents[0].AssetID)
+	assert.Equal(t, "router", incidents[0].AssetName)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -25,12 +27,95 @@ func NewLogRepository(db *pgxpool.Pool) *LogRepository {
 	return &LogRepository{db: db}
 }
 
+// logColumns is the column list selected for every AssetLog query.
+// It must stay in sync with scanLog. Linked assets come from a subquery
+// so a log is always read in one row.
+const logColumns = `id, asset_id, user_id, kind, content, tags,
+		severity, started_at, resolved_at, root_cause,
+		incident_status, mitigated_at,
+		planned, rollback_notes, duration_minutes,
+		created_at, updated_at,
+		parent_log_id, thread_root_id, pinned, favorite,
+		COALESCE((SELECT array_agg(la.asset_id::text ORDER BY la.asset_id) FROM log_assets la WHERE la.log_id = asset_logs.id), '{}')`
+
+// rowScanner is satisfied by both pgx.Row and pgx.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// extraScanner appends destinations for columns selected after logColumns,
+// so scanLog can read rows that carry extra aggregate columns
+type extraScanner struct {
+	row   rowScanner
+	extra []any
+}
+
+func (s extraScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+// scanLog scans a row selected with logColumns into an AssetLog
+func scanLog(row rowScanner) (*model.AssetLog, error) {
+	var log model.AssetLog
+	var linkedAssetIDs []string
+	err := row.Scan(
+		&log.ID,
+		&log.AssetID,
+		&log.UserID,
+		&log.Kind,
+		&log.Content,
+		&log.Tags, // pgx handles []string ↔ text[] automatically
+		&log.Severity,
+		&log.StartedAt,
+		&log.ResolvedAt,
+		&log.RootCause,
+		&log.IncidentStatus,
+		&log.MitigatedAt,
+		&log.Planned,
+		&log.RollbackNotes,
+		&log.DurationMinutes,
+		&log.CreatedAt,
+		&log.UpdatedAt,
+		&log.ParentLogID,
+		&log.ThreadRootID,
+		&log.Pinned,
+		&log.Favorite,
+		&linkedAssetIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.LinkedAssetIDs = make([]uuid.UUID, 0, len(linkedAssetIDs))
+	for _, id := range linkedAssetIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("parse linked asset id: %w", err)
+		}
+		log.LinkedAssetIDs = append(log.LinkedAssetIDs, parsed)
+	}
+
+	return &log, nil
+}
+
 // GetByID retrieves a single log by ID for the specified user.
 // Returns NotFoundError if the log doesn't exist or belongs to another user.
 // Note: Only user_id is checked (not asset_id) since log ID is globally unique.
 func (r *LogRepository) GetByID(ctx context.Context, userID string, logID uuid.UUID) (*model.AssetLog, error) {
+	return getLog(ctx, r.db, userID, logID)
+}
+
+// getLog reads a log using q
+func getLog(ctx context.Context, q querier, userID string, logID uuid.UUID) (*model.AssetLog, error) {
 	query := `
-		SELECT id, asset_id, user_id, content, tags, created_at, updated_at
+		SELECT ` + logColumns + `
 		FROM asset_logs
 		WHERE id = @logID AND user_id = @userID
 	`
@@ -40,17 +125,7 @@ func (r *LogRepository) GetByID(ctx context.Context, userID string, logID uuid.U
 		"userID": userID,
 	}
 
-	var log model.AssetLog
-	err := r.db.QueryRow(ctx, query, args).Scan(
-		&log.ID,
-		&log.AssetID,
-		&log.UserID,
-		&log.Content,
-		&log.Tags, // pgx handles []string ↔ text[] automatically
-		&log.CreatedAt,
-		&log.UpdatedAt,
-	)
-
+	log, err := scanLog(q.QueryRow(ctx, query, args))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.NewNotFoundError("log not found", false, nil)
@@ -58,19 +133,47 @@ func (r *LogRepository) GetByID(ctx context.Context, userID string, logID uuid.U
 		return nil, fmt.Errorf("get log by id: %w", err)
 	}
 
-	return &log, nil
+	return log, nil
 }
 
-// buildLogWhereClause builds dynamic WHERE clause for ListByAsset/CountByAsset with filters
+// buildLogWhereClause builds dynamic WHERE clause for ListByAsset/CountByAsset with filters.
+// A log belongs to the asset when it is the primary asset or a linked one.
 func buildLogWhereClause(params *model.LogQueryParams, args pgx.NamedArgs) string {
-	clauses := []string{"user_id = @userID", "asset_id = @assetID"}
+	clauses := []string{
+		"user_id = @userID",
+		"(asset_id = @assetID OR EXISTS (SELECT 1 FROM log_assets la WHERE la.log_id = asset_logs.id AND la.asset_id = @assetID))",
+	}
 
-	// Tags filter: log must have ALL specified tags (AND logic)
+	// Tags filters. tags and tags_all require every tag (@>), tags_any at least
+	// one (&&); both can use the partial GIN index idx_asset_logs_tags, so they
+	// state its tags IS NOT NULL predicate explicitly. tags_none excludes logs
+	// carrying any of the tags and keeps logs without tags.
 	if len(params.Tags) > 0 {
-		clauses = append(clauses, "tags @> @tags::text[]")
+		clauses = append(clauses, "tags IS NOT NULL AND tags @> @tags::text[]")
 		args["tags"] = params.Tags
 	}
 
+	if len(params.TagsAll) > 0 {
+		clauses = append(clauses, "tags IS NOT NULL AND tags @> @tagsAll::text[]")
+		args["tagsAll"] = params.TagsAll
+	}
+
+	if len(params.TagsAny) > 0 {
+		clauses = append(clauses, "tags IS NOT NULL AND tags && @tagsAny::text[]")
+		args["tagsAny"] = params.TagsAny
+	}
+
+	if len(params.TagsNone) > 0 {
+		clauses = append(clauses, "(tags IS NULL OR NOT tags && @tagsNone::text[])")
+		args["tagsNone"] = params.TagsNone
+	}
+
+	// Kind filter
+	if params.Kind != nil {
+		clauses = append(clauses, "kind = @kind")
+		args["kind"] = *params.Kind
+	}
+
 	// Content search (case-insensitive)
 	if params.Search != nil {
 		searchPattern := "%" + *params.Search + "%"
@@ -90,6 +193,16 @@ func buildLogWhereClause(params *model.LogQueryParams, args pgx.NamedArgs) strin
 		args["endDate"] = *params.EndDate
 	}
 
+	if params.Favorite != nil {
+		clauses = append(clauses, "favorite = @favorite")
+		args["favorite"] = *params.Favorite
+	}
+
+	if params.CreatedWithinDays != nil {
+		clauses = append(clauses, "created_at >= now() - make_interval(days => @createdWithinDays)")
+		args["createdWithinDays"] = *params.CreatedWithinDays
+	}
+
 	return "WHERE " + strings.Join(clauses, " AND ")
 }
 
@@ -124,14 +237,19 @@ func (r *LogRepository) ListByAsset(ctx context.Context, userID string, assetID
 	}
 	whereClause := buildLogWhereClause(params, args)
 
-	// Build complete query with ORDER BY and LIMIT/OFFSET
+	if params.CollapseThreads {
+		return r.listThreadRoots(ctx, whereClause, args, params)
+	}
+
+	// Build complete query with ORDER BY and LIMIT/OFFSET; pinned logs come
+	// first whatever the sort
 	query := fmt.Sprintf(`
-		SELECT id, asset_id, user_id, content, tags, created_at, updated_at
+		SELECT %s
 		FROM asset_logs
 		%s
-		ORDER BY %s %s
+		ORDER BY pinned DESC, %s %s
 		LIMIT @limit OFFSET @offset
-	`, whereClause, params.SortBy, params.SortOrder)
+	`, logColumns, whereClause, params.SortBy, params.SortOrder)
 
 	rows, err := r.db.Query(ctx, query, args)
 	if err != nil {
@@ -141,20 +259,51 @@ func (r *LogRepository) ListByAsset(ctx context.Context, userID string, assetID
 
 	logs := make([]*model.AssetLog, 0)
 	for rows.Next() {
-		var log model.AssetLog
-		err := rows.Scan(
-			&log.ID,
-			&log.AssetID,
-			&log.UserID,
-			&log.Content,
-			&log.Tags,
-			&log.CreatedAt,
-			&log.UpdatedAt,
-		)
+		log, err := scanLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan log: %w", err)
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// listThreadRoots lists the root of every thread with a log matching whereClause,
+// with the thread's reply count and last activity
+func (r *LogRepository) listThreadRoots(ctx context.Context, whereClause string, args pgx.NamedArgs, params *model.LogQueryParams) ([]*model.AssetLog, error) {
+	query := fmt.Sprintf(`
+		SELECT %s,
+			(SELECT count(*) FROM asset_logs r WHERE r.thread_root_id = asset_logs.id),
+			GREATEST(updated_at, (SELECT max(r.updated_at) FROM asset_logs r WHERE r.thread_root_id = asset_logs.id))
+		FROM asset_logs
+		WHERE user_id = @userID
+			AND id IN (SELECT COALESCE(thread_root_id, id) FROM asset_logs %s)
+		ORDER BY pinned DESC, %s %s
+		LIMIT @limit OFFSET @offset
+	`, logColumns, whereClause, params.SortBy, params.SortOrder)
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list log threads by asset: %w", err)
+	}
+	defer rows.Close()
+
+	logs := make([]*model.AssetLog, 0)
+	for rows.Next() {
+		var replyCount int
+		var lastActivityAt time.Time
+		log, err := scanLog(extraScanner{row: rows, extra: []any{&replyCount, &lastActivityAt}})
 		if err != nil {
 			return nil, fmt.Errorf("scan log: %w", err)
 		}
-		logs = append(logs, &log)
+		log.ReplyCount = &replyCount
+		log.LastActivityAt = &lastActivityAt
+		logs = append(logs, log)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -164,7 +313,8 @@ func (r *LogRepository) ListByAsset(ctx context.Context, userID string, assetID
 	return logs, nil
 }
 
-// CountByAsset returns the total number of logs matching the filters for a specific asset
+// CountByAsset returns the total number of logs matching the filters for a specific asset.
+// With collapsed threads it counts threads instead of logs.
 func (r *LogRepository) CountByAsset(ctx context.Context, userID string, assetID uuid.UUID, params *model.LogQueryParams) (int64, error) {
 	// Build WHERE clause (same logic as ListByAsset)
 	args := pgx.NamedArgs{
@@ -173,11 +323,16 @@ func (r *LogRepository) CountByAsset(ctx context.Context, userID string, assetID
 	}
 	whereClause := buildLogWhereClause(params, args)
 
+	countExpr := "COUNT(*)"
+	if params.CollapseThreads {
+		countExpr = "COUNT(DISTINCT COALESCE(thread_root_id, id))"
+	}
+
 	query := fmt.Sprintf(`
-		SELECT COUNT(*)
+		SELECT %s
 		FROM asset_logs
 		%s
-	`, whereClause)
+	`, countExpr, whereClause)
 
 	var count int64
 	err := r.db.QueryRow(ctx, query, args).Scan(&count)
@@ -188,33 +343,152 @@ func (r *LogRepository) CountByAsset(ctx context.Context, userID string, assetID
 	return count, nil
 }
 
-// Create inserts a new log for an asset
-// Returns NotFoundError if the asset doesn't exist or doesn't belong to the user
+// Create inserts a new log for an asset together with its linked assets
+// Returns NotFoundError if an asset doesn't exist or doesn't belong to the user
 func (r *LogRepository) Create(ctx context.Context, userID string, assetID uuid.UUID, req *model.CreateLogRequest) (*model.AssetLog, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin create log transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	log, err := insertLog(ctx, tx, userID, assetID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit create log transaction: %w", err)
+	}
+
+	return log, nil
+}
+
+// ListThread returns the replies in a thread, oldest first
+func (r *LogRepository) ListThread(ctx context.Context, userID string, rootID uuid.UUID) ([]*model.AssetLog, error) {
 	query := `
-		INSERT INTO asset_logs (asset_id, user_id, content, tags)
-		VALUES (@assetID, @userID, @content, @tags)
-		RETURNING id, asset_id, user_id, content, tags, created_at, updated_at
+		SELECT ` + logColumns + `
+		FROM asset_logs
+		WHERE user_id = @userID AND thread_root_id = @rootID
+		ORDER BY created_at ASC, id ASC
 	`
 
 	args := pgx.NamedArgs{
-		"assetID": assetID,
-		"userID":  userID,
-		"content": req.Content,
-		"tags":    req.Tags, // nil becomes NULL, []string{} becomes empty array
+		"userID": userID,
+		"rootID": rootID,
 	}
 
-	var log model.AssetLog
-	err := r.db.QueryRow(ctx, query, args).Scan(
-		&log.ID,
-		&log.AssetID,
-		&log.UserID,
-		&log.Content,
-		&log.Tags,
-		&log.CreatedAt,
-		&log.UpdatedAt,
-	)
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list log thread: %w", err)
+	}
+	defer rows.Close()
+
+	logs := make([]*model.AssetLog, 0)
+	for rows.Next() {
+		log, err := scanLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan log: %w", err)
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// replaceLogAssets replaces the linked assets of a log. Assets that don't
+// belong to the user are reported as not found.
+func replaceLogAssets(ctx context.Context, q querier, userID string, logID uuid.UUID, assetIDs []uuid.UUID) error {
+	if _, err := q.Exec(ctx, `DELETE FROM log_assets WHERE log_id = @logID`, pgx.NamedArgs{"logID": logID}); err != nil {
+		return fmt.Errorf("delete log assets: %w", err)
+	}
+
+	if len(assetIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO log_assets (log_id, asset_id, user_id)
+		SELECT @logID, a.id, @userID
+		FROM assets a
+		WHERE a.id = ANY(@assetIDs::uuid[]) AND a.user_id = @userID
+		ON CONFLICT DO NOTHING
+	`
+
+	args := pgx.NamedArgs{
+		"logID":    logID,
+		"userID":   userID,
+		"assetIDs": uuidStrings(assetIDs),
+	}
 
+	result, err := q.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("insert log assets: %w", err)
+	}
+
+	if result.RowsAffected() != int64(len(assetIDs)) {
+		return errs.NewNotFoundError("asset not found", false, nil)
+	}
+
+	return nil
+}
+
+// insertLog inserts a log and its linked assets using q, so other repositories
+// can record logs inside their own transactions. req.LinkedAssetIDs must not
+// contain duplicates or the primary asset.
+func insertLog(ctx context.Context, q querier, userID string, assetID uuid.UUID, req *model.CreateLogRequest) (*model.AssetLog, error) {
+	query := `
+		INSERT INTO asset_logs (
+			asset_id, user_id, kind, content, tags,
+			severity, started_at, resolved_at, root_cause, incident_status,
+			planned, rollback_notes, duration_minutes,
+			parent_log_id, thread_root_id,
+			pinned, favorite
+		)
+		VALUES (
+			@assetID, @userID, @kind, @content, @tags,
+			@severity, @startedAt, @resolvedAt, @rootCause, @incidentStatus,
+			@planned, @rollbackNotes, @durationMinutes,
+			@parentLogID, (SELECT COALESCE(p.thread_root_id, p.id) FROM asset_logs p WHERE p.id = @parentLogID AND p.user_id = @userID),
+			@pinned, @favorite
+		)
+		RETURNING ` + logColumns
+
+	kind := req.Kind
+	if kind == "" {
+		kind = model.LogKindNote
+	}
+
+	var incidentStatus *string
+	if kind == model.LogKindIncident {
+		status := model.InitialIncidentStatus(req.ResolvedAt)
+		incidentStatus = &status
+	}
+
+	args := pgx.NamedArgs{
+		"assetID":         assetID,
+		"userID":          userID,
+		"kind":            kind,
+		"content":         req.Content,
+		"tags":            req.Tags, // nil becomes NULL, []string{} becomes empty array
+		"severity":        req.Severity,
+		"startedAt":       req.StartedAt,
+		"resolvedAt":      req.ResolvedAt,
+		"rootCause":       req.RootCause,
+		"incidentStatus":  incidentStatus,
+		"planned":         req.Planned,
+		"rollbackNotes":   req.RollbackNotes,
+		"durationMinutes": req.DurationMinutes,
+		"parentLogID":     req.ParentLogID,
+		"pinned":          req.Pinned,
+		"favorite":        req.Favorite,
+	}
+
+	log, err := scanLog(q.QueryRow(ctx, query, args))
 	if err != nil {
 		// Check for foreign key violation (asset doesn't exist or doesn't belong to user)
 		var pgErr *pgconn.PgError
@@ -224,7 +498,39 @@ func (r *LogRepository) Create(ctx context.Context, userID string, assetID uuid.
 		return nil, fmt.Errorf("create log: %w", err)
 	}
 
-	return &log, nil
+	if len(req.LinkedAssetIDs) > 0 {
+		if err := replaceLogAssets(ctx, q, userID, log.ID, req.LinkedAssetIDs); err != nil {
+			return nil, err
+		}
+		log.LinkedAssetIDs = sortedUUIDs(req.LinkedAssetIDs)
+	}
+
+	return log, nil
+}
+
+// sortedUUIDs returns a sorted copy of ids, matching the order logColumns reads them in
+func sortedUUIDs(ids []uuid.UUID) []uuid.UUID {
+	sorted := append([]uuid.UUID(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+	return sorted
+}
+
+// kindSpecificColumns lists each kind-specific column with the kind that owns it
+var kindSpecificColumns = []struct {
+	column string
+	kind   string
+}{
+	{"severity", model.LogKindIncident},
+	{"started_at", model.LogKindIncident},
+	{"resolved_at", model.LogKindIncident},
+	{"root_cause", model.LogKindIncident},
+	{"incident_status", model.LogKindIncident},
+	{"mitigated_at", model.LogKindIncident},
+	{"planned", model.LogKindChange},
+	{"rollback_notes", model.LogKindChange},
+	{"duration_minutes", model.LogKindMaintenance},
 }
 
 // buildLogUpdateSetClause builds dynamic SET clause for Update
@@ -241,11 +547,84 @@ func buildLogUpdateSetClause(req *model.UpdateLogRequest, args pgx.NamedArgs) st
 		args["tags"] = *req.Tags
 	}
 
+	if req.Kind != nil {
+		setClauses = append(setClauses, "kind = @kind")
+		args["kind"] = *req.Kind
+	}
+
+	if req.Severity != nil {
+		setClauses = append(setClauses, "severity = @severity")
+		args["severity"] = *req.Severity
+	}
+
+	if req.StartedAt != nil {
+		setClauses = append(setClauses, "started_at = @startedAt")
+		args["startedAt"] = *req.StartedAt
+	}
+
+	if req.ResolvedAt != nil {
+		setClauses = append(setClauses, "resolved_at = @resolvedAt")
+		args["resolvedAt"] = *req.ResolvedAt
+	}
+
+	// A log converted to an incident starts its lifecycle like a new one.
+	// Later status changes go through the incident timeline.
+	if req.Kind != nil && *req.Kind == model.LogKindIncident {
+		setClauses = append(setClauses, "incident_status = COALESCE(incident_status, @incidentStatus)")
+		args["incidentStatus"] = model.InitialIncidentStatus(req.ResolvedAt)
+	}
+
+	if req.RootCause != nil {
+		setClauses = append(setClauses, "root_cause = @rootCause")
+		args["rootCause"] = *req.RootCause
+	}
+
+	if req.Planned != nil {
+		setClauses = append(setClauses, "planned = @planned")
+		args["planned"] = *req.Planned
+	}
+
+	if req.RollbackNotes != nil {
+		setClauses = append(setClauses, "rollback_notes = @rollbackNotes")
+		args["rollbackNotes"] = *req.RollbackNotes
+	}
+
+	if req.DurationMinutes != nil {
+		setClauses = append(setClauses, "duration_minutes = @durationMinutes")
+		args["durationMinutes"] = *req.DurationMinutes
+	}
+
+	if req.Pinned != nil {
+		setClauses = append(setClauses, "pinned = @pinned")
+		args["pinned"] = *req.Pinned
+	}
+
+	if req.Favorite != nil {
+		setClauses = append(setClauses, "favorite = @favorite")
+		args["favorite"] = *req.Favorite
+	}
+
+	// Changing kind clears fields that belong to other kinds
+	if req.Kind != nil {
+		for _, c := range kindSpecificColumns {
+			if c.kind != *req.Kind {
+				setClauses = append(setClauses, c.column+" = NULL")
+			}
+		}
+	}
+
 	return strings.Join(setClauses, ", ")
 }
 
-// Update modifies an existing log (only non-nil fields are updated)
+// Update modifies an existing log (only non-nil fields are updated).
+// Linked assets are replaced in the same transaction when set.
 func (r *LogRepository) Update(ctx context.Context, userID string, logID uuid.UUID, req *model.UpdateLogRequest) (*model.AssetLog, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin update log transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	// Build SET clause dynamically based on non-nil fields
 	args := pgx.NamedArgs{
 		"logID":  logID,
@@ -257,28 +636,32 @@ func (r *LogRepository) Update(ctx context.Context, userID string, logID uuid.UU
 		UPDATE asset_logs
 		SET %s
 		WHERE id = @logID AND user_id = @userID
-		RETURNING id, asset_id, user_id, content, tags, created_at, updated_at
 	`, setClause)
 
-	var log model.AssetLog
-	err := r.db.QueryRow(ctx, query, args).Scan(
-		&log.ID,
-		&log.AssetID,
-		&log.UserID,
-		&log.Content,
-		&log.Tags,
-		&log.CreatedAt,
-		&log.UpdatedAt,
-	)
-
+	result, err := tx.Exec(ctx, query, args)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errs.NewNotFoundError("log not found", false, nil)
-		}
 		return nil, fmt.Errorf("update log: %w", err)
 	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.NewNotFoundError("log not found", false, nil)
+	}
 
-	return &log, nil
+	if req.LinkedAssetIDs != nil {
+		if err := replaceLogAssets(ctx, tx, userID, logID, *req.LinkedAssetIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	log, err := getLog(ctx, tx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit update log transaction: %w", err)
+	}
+
+	return log, nil
 }
 
 // Delete removes a log for a user
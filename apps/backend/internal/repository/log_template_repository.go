@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// LogTemplateRepository provides data access for the log_templates table.
+// All methods enforce user isolation.
+type LogTemplateRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewLogTemplateRepository creates a new LogTemplateRepository with the given database pool.
+func NewLogTemplateRepository(db *pgxpool.Pool) *LogTemplateRepository {
+	return &LogTemplateRepository{db: db}
+}
+
+// logTemplateColumns is the column list scanned by scanLogTemplate
+const logTemplateColumns = `id, user_id, name, description, kind, content, tags, placeholders, created_at, updated_at`
+
+// scanLogTemplate scans a row selected with logTemplateColumns
+func scanLogTemplate(row rowScanner) (*model.LogTemplate, error) {
+	var template model.LogTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.UserID,
+		&template.Name,
+		&template.Description,
+		&template.Kind,
+		&template.Content,
+		&template.Tags,
+		&template.Placeholders,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.Placeholders == nil {
+		template.Placeholders = []model.TemplatePlaceholder{}
+	}
+
+	return &template, nil
+}
+
+// marshalPlaceholders encodes placeholders for the JSONB column
+func marshalPlaceholders(placeholders []model.TemplatePlaceholder) ([]byte, error) {
+	if placeholders == nil {
+		placeholders = []model.TemplatePlaceholder{}
+	}
+	data, err := json.Marshal(placeholders)
+	if err != nil {
+		return nil, fmt.Errorf("marshal placeholders: %w", err)
+	}
+	return data, nil
+}
+
+// logTemplateWriteError maps a duplicate name to a validation error
+func logTemplateWriteError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "name", Error: "a template with this name already exists"},
+		}, nil)
+	}
+	return fmt.Errorf("%s log template: %w", op, err)
+}
+
+func (r *LogTemplateRepository) GetByID(ctx context.Context, userID string, templateID uuid.UUID) (*model.LogTemplate, error) {
+	query := `
+		SELECT ` + logTemplateColumns + `
+		FROM log_templates
+		WHERE id = @templateID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"templateID": templateID,
+		"userID":     userID,
+	}
+
+	template, err := scanLogTemplate(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("log template not found", false, nil)
+		}
+		return nil, fmt.Errorf("get log template: %w", err)
+	}
+
+	return template, nil
+}
+
+// List returns the user's log templates ordered by name
+func (r *LogTemplateRepository) List(ctx context.Context, userID string) ([]*model.LogTemplate, error) {
+	query := `
+		SELECT ` + logTemplateColumns + `
+		FROM log_templates
+		WHERE user_id = @userID
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID})
+	if err != nil {
+		return nil, fmt.Errorf("list log templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]*model.LogTemplate, 0)
+	for rows.Next() {
+		template, err := scanLogTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan log template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate log templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (r *LogTemplateRepository) Create(ctx context.Context, userID string, req *model.CreateLogTemplateRequest) (*model.LogTemplate, error) {
+	placeholders, err := marshalPlaceholders(req.Placeholders)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO log_templates (user_id, name, description, kind, content, tags, placeholders)
+		VALUES (@userID, @name, @description, @kind, @content, @tags, @placeholders)
+		RETURNING ` + logTemplateColumns
+
+	args := pgx.NamedArgs{
+		"userID":       userID,
+		"name":         req.Name,
+		"description":  req.Description,
+		"kind":         req.Kind,
+		"content":      req.Content,
+		"tags":         req.Tags,
+		"placeholders": placeholders,
+	}
+
+	template, err := scanLogTemplate(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		return nil, logTemplateWriteError("create", err)
+	}
+
+	return template, nil
+}
+
+// Update applies a partial update to a log template
+func (r *LogTemplateRepository) Update(ctx context.Context, userID string, templateID uuid.UUID, req *model.UpdateLogTemplateRequest) (*model.LogTemplate, error) {
+	args := pgx.NamedArgs{
+		"templateID": templateID,
+		"userID":     userID,
+	}
+
+	var setClauses []string
+	if req.Name != nil {
+		setClauses = append(setClauses, "name = @name")
+		args["name"] = *req.Name
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *req.Description
+	}
+	if req.Kind != nil {
+		setClauses = append(setClauses, "kind = @kind")
+		args["kind"] = *req.Kind
+	}
+	if req.Content != nil {
+		setClauses = append(setClauses, "content = @content")
+		args["content"] = *req.Content
+	}
+	if req.Tags != nil {
+		setClauses = append(setClauses, "tags = @tags")
+		args["tags"] = *req.Tags
+	}
+	if req.Placeholders != nil {
+		placeholders, err := marshalPlaceholders(*req.Placeholders)
+		if err != nil {
+			return nil, err
+		}
+		setClauses = append(setClauses, "placeholders = @placeholders")
+		args["placeholders"] = placeholders
+	}
+
+	// updated_at is handled by the database trigger; touch a column so an empty update still returns the row
+	if len(setClauses) == 0 {
+		setClauses = append(setClauses, "name = name")
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE log_templates
+		SET %s
+		WHERE id = @templateID AND user_id = @userID
+		RETURNING %s
+	`, strings.Join(setClauses, ", "), logTemplateColumns)
+
+	template, err := scanLogTemplate(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("log template not found", false, nil)
+		}
+		return nil, logTemplateWriteError("update", err)
+	}
+
+	return template, nil
+}
+
+// Delete removes a log template. Logs created from it are unaffected.
+func (r *LogTemplateRepository) Delete(ctx context.Context, userID string, templateID uuid.UUID) error {
+	query := `
+		DELETE FROM log_templates
+		WHERE id = @templateID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"templateID": templateID,
+		"userID":     userID,
+	}
+
+	result, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("delete log template: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errs.NewNotFoundError("log template not found", false, nil)
+	}
+
+	return nil
+}
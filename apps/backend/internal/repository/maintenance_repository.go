@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// MaintenanceRepository provides data access for the maintenance_tasks table.
+// All user-facing methods enforce user isolation; the reminder scan methods
+// run across users on behalf of the background scheduler.
+type MaintenanceRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewMaintenanceRepository creates a new MaintenanceRepository with the given database pool.
+func NewMaintenanceRepository(db *pgxpool.Pool) *MaintenanceRepository {
+	return &MaintenanceRepository{db: db}
+}
+
+// maintenanceTaskColumns is the column list scanned by scanMaintenanceTask
+const maintenanceTaskColumns = `id, asset_id, user_id, title, description, recurrence,
+		starts_at, next_due_at, last_completed_at, last_reminded_at,
+		created_at, updated_at`
+
+// scanMaintenanceTask scans a row selected with maintenanceTaskColumns
+func scanMaintenanceTask(row rowScanner) (*model.MaintenanceTask, error) {
+	var task model.MaintenanceTask
+	err := row.Scan(
+		&task.ID,
+		&task.AssetID,
+		&task.UserID,
+		&task.Title,
+		&task.Description,
+		&task.Recurrence,
+		&task.StartsAt,
+		&task.NextDueAt,
+		&task.LastCompletedAt,
+		&task.LastRemindedAt,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *MaintenanceRepository) GetByID(ctx context.Context, userID string, taskID uuid.UUID) (*model.MaintenanceTask, error) {
+	query := `
+		SELECT ` + maintenanceTaskColumns + `
+		FROM maintenance_tasks
+		WHERE id = @taskID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"taskID": taskID,
+		"userID": userID,
+	}
+
+	task, err := scanMaintenanceTask(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("maintenance task not found", false, nil)
+		}
+		return nil, fmt.Errorf("get maintenance task by id: %w", err)
+	}
+
+	return task, nil
+}
+
+// List returns the user's maintenance tasks ordered by due date, optionally for a
+// single asset. Finished tasks (no next due date) sort last.
+func (r *MaintenanceRepository) List(ctx context.Context, userID string, assetID *uuid.UUID, params *model.MaintenanceTaskQueryParams, now time.Time) ([]*model.MaintenanceTask, error) {
+	clauses := []string{"user_id = @userID"}
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	if assetID != nil {
+		clauses = append(clauses, "asset_id = @assetID")
+		args["assetID"] = *assetID
+	}
+
+	if params.Overdue != nil {
+		if *params.Overdue {
+			clauses = append(clauses, "next_due_at <= @now")
+		} else {
+			clauses = append(clauses, "(next_due_at IS NULL OR next_due_at > @now)")
+		}
+		args["now"] = now
+	}
+
+	if params.DueBy != nil {
+		clauses = append(clauses, "next_due_at <= @dueBy")
+		args["dueBy"] = *params.DueBy
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM maintenance_tasks
+		WHERE %s
+		ORDER BY next_due_at ASC NULLS LAST, title ASC
+	`, maintenanceTaskColumns, strings.Join(clauses, " AND "))
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list maintenance tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]*model.MaintenanceTask, 0)
+	for rows.Next() {
+		task, err := scanMaintenanceTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan maintenance task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate maintenance tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// Create inserts a maintenance task. The service resolves StartsAt and the first due date.
+func (r *MaintenanceRepository) Create(ctx context.Context, userID string, assetID uuid.UUID, req *model.CreateMaintenanceTaskRequest, nextDueAt *time.Time) (*model.MaintenanceTask, error) {
+	query := `
+		INSERT INTO maintenance_tasks (asset_id, user_id, title, description, recurrence, starts_at, next_due_at)
+		VALUES (@assetID, @userID, @title, @description, @recurrence, @startsAt, @nextDueAt)
+		RETURNING ` + maintenanceTaskColumns
+
+	args := pgx.NamedArgs{
+		"assetID":     assetID,
+		"userID":      userID,
+		"title":       req.Title,
+		"description": req.Description,
+		"recurrence":  req.Recurrence,
+		"startsAt":    req.StartsAt,
+		"nextDueAt":   nextDueAt,
+	}
+
+	task, err := scanMaintenanceTask(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		// Check for foreign key violation (asset doesn't exist)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return nil, errs.NewNotFoundError("asset not found", false, nil)
+		}
+		return nil, fmt.Errorf("create maintenance task: %w", err)
+	}
+
+	return task, nil
+}
+
+// buildMaintenanceUpdateSetClause builds the SET clause for a task update.
+// When the schedule changes (recurrence or starts_at), next_due_at is replaced
+// with the recomputed value and any pending reminder is reset.
+func buildMaintenanceUpdateSetClause(req *model.UpdateMaintenanceTaskRequest, nextDueAt *time.Time, args pgx.NamedArgs) string {
+	var setClauses []string
+
+	if req.Title != nil {
+		setClauses = append(setClauses, "title = @title")
+		args["title"] = *req.Title
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *req.Description
+	}
+	if req.Recurrence != nil {
+		setClauses = append(setClauses, "recurrence = @recurrence")
+		args["recurrence"] = *req.Recurrence
+	}
+	if req.StartsAt != nil {
+		setClauses = append(setClauses, "starts_at = @startsAt")
+		args["startsAt"] = *req.StartsAt
+	}
+	if req.Recurrence != nil || req.StartsAt != nil {
+		setClauses = append(setClauses, "next_due_at = @nextDueAt", "last_reminded_at = NULL")
+		args["nextDueAt"] = nextDueAt
+	}
+
+	// updated_at is handled by the database trigger; touch a column so an empty update still returns the row
+	if len(setClauses) == 0 {
+		setClauses = append(setClauses, "title = title")
+	}
+
+	return strings.Join(setClauses, ", ")
+}
+
+// Update applies a partial update. nextDueAt is only written when the schedule changes.
+func (r *MaintenanceRepository) Update(ctx context.Context, userID string, taskID uuid.UUID, req *model.UpdateMaintenanceTaskRequest, nextDueAt *time.Time) (*model.MaintenanceTask, error) {
+	args := pgx.NamedArgs{
+		"taskID": taskID,
+		"userID": userID,
+	}
+	setClause := buildMaintenanceUpdateSetClause(req, nextDueAt, args)
+
+	query := fmt.Sprintf(`
+		UPDATE maintenance_tasks
+		SET %s
+		WHERE id = @taskID AND user_id = @userID
+		RETURNING %s
+	`, setClause, maintenanceTaskColumns)
+
+	task, err := scanMaintenanceTask(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("maintenance task not found", false, nil)
+		}
+		return nil, fmt.Errorf("update maintenance task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (r *MaintenanceRepository) Delete(ctx context.Context, userID string, taskID uuid.UUID) error {
+	query := `
+		DELETE FROM maintenance_tasks
+		WHERE id = @taskID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"taskID": taskID,
+		"userID": userID,
+	}
+
+	result, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("delete maintenance task: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errs.NewNotFoundError("maintenance task not found", false, nil)
+	}
+
+	return nil
+}
+
+// Complete records the maintenance log and advances the task in one transaction,
+// so a task is never marked done without its log (or vice versa).
+func (r *MaintenanceRepository) Complete(ctx context.Context, userID string, task *model.MaintenanceTask, logReq *model.CreateLogRequest, completedAt time.Time, nextDueAt *time.Time) (*model.MaintenanceTask, *model.AssetLog, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin complete transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	log, err := insertLog(ctx, tx, userID, task.AssetID, logReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := `
+		UPDATE maintenance_tasks
+		SET last_completed_at = @completedAt, next_due_at = @nextDueAt, last_reminded_at = NULL
+		WHERE id = @taskID AND user_id = @userID
+		RETURNING ` + maintenanceTaskColumns
+
+	args := pgx.NamedArgs{
+		"taskID":      task.ID,
+		"userID":      userID,
+		"completedAt": completedAt,
+		"nextDueAt":   nextDueAt,
+	}
+
+	updated, err := scanMaintenanceTask(tx.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, errs.NewNotFoundError("maintenance task not found", false, nil)
+		}
+		return nil, nil, fmt.Errorf("advance maintenance task: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("commit complete transaction: %w", err)
+	}
+
+	return updated, log, nil
+}
+
+// ClaimDueReminders marks every overdue task that has not been reminded since it
+// became due as reminded, and returns them. Claiming and returning in one statement
+// means concurrent scans never remind about the same task twice.
+func (r *MaintenanceRepository) ClaimDueReminders(ctx context.Context, now time.Time) ([]model.MaintenanceReminder, error) {
+	query := `
+		UPDATE maintenance_tasks t
+		SET last_reminded_at = @now
+		FROM assets a
+		WHERE a.id = t.asset_id AND a.user_id = t.user_id
+			AND t.next_due_at <= @now
+			AND (t.last_reminded_at IS NULL OR t.last_reminded_at < t.next_due_at)
+		RETURNING t.id, t.user_id, t.title, a.name, t.next_due_at
+	`
+
+	args := pgx.NamedArgs{
+		"now": now,
+	}
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("claim maintenance reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders := make([]model.MaintenanceReminder, 0)
+	for rows.Next() {
+		var reminder model.MaintenanceReminder
+		err := rows.Scan(
+			&reminder.TaskID,
+			&reminder.UserID,
+			&reminder.Title,
+			&reminder.AssetName,
+			&reminder.NextDueAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan maintenance reminder: %w", err)
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate maintenance reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// ReleaseReminders clears the reminder claim on a user's tasks so the next
+// scan reminds about them again. It undoes ClaimDueReminders when the
+// reminder could not be sent.
+func (r *MaintenanceRepository) ReleaseReminders(ctx context.Context, userID string, taskIDs []uuid.UUID) error {
+	query := `
+		UPDATE maintenance_tasks
+		SET last_reminded_at = NULL
+		WHERE user_id = @userID AND id = ANY(@taskIDs::uuid[])
+	`
+
+	args := pgx.NamedArgs{
+		"userID":  userID,
+		"taskIDs": taskIDs,
+	}
+
+	if _, err := r.db.Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("release maintenance reminders: %w", err)
+	}
+
+	return nil
+}
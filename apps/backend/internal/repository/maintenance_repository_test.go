@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testingPkg "ark/internal/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertMaintenanceTask inserts a task due at dueAt on a new asset
+func insertMaintenanceTask(t *testing.T, testDB *testingPkg.TestDB, userID, title string, dueAt time.Time) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+
+	assetID := uuid.New()
+	_, err := testDB.Pool.Exec(ctx, `INSERT INTO assets (id, user_id, name) VALUES ($1, $2, $3)`, assetID, userID, title+" asset")
+	require.NoError(t, err)
+
+	taskID := uuid.New()
+	_, err = testDB.Pool.Exec(ctx, `
+		INSERT INTO maintenance_tasks (id, asset_id, user_id, title, recurrence, starts_at, next_due_at)
+		VALUES ($1, $2, $3, $4, 'FREQ=WEEKLY', $5, $5)
+	`, taskID, assetID, userID, title, dueAt)
+	require.NoError(t, err)
+
+	return taskID
+}
+
+// ========== ClaimDueReminders Tests ==========
+
+// Test 1: TestMaintenanceRepository_ClaimDueReminders_ClaimsOnce
+func TestMaintenanceRepository_ClaimDueReminders_ClaimsOnce(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewMaintenanceRepository(testDB.Pool)
+
+	now := time.Now()
+	overdueID := insertMaintenanceTask(t, testDB, "alice", "Scrub pool", now.Add(-time.Hour))
+	insertMaintenanceTask(t, testDB, "alice", "Rotate backups", now.Add(24*time.Hour))
+
+	reminders, err := repo.ClaimDueReminders(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, overdueID, reminders[0].TaskID)
+	assert.Equal(t, "alice", reminders[0].UserID)
+	assert.Equal(t, "Scrub pool asset", reminders[0].AssetName)
+
+	// A second scan finds nothing new to claim
+	reminders, err = repo.ClaimDueReminders(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+}
+
+// ========== ReleaseReminders Tests ==========
+
+// Test 2: TestMaintenanceRepository_ReleaseReminders_ReclaimedByNextScan
+func TestMaintenanceRepository_ReleaseReminders_ReclaimedByNextScan(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewMaintenanceRepository(testDB.Pool)
+
+	now := time.Now()
+	aliceID := insertMaintenanceTask(t, testDB, "alice", "Scrub pool", now.Add(-time.Hour))
+	bobID := insertMaintenanceTask(t, testDB, "bob", "Update firmware", now.Add(-time.Hour))
+
+	reminders, err := repo.ClaimDueReminders(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+
+	// Sending alice's reminder failed; bob's task ID is ignored for alice
+	err = repo.ReleaseReminders(ctx, "alice", []uuid.UUID{aliceID, bobID})
+	require.NoError(t, err)
+
+	reminders, err = repo.ClaimDueReminders(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, aliceID, reminders[0].TaskID)
+}
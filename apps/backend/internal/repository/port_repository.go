@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// PortRepository provides data access for the asset_ports table.
+// All methods enforce user isolation by filtering on user_id.
+type PortRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPortRepository creates a new PortRepository with the given database pool.
+func NewPortRepository(db *pgxpool.Pool) *PortRepository {
+	return &PortRepository{db: db}
+}
+
+// assetPortColumns is the column list scanned by scanAssetPort
+const assetPortColumns = `id, asset_id, user_id, port, protocol, service, description,
+		last_seen_at, created_at, updated_at`
+
+// scanAssetPort scans a row selected with assetPortColumns
+func scanAssetPort(row rowScanner) (*model.AssetPort, error) {
+	var port model.AssetPort
+	err := row.Scan(
+		&port.ID,
+		&port.AssetID,
+		&port.UserID,
+		&port.Port,
+		&port.Protocol,
+		&port.Service,
+		&port.Description,
+		&port.LastSeenAt,
+		&port.CreatedAt,
+		&port.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &port, nil
+}
+
+// portWriteError maps a port the asset already has to a validation error and
+// a missing asset to not found
+func portWriteError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+				{Field: "port", Error: "the asset already has this port and protocol"},
+			}, nil)
+		case "23503": // foreign_key_violation
+			return errs.NewNotFoundError("asset not found", false, nil)
+		}
+	}
+	return fmt.Errorf("%s asset port: %w", op, err)
+}
+
+func (r *PortRepository) GetByID(ctx context.Context, userID string, portID uuid.UUID) (*model.AssetPort, error) {
+	query := `
+		SELECT ` + assetPortColumns + `
+		FROM asset_ports
+		WHERE id = @portID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"portID": portID,
+		"userID": userID,
+	}
+
+	port, err := scanAssetPort(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("port not found", false, nil)
+		}
+		return nil, fmt.Errorf("get asset port by id: %w", err)
+	}
+
+	return port, nil
+}
+
+// ListByAssets returns the ports of the given assets ordered by asset, port
+// and protocol
+func (r *PortRepository) ListByAssets(ctx context.Context, userID string, assetIDs []uuid.UUID) ([]*model.AssetPort, error) {
+	query := `
+		SELECT ` + assetPortColumns + `
+		FROM asset_ports
+		WHERE user_id = @userID AND asset_id = ANY(@assetIDs)
+		ORDER BY asset_id, port, protocol
+	`
+
+	args := pgx.NamedArgs{
+		"userID":   userID,
+		"assetIDs": assetIDs,
+	}
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list asset ports: %w", err)
+	}
+	defer rows.Close()
+
+	ports := make([]*model.AssetPort, 0)
+	for rows.Next() {
+		port, err := scanAssetPort(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan asset port: %w", err)
+		}
+		ports = append(ports, port)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate asset ports: %w", err)
+	}
+
+	return ports, nil
+}
+
+// Create adds a port to an asset. The service resolves the default protocol.
+func (r *PortRepository) Create(ctx context.Context, userID string, assetID uuid.UUID, req *model.CreateAssetPortRequest) (*model.AssetPort, error) {
+	query := `
+		INSERT INTO asset_ports (asset_id, user_id, port, protocol, service, description)
+		VALUES (@assetID, @userID, @port, @protocol, @service, @description)
+		RETURNING ` + assetPortColumns
+
+	args := pgx.NamedArgs{
+		"assetID":     assetID,
+		"userID":      userID,
+		"port":        req.Port,
+		"protocol":    req.Protocol,
+		"service":     req.Service,
+		"description": req.Description,
+	}
+
+	port, err := scanAssetPort(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		return nil, portWriteError("create", err)
+	}
+
+	return port, nil
+}
+
+// buildPortUpdateSetClause builds the SET clause for a port update
+func buildPortUpdateSetClause(req *model.UpdateAssetPortRequest, args pgx.NamedArgs) string {
+	var setClauses []string
+
+	if req.Port != nil {
+		setClauses = append(setClauses, "port = @port")
+		args["port"] = *req.Port
+	}
+	if req.Protocol != nil {
+		setClauses = append(setClauses, "protocol = @protocol")
+		args["protocol"] = *req.Protocol
+	}
+	if req.Service != nil {
+		// An empty service clears it
+		setClauses = append(setClauses, "service = NULLIF(@service, '')")
+		args["service"] = *req.Service
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *req.Description
+	}
+
+	// updated_at is handled by the database trigger; touch a column so an empty update still returns the row
+	if len(setClauses) == 0 {
+		setClauses = append(setClauses, "port = port")
+	}
+
+	return strings.Join(setClauses, ", ")
+}
+
+func (r *PortRepository) Update(ctx context.Context, userID string, portID uuid.UUID, req *model.UpdateAssetPortRequest) (*model.AssetPort, error) {
+	args := pgx.NamedArgs{
+		"portID": portID,
+		"userID": userID,
+	}
+	setClause := buildPortUpdateSetClause(req, args)
+
+	query := fmt.Sprintf(`
+		UPDATE asset_ports
+		SET %s
+		WHERE id = @portID AND user_id = @userID
+		RETURNING %s
+	`, setClause, assetPortColumns)
+
+	port, err := scanAssetPort(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("port not found", false, nil)
+		}
+		return nil, portWriteError("update", err)
+	}
+
+	return port, nil
+}
+
+func (r *PortRepository) Delete(ctx context.Context, userID string, portID uuid.UUID) error {
+	query := `
+		DELETE FROM asset_ports
+		WHERE id = @portID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"portID": portID,
+		"userID": userID,
+	}
+
+	result, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("delete asset port: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errs.NewNotFoundError("port not found", false, nil)
+	}
+
+	return nil
+}
+
+// upsertPorts records ports a network scan found open on an asset at seenAt.
+// Ports the asset already has keep their service and description unless they
+// had no service.
+func upsertPorts(ctx context.Context, q querier, userID string, assetID uuid.UUID, ports []*model.CreateAssetPortRequest, seenAt time.Time) error {
+	query := `
+		INSERT INTO asset_ports (asset_id, user_id, port, protocol, service, last_seen_at)
+		VALUES (@assetID, @userID, @port, @protocol, @service, @seenAt)
+		ON CONFLICT (asset_id, port, protocol) DO UPDATE
+		SET service = COALESCE(asset_ports.service, EXCLUDED.service),
+			last_seen_at = GREATEST(asset_ports.last_seen_at, EXCLUDED.last_seen_at)
+	`
+
+	for _, port := range ports {
+		args := pgx.NamedArgs{
+			"assetID":  assetID,
+			"userID":   userID,
+			"port":     port.Port,
+			"protocol": port.Protocol,
+			"service":  port.Service,
+			"seenAt":   seenAt,
+		}
+		if _, err := q.Exec(ctx, query, args); err != nil {
+			return portWriteError("upsert", err)
+		}
+	}
+
+	return nil
+}
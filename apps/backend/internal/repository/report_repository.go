@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/model"
+)
+
+// ReportRepository provides read-only reporting queries across assets and logs.
+// All per-user methods enforce user isolation.
+type ReportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReportRepository creates a new ReportRepository with the given database pool.
+func NewReportRepository(db *pgxpool.Pool) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// assetActivityQuery selects each asset with its last log and last activity.
+// A log counts for its primary asset and for every asset it is linked to.
+// With perUser set, every table is filtered on @userID.
+func assetActivityQuery(perUser bool) string {
+	assetFilter, logFilter, linkFilter := "", "", ""
+	if perUser {
+		assetFilter = "WHERE a.user_id = @userID"
+		logFilter = "WHERE user_id = @userID"
+		linkFilter = "WHERE la.user_id = @userID"
+	}
+
+	return fmt.Sprintf(`
+		SELECT a.id, a.user_id, a.name, a.type, a.hostname, a.updated_at, l.last_log_at,
+			GREATEST(a.updated_at, COALESCE(l.last_log_at, a.updated_at)) AS last_activity_at
+		FROM assets a
+		LEFT JOIN (
+			SELECT x.asset_id, max(x.created_at) AS last_log_at
+			FROM (
+				SELECT asset_id, created_at FROM asset_logs %s
+				UNION ALL
+				SELECT la.asset_id, l.created_at
+				FROM log_assets la
+				JOIN asset_logs l ON l.id = la.log_id
+				%s
+			) x
+			GROUP BY x.asset_id
+		) l ON l.asset_id = a.id
+		%s
+	`, logFilter, linkFilter, assetFilter)
+}
+
+// StaleAssets returns the user's assets with no logs and no updates in the
+// last days days, stalest first, and how many there are in total. A non-nil
+// filter only reports the assets that match it.
+func (r *ReportRepository) StaleAssets(ctx context.Context, userID string, days, limit int, filter *model.AssetQueryParams) ([]model.StaleAsset, int64, error) {
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"days":   days,
+		"limit":  limit,
+	}
+
+	query := `
+		SELECT s.id, s.name, s.type, s.hostname, s.updated_at, s.last_log_at, s.last_activity_at,
+			floor(extract(epoch FROM now() - s.last_activity_at) / 86400)::int,
+			count(*) OVER ()
+		FROM (` + assetActivityQuery(true) + `) s
+		WHERE s.last_activity_at < now() - make_interval(days => @days)
+			` + assetFilterClause("s.id", filter, args) + `
+		ORDER BY s.last_activity_at ASC, s.name ASC
+		LIMIT @limit
+	`
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list stale assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]model.StaleAsset, 0)
+	var total int64
+	for rows.Next() {
+		var asset model.StaleAsset
+		err := rows.Scan(
+			&asset.ID,
+			&asset.Name,
+			&asset.Type,
+			&asset.Hostname,
+			&asset.UpdatedAt,
+			&asset.LastLogAt,
+			&asset.LastActivityAt,
+			&asset.DaysStale,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan stale asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate stale assets: %w", err)
+	}
+
+	return assets, total, nil
+}
+
+// ListUsersWithStaleAssets returns every user with at least one asset that has
+// had no logs and no updates in the last days days. Used by the weekly report job.
+func (r *ReportRepository) ListUsersWithStaleAssets(ctx context.Context, days int) ([]string, error) {
+	query := `
+		SELECT DISTINCT s.user_id
+		FROM (` + assetActivityQuery(false) + `) s
+		WHERE s.last_activity_at < now() - make_interval(days => @days)
+		ORDER BY s.user_id
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"days": days})
+	if err != nil {
+		return nil, fmt.Errorf("list users with stale assets: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scan user id: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate users with stale assets: %w", err)
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"ark/internal/model"
+	testingPkg "ark/internal/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertStaleAsset inserts an asset last updated days days ago
+func insertStaleAsset(t *testing.T, testDB *testingPkg.TestDB, userID, name, assetType string, days int) uuid.UUID {
+	t.Helper()
+
+	assetID := uuid.New()
+	_, err := testDB.Pool.Exec(context.Background(), `
+		INSERT INTO assets (id, user_id, name, type, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, now() - make_interval(days => $5), now() - make_interval(days => $5))
+	`, assetID, userID, name, assetType, days)
+	require.NoError(t, err)
+
+	return assetID
+}
+
+// ========== StaleAssets Tests ==========
+
+// Test 1: TestReportRepository_StaleAssets_StalestFirst
+func TestReportRepository_StaleAssets_StalestFirst(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewReportRepository(testDB.Pool)
+
+	oldestID := insertStaleAsset(t, testDB, "alice", "old-nas", "nas", 400)
+	olderID := insertStaleAsset(t, testDB, "alice", "old-switch", "switch", 200)
+	insertStaleAsset(t, testDB, "alice", "new-nas", "nas", 1)
+	insertStaleAsset(t, testDB, "bob", "bob-nas", "nas", 400)
+
+	assets, total, err := repo.StaleAssets(ctx, "alice", 90, 10, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, assets, 2)
+	assert.Equal(t, oldestID, assets[0].ID)
+	assert.Equal(t, olderID, assets[1].ID)
+}
+
+// Test 2: TestReportRepository_StaleAssets_Filter
+func TestReportRepository_StaleAssets_Filter(t *testing.T) {
+	testDB, _, cleanup := testingPkg.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewReportRepository(testDB.Pool)
+
+	nasID := insertStaleAsset(t, testDB, "alice", "old-nas", "nas", 400)
+	insertStaleAsset(t, testDB, "alice", "old-switch", "switch", 200)
+	insertStaleAsset(t, testDB, "bob", "bob-nas", "nas", 400)
+
+	// The filters of a saved asset view restrict the report
+	filter := &model.AssetQueryParams{Type: testingPkg.Ptr("nas")}
+	assets, total, err := repo.StaleAssets(ctx, "alice", 90, 10, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, assets, 1)
+	assert.Equal(t, nasID, assets[0].ID)
+}
@@ -3,13 +3,39 @@ package repository
 import "ark/internal/server"
 
 type Repositories struct {
-	Asset *AssetRepository
-	Log   *LogRepository
+	Asset       *AssetRepository
+	Log         *LogRepository
+	LogTemplate *LogTemplateRepository
+	Incident    *IncidentRepository
+	Maintenance *MaintenanceRepository
+	Runbook     *RunbookRepository
+	Tag         *TagRepository
+	SavedView   *SavedViewRepository
+	Report      *ReportRepository
+	Stats       *StatsRepository
+	Activity    *ActivityRepository
+	Export      *ExportRepository
+	Import      *ImportRepository
+	APIToken    *APITokenRepository
+	Port        *PortRepository
 }
 
 func NewRepositories(s *server.Server) *Repositories {
 	return &Repositories{
-		Asset: NewAssetRepository(s.DB.Pool),
-		Log:   NewLogRepository(s.DB.Pool),
+		Asset:       NewAssetRepository(s.DB.Pool),
+		Log:         NewLogRepository(s.DB.Pool),
+		LogTemplate: NewLogTemplateRepository(s.DB.Pool),
+		Incident:    NewIncidentRepository(s.DB.Pool),
+		Maintenance: NewMaintenanceRepository(s.DB.Pool),
+		Runbook:     NewRunbookRepository(s.DB.Pool),
+		Tag:         NewTagRepository(s.DB.Pool),
+		SavedView:   NewSavedViewRepository(s.DB.Pool),
+		Report:      NewReportRepository(s.DB.Pool),
+		Stats:       NewStatsRepository(s.DB.Pool),
+		Activity:    NewActivityRepository(s.DB.Pool),
+		Export:      NewExportRepository(s.DB.Pool),
+		Import:      NewImportRepository(s.DB.Pool),
+		APIToken:    NewAPITokenRepository(s.DB.Pool),
+		Port:        NewPortRepository(s.DB.Pool),
 	}
 }
@@ -0,0 +1,628 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// RunbookRepository provides data access for runbooks and their runs:
+// the runbooks, runbook_steps, runbook_assets, runbook_runs and runbook_run_steps tables.
+// All methods enforce user isolation.
+type RunbookRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRunbookRepository creates a new RunbookRepository with the given database pool.
+func NewRunbookRepository(db *pgxpool.Pool) *RunbookRepository {
+	return &RunbookRepository{db: db}
+}
+
+// runbookColumns is the column list scanned by scanRunbook; attached asset IDs and
+// the step count come from subqueries so a runbook is always read in one row
+const runbookColumns = `r.id, r.user_id, r.title, r.description, r.asset_types,
+		COALESCE((SELECT array_agg(ra.asset_id::text ORDER BY ra.asset_id) FROM runbook_assets ra WHERE ra.runbook_id = r.id), '{}'),
+		(SELECT count(*) FROM runbook_steps rs WHERE rs.runbook_id = r.id),
+		r.created_at, r.updated_at`
+
+// scanRunbook scans a row selected with runbookColumns
+func scanRunbook(row rowScanner) (*model.Runbook, error) {
+	var runbook model.Runbook
+	var assetIDs []string
+	err := row.Scan(
+		&runbook.ID,
+		&runbook.UserID,
+		&runbook.Title,
+		&runbook.Description,
+		&runbook.AssetTypes,
+		&assetIDs,
+		&runbook.StepCount,
+		&runbook.CreatedAt,
+		&runbook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	runbook.AssetIDs = make([]uuid.UUID, 0, len(assetIDs))
+	for _, id := range assetIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("parse runbook asset id: %w", err)
+		}
+		runbook.AssetIDs = append(runbook.AssetIDs, parsed)
+	}
+
+	return &runbook, nil
+}
+
+// uuidStrings converts IDs for binding as a uuid[] parameter
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
+
+// getRunbook reads a runbook with its steps using q
+func getRunbook(ctx context.Context, q querier, userID string, runbookID uuid.UUID) (*model.Runbook, error) {
+	query := `
+		SELECT ` + runbookColumns + `
+		FROM runbooks r
+		WHERE r.id = @runbookID AND r.user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"runbookID": runbookID,
+		"userID":    userID,
+	}
+
+	runbook, err := scanRunbook(q.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("runbook not found", false, nil)
+		}
+		return nil, fmt.Errorf("get runbook by id: %w", err)
+	}
+
+	stepsQuery := `
+		SELECT id, runbook_id, position, title, body
+		FROM runbook_steps
+		WHERE runbook_id = @runbookID
+		ORDER BY position ASC
+	`
+
+	rows, err := q.Query(ctx, stepsQuery, args)
+	if err != nil {
+		return nil, fmt.Errorf("list runbook steps: %w", err)
+	}
+	defer rows.Close()
+
+	runbook.Steps = make([]model.RunbookStep, 0, runbook.StepCount)
+	for rows.Next() {
+		var step model.RunbookStep
+		if err := rows.Scan(&step.ID, &step.RunbookID, &step.Position, &step.Title, &step.Body); err != nil {
+			return nil, fmt.Errorf("scan runbook step: %w", err)
+		}
+		runbook.Steps = append(runbook.Steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate runbook steps: %w", err)
+	}
+
+	return runbook, nil
+}
+
+func (r *RunbookRepository) GetByID(ctx context.Context, userID string, runbookID uuid.UUID) (*model.Runbook, error) {
+	return getRunbook(ctx, r.db, userID, runbookID)
+}
+
+// List returns the user's runbooks without their steps, ordered by title
+func (r *RunbookRepository) List(ctx context.Context, userID string, params *model.RunbookQueryParams) ([]*model.Runbook, error) {
+	clauses := []string{"r.user_id = @userID"}
+	args := pgx.NamedArgs{
+		"userID": userID,
+	}
+
+	if params.AssetType != nil {
+		clauses = append(clauses, "@assetType = ANY(r.asset_types)")
+		args["assetType"] = *params.AssetType
+	}
+
+	if params.Search != nil && *params.Search != "" {
+		clauses = append(clauses, "r.title ILIKE @search")
+		args["search"] = "%" + *params.Search + "%"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM runbooks r
+		WHERE %s
+		ORDER BY r.title ASC
+	`, runbookColumns, strings.Join(clauses, " AND "))
+
+	return r.queryRunbooks(ctx, query, args)
+}
+
+// ListForAsset returns the runbooks that apply to an asset: those attached to it,
+// those attached to its type, and those attached to nothing
+func (r *RunbookRepository) ListForAsset(ctx context.Context, userID string, asset *model.Asset) ([]*model.Runbook, error) {
+	query := `
+		SELECT ` + runbookColumns + `
+		FROM runbooks r
+		WHERE r.user_id = @userID
+			AND (
+				EXISTS (SELECT 1 FROM runbook_assets ra WHERE ra.runbook_id = r.id AND ra.asset_id = @assetID)
+				OR (@assetType::text IS NOT NULL AND @assetType = ANY(r.asset_types))
+				OR (cardinality(r.asset_types) = 0 AND NOT EXISTS (SELECT 1 FROM runbook_assets ra WHERE ra.runbook_id = r.id))
+			)
+		ORDER BY r.title ASC
+	`
+
+	args := pgx.NamedArgs{
+		"userID":    userID,
+		"assetID":   asset.ID,
+		"assetType": asset.Type,
+	}
+
+	return r.queryRunbooks(ctx, query, args)
+}
+
+func (r *RunbookRepository) queryRunbooks(ctx context.Context, query string, args pgx.NamedArgs) ([]*model.Runbook, error) {
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list runbooks: %w", err)
+	}
+	defer rows.Close()
+
+	runbooks := make([]*model.Runbook, 0)
+	for rows.Next() {
+		runbook, err := scanRunbook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan runbook: %w", err)
+		}
+		runbooks = append(runbooks, runbook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate runbooks: %w", err)
+	}
+
+	return runbooks, nil
+}
+
+// replaceRunbookSteps replaces all steps of a runbook, numbering them from 1
+func replaceRunbookSteps(ctx context.Context, tx pgx.Tx, runbookID uuid.UUID, steps []model.RunbookStepInput) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM runbook_steps WHERE runbook_id = @runbookID`, pgx.NamedArgs{"runbookID": runbookID}); err != nil {
+		return fmt.Errorf("delete runbook steps: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for i, step := range steps {
+		batch.Queue(`
+			INSERT INTO runbook_steps (runbook_id, position, title, body)
+			VALUES (@runbookID, @position, @title, @body)
+		`, pgx.NamedArgs{
+			"runbookID": runbookID,
+			"position":  i + 1,
+			"title":     step.Title,
+			"body":      step.Body,
+		})
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("insert runbook steps: %w", err)
+	}
+
+	return nil
+}
+
+// replaceRunbookAssets replaces the assets a runbook is attached to.
+// Only assets owned by the user are attached.
+func replaceRunbookAssets(ctx context.Context, tx pgx.Tx, userID string, runbookID uuid.UUID, assetIDs []uuid.UUID) error {
+	args := pgx.NamedArgs{
+		"runbookID": runbookID,
+		"userID":    userID,
+		"assetIDs":  uuidStrings(assetIDs),
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM runbook_assets WHERE runbook_id = @runbookID`, args); err != nil {
+		return fmt.Errorf("delete runbook assets: %w", err)
+	}
+
+	if len(assetIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO runbook_assets (runbook_id, asset_id)
+		SELECT @runbookID, a.id
+		FROM assets a
+		WHERE a.id = ANY(@assetIDs::uuid[]) AND a.user_id = @userID
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("insert runbook assets: %w", err)
+	}
+
+	return nil
+}
+
+// Create inserts a runbook with its steps and asset attachments in one transaction
+func (r *RunbookRepository) Create(ctx context.Context, userID string, req *model.CreateRunbookRequest) (*model.Runbook, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin runbook transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	assetTypes := req.AssetTypes
+	if assetTypes == nil {
+		assetTypes = []string{}
+	}
+
+	query := `
+		INSERT INTO runbooks (user_id, title, description, asset_types)
+		VALUES (@userID, @title, @description, @assetTypes)
+		RETURNING id
+	`
+
+	args := pgx.NamedArgs{
+		"userID":      userID,
+		"title":       req.Title,
+		"description": req.Description,
+		"assetTypes":  assetTypes,
+	}
+
+	var runbookID uuid.UUID
+	if err := tx.QueryRow(ctx, query, args).Scan(&runbookID); err != nil {
+		return nil, fmt.Errorf("create runbook: %w", err)
+	}
+
+	if err := replaceRunbookSteps(ctx, tx, runbookID, req.Steps); err != nil {
+		return nil, err
+	}
+
+	if err := replaceRunbookAssets(ctx, tx, userID, runbookID, req.AssetIDs); err != nil {
+		return nil, err
+	}
+
+	runbook, err := getRunbook(ctx, tx, userID, runbookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit runbook transaction: %w", err)
+	}
+
+	return runbook, nil
+}
+
+// Update applies a partial update; steps and attachments are replaced when set
+func (r *RunbookRepository) Update(ctx context.Context, userID string, runbookID uuid.UUID, req *model.UpdateRunbookRequest) (*model.Runbook, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin runbook transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var setClauses []string
+	args := pgx.NamedArgs{
+		"runbookID": runbookID,
+		"userID":    userID,
+	}
+
+	if req.Title != nil {
+		setClauses = append(setClauses, "title = @title")
+		args["title"] = *req.Title
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *req.Description
+	}
+	if req.AssetTypes != nil {
+		assetTypes := *req.AssetTypes
+		if assetTypes == nil {
+			assetTypes = []string{}
+		}
+		setClauses = append(setClauses, "asset_types = @assetTypes")
+		args["assetTypes"] = assetTypes
+	}
+
+	// Always touch the row so updated_at reflects step and attachment changes
+	setClauses = append(setClauses, "updated_at = now()")
+
+	query := fmt.Sprintf(`
+		UPDATE runbooks
+		SET %s
+		WHERE id = @runbookID AND user_id = @userID
+	`, strings.Join(setClauses, ", "))
+
+	result, err := tx.Exec(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("update runbook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, errs.NewNotFoundError("runbook not found", false, nil)
+	}
+
+	if req.Steps != nil {
+		if err := replaceRunbookSteps(ctx, tx, runbookID, *req.Steps); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.AssetIDs != nil {
+		if err := replaceRunbookAssets(ctx, tx, userID, runbookID, *req.AssetIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	runbook, err := getRunbook(ctx, tx, userID, runbookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit runbook transaction: %w", err)
+	}
+
+	return runbook, nil
+}
+
+// Delete removes a runbook. Past runs keep their copied steps and their logs.
+func (r *RunbookRepository) Delete(ctx context.Context, userID string, runbookID uuid.UUID) error {
+	query := `
+		DELETE FROM runbooks
+		WHERE id = @runbookID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"runbookID": runbookID,
+		"userID":    userID,
+	}
+
+	result, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("delete runbook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errs.NewNotFoundError("runbook not found", false, nil)
+	}
+
+	return nil
+}
+
+// getRun reads a run with its steps using q
+func getRun(ctx context.Context, q querier, userID string, runID uuid.UUID) (*model.RunbookRun, error) {
+	query := `
+		SELECT id, runbook_id, asset_id, user_id, title, status, notes, log_id, started_at, finished_at
+		FROM runbook_runs
+		WHERE id = @runID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"runID":  runID,
+		"userID": userID,
+	}
+
+	var run model.RunbookRun
+	err := q.QueryRow(ctx, query, args).Scan(
+		&run.ID,
+		&run.RunbookID,
+		&run.AssetID,
+		&run.UserID,
+		&run.Title,
+		&run.Status,
+		&run.Notes,
+		&run.LogID,
+		&run.StartedAt,
+		&run.FinishedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("runbook run not found", false, nil)
+		}
+		return nil, fmt.Errorf("get runbook run by id: %w", err)
+	}
+
+	stepsQuery := `
+		SELECT id, run_id, position, title, body, checked, checked_at, notes
+		FROM runbook_run_steps
+		WHERE run_id = @runID
+		ORDER BY position ASC
+	`
+
+	rows, err := q.Query(ctx, stepsQuery, args)
+	if err != nil {
+		return nil, fmt.Errorf("list runbook run steps: %w", err)
+	}
+	defer rows.Close()
+
+	run.Steps = make([]model.RunbookRunStep, 0)
+	for rows.Next() {
+		var step model.RunbookRunStep
+		err := rows.Scan(
+			&step.ID,
+			&step.RunID,
+			&step.Position,
+			&step.Title,
+			&step.Body,
+			&step.Checked,
+			&step.CheckedAt,
+			&step.Notes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan runbook run step: %w", err)
+		}
+		run.Steps = append(run.Steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate runbook run steps: %w", err)
+	}
+
+	return &run, nil
+}
+
+func (r *RunbookRepository) GetRun(ctx context.Context, userID string, runID uuid.UUID) (*model.RunbookRun, error) {
+	return getRun(ctx, r.db, userID, runID)
+}
+
+// StartRun creates a run for the asset and copies the runbook's current steps into it
+func (r *RunbookRepository) StartRun(ctx context.Context, userID string, runbook *model.Runbook, assetID uuid.UUID) (*model.RunbookRun, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin runbook run transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"runbookID": runbook.ID,
+		"assetID":   assetID,
+		"userID":    userID,
+		"title":     runbook.Title,
+	}
+
+	var runID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO runbook_runs (runbook_id, asset_id, user_id, title)
+		VALUES (@runbookID, @assetID, @userID, @title)
+		RETURNING id
+	`, args).Scan(&runID)
+	if err != nil {
+		return nil, fmt.Errorf("create runbook run: %w", err)
+	}
+
+	args["runID"] = runID
+	_, err = tx.Exec(ctx, `
+		INSERT INTO runbook_run_steps (run_id, position, title, body)
+		SELECT @runID, position, title, body
+		FROM runbook_steps
+		WHERE runbook_id = @runbookID
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("copy runbook steps: %w", err)
+	}
+
+	run, err := getRun(ctx, tx, userID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit runbook run transaction: %w", err)
+	}
+
+	return run, nil
+}
+
+// UpdateRunStep checks or unchecks a step and/or sets its notes on a run in progress
+func (r *RunbookRepository) UpdateRunStep(ctx context.Context, userID string, runID uuid.UUID, position int, req *model.UpdateRunbookRunStepRequest) error {
+	var setClauses []string
+	args := pgx.NamedArgs{
+		"runID":    runID,
+		"userID":   userID,
+		"position": position,
+	}
+
+	if req.Checked != nil {
+		setClauses = append(setClauses,
+			"checked = @checked",
+			"checked_at = CASE WHEN @checked THEN COALESCE(s.checked_at, now()) ELSE NULL END")
+		args["checked"] = *req.Checked
+	}
+	if req.Notes != nil {
+		setClauses = append(setClauses, "notes = @notes")
+		args["notes"] = *req.Notes
+	}
+
+	if len(setClauses) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE runbook_run_steps s
+		SET %s
+		FROM runbook_runs r
+		WHERE r.id = s.run_id
+			AND s.run_id = @runID
+			AND s.position = @position
+			AND r.user_id = @userID
+			AND r.status = 'in_progress'
+	`, strings.Join(setClauses, ", "))
+
+	result, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("update runbook run step: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errs.NewNotFoundError("runbook run step not found", false, nil)
+	}
+
+	return nil
+}
+
+// CloseRun records the consolidated log and closes a run in progress in one transaction.
+// Closing a run that is no longer in progress returns a 400.
+func (r *RunbookRepository) CloseRun(ctx context.Context, userID string, run *model.RunbookRun, status string, notes *string, logReq *model.CreateLogRequest, finishedAt time.Time) (*model.RunbookRun, *model.AssetLog, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin close run transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	log, err := insertLog(ctx, tx, userID, run.AssetID, logReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := `
+		UPDATE runbook_runs
+		SET status = @status, notes = @notes, log_id = @logID, finished_at = @finishedAt
+		WHERE id = @runID AND user_id = @userID AND status = 'in_progress'
+	`
+
+	args := pgx.NamedArgs{
+		"runID":      run.ID,
+		"userID":     userID,
+		"status":     status,
+		"notes":      notes,
+		"logID":      log.ID,
+		"finishedAt": finishedAt,
+	}
+
+	result, err := tx.Exec(ctx, query, args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("close runbook run: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, nil, errs.NewBadRequestError("runbook run is already finished", false, nil, nil, nil)
+	}
+
+	closed, err := getRun(ctx, tx, userID, run.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("commit close run transaction: %w", err)
+	}
+
+	return closed, log, nil
+}
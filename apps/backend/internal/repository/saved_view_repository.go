@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// SavedViewRepository provides data access for the saved_views table.
+// All methods enforce user isolation.
+type SavedViewRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSavedViewRepository creates a new SavedViewRepository with the given database pool.
+func NewSavedViewRepository(db *pgxpool.Pool) *SavedViewRepository {
+	return &SavedViewRepository{db: db}
+}
+
+// savedViewColumns is the column list scanned by scanSavedView
+const savedViewColumns = `id, user_id, name, description, target, asset_id, params, created_at, updated_at`
+
+// scanSavedView scans a row selected with savedViewColumns
+func scanSavedView(row rowScanner) (*model.SavedView, error) {
+	var view model.SavedView
+	err := row.Scan(
+		&view.ID,
+		&view.UserID,
+		&view.Name,
+		&view.Description,
+		&view.Target,
+		&view.AssetID,
+		&view.Params,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// savedViewWriteError maps a duplicate name to a validation error and a
+// missing asset to not found
+func savedViewWriteError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+				{Field: "name", Error: "a view with this name already exists"},
+			}, nil)
+		case "23503": // foreign_key_violation
+			return errs.NewNotFoundError("asset not found", false, nil)
+		}
+	}
+	return fmt.Errorf("%s saved view: %w", op, err)
+}
+
+func (r *SavedViewRepository) GetByID(ctx context.Context, userID string, viewID uuid.UUID) (*model.SavedView, error) {
+	query := `
+		SELECT ` + savedViewColumns + `
+		FROM saved_views
+		WHERE id = @viewID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"viewID": viewID,
+		"userID": userID,
+	}
+
+	view, err := scanSavedView(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("saved view not found", false, nil)
+		}
+		return nil, fmt.Errorf("get saved view: %w", err)
+	}
+
+	return view, nil
+}
+
+// List returns the user's saved views ordered by name
+func (r *SavedViewRepository) List(ctx context.Context, userID string) ([]*model.SavedView, error) {
+	query := `
+		SELECT ` + savedViewColumns + `
+		FROM saved_views
+		WHERE user_id = @userID
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID})
+	if err != nil {
+		return nil, fmt.Errorf("list saved views: %w", err)
+	}
+	defer rows.Close()
+
+	views := make([]*model.SavedView, 0)
+	for rows.Next() {
+		view, err := scanSavedView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan saved view: %w", err)
+		}
+		views = append(views, view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate saved views: %w", err)
+	}
+
+	return views, nil
+}
+
+// Create inserts a saved view. req.Params must already be validated.
+func (r *SavedViewRepository) Create(ctx context.Context, userID string, req *model.CreateSavedViewRequest) (*model.SavedView, error) {
+	query := `
+		INSERT INTO saved_views (user_id, name, description, target, asset_id, params)
+		VALUES (@userID, @name, @description, @target, @assetID, @params)
+		RETURNING ` + savedViewColumns
+
+	args := pgx.NamedArgs{
+		"userID":      userID,
+		"name":        req.Name,
+		"description": req.Description,
+		"target":      req.Target,
+		"assetID":     req.AssetID,
+		"params":      req.Params,
+	}
+
+	view, err := scanSavedView(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		return nil, savedViewWriteError("create", err)
+	}
+
+	return view, nil
+}
+
+// Update applies a partial update to a saved view
+func (r *SavedViewRepository) Update(ctx context.Context, userID string, viewID uuid.UUID, req *model.UpdateSavedViewRequest) (*model.SavedView, error) {
+	args := pgx.NamedArgs{
+		"viewID": viewID,
+		"userID": userID,
+	}
+
+	var setClauses []string
+	if req.Name != nil {
+		setClauses = append(setClauses, "name = @name")
+		args["name"] = *req.Name
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *req.Description
+	}
+	if req.AssetID != nil {
+		setClauses = append(setClauses, "asset_id = @assetID")
+		args["assetID"] = *req.AssetID
+	}
+	if req.Params != nil {
+		setClauses = append(setClauses, "params = @params")
+		args["params"] = *req.Params
+	}
+
+	// updated_at is handled by the database trigger; touch a column so an empty update still returns the row
+	if len(setClauses) == 0 {
+		setClauses = append(setClauses, "name = name")
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE saved_views
+		SET %s
+		WHERE id = @viewID AND user_id = @userID
+		RETURNING %s
+	`, strings.Join(setClauses, ", "), savedViewColumns)
+
+	view, err := scanSavedView(r.db.QueryRow(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("saved view not found", false, nil)
+		}
+		return nil, savedViewWriteError("update", err)
+	}
+
+	return view, nil
+}
+
+// Delete removes a saved view
+func (r *SavedViewRepository) Delete(ctx context.Context, userID string, viewID uuid.UUID) error {
+	query := `
+		DELETE FROM saved_views
+		WHERE id = @viewID AND user_id = @userID
+	`
+
+	args := pgx.NamedArgs{
+		"viewID": viewID,
+		"userID": userID,
+	}
+
+	result, err := r.db.Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("delete saved view: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return errs.NewNotFoundError("saved view not found", false, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/model"
+)
+
+// StatsRepository computes the dashboard statistics in SQL.
+// All methods enforce user isolation.
+type StatsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewStatsRepository creates a new StatsRepository with the given database pool.
+func NewStatsRepository(db *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// scanCountBuckets reads (key, count) rows
+func scanCountBuckets(rows pgx.Rows) ([]model.CountBucket, error) {
+	defer rows.Close()
+
+	buckets := make([]model.CountBucket, 0)
+	for rows.Next() {
+		var bucket model.CountBucket
+		if err := rows.Scan(&bucket.Key, &bucket.Count); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+// AssetCounts counts the user's assets in total, by type and by status.
+// An asset with an unresolved incident has status incident; otherwise it is
+// stale when it has had no logs or updates in model.DefaultStaleAssetDays days.
+func (r *StatsRepository) AssetCounts(ctx context.Context, userID string) (*model.AssetCountStats, error) {
+	args := pgx.NamedArgs{
+		"userID":    userID,
+		"none":      model.AssetTypeNone,
+		"staleDays": model.DefaultStaleAssetDays,
+		"active":    model.AssetStatusActive,
+		"stale":     model.AssetStatusStale,
+		"incident":  model.AssetStatusIncident,
+		"resolved":  model.IncidentStatusResolved,
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT COALESCE(type, @none), count(*)
+		FROM assets
+		WHERE user_id = @userID
+		GROUP BY 1
+		ORDER BY 2 DESC, 1 ASC
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("count assets by type: %w", err)
+	}
+	byType, err := scanCountBuckets(rows)
+	if err != nil {
+		return nil, fmt.Errorf("scan asset type counts: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, `
+		SELECT CASE
+				WHEN EXISTS (
+					SELECT 1 FROM asset_logs i
+					WHERE i.asset_id = s.id AND i.user_id = @userID
+						AND i.kind = 'incident' AND i.incident_status <> @resolved
+				) THEN @incident
+				WHEN s.last_activity_at < now() - make_interval(days => @staleDays) THEN @stale
+				ELSE @active
+			END AS status,
+			count(*)
+		FROM (`+assetActivityQuery(true)+`) s
+		GROUP BY 1
+		ORDER BY 2 DESC, 1 ASC
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("count assets by status: %w", err)
+	}
+	byStatus, err := scanCountBuckets(rows)
+	if err != nil {
+		return nil, fmt.Errorf("scan asset status counts: %w", err)
+	}
+
+	stats := &model.AssetCountStats{ByType: byType, ByStatus: byStatus}
+	for _, bucket := range byType {
+		stats.Total += bucket.Count
+	}
+
+	return stats, nil
+}
+
+// LogsPerDay counts the user's logs per UTC day over the last days days,
+// oldest first. Days without logs are included with a count of zero.
+func (r *StatsRepository) LogsPerDay(ctx context.Context, userID string, days int) ([]model.DailyCount, error) {
+	query := `
+		SELECT to_char(d.day, 'YYYY-MM-DD'), count(l.id)
+		FROM generate_series(
+			(now() AT TIME ZONE 'UTC')::date - (@days - 1),
+			(now() AT TIME ZONE 'UTC')::date,
+			interval '1 day'
+		) AS d(day)
+		LEFT JOIN asset_logs l
+			ON l.user_id = @userID
+			AND (l.created_at AT TIME ZONE 'UTC')::date = d.day::date
+		GROUP BY d.day
+		ORDER BY d.day ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID, "days": days})
+	if err != nil {
+		return nil, fmt.Errorf("count logs per day: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]model.DailyCount, 0, days)
+	for rows.Next() {
+		var count model.DailyCount
+		if err := rows.Scan(&count.Date, &count.Count); err != nil {
+			return nil, fmt.Errorf("scan daily log count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate daily log counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// MostActiveAssets returns the user's assets with the most logs over the last
+// days days, most active first. A log counts for its primary asset and for
+// every asset it is linked to.
+func (r *StatsRepository) MostActiveAssets(ctx context.Context, userID string, days, limit int) ([]model.ActiveAsset, error) {
+	query := `
+		SELECT a.id, a.name, a.type, count(*), max(x.created_at)
+		FROM (
+			SELECT asset_id, created_at
+			FROM asset_logs
+			WHERE user_id = @userID AND created_at >= now() - make_interval(days => @days)
+			UNION ALL
+			SELECT la.asset_id, l.created_at
+			FROM log_assets la
+			JOIN asset_logs l ON l.id = la.log_id
+			WHERE la.user_id = @userID AND l.created_at >= now() - make_interval(days => @days)
+		) x
+		JOIN assets a ON a.id = x.asset_id AND a.user_id = @userID
+		GROUP BY a.id, a.name, a.type
+		ORDER BY count(*) DESC, max(x.created_at) DESC
+		LIMIT @limit
+	`
+
+	rows, err := r.db.Query(ctx, query, pgx.NamedArgs{"userID": userID, "days": days, "limit": limit})
+	if err != nil {
+		return nil, fmt.Errorf("list most active assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]model.ActiveAsset, 0)
+	for rows.Next() {
+		var asset model.ActiveAsset
+		if err := rows.Scan(&asset.ID, &asset.Name, &asset.Type, &asset.LogCount, &asset.LastLogAt); err != nil {
+			return nil, fmt.Errorf("scan active asset: %w", err)
+		}
+		assets = append(assets, asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate most active assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// Storage sums the stored size of the user's asset and log rows, including
+// TOASTed content, as reported by pg_column_size
+func (r *StatsRepository) Storage(ctx context.Context, userID string) (*model.StorageStats, error) {
+	query := `
+		SELECT
+			COALESCE((SELECT sum(pg_column_size(a.*)) FROM assets a WHERE a.user_id = @userID), 0)::bigint,
+			COALESCE((SELECT sum(pg_column_size(l.*)) FROM asset_logs l WHERE l.user_id = @userID), 0)::bigint
+	`
+
+	var stats model.StorageStats
+	err := r.db.QueryRow(ctx, query, pgx.NamedArgs{"userID": userID}).Scan(&stats.AssetBytes, &stats.LogBytes)
+	if err != nil {
+		return nil, fmt.Errorf("sum storage: %w", err)
+	}
+	stats.TotalBytes = stats.AssetBytes + stats.LogBytes
+
+	return &stats, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ark/internal/model"
+)
+
+// TagRepository provides access to the tag vocabulary stored in the tags
+// arrays of asset_logs, assets and log_templates. All methods enforce user isolation.
+type TagRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTagRepository creates a new TagRepository with the given database pool.
+func NewTagRepository(db *pgxpool.Pool) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// List returns the tags used on the user's logs and assets with their usage
+// counts and the time they were last used, most used first. A tag is last used
+// when the newest log or the most recently updated asset carrying it was written.
+func (r *TagRepository) List(ctx context.Context, userID string, params *model.TagQueryParams) ([]model.Tag, error) {
+	args := pgx.NamedArgs{
+		"userID": userID,
+		"limit":  params.Limit,
+	}
+
+	// Prefix filter for autocomplete
+	prefixClause := ""
+	if params.Prefix != nil && *params.Prefix != "" {
+		prefixClause = "WHERE u.tag LIKE @prefix"
+		args["prefix"] = likeEscaper.Replace(*params.Prefix) + "%"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT u.tag, count(*), count(*) FILTER (WHERE u.source = 'log'),
+			count(*) FILTER (WHERE u.source = 'asset'), max(u.used_at)
+		FROM (
+			SELECT t.tag, 'log' AS source, l.created_at AS used_at
+			FROM asset_logs l, unnest(l.tags) AS t(tag)
+			WHERE l.user_id = @userID
+			UNION ALL
+			SELECT t.tag, 'asset' AS source, a.updated_at AS used_at
+			FROM assets a, unnest(a.tags) AS t(tag)
+			WHERE a.user_id = @userID
+		) u
+		%s
+		GROUP BY u.tag
+		ORDER BY count(*) DESC, u.tag ASC
+		LIMIT @limit
+	`, prefixClause)
+
+	rows, err := r.db.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]model.Tag, 0)
+	for rows.Next() {
+		var tag model.Tag
+		if err := rows.Scan(&tag.Name, &tag.Count, &tag.LogCount, &tag.AssetCount, &tag.LastUsedAt); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// mergedTagsExpr rewrites a tags array, replacing every source tag with the
+// target. Each tag keeps the position of its first occurrence and the target
+// appears once even when the array held several sources.
+const mergedTagsExpr = `ARRAY(
+		SELECT m.tag FROM (
+			SELECT CASE WHEN u.tag = ANY(@sources::text[]) THEN @target::text ELSE u.tag END AS tag,
+				min(u.ord) AS ord
+			FROM unnest(tags) WITH ORDINALITY AS u(tag, ord)
+			GROUP BY 1
+		) m
+		ORDER BY m.ord
+	)`
+
+// Merge replaces the source tags with the target on all of the user's logs,
+// assets and log templates in one transaction
+func (r *TagRepository) Merge(ctx context.Context, userID string, sources []string, target string) (*model.TagOperationResponse, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin merge tags transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"userID":  userID,
+		"sources": sources,
+		"target":  target,
+	}
+
+	// Every table that stores a tags array, with the counter it reports into
+	result := &model.TagOperationResponse{Tag: target}
+	tables := []struct {
+		name    string
+		updated *int64
+	}{
+		{"asset_logs", &result.LogsUpdated},
+		{"assets", &result.AssetsUpdated},
+		{"log_templates", &result.TemplatesUpdated},
+	}
+
+	for _, table := range tables {
+		cmd, err := tx.Exec(ctx, `
+			UPDATE `+table.name+`
+			SET tags = `+mergedTagsExpr+`
+			WHERE user_id = @userID AND tags && @sources::text[]
+		`, args)
+		if err != nil {
+			return nil, fmt.Errorf("merge %s tags: %w", table.name, err)
+		}
+		*table.updated = cmd.RowsAffected()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit merge tags transaction: %w", err)
+	}
+
+	return result, nil
+}
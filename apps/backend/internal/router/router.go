@@ -16,6 +16,7 @@ import (
 
 func NewRouter(s *server.Server, h *handler.Handlers, services *service.Services) *echo.Echo {
 	middlewares := middleware.NewMiddlewares(s)
+	middlewares.APIToken = middleware.NewAPITokenMiddleware(s, middlewares.Auth, services.APIToken)
 
 	router := echo.New()
 
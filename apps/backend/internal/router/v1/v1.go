@@ -25,10 +25,33 @@ import (
 //
 // Route Structure:
 //   - Asset routes: /api/v1/assets (collection and individual operations)
+//                   /api/v1/assets/csv (spreadsheet export and import with column mapping)
 //   - Log routes: /api/v1/assets/:id/logs (nested for create/list)
 //                 /api/v1/logs/:id (flat for individual operations)
+//                 /api/v1/logs/:id/thread (replies and follow-ups)
+//   - Log template routes: /api/v1/log-templates (used via POST /api/v1/assets/:id/logs?template=<id>)
+//   - Incident routes: /api/v1/logs/:id/timeline (incident lifecycle)
+//   - Maintenance routes: /api/v1/assets/:id/maintenance-tasks (nested for create/list),
+//                         /api/v1/maintenance-tasks/:id (flat for individual operations)
+//   - Port routes: /api/v1/assets/:id/ports (nested for create/list),
+//                  /api/v1/ports/:id (flat for individual operations)
+//   - Runbook routes: /api/v1/runbooks (definitions), /api/v1/assets/:id/runbooks (applicable),
+//                     /api/v1/runbook-runs/:id (step-by-step execution)
+//   - Tag routes: /api/v1/tags (vocabulary with counts, rename and merge)
+//   - Saved view routes: /api/v1/views (named asset and log filters, run via /results)
+//   - Stats routes: /api/v1/stats (dashboard statistics, cached per user, and aggregated reporting)
+//   - Report routes: /api/v1/reports (inventory reports such as stale assets)
+//   - Activity routes: /api/v1/activity (log heatmap and timeline, per user or per asset)
+//   - Export routes: /api/v1/export, /api/v1/exports (zip archive of the account, direct or in the background)
+//   - Import routes: /api/v1/imports (restore an export archive, with conflict policies and dry runs)
+//   - Inventory routes: /api/v1/inventory (assets from infrastructure files such as docker-compose.yml and Ansible inventories,
+//                       and reconciliation with nmap scans)
+//   - API token routes: /api/v1/tokens (long-lived tokens for integration scripts)
+//   - Integration routes: /api/v1/integrations (read by outside tools: Ansible dynamic inventory, Prometheus
+//                         service discovery, SSH config and /etc/hosts generators)
 //
-// All routes require authentication via ClerkAuthMiddleware.
+// All routes require authentication via ClerkAuthMiddleware, except that
+// integration routes also accept an API token instead.
 
 // RegisterRoutes registers all v1 API routes
 func RegisterRoutes(router *echo.Echo, h *handler.Handlers, m *middleware.Middlewares) {
@@ -52,6 +75,11 @@ func RegisterRoutes(router *echo.Echo, h *handler.Handlers, m *middleware.Middle
 	assets.PATCH("/:id", h.Asset.Update)  // PATCH /api/v1/assets/:id - Update asset
 	assets.DELETE("/:id", h.Asset.Delete) // DELETE /api/v1/assets/:id - Delete asset
 
+	// Asset CSV routes - static paths take precedence over /:id
+	assets.GET("/csv", h.AssetCSV.Export)           // GET /api/v1/assets/csv - Export filtered assets as CSV
+	assets.POST("/csv/preview", h.AssetCSV.Preview) // POST /api/v1/assets/csv/preview - Suggest a column mapping
+	assets.POST("/csv", h.AssetCSV.Import)          // POST /api/v1/assets/csv - Import assets (multipart "file", "mapping")
+
 	// Log routes (nested under assets for create/list)
 	// These routes require asset_id in URL path
 	assets.POST("/:id/logs", h.Log.Create)     // POST /api/v1/assets/:id/logs - Create log for asset
@@ -60,7 +88,121 @@ func RegisterRoutes(router *echo.Echo, h *handler.Handlers, m *middleware.Middle
 	// Log routes (flat for direct access)
 	// These routes operate on logs by log_id
 	logs := v1.Group("/logs")
-	logs.GET("/:id", h.Log.GetByID)   // GET /api/v1/logs/:id - Get single log
-	logs.PATCH("/:id", h.Log.Update)  // PATCH /api/v1/logs/:id - Update log
-	logs.DELETE("/:id", h.Log.Delete) // DELETE /api/v1/logs/:id - Delete log
+	logs.GET("/:id", h.Log.GetByID)          // GET /api/v1/logs/:id - Get single log
+	logs.PATCH("/:id", h.Log.Update)         // PATCH /api/v1/logs/:id - Update log
+	logs.DELETE("/:id", h.Log.Delete)        // DELETE /api/v1/logs/:id - Delete log
+	logs.GET("/:id/thread", h.Log.GetThread) // GET /api/v1/logs/:id/thread - Get log thread (root and replies)
+
+	// Log template routes - reusable log layouts with placeholders
+	templates := v1.Group("/log-templates")
+	templates.GET("", h.LogTemplate.List)               // GET /api/v1/log-templates - List templates
+	templates.POST("", h.LogTemplate.Create)            // POST /api/v1/log-templates - Create template
+	templates.GET("/:id", h.LogTemplate.GetByID)        // GET /api/v1/log-templates/:id - Get template
+	templates.PATCH("/:id", h.LogTemplate.Update)       // PATCH /api/v1/log-templates/:id - Update template
+	templates.DELETE("/:id", h.LogTemplate.Delete)      // DELETE /api/v1/log-templates/:id - Delete template
+	templates.POST("/:id/render", h.LogTemplate.Render) // POST /api/v1/log-templates/:id/render - Preview rendered content
+
+	// Incident lifecycle routes (incidents are logs of kind "incident")
+	logs.GET("/:id/timeline", h.Incident.GetTimeline)       // GET /api/v1/logs/:id/timeline - Get incident timeline
+	logs.POST("/:id/timeline", h.Incident.AddTimelineEntry) // POST /api/v1/logs/:id/timeline - Add entry / transition
+
+	// Maintenance task routes (nested under assets for create/list)
+	assets.GET("/:id/maintenance-tasks", h.Maintenance.ListByAsset) // GET /api/v1/assets/:id/maintenance-tasks - List tasks for asset
+	assets.POST("/:id/maintenance-tasks", h.Maintenance.Create)     // POST /api/v1/assets/:id/maintenance-tasks - Create task for asset
+
+	// Maintenance task routes (flat for direct access)
+	maintenance := v1.Group("/maintenance-tasks")
+	maintenance.GET("", h.Maintenance.List)                   // GET /api/v1/maintenance-tasks - List all tasks by due date
+	maintenance.GET("/:id", h.Maintenance.GetByID)            // GET /api/v1/maintenance-tasks/:id - Get single task
+	maintenance.PATCH("/:id", h.Maintenance.Update)           // PATCH /api/v1/maintenance-tasks/:id - Update task
+	maintenance.DELETE("/:id", h.Maintenance.Delete)          // DELETE /api/v1/maintenance-tasks/:id - Delete task
+	maintenance.POST("/:id/complete", h.Maintenance.Complete) // POST /api/v1/maintenance-tasks/:id/complete - Complete task, log it and schedule next
+
+	// Port routes (nested under assets for create/list)
+	assets.GET("/:id/ports", h.Port.ListByAsset) // GET /api/v1/assets/:id/ports - List ports of asset
+	assets.POST("/:id/ports", h.Port.Create)     // POST /api/v1/assets/:id/ports - Add port to asset
+
+	// Port routes (flat for direct access)
+	ports := v1.Group("/ports")
+	ports.PATCH("/:id", h.Port.Update)  // PATCH /api/v1/ports/:id - Update port
+	ports.DELETE("/:id", h.Port.Delete) // DELETE /api/v1/ports/:id - Delete port
+
+	// Runbook routes - reusable procedures
+	runbooks := v1.Group("/runbooks")
+	runbooks.GET("", h.Runbook.List)                    // GET /api/v1/runbooks - List runbooks
+	runbooks.POST("", h.Runbook.Create)                 // POST /api/v1/runbooks - Create runbook
+	runbooks.GET("/:id", h.Runbook.GetByID)             // GET /api/v1/runbooks/:id - Get runbook with steps
+	runbooks.PATCH("/:id", h.Runbook.Update)            // PATCH /api/v1/runbooks/:id - Update runbook
+	runbooks.DELETE("/:id", h.Runbook.Delete)           // DELETE /api/v1/runbooks/:id - Delete runbook
+	runbooks.POST("/:id/runs", h.Runbook.StartRun)      // POST /api/v1/runbooks/:id/runs - Start run against an asset
+	assets.GET("/:id/runbooks", h.Runbook.ListForAsset) // GET /api/v1/assets/:id/runbooks - List runbooks applicable to asset
+
+	// Runbook run routes - step-by-step execution
+	runs := v1.Group("/runbook-runs")
+	runs.GET("/:id", h.Runbook.GetRun)                          // GET /api/v1/runbook-runs/:id - Get run with step state
+	runs.PATCH("/:id/steps/:position", h.Runbook.UpdateRunStep) // PATCH /api/v1/runbook-runs/:id/steps/:position - Check off step
+	runs.POST("/:id/finish", h.Runbook.FinishRun)               // POST /api/v1/runbook-runs/:id/finish - Finish run, record log
+	runs.POST("/:id/abort", h.Runbook.AbortRun)                 // POST /api/v1/runbook-runs/:id/abort - Abort run, record log
+
+	// Tag routes - tag vocabulary across logs
+	tags := v1.Group("/tags")
+	tags.GET("", h.Tag.List)           // GET /api/v1/tags - List tags with counts (prefix autocomplete)
+	tags.POST("/rename", h.Tag.Rename) // POST /api/v1/tags/rename - Rename tag on all logs
+	tags.POST("/merge", h.Tag.Merge)   // POST /api/v1/tags/merge - Merge tags into one on all logs
+
+	// Saved view routes - named list filters
+	views := v1.Group("/views")
+	views.GET("", h.SavedView.List)            // GET /api/v1/views - List saved views
+	views.POST("", h.SavedView.Create)         // POST /api/v1/views - Create saved view
+	views.GET("/:id", h.SavedView.GetByID)     // GET /api/v1/views/:id - Get saved view
+	views.PATCH("/:id", h.SavedView.Update)    // PATCH /api/v1/views/:id - Update saved view
+	views.DELETE("/:id", h.SavedView.Delete)   // DELETE /api/v1/views/:id - Delete saved view
+	views.GET("/:id/results", h.SavedView.Run) // GET /api/v1/views/:id/results - Run view, same shape as the list endpoint
+
+	// Stats routes - read-only aggregations
+	stats := v1.Group("/stats")
+	stats.GET("", h.Stats.Get)                // GET /api/v1/stats - Dashboard statistics (asset counts, daily logs, top tags, active assets, storage)
+	stats.GET("/incidents", h.Incident.Stats) // GET /api/v1/stats/incidents - MTTR, counts and availability
+
+	// Report routes - read-only inventory reports
+	reports := v1.Group("/reports")
+	reports.GET("/stale-assets", h.Report.StaleAssets) // GET /api/v1/reports/stale-assets - Assets with no logs or updates in N days
+
+	// Activity routes - log activity over time in the user's time zone
+	activity := v1.Group("/activity")
+	activity.GET("/heatmap", h.Activity.Heatmap)                 // GET /api/v1/activity/heatmap - Daily log counts over a year
+	activity.GET("/timeline", h.Activity.Timeline)               // GET /api/v1/activity/timeline - Log counts per interval by tag or asset type
+	assets.GET("/:id/activity/heatmap", h.Activity.AssetHeatmap) // GET /api/v1/assets/:id/activity/heatmap - Daily log counts for one asset
+
+	// Export routes - account archives for backup, migration and data requests
+	v1.GET("/export", h.Export.Export) // GET /api/v1/export - Download archive built within the request (small accounts)
+	exports := v1.Group("/exports")
+	exports.POST("", h.Export.Start)                // POST /api/v1/exports - Start background export
+	exports.GET("", h.Export.List)                  // GET /api/v1/exports - List exports
+	exports.GET("/:id", h.Export.GetByID)           // GET /api/v1/exports/:id - Get export status
+	exports.GET("/:id/download", h.Export.Download) // GET /api/v1/exports/:id/download - Download completed export
+	exports.DELETE("/:id", h.Export.Delete)         // DELETE /api/v1/exports/:id - Delete export
+
+	// Import routes - recreate assets and logs from an export archive
+	v1.POST("/imports", h.Import.Import) // POST /api/v1/imports - Import archive (multipart field "file")
+
+	// Inventory routes - create and update assets from infrastructure files
+	inventory := v1.Group("/inventory")
+	inventory.POST("/compose", h.Inventory.ImportCompose) // POST /api/v1/inventory/compose - Import compose services (multipart field "files")
+	inventory.POST("/ansible", h.Inventory.ImportAnsible) // POST /api/v1/inventory/ansible - Import Ansible hosts (multipart field "files")
+	inventory.POST("/nmap", h.Inventory.ImportNmap)       // POST /api/v1/inventory/nmap - Reconcile with nmap XML scans (multipart field "files")
+
+	// API token routes - long-lived tokens for integration scripts
+	tokens := v1.Group("/tokens")
+	tokens.GET("", h.APIToken.List)          // GET /api/v1/tokens - List tokens
+	tokens.POST("", h.APIToken.Create)       // POST /api/v1/tokens - Create token (shown once)
+	tokens.DELETE("/:id", h.APIToken.Delete) // DELETE /api/v1/tokens/:id - Revoke token
+
+	// Integration routes - registered outside the v1 group so an API token
+	// can stand in for a Clerk session
+	integrations := router.Group("/api/v1/integrations", m.APIToken.TokenOrSessionAuth)
+	integrations.GET("/ansible/inventory", h.Integration.AnsibleInventory) // GET /api/v1/integrations/ansible/inventory - Ansible dynamic inventory
+	integrations.GET("/prometheus/sd", h.Integration.PrometheusSD)         // GET /api/v1/integrations/prometheus/sd - Prometheus HTTP service discovery
+	integrations.GET("/ssh/config", h.Integration.SSHConfig)               // GET /api/v1/integrations/ssh/config - ~/.ssh/config snippet
+	integrations.GET("/hosts", h.Integration.HostsFile)                    // GET /api/v1/integrations/hosts - /etc/hosts block
 }
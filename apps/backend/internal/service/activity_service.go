@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"time"
+	// Embed the time zone database so tz validation works on hosts without one
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+type ActivityService struct {
+	activityRepo *repository.ActivityRepository
+	assetRepo    *repository.AssetRepository
+}
+
+func NewActivityService(activityRepo *repository.ActivityRepository, assetRepo *repository.AssetRepository) *ActivityService {
+	return &ActivityService{
+		activityRepo: activityRepo,
+		assetRepo:    assetRepo,
+	}
+}
+
+// loadActivityLocation resolves an IANA time zone name such as Europe/Berlin
+func loadActivityLocation(tz string) (*time.Location, error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "tz", Error: "must be an IANA time zone such as Europe/Berlin"},
+		}, nil)
+	}
+	return loc, nil
+}
+
+// heatmapRange returns the local midnights bounding a heatmap in loc: the
+// calendar year when year is set, otherwise the HeatmapDays days up to today
+func heatmapRange(now time.Time, loc *time.Location, year *int) (time.Time, time.Time) {
+	if year != nil {
+		start := time.Date(*year, time.January, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(1, 0, 0)
+	}
+
+	local := now.In(loc)
+	end := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+	return end.AddDate(0, 0, -model.HeatmapDays), end
+}
+
+// activityBucketCount returns an upper bound on the intervals covering [start, end)
+func activityBucketCount(start, end time.Time, interval string) int {
+	span := end.Sub(start)
+	switch interval {
+	case model.ActivityIntervalHour:
+		return int(span/time.Hour) + 2
+	case model.ActivityIntervalWeek:
+		return int(span/(7*24*time.Hour)) + 2
+	case model.ActivityIntervalMonth:
+		return (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 2
+	default:
+		return int(span/(24*time.Hour)) + 2
+	}
+}
+
+// verifyActivityAsset checks that the asset belongs to the user
+func (s *ActivityService) verifyActivityAsset(ctx context.Context, userID string, assetID *uuid.UUID) error {
+	if assetID == nil {
+		return nil
+	}
+	_, err := s.assetRepo.GetByID(ctx, userID, *assetID)
+	return err
+}
+
+// Heatmap returns the number of logs created per day over a year in the
+// requested time zone, for all of the user's assets or for one asset
+func (s *ActivityService) Heatmap(ctx context.Context, userID string, assetID *uuid.UUID, params *model.ActivityHeatmapQueryParams) (*model.ActivityHeatmap, error) {
+	params.SetDefaults()
+
+	loc, err := loadActivityLocation(params.TZ)
+	if err != nil {
+		return nil, err
+	}
+	if params.Year != nil && (*params.Year < 1970 || *params.Year > 9999) {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "year", Error: "must be between 1970 and 9999"},
+		}, nil)
+	}
+
+	if err := s.verifyActivityAsset(ctx, userID, assetID); err != nil {
+		return nil, err
+	}
+
+	start, end := heatmapRange(time.Now(), loc, params.Year)
+	buckets, err := s.activityRepo.CountByInterval(ctx, userID, start, end, model.ActivityIntervalDay, params.TZ, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newActivityHeatmap(params.TZ, loc, start, end, buckets), nil
+}
+
+// newActivityHeatmap converts daily buckets to heatmap days in loc
+func newActivityHeatmap(tz string, loc *time.Location, start, end time.Time, buckets []model.ActivityBucket) *model.ActivityHeatmap {
+	heatmap := &model.ActivityHeatmap{
+		TZ:    tz,
+		Start: start.In(loc).Format(time.DateOnly),
+		End:   end.In(loc).AddDate(0, 0, -1).Format(time.DateOnly),
+		Days:  make([]model.DailyCount, 0, len(buckets)),
+	}
+
+	for _, bucket := range buckets {
+		heatmap.Days = append(heatmap.Days, model.DailyCount{
+			Date:  bucket.Start.In(loc).Format(time.DateOnly),
+			Count: bucket.Total,
+		})
+		heatmap.Total += bucket.Total
+		if bucket.Total > heatmap.Max {
+			heatmap.Max = bucket.Total
+		}
+	}
+
+	return heatmap
+}
+
+// Timeline returns the number of logs created per interval between start and
+// end, broken down by tag or asset type
+func (s *ActivityService) Timeline(ctx context.Context, userID string, params *model.ActivityTimelineQueryParams) (*model.ActivityTimeline, error) {
+	params.SetDefaults(time.Now().UTC())
+
+	var fieldErrors []errs.FieldError
+	if !model.IsValidActivityInterval(params.Interval) {
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: "interval", Error: "must be one of hour, day, week, month"})
+	}
+	if params.GroupBy != model.ActivityGroupByTag && params.GroupBy != model.ActivityGroupByAssetType {
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: "group_by", Error: "must be one of tag, asset_type"})
+	}
+	if len(fieldErrors) > 0 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	if _, err := loadActivityLocation(params.TZ); err != nil {
+		return nil, err
+	}
+
+	start, end := params.Start.UTC(), params.End.UTC()
+	if !end.After(start) {
+		return nil, errs.NewBadRequestError("end must be after start", false, nil, nil, nil)
+	}
+	if activityBucketCount(start, end, params.Interval) > model.MaxActivityBuckets {
+		return nil, errs.NewBadRequestError("too many intervals, use a larger interval or a shorter window", false, nil, nil, nil)
+	}
+
+	var assetID *uuid.UUID
+	if params.AssetID != nil {
+		id, err := uuid.Parse(*params.AssetID)
+		if err != nil {
+			return nil, errs.NewBadRequestError("invalid asset id", false, nil, nil, nil)
+		}
+		assetID = &id
+	}
+	if err := s.verifyActivityAsset(ctx, userID, assetID); err != nil {
+		return nil, err
+	}
+
+	buckets, err := s.activityRepo.CountByInterval(ctx, userID, start, end, params.Interval, params.TZ, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.activityRepo.CountByGroup(ctx, userID, start, end, params.Interval, params.TZ, params.GroupBy, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ActivityTimeline{
+		Start:    start,
+		End:      end,
+		Interval: params.Interval,
+		GroupBy:  params.GroupBy,
+		TZ:       params.TZ,
+		Buckets:  mergeActivityGroups(buckets, groups),
+	}, nil
+}
+
+// mergeActivityGroups attaches group counts to the bucket with the same start
+func mergeActivityGroups(buckets []model.ActivityBucket, groups []model.ActivityGroupCount) []model.ActivityBucket {
+	index := make(map[int64]int, len(buckets))
+	for i := range buckets {
+		buckets[i].Groups = make(map[string]int64)
+		index[buckets[i].Start.UnixMicro()] = i
+	}
+
+	for _, group := range groups {
+		if i, ok := index[group.Start.UnixMicro()]; ok {
+			buckets[i].Groups[group.Key] += group.Count
+		}
+	}
+
+	return buckets
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestActivityService_Constructor verifies NewActivityService works correctly
+func TestActivityService_Constructor(t *testing.T) {
+	service := NewActivityService(nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestLoadActivityLocation accepts IANA names and rejects anything else
+func TestLoadActivityLocation(t *testing.T) {
+	loc, err := loadActivityLocation("Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", loc.String())
+
+	for _, tz := range []string{"Mars/Olympus", "Local"} {
+		_, err := loadActivityLocation(tz)
+
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, "tz", httpErr.Errors[0].Field)
+	}
+}
+
+// TestHeatmapRange covers the rolling year and calendar years at local midnight
+func TestHeatmapRange(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 02:00 UTC on May 30 is still May 29 in New York
+	now := time.Date(2024, 5, 30, 2, 0, 0, 0, time.UTC)
+	start, end := heatmapRange(now, loc, nil)
+	assert.Equal(t, time.Date(2024, 5, 30, 0, 0, 0, 0, loc), end)
+	assert.Equal(t, time.Date(2023, 5, 31, 0, 0, 0, 0, loc), start) // 365 days back across Feb 29
+
+	year := 2023
+	start, end = heatmapRange(now, loc, &year)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, loc), end)
+}
+
+// TestNewActivityHeatmap formats local dates and tracks the total and busiest day
+func TestNewActivityHeatmap(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 2)
+	heatmap := newActivityHeatmap("Asia/Tokyo", loc, start, end, []model.ActivityBucket{
+		{Start: start.UTC(), Total: 3},
+		{Start: start.AddDate(0, 0, 1).UTC(), Total: 5},
+	})
+
+	assert.Equal(t, "2024-05-01", heatmap.Start)
+	assert.Equal(t, "2024-05-02", heatmap.End)
+	assert.Equal(t, int64(8), heatmap.Total)
+	assert.Equal(t, int64(5), heatmap.Max)
+	require.Len(t, heatmap.Days, 2)
+	assert.Equal(t, "2024-05-01", heatmap.Days[0].Date)
+	assert.Equal(t, "2024-05-02", heatmap.Days[1].Date)
+}
+
+// TestMergeActivityGroups attaches group counts by bucket start
+func TestMergeActivityGroups(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	buckets := mergeActivityGroups(
+		[]model.ActivityBucket{{Start: day, Total: 2}, {Start: day.AddDate(0, 0, 1)}},
+		[]model.ActivityGroupCount{
+			{Start: day, Key: "zfs", Count: 2},
+			{Start: day, Key: "backup", Count: 1},
+		},
+	)
+
+	assert.Equal(t, map[string]int64{"zfs": 2, "backup": 1}, buckets[0].Groups)
+	assert.NotNil(t, buckets[1].Groups)
+	assert.Empty(t, buckets[1].Groups)
+}
+
+// TestActivityService_Timeline_Validation rejects bad parameters before any query
+func TestActivityService_Timeline_Validation(t *testing.T) {
+	service := NewActivityService(nil, nil)
+	now := time.Now().UTC()
+	earlier := now.Add(-time.Hour)
+	yearAgo := now.AddDate(-1, 0, 0)
+
+	tests := []struct {
+		name   string
+		params model.ActivityTimelineQueryParams
+		field  string
+	}{
+		{name: "invalid interval", params: model.ActivityTimelineQueryParams{Interval: "year"}, field: "interval"},
+		{name: "invalid group_by", params: model.ActivityTimelineQueryParams{GroupBy: "severity"}, field: "group_by"},
+		{name: "invalid tz", params: model.ActivityTimelineQueryParams{TZ: "Nowhere/City"}, field: "tz"},
+		{name: "end before start", params: model.ActivityTimelineQueryParams{Start: &now, End: &earlier}},
+		{name: "too many buckets", params: model.ActivityTimelineQueryParams{Start: &yearAgo, End: &now, Interval: model.ActivityIntervalHour}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.Timeline(context.Background(), "user-123", &tc.params)
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			if tc.field != "" {
+				require.NotEmpty(t, httpErr.Errors)
+				assert.Equal(t, tc.field, httpErr.Errors[0].Field)
+			}
+		})
+	}
+}
@@ -0,0 +1,521 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// Ansible inventories come as INI:
+//
+//	[web]
+//	web[01:02].lan ansible_host=10.0.0.11 http_port=8080
+//
+//	[prod:children]
+//	web
+//
+// or as YAML:
+//
+//	all:
+//	  children:
+//	    web:
+//	      hosts:
+//	        web01.lan: {ansible_host: 10.0.0.11, http_port: 8080}
+//
+// Every host becomes an asset named after it, tagged with its groups and
+// their parent groups. ansible_host becomes the hostname, and the other host
+// variables metadata. Group variables are not imported.
+
+// ansibleImplicitGroups contain every host, so they make no useful tag
+var ansibleImplicitGroups = map[string]bool{"all": true, "ungrouped": true}
+
+// ansibleSecretVars hold credentials, which are never copied into metadata
+var ansibleSecretVars = map[string]bool{
+	"ansible_password":        true,
+	"ansible_ssh_pass":        true,
+	"ansible_ssh_password":    true,
+	"ansible_become_password": true,
+	"ansible_become_pass":     true,
+	"ansible_sudo_pass":       true,
+}
+
+// ansibleHost is a host as it appears across the uploaded inventories
+type ansibleHost struct {
+	Name   string
+	Source string
+	Groups map[string]bool
+	Vars   map[string]any
+}
+
+// ansibleInventory collects hosts and the group hierarchy from one or more
+// inventory files
+type ansibleInventory struct {
+	hosts   []*ansibleHost
+	byName  map[string]*ansibleHost
+	parents map[string]map[string]bool
+}
+
+func newAnsibleInventory() *ansibleInventory {
+	return &ansibleInventory{
+		byName:  make(map[string]*ansibleHost),
+		parents: make(map[string]map[string]bool),
+	}
+}
+
+// addHost records a host in a group. Hosts listed more than once, in any
+// file, are merged; later variables win.
+func (inv *ansibleInventory) addHost(name, group, source string, vars map[string]any) error {
+	if len(inv.hosts) == model.MaxInventoryAssets && inv.byName[strings.ToLower(name)] == nil {
+		return inventoryFileError(source, fmt.Sprintf("imports must not describe more than %d assets", model.MaxInventoryAssets))
+	}
+
+	host := inv.byName[strings.ToLower(name)]
+	if host == nil {
+		host = &ansibleHost{Name: name, Source: source, Groups: make(map[string]bool), Vars: make(map[string]any)}
+		inv.byName[strings.ToLower(name)] = host
+		inv.hosts = append(inv.hosts, host)
+	}
+	host.Groups[group] = true
+	for key, value := range vars {
+		host.Vars[key] = value
+	}
+	return nil
+}
+
+// addChild records that a group is a child of another
+func (inv *ansibleInventory) addChild(parent, child string) {
+	if inv.parents[child] == nil {
+		inv.parents[child] = make(map[string]bool)
+	}
+	inv.parents[child][parent] = true
+}
+
+// groupsOf returns a host's groups and all their ancestors, sorted
+func (inv *ansibleInventory) groupsOf(host *ansibleHost) []string {
+	seen := make(map[string]bool)
+	var visit func(group string)
+	visit = func(group string) {
+		if seen[group] {
+			return
+		}
+		seen[group] = true
+		for parent := range inv.parents[group] {
+			visit(parent)
+		}
+	}
+	for group := range host.Groups {
+		visit(group)
+	}
+
+	groups := make([]string, 0, len(seen))
+	for group := range seen {
+		if !ansibleImplicitGroups[group] {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// expandAnsibleHostPattern expands ranges such as web[01:03].lan or
+// db-[a:c], with an optional stride as in [1:9:2]
+func expandAnsibleHostPattern(pattern string) ([]string, error) {
+	start := strings.Index(pattern, "[")
+	if start < 0 {
+		return []string{pattern}, nil
+	}
+	end := strings.Index(pattern[start:], "]")
+	if end < 0 {
+		return nil, fmt.Errorf("host pattern %s has an unclosed range", pattern)
+	}
+	end += start
+
+	bounds := strings.Split(pattern[start+1:end], ":")
+	if len(bounds) < 2 || len(bounds) > 3 {
+		return nil, fmt.Errorf("host pattern %s must give ranges as [start:end] or [start:end:stride]", pattern)
+	}
+	stride := 1
+	if len(bounds) == 3 {
+		var err error
+		if stride, err = strconv.Atoi(bounds[2]); err != nil || stride < 1 {
+			return nil, fmt.Errorf("host pattern %s has an invalid stride", pattern)
+		}
+	}
+
+	var values []string
+	if from, err := strconv.Atoi(bounds[0]); err == nil {
+		to, err := strconv.Atoi(bounds[1])
+		if err != nil || to < from {
+			return nil, fmt.Errorf("host pattern %s has an invalid range", pattern)
+		}
+		if (to-from)/stride >= model.MaxInventoryAssets {
+			return nil, fmt.Errorf("host pattern %s describes more than %d hosts", pattern, model.MaxInventoryAssets)
+		}
+		// Leading zeros set the width, as in [01:10]
+		for i := from; i <= to; i += stride {
+			values = append(values, fmt.Sprintf("%0*d", len(bounds[0]), i))
+		}
+	} else if len(bounds[0]) == 1 && len(bounds[1]) == 1 && bounds[0] <= bounds[1] {
+		for c := int(bounds[0][0]); c <= int(bounds[1][0]); c += stride {
+			values = append(values, string(rune(c)))
+		}
+	} else {
+		return nil, fmt.Errorf("host pattern %s has an invalid range", pattern)
+	}
+
+	rest, err := expandAnsibleHostPattern(pattern[end+1:])
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, value := range values {
+		for _, suffix := range rest {
+			names = append(names, pattern[:start]+value+suffix)
+		}
+		if len(names) > model.MaxInventoryAssets {
+			return nil, fmt.Errorf("host pattern %s describes more than %d hosts", pattern, model.MaxInventoryAssets)
+		}
+	}
+	return names, nil
+}
+
+// splitAnsibleINILine splits a line on whitespace outside quotes and drops a
+// trailing # comment. Quotes are kept so values can tell strings from numbers.
+func splitAnsibleINILine(line string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	var quote rune
+	inField := false
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			field.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			field.WriteRune(r)
+			quote = r
+			inField = true
+		case r == ' ' || r == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		case r == '#' && !inField:
+			return fields, nil
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// parseAnsibleINIValue reads a variable value: quoted values are strings,
+// and unquoted ones numbers or booleans where they look like one
+func parseAnsibleINIValue(value string) any {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	switch strings.ToLower(value) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return value
+}
+
+// parseAnsibleINI reads an INI inventory into inv
+func parseAnsibleINI(inv *ansibleInventory, name string, data []byte) error {
+	group, section := "ungrouped", "hosts"
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		lineError := func(message string) error {
+			return inventoryFileError(fmt.Sprintf("%s: line %d", name, lineNumber), message)
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return lineError("section header must end with ]")
+			}
+			group, section = strings.TrimSpace(line[1:len(line)-1]), "hosts"
+			if before, after, found := strings.Cut(group, ":"); found {
+				group, section = before, after
+			}
+			if group == "" || (section != "hosts" && section != "vars" && section != "children") {
+				return lineError("section must be [group], [group:vars] or [group:children]")
+			}
+			continue
+		}
+
+		switch section {
+		case "vars":
+			// Group variables are not imported
+		case "children":
+			inv.addChild(group, line)
+		default:
+			fields, err := splitAnsibleINILine(line)
+			if err != nil {
+				return lineError(err.Error())
+			}
+			if len(fields) == 0 {
+				continue
+			}
+
+			vars := make(map[string]any, len(fields)-1)
+			for _, field := range fields[1:] {
+				key, value, found := strings.Cut(field, "=")
+				if !found || key == "" {
+					return lineError(fmt.Sprintf("host variable %s must be written as key=value", field))
+				}
+				vars[key] = parseAnsibleINIValue(value)
+			}
+
+			hosts, err := expandAnsibleHostPattern(fields[0])
+			if err != nil {
+				return lineError(err.Error())
+			}
+			for _, host := range hosts {
+				if err := inv.addHost(host, group, name+": "+host, vars); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return inventoryFileError(name, "is not a valid INI inventory: "+err.Error())
+	}
+
+	return nil
+}
+
+// ansibleYAMLGroup is a group of a YAML inventory
+type ansibleYAMLGroup struct {
+	Hosts    map[string]map[string]any    `yaml:"hosts"`
+	Children map[string]*ansibleYAMLGroup `yaml:"children"`
+}
+
+// parseAnsibleYAML reads a YAML inventory into inv
+func parseAnsibleYAML(inv *ansibleInventory, name string, data []byte) error {
+	var groups map[string]*ansibleYAMLGroup
+	if err := yaml.Unmarshal(data, &groups); err != nil {
+		return inventoryFileError(name, "is not a valid YAML inventory: "+err.Error())
+	}
+
+	var walk func(groupName string, group *ansibleYAMLGroup) error
+	walk = func(groupName string, group *ansibleYAMLGroup) error {
+		if group == nil {
+			return nil
+		}
+
+		hostNames := make([]string, 0, len(group.Hosts))
+		for host := range group.Hosts {
+			hostNames = append(hostNames, host)
+		}
+		sort.Strings(hostNames)
+		for _, pattern := range hostNames {
+			hosts, err := expandAnsibleHostPattern(pattern)
+			if err != nil {
+				return inventoryFileError(name+": "+pattern, err.Error())
+			}
+			for _, host := range hosts {
+				if err := inv.addHost(host, groupName, name+": "+host, group.Hosts[pattern]); err != nil {
+					return err
+				}
+			}
+		}
+
+		childNames := make([]string, 0, len(group.Children))
+		for child := range group.Children {
+			childNames = append(childNames, child)
+		}
+		sort.Strings(childNames)
+		for _, child := range childNames {
+			inv.addChild(groupName, child)
+			if err := walk(child, group.Children[child]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for group := range groups {
+		groupNames = append(groupNames, group)
+	}
+	sort.Strings(groupNames)
+	for _, group := range groupNames {
+		if err := walk(group, groups[group]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isAnsibleYAML tells YAML inventories from INI ones by their extension
+func isAnsibleYAML(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yml", ".yaml", ".json":
+		return true
+	}
+	return false
+}
+
+// ansibleHostVarString returns a variable's value if it is a string
+func ansibleHostVarString(vars map[string]any, key string) (string, bool) {
+	value, ok := vars[key].(string)
+	return value, ok && value != ""
+}
+
+// parseAnsibleInventories reads the hosts of Ansible inventory files as
+// assets, keyed by their lowercased inventory hostname. assetType, if not
+// empty, is given to every host.
+func parseAnsibleInventories(files []model.InventoryFile, assetType string) ([]inventoryAsset, error) {
+	inv := newAnsibleInventory()
+	for _, f := range files {
+		parse := parseAnsibleINI
+		if isAnsibleYAML(f.Name) {
+			parse = parseAnsibleYAML
+		}
+		if err := parse(inv, f.Name, f.Data); err != nil {
+			return nil, err
+		}
+	}
+	if len(inv.hosts) == 0 {
+		return nil, inventoryFileError("files", "contain no hosts")
+	}
+
+	items := make([]inventoryAsset, 0, len(inv.hosts))
+	for _, host := range inv.hosts {
+		if utf8.RuneCountInString(host.Name) > 100 {
+			return nil, inventoryFileError(host.Source, "host name must be at most 100 characters")
+		}
+
+		// Without ansible_host, Ansible connects to the inventory hostname
+		hostname := host.Name
+		if value, ok := ansibleHostVarString(host.Vars, "ansible_host"); ok {
+			hostname = value
+		}
+		if utf8.RuneCountInString(hostname) > 255 {
+			return nil, inventoryFileError(host.Source, "ansible_host must be at most 255 characters")
+		}
+
+		tags := inv.groupsOf(host)
+		for _, tag := range tags {
+			if utf8.RuneCountInString(tag) > 50 {
+				return nil, inventoryFileError(host.Source, fmt.Sprintf("group %s must be at most 50 characters to become a tag", tag))
+			}
+		}
+
+		metadata := make(map[string]any, len(host.Vars))
+		for key, value := range host.Vars {
+			if key == "ansible_host" || ansibleSecretVars[key] {
+				continue
+			}
+			if s, ok := value.(string); ok && strings.HasPrefix(s, "$ANSIBLE_VAULT") {
+				continue
+			}
+			metadata[key] = value
+		}
+
+		item := inventoryAsset{
+			Source:   host.Source,
+			Key:      strings.ToLower(host.Name),
+			Name:     host.Name,
+			Hostname: &hostname,
+			Tags:     tags,
+			Metadata: metadata,
+		}
+		if assetType != "" {
+			item.Type = &assetType
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// ImportAnsible imports the hosts of Ansible inventory files. Hosts are
+// matched to existing assets by name, ignoring case, and updated in place with
+// a change log describing what differed.
+func (s *InventoryService) ImportAnsible(ctx context.Context, userID string, params *model.AnsibleImportParams, files []model.InventoryFile) (*model.InventoryImportResult, error) {
+	if params.Type != "" && !model.IsValidAssetType(params.Type) {
+		return nil, errs.NewBadRequestError("Invalid type", false, nil, []errs.FieldError{
+			{Field: "type", Error: "must be one of: server vm nas container network other"},
+		}, nil)
+	}
+
+	items, err := parseAnsibleInventories(files, params.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	assets, err := s.assetRepo.FindByField(ctx, userID, "name", names)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*model.Asset, len(assets))
+	for _, asset := range assets {
+		// The oldest asset wins if several share a name
+		if key := strings.ToLower(asset.Name); existing[key] == nil {
+			existing[key] = asset
+		}
+	}
+	for i, item := range items {
+		// Matching ignores case, so keep the existing spelling
+		if asset := existing[item.Key]; asset != nil {
+			items[i].Name = asset.Name
+		}
+	}
+
+	writes, writeResults, result, err := planInventoryImport(items, existing, inventorySource{
+		Label: func(item inventoryAsset) string {
+			file, _, _ := strings.Cut(item.Source, ": ")
+			return "Ansible inventory " + file
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyInventoryImport(ctx, userID, writes, writeResults, result, params.DryRun)
+}
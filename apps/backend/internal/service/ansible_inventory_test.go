@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+const testAnsibleINI = `
+# Bastion has no group
+bastion.lan ansible_user=admin
+
+[web]
+web[01:02].lan ansible_host=10.0.0.1 http_port=8080 ansible_password=secret
+proxy.lan motd="hello world" # front door
+
+[db]
+db01.lan ansible_host=10.0.0.5 primary=true
+
+[db:vars]
+backup_window=02:00
+
+[prod:children]
+web
+db
+`
+
+const testAnsibleYAML = `
+all:
+  hosts:
+    bastion.lan:
+  children:
+    prod:
+      children:
+        db:
+          hosts:
+            db01.lan:
+              ansible_host: 10.0.0.5
+              disks: [sda, sdb]
+`
+
+// TestParseAnsibleInventories_INI reads hosts, group tags and host variables
+func TestParseAnsibleInventories_INI(t *testing.T) {
+	items, err := parseAnsibleInventories([]model.InventoryFile{{Name: "hosts", Data: []byte(testAnsibleINI)}}, "server")
+	require.NoError(t, err)
+	require.Len(t, items, 5)
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"bastion.lan", "web01.lan", "web02.lan", "proxy.lan", "db01.lan"}, names)
+
+	bastion := items[0]
+	assert.Equal(t, "bastion.lan", *bastion.Hostname)
+	assert.Empty(t, bastion.Tags)
+	assert.Equal(t, map[string]any{"ansible_user": "admin"}, bastion.Metadata)
+	assert.Equal(t, "server", *bastion.Type)
+
+	web := items[2]
+	assert.Equal(t, "hosts: web02.lan", web.Source)
+	assert.Equal(t, "web02.lan", web.Key)
+	assert.Equal(t, "10.0.0.1", *web.Hostname)
+	assert.Equal(t, []string{"prod", "web"}, web.Tags)
+	assert.Equal(t, map[string]any{"http_port": int64(8080)}, web.Metadata)
+
+	assert.Equal(t, map[string]any{"motd": "hello world"}, items[3].Metadata)
+	assert.Equal(t, []string{"db", "prod"}, items[4].Tags)
+	assert.Equal(t, map[string]any{"primary": true}, items[4].Metadata)
+}
+
+// TestParseAnsibleInventories_YAML merges hosts across files
+func TestParseAnsibleInventories_YAML(t *testing.T) {
+	items, err := parseAnsibleInventories([]model.InventoryFile{
+		{Name: "hosts.yml", Data: []byte(testAnsibleYAML)},
+		{Name: "extra.ini", Data: []byte("[backup]\nDB01.lan\n")},
+	}, "")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "bastion.lan", items[0].Name)
+	assert.Nil(t, items[0].Type)
+
+	db := items[1]
+	assert.Equal(t, "db01.lan", db.Name)
+	assert.Equal(t, "10.0.0.5", *db.Hostname)
+	assert.Equal(t, []string{"backup", "db", "prod"}, db.Tags)
+	assert.Equal(t, map[string]any{"disks": []any{"sda", "sdb"}}, db.Metadata)
+}
+
+// TestExpandAnsibleHostPattern expands numeric and alphabetic ranges
+func TestExpandAnsibleHostPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"web.lan", []string{"web.lan"}},
+		{"web[01:03].lan", []string{"web01.lan", "web02.lan", "web03.lan"}},
+		{"db-[a:c]", []string{"db-a", "db-b", "db-c"}},
+		{"node[1:5:2]", []string{"node1", "node3", "node5"}},
+		{"r[1:2]u[1:2]", []string{"r1u1", "r1u2", "r2u1", "r2u2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := expandAnsibleHostPattern(tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, pattern := range []string{"web[1:", "web[3:1]", "web[1:9:0]", "web[1:99999]"} {
+		_, err := expandAnsibleHostPattern(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
+
+// TestParseAnsibleInventories_Errors names the file and line at fault
+func TestParseAnsibleInventories_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  model.InventoryFile
+		field string
+	}{
+		{"bad section", model.InventoryFile{Name: "hosts", Data: []byte("[web:other]\n")}, "hosts: line 1"},
+		{"bad variable", model.InventoryFile{Name: "hosts", Data: []byte("[web]\nweb01 port\n")}, "hosts: line 2"},
+		{"unterminated quote", model.InventoryFile{Name: "hosts", Data: []byte("web01 motd='hi\n")}, "hosts: line 1"},
+		{"invalid YAML", model.InventoryFile{Name: "hosts.yml", Data: []byte("all: [")}, "hosts.yml"},
+		{"no hosts", model.InventoryFile{Name: "hosts", Data: []byte("[web]\n")}, "files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseAnsibleInventories([]model.InventoryFile{tt.file}, "")
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			require.Len(t, httpErr.Errors, 1)
+			assert.Equal(t, tt.field, httpErr.Errors[0].Field)
+		})
+	}
+}
+
+// TestInventoryService_ImportAnsible_InvalidType rejects unknown asset types
+func TestInventoryService_ImportAnsible_InvalidType(t *testing.T) {
+	service := NewInventoryService(nil, nil, nil)
+
+	_, err := service.ImportAnsible(context.Background(), "user-123", &model.AnsibleImportParams{Type: "router"}, nil)
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// apiTokenBytes is the amount of randomness in an API token
+const apiTokenBytes = 32
+
+// apiTokenPrefixLength is how much of a token is kept to recognize it
+const apiTokenPrefixLength = len(model.APITokenPrefix) + 6
+
+// APITokenService issues and checks the long-lived tokens scripts use for
+// the integration endpoints. Tokens are random, returned once, and stored
+// only as a SHA-256 hash.
+type APITokenService struct {
+	tokenRepo *repository.APITokenRepository
+}
+
+func NewAPITokenService(tokenRepo *repository.APITokenRepository) *APITokenService {
+	return &APITokenService{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// newAPIToken generates a token: the prefix followed by random bytes in
+// URL-safe base64
+func newAPIToken() (string, error) {
+	secret := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate api token: %w", err)
+	}
+	return model.APITokenPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashAPIToken returns the hash a token is stored and looked up by
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *APITokenService) Create(ctx context.Context, userID string, req *model.CreateAPITokenRequest) (*model.CreatedAPIToken, error) {
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		expires := time.Now().UTC().AddDate(0, 0, *req.ExpiresInDays)
+		expiresAt = &expires
+	}
+
+	token, err := newAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.tokenRepo.Create(ctx, userID, req.Name, hashAPIToken(token), token[:apiTokenPrefixLength], expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CreatedAPIToken{APIToken: *created, Token: token}, nil
+}
+
+func (s *APITokenService) List(ctx context.Context, userID string) (*model.APITokenListResponse, error) {
+	tokens, err := s.tokenRepo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewAPITokenListResponse(tokens), nil
+}
+
+func (s *APITokenService) Delete(ctx context.Context, userID string, tokenID uuid.UUID) error {
+	return s.tokenRepo.Delete(ctx, userID, tokenID)
+}
+
+// Authenticate returns the user an unexpired API token belongs to
+func (s *APITokenService) Authenticate(ctx context.Context, token string) (string, error) {
+	if !strings.HasPrefix(token, model.APITokenPrefix) {
+		return "", errs.NewUnauthorizedError("Invalid or expired token", false)
+	}
+
+	apiToken, err := s.tokenRepo.Authenticate(ctx, hashAPIToken(token))
+	if err != nil {
+		return "", err
+	}
+	if apiToken == nil {
+		return "", errs.NewUnauthorizedError("Invalid or expired token", false)
+	}
+
+	return apiToken.UserID, nil
+}
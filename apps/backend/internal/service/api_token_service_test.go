@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestAPITokenService_Constructor verifies NewAPITokenService works correctly
+func TestAPITokenService_Constructor(t *testing.T) {
+	service := NewAPITokenService(nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestNewAPIToken generates distinct prefixed tokens
+func TestNewAPIToken(t *testing.T) {
+	first, err := newAPIToken()
+	require.NoError(t, err)
+	second, err := newAPIToken()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, model.APITokenPrefix))
+	assert.Len(t, first, len(model.APITokenPrefix)+43)
+	assert.NotEqual(t, first, second)
+	assert.Greater(t, len(first), apiTokenPrefixLength)
+}
+
+// TestHashAPIToken is stable and hides the token
+func TestHashAPIToken(t *testing.T) {
+	hash := hashAPIToken("ark_example")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, hashAPIToken("ark_example"))
+	assert.NotEqual(t, hash, hashAPIToken("ark_other"))
+}
+
+// TestAPITokenService_Authenticate_NotAPIToken rejects other tokens without a lookup
+func TestAPITokenService_Authenticate_NotAPIToken(t *testing.T) {
+	service := NewAPITokenService(nil)
+
+	_, err := service.Authenticate(context.Background(), "eyJhbGciOiJSUzI1NiJ9.e30.sig")
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Status)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// Asset CSV layout:
+//
+//	id,name,type,hostname,tags,pinned,favorite,created_at,updated_at,metadata.<key>...
+//
+// Tags are joined with ", ". Metadata keys of all exported assets become
+// columns in alphabetical order; string values are written as is and other
+// values as JSON. Text cells that a spreadsheet would run as a formula are
+// prefixed with a single quote, which imports strip again. Imports read the
+// same layout, or any columns mapped to asset fields.
+
+// assetCSVColumns are the fixed columns of an exported asset CSV
+var assetCSVColumns = []string{
+	model.AssetCSVFieldID,
+	model.AssetCSVFieldName,
+	model.AssetCSVFieldType,
+	model.AssetCSVFieldHostname,
+	model.AssetCSVFieldTags,
+	model.AssetCSVFieldPinned,
+	model.AssetCSVFieldFavorite,
+	model.AssetCSVFieldCreatedAt,
+	model.AssetCSVFieldUpdatedAt,
+}
+
+// assetCSVTagSeparator joins tags in a single cell
+const assetCSVTagSeparator = ", "
+
+// utf8BOM is written by spreadsheet applications at the start of CSV files
+const utf8BOM = "\ufeff"
+
+// csvFormulaPrefixes start a cell that spreadsheet applications evaluate as a
+// formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvEscapeCell prefixes a cell that would be evaluated as a formula with a
+// single quote
+func csvEscapeCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvUnescapeCell removes the quote added by csvEscapeCell
+func csvUnescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// decodeAssetMetadata returns the keys of an asset's metadata object
+func decodeAssetMetadata(metadata json.RawMessage) map[string]json.RawMessage {
+	var values map[string]json.RawMessage
+	if len(metadata) == 0 || json.Unmarshal(metadata, &values) != nil {
+		return nil
+	}
+	return values
+}
+
+// metadataCell writes a metadata value: strings as is, null as empty and
+// anything else as JSON
+func metadataCell(value json.RawMessage) string {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+
+	var compact bytes.Buffer
+	if json.Compact(&compact, value) != nil || compact.String() == "null" {
+		return ""
+	}
+	return compact.String()
+}
+
+// writeAssetCSV writes assets as CSV with their metadata keys flattened into
+// columns
+func writeAssetCSV(assets []*model.Asset) ([]byte, error) {
+	metadata := make([]map[string]json.RawMessage, len(assets))
+	keySet := make(map[string]bool)
+	for i, asset := range assets {
+		metadata[i] = decodeAssetMetadata(asset.Metadata)
+		for key := range metadata[i] {
+			keySet[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := append([]string{}, assetCSVColumns...)
+	for _, key := range keys {
+		header = append(header, model.AssetCSVMetadataPrefix+key)
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for i, asset := range assets {
+		record := []string{
+			asset.ID.String(),
+			csvEscapeCell(asset.Name),
+			csvEscapeCell(derefString(asset.Type)),
+			csvEscapeCell(derefString(asset.Hostname)),
+			csvEscapeCell(strings.Join(asset.Tags, assetCSVTagSeparator)),
+			strconv.FormatBool(asset.Pinned),
+			strconv.FormatBool(asset.Favorite),
+			asset.CreatedAt.UTC().Format(time.RFC3339),
+			asset.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		for _, key := range keys {
+			record = append(record, csvEscapeCell(metadataCell(metadata[i][key])))
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// derefString returns the string or "" for nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// assetCSVError reports a problem with an uploaded CSV
+func assetCSVError(field, message string) error {
+	return errs.NewBadRequestError("Invalid CSV", false, nil, []errs.FieldError{
+		{Field: field, Error: message},
+	}, nil)
+}
+
+// readAssetCSV reads the header and rows of an uploaded CSV. Every row must
+// have as many cells as the header.
+func readAssetCSV(r io.Reader) ([]string, [][]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, assetCSVError("file", "is empty")
+	}
+	if err != nil {
+		return nil, nil, csvParseError(err)
+	}
+
+	header[0] = strings.TrimPrefix(header[0], utf8BOM)
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return nil, nil, assetCSVError(fmt.Sprintf("column %d", i+1), "has no name")
+		}
+		if seen[column] {
+			return nil, nil, assetCSVError(column, "appears more than once")
+		}
+		seen[column] = true
+		header[i] = column
+	}
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, csvParseError(err)
+		}
+		if len(rows) == model.MaxAssetCSVRows {
+			return nil, nil, assetCSVError("file", fmt.Sprintf("must not have more than %d rows", model.MaxAssetCSVRows))
+		}
+		rows = append(rows, record)
+	}
+
+	return header, rows, nil
+}
+
+// csvParseError reports the line of a malformed CSV
+func csvParseError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return assetCSVError(fmt.Sprintf("line %d", parseErr.StartLine), parseErr.Err.Error())
+	}
+	return assetCSVError("file", "is not a valid CSV file")
+}
+
+// suggestAssetCSVField maps a column to the asset field of the same name.
+// Exported timestamps and IDs are ignored, and any other column is kept as
+// a metadata key.
+func suggestAssetCSVField(column string) string {
+	switch name := strings.ToLower(column); name {
+	case model.AssetCSVFieldName, model.AssetCSVFieldType, model.AssetCSVFieldHostname,
+		model.AssetCSVFieldTags, model.AssetCSVFieldPinned, model.AssetCSVFieldFavorite:
+		return name
+	case "host":
+		return model.AssetCSVFieldHostname
+	case "tag":
+		return model.AssetCSVFieldTags
+	case model.AssetCSVFieldID, model.AssetCSVFieldCreatedAt, model.AssetCSVFieldUpdatedAt:
+		return ""
+	}
+
+	field := column
+	if !strings.HasPrefix(field, model.AssetCSVMetadataPrefix) {
+		field = model.AssetCSVMetadataPrefix + column
+	}
+	if !model.IsAssetCSVImportField(field) {
+		return ""
+	}
+	return field
+}
+
+// suggestAssetCSVMapping suggests a field for every column
+func suggestAssetCSVMapping(header []string) map[string]string {
+	mapping := make(map[string]string, len(header))
+	for _, column := range header {
+		mapping[column] = suggestAssetCSVField(column)
+	}
+	return mapping
+}
+
+// parseCSVBool accepts the usual spreadsheet spellings of true and false
+func parseCSVBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "x":
+		return true, true
+	case "false", "no", "n", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// parseCSVMetadataValue keeps JSON numbers, booleans, objects and arrays
+// written by exports; anything else is a string
+func parseCSVMetadataValue(value string) json.RawMessage {
+	if json.Valid([]byte(value)) && !strings.HasPrefix(value, `"`) && value != "null" {
+		return json.RawMessage(value)
+	}
+
+	encoded, _ := json.Marshal(value)
+	return encoded
+}
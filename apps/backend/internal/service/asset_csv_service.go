@@ -0,0 +1,435 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// maxAssetCSVFieldErrors bounds the row errors reported for an invalid CSV
+const maxAssetCSVFieldErrors = 100
+
+type AssetCSVService struct {
+	assetRepo *repository.AssetRepository
+	views     *SavedViewService
+	stats     *StatsService
+}
+
+func NewAssetCSVService(assetRepo *repository.AssetRepository, views *SavedViewService, stats *StatsService) *AssetCSVService {
+	return &AssetCSVService{
+		assetRepo: assetRepo,
+		views:     views,
+		stats:     stats,
+	}
+}
+
+// Export writes the assets matching the list filters, or the filters of the
+// saved asset view req.ViewID, as CSV in list order. Limit and offset are
+// ignored; at most model.MaxAssetCSVRows assets are exported.
+func (s *AssetCSVService) Export(ctx context.Context, userID string, req *model.AssetCSVExportRequest) ([]byte, error) {
+	params := &req.AssetQueryParams
+	if req.ViewID != nil {
+		filter, err := s.views.AssetFilter(ctx, userID, *req.ViewID)
+		if err != nil {
+			return nil, err
+		}
+		params = filter
+	}
+
+	params.SetDefaults()
+	if params.Tags != nil {
+		params.Tags = processTags(params.Tags)
+	}
+
+	total, err := s.assetRepo.Count(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if total > model.MaxAssetCSVRows {
+		code := "CSV_TOO_LARGE"
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("%d assets match, narrow the filters to at most %d", total, model.MaxAssetCSVRows),
+			false, &code, nil, nil)
+	}
+
+	params.Limit = model.MaxAssetCSVRows
+	params.Offset = 0
+	assets, err := s.assetRepo.List(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeAssetCSV(assets)
+}
+
+// Preview reads an uploaded CSV for the column-mapping step: its columns, a
+// suggested mapping and the first rows
+func (s *AssetCSVService) Preview(r io.Reader) (*model.AssetCSVPreview, error) {
+	header, rows, err := readAssetCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := rows
+	if len(sample) > model.AssetCSVPreviewRows {
+		sample = sample[:model.AssetCSVPreviewRows]
+	}
+	if sample == nil {
+		sample = [][]string{}
+	}
+
+	return &model.AssetCSVPreview{
+		Columns:   header,
+		Mapping:   suggestAssetCSVMapping(header),
+		Rows:      sample,
+		TotalRows: len(rows),
+	}, nil
+}
+
+// assetCSVRow is a CSV row mapped to asset fields; nil fields were empty
+type assetCSVRow struct {
+	Row      int
+	Key      string
+	Name     *string
+	Type     *string
+	Hostname *string
+	Tags     *[]string
+	Pinned   *bool
+	Favorite *bool
+	Metadata map[string]json.RawMessage
+}
+
+// fieldErrorList collects row errors up to maxAssetCSVFieldErrors
+type fieldErrorList []errs.FieldError
+
+func (l *fieldErrorList) add(row int, field, message string) {
+	if len(*l) < maxAssetCSVFieldErrors {
+		*l = append(*l, errs.FieldError{Field: fmt.Sprintf("row %d: %s", row, field), Error: message})
+	}
+}
+
+// validateAssetCSVMapping checks that every mapped column exists, every field
+// is an asset field or metadata key mapped once, and the match field is mapped
+func validateAssetCSVMapping(header []string, mapping map[string]string, match string) error {
+	columns := make(map[string]bool, len(header))
+	for _, column := range header {
+		columns[column] = true
+	}
+
+	var fieldErrors []errs.FieldError
+	mappedBy := make(map[string]string)
+	for column, field := range mapping {
+		switch {
+		case !columns[column]:
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "mapping." + column, Error: "is not a column of the file"})
+		case field == "":
+			// ignored column
+		case !model.IsAssetCSVImportField(field):
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "mapping." + column, Error: "must be an asset field or metadata.<key>"})
+		case mappedBy[field] != "":
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "mapping." + column, Error: field + " is already mapped from " + mappedBy[field]})
+		default:
+			mappedBy[field] = column
+		}
+	}
+
+	if len(fieldErrors) == 0 && mappedBy[match] == "" {
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: "mapping", Error: "a column must be mapped to " + match + " to match assets"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	return nil
+}
+
+// parseAssetCSVRow maps the cells of a row to asset fields. Empty cells leave
+// the field unset.
+func parseAssetCSVRow(row int, header, record []string, mapping map[string]string, fieldErrors *fieldErrorList) *assetCSVRow {
+	parsed := &assetCSVRow{Row: row}
+	for i, column := range header {
+		field := mapping[column]
+		value := csvUnescapeCell(strings.TrimSpace(record[i]))
+		if field == "" || value == "" {
+			continue
+		}
+
+		switch field {
+		case model.AssetCSVFieldName:
+			if utf8.RuneCountInString(value) > 100 {
+				fieldErrors.add(row, field, "must be at most 100 characters")
+			}
+			parsed.Name = &value
+		case model.AssetCSVFieldType:
+			value = strings.ToLower(value)
+			if !model.IsValidAssetType(value) {
+				fieldErrors.add(row, field, "must be one of: server vm nas container network other")
+			}
+			parsed.Type = &value
+		case model.AssetCSVFieldHostname:
+			if utf8.RuneCountInString(value) > 255 {
+				fieldErrors.add(row, field, "must be at most 255 characters")
+			}
+			parsed.Hostname = &value
+		case model.AssetCSVFieldTags:
+			tags := processTags(strings.Split(value, ","))
+			parsed.Tags = &tags
+		case model.AssetCSVFieldPinned, model.AssetCSVFieldFavorite:
+			flag, ok := parseCSVBool(value)
+			if !ok {
+				fieldErrors.add(row, field, "must be true or false")
+			}
+			if field == model.AssetCSVFieldPinned {
+				parsed.Pinned = &flag
+			} else {
+				parsed.Favorite = &flag
+			}
+		default:
+			if parsed.Metadata == nil {
+				parsed.Metadata = make(map[string]json.RawMessage)
+			}
+			parsed.Metadata[strings.TrimPrefix(field, model.AssetCSVMetadataPrefix)] = parseCSVMetadataValue(value)
+		}
+	}
+
+	return parsed
+}
+
+// mergeAssetMetadata sets keys on an existing metadata object
+func mergeAssetMetadata(existing json.RawMessage, values map[string]json.RawMessage) (json.RawMessage, error) {
+	merged := decodeAssetMetadata(existing)
+	if merged == nil {
+		merged = make(map[string]json.RawMessage, len(values))
+	}
+	for key, value := range values {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
+
+// diffAssetCSVRow builds the update a row makes to its matched asset, with
+// only the fields that differ. It returns nil when the row changes nothing.
+func diffAssetCSVRow(asset *model.Asset, row *assetCSVRow) (*model.UpdateAssetRequest, error) {
+	update := &model.UpdateAssetRequest{}
+	changed := false
+
+	if row.Name != nil && *row.Name != asset.Name {
+		update.Name = row.Name
+		changed = true
+	}
+	if row.Type != nil && *row.Type != derefString(asset.Type) {
+		update.Type = row.Type
+		changed = true
+	}
+	if row.Hostname != nil && *row.Hostname != derefString(asset.Hostname) {
+		update.Hostname = row.Hostname
+		changed = true
+	}
+	if row.Tags != nil && !slices.Equal(*row.Tags, asset.Tags) {
+		update.Tags = row.Tags
+		changed = true
+	}
+	if row.Pinned != nil && *row.Pinned != asset.Pinned {
+		update.Pinned = row.Pinned
+		changed = true
+	}
+	if row.Favorite != nil && *row.Favorite != asset.Favorite {
+		update.Favorite = row.Favorite
+		changed = true
+	}
+
+	existing := decodeAssetMetadata(asset.Metadata)
+	for key, value := range row.Metadata {
+		if current, ok := existing[key]; ok && jsonEqual(current, value) {
+			continue
+		}
+		metadata, err := mergeAssetMetadata(asset.Metadata, row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		update.Metadata = (*json.RawMessage)(&metadata)
+		changed = true
+		break
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return update, nil
+}
+
+// Import creates and updates assets from an uploaded CSV. mapping assigns an
+// asset field or metadata key to each column; columns mapped to "" are
+// ignored, and a nil mapping uses the suggested one. Rows update the asset
+// whose name or hostname (params.Match) equals theirs, ignoring case, and
+// create an asset otherwise; rows that change nothing are reported as
+// unchanged and not written. Every row is validated before anything is
+// written, and all rows are written in one transaction.
+func (s *AssetCSVService) Import(ctx context.Context, userID string, r io.Reader, mapping map[string]string, params *model.AssetCSVImportParams) (*model.AssetCSVImportResult, error) {
+	params.SetDefaults()
+	if params.Match != model.AssetCSVMatchName && params.Match != model.AssetCSVMatchHostname {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "match", Error: "must be one of: name hostname"},
+		}, nil)
+	}
+
+	header, records, err := readAssetCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping == nil {
+		mapping = suggestAssetCSVMapping(header)
+	}
+	if err := validateAssetCSVMapping(header, mapping, params.Match); err != nil {
+		return nil, err
+	}
+
+	// Parse every row, keyed by the lowercased match field
+	var fieldErrors fieldErrorList
+	rows := make([]*assetCSVRow, 0, len(records))
+	firstRow := make(map[string]int, len(records))
+	for i, record := range records {
+		row := parseAssetCSVRow(i+2, header, record, mapping, &fieldErrors)
+
+		key := row.Name
+		if params.Match == model.AssetCSVMatchHostname {
+			key = row.Hostname
+		}
+		if key == nil {
+			fieldErrors.add(row.Row, params.Match, "is required to match assets")
+			continue
+		}
+
+		row.Key = strings.ToLower(*key)
+		if first, ok := firstRow[row.Key]; ok {
+			fieldErrors.add(row.Row, params.Match, fmt.Sprintf("duplicates row %d", first))
+			continue
+		}
+		firstRow[row.Key] = row.Row
+		rows = append(rows, row)
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	keys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, row.Key)
+	}
+
+	existing, err := s.assetRepo.FindByField(ctx, userID, params.Match, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make(map[string][]*model.Asset, len(existing))
+	for _, asset := range existing {
+		key := asset.Name
+		if params.Match == model.AssetCSVMatchHostname {
+			key = derefString(asset.Hostname)
+		}
+		key = strings.ToLower(key)
+		matches[key] = append(matches[key], asset)
+	}
+
+	result := &model.AssetCSVImportResult{
+		DryRun: params.DryRun,
+		Match:  params.Match,
+		Rows:   make([]model.AssetCSVRowResult, 0, len(rows)),
+	}
+	writes := make([]repository.AssetWrite, 0, len(rows))
+	// writeRows[i] is the index in result.Rows of writes[i]
+	writeRows := make([]int, 0, len(rows))
+	for _, row := range rows {
+		found := matches[row.Key]
+		switch {
+		case len(found) > 1:
+			fieldErrors.add(row.Row, params.Match, fmt.Sprintf("matches %d assets", len(found)))
+
+		case len(found) == 1:
+			asset := found[0]
+			update, err := diffAssetCSVRow(asset, row)
+			if err != nil {
+				return nil, err
+			}
+			if update == nil {
+				result.Unchanged++
+				result.Rows = append(result.Rows, model.AssetCSVRowResult{Row: row.Row, Action: model.AssetCSVActionUnchanged, ID: &asset.ID, Name: asset.Name})
+				continue
+			}
+
+			result.Updated++
+			writeRows = append(writeRows, len(result.Rows))
+			result.Rows = append(result.Rows, model.AssetCSVRowResult{Row: row.Row, Action: model.AssetCSVActionUpdate, ID: &asset.ID, Name: derefOr(row.Name, asset.Name)})
+			writes = append(writes, repository.AssetWrite{ID: asset.ID, Update: update})
+
+		case row.Name == nil:
+			fieldErrors.add(row.Row, model.AssetCSVFieldName, "is required to create an asset")
+
+		default:
+			create := &model.CreateAssetRequest{
+				Name:     *row.Name,
+				Type:     row.Type,
+				Hostname: row.Hostname,
+				Pinned:   row.Pinned != nil && *row.Pinned,
+				Favorite: row.Favorite != nil && *row.Favorite,
+			}
+			if row.Tags != nil {
+				create.Tags = *row.Tags
+			}
+			if row.Metadata != nil {
+				metadata, err := json.Marshal(row.Metadata)
+				if err != nil {
+					return nil, err
+				}
+				create.Metadata = (*json.RawMessage)(&metadata)
+			}
+
+			result.Created++
+			writeRows = append(writeRows, len(result.Rows))
+			result.Rows = append(result.Rows, model.AssetCSVRowResult{Row: row.Row, Action: model.AssetCSVActionCreate, Name: *row.Name})
+			writes = append(writes, repository.AssetWrite{Create: create})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	if params.DryRun || len(writes) == 0 {
+		return result, nil
+	}
+
+	assets, err := s.assetRepo.BulkWrite(ctx, userID, writes)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.Invalidate(ctx, userID)
+
+	for i, asset := range assets {
+		result.Rows[writeRows[i]].ID = &asset.ID
+		result.Rows[writeRows[i]].Name = asset.Name
+	}
+
+	return result, nil
+}
+
+// derefOr returns the string, or fallback for nil
+func derefOr(s *string, fallback string) string {
+	if s == nil {
+		return fallback
+	}
+	return *s
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestAssetCSVService_Constructor verifies NewAssetCSVService works correctly
+func TestAssetCSVService_Constructor(t *testing.T) {
+	service := NewAssetCSVService(nil, nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestWriteAssetCSV flattens metadata keys into sorted columns
+func TestWriteAssetCSV(t *testing.T) {
+	created := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+	assets := []*model.Asset{
+		{
+			ID:        uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+			Name:      "nas-01",
+			Type:      stringPtr("nas"),
+			Tags:      []string{"storage", "prod"},
+			Metadata:  json.RawMessage(`{"rack": "A2", "disks": 4, "raid": {"level": 6}}`),
+			Pinned:    true,
+			CreatedAt: created,
+			UpdatedAt: created,
+		},
+		{
+			ID:        uuid.MustParse("66666666-7777-8888-9999-000000000000"),
+			Name:      "Router, basement",
+			CreatedAt: created,
+			UpdatedAt: created,
+		},
+	}
+
+	data, err := writeAssetCSV(assets)
+	require.NoError(t, err)
+
+	// Spreadsheet applications prepend a byte order mark
+	header, rows, err := readAssetCSV(bytes.NewReader(append([]byte("\ufeff"), data...)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"id", "name", "type", "hostname", "tags", "pinned", "favorite", "created_at", "updated_at",
+		"metadata.disks", "metadata.rack", "metadata.raid",
+	}, header)
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{
+		"11111111-2222-3333-4444-555555555555", "nas-01", "nas", "", "storage, prod", "true", "false",
+		"2024-05-30T12:00:00Z", "2024-05-30T12:00:00Z", "4", "A2", `{"level":6}`,
+	}, rows[0])
+	assert.Equal(t, "Router, basement", rows[1][1])
+	assert.Equal(t, "", rows[1][10], "assets without a key have an empty cell")
+}
+
+// TestWriteAssetCSV_FormulaCells quotes cells a spreadsheet would evaluate and
+// strips the quote again on import
+func TestWriteAssetCSV_FormulaCells(t *testing.T) {
+	created := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+	assets := []*model.Asset{{
+		ID:        uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Name:      `=HYPERLINK("http://evil.example","x")`,
+		Hostname:  stringPtr("@SUM(1+1)"),
+		Tags:      []string{"+cmd"},
+		Metadata:  json.RawMessage(`{"offset": -5, "note": "it's fine"}`),
+		CreatedAt: created,
+		UpdatedAt: created,
+	}}
+
+	data, err := writeAssetCSV(assets)
+	require.NoError(t, err)
+
+	header, rows, err := readAssetCSV(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, `'=HYPERLINK("http://evil.example","x")`, rows[0][1])
+	assert.Equal(t, "'@SUM(1+1)", rows[0][3])
+	assert.Equal(t, "'+cmd", rows[0][4])
+	assert.Equal(t, "it's fine", rows[0][9], "other cells are written as is")
+	assert.Equal(t, "'-5", rows[0][10])
+
+	mapping := suggestAssetCSVMapping(header)
+	var fieldErrors fieldErrorList
+	row := parseAssetCSVRow(2, header, rows[0], mapping, &fieldErrors)
+	assert.Empty(t, fieldErrors)
+	assert.Equal(t, assets[0].Name, *row.Name)
+	assert.Equal(t, "@SUM(1+1)", *row.Hostname)
+	assert.Equal(t, []string{"+cmd"}, *row.Tags)
+	assert.JSONEq(t, `-5`, string(row.Metadata["offset"]))
+	assert.JSONEq(t, `"it's fine"`, string(row.Metadata["note"]))
+}
+
+// TestReadAssetCSV_Invalid reports malformed files with their line
+func TestReadAssetCSV_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		csv   string
+		field string
+	}{
+		{name: "empty", csv: "", field: "file"},
+		{name: "duplicate column", csv: "name,Name,name\n", field: "name"},
+		{name: "unnamed column", csv: "name,,type\n", field: "column 2"},
+		{name: "ragged row", csv: "name,type\nnas-01,nas\npve-01\n", field: "line 3"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := readAssetCSV(strings.NewReader(tc.csv))
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			assert.Equal(t, tc.field, httpErr.Errors[0].Field)
+		})
+	}
+}
+
+// TestSuggestAssetCSVMapping maps exported columns back and keeps others as metadata
+func TestSuggestAssetCSVMapping(t *testing.T) {
+	mapping := suggestAssetCSVMapping([]string{"Name", "Host", "id", "updated_at", "metadata.rack", "Serial Number"})
+
+	assert.Equal(t, map[string]string{
+		"Name":          "name",
+		"Host":          "hostname",
+		"id":            "",
+		"updated_at":    "",
+		"metadata.rack": "metadata.rack",
+		"Serial Number": "metadata.Serial Number",
+	}, mapping)
+}
+
+// TestValidateAssetCSVMapping requires known columns, known fields and the match field
+func TestValidateAssetCSVMapping(t *testing.T) {
+	header := []string{"Name", "Host", "Rack"}
+
+	assert.NoError(t, validateAssetCSVMapping(header, map[string]string{"Name": "name", "Host": "hostname", "Rack": ""}, model.AssetCSVMatchName))
+
+	tests := []struct {
+		name    string
+		mapping map[string]string
+		match   string
+		field   string
+	}{
+		{name: "unknown column", mapping: map[string]string{"Name": "name", "Owner": "metadata.owner"}, match: model.AssetCSVMatchName, field: "mapping.Owner"},
+		{name: "unknown field", mapping: map[string]string{"Name": "name", "Rack": "rack"}, match: model.AssetCSVMatchName, field: "mapping.Rack"},
+		{name: "match not mapped", mapping: map[string]string{"Name": "name"}, match: model.AssetCSVMatchHostname, field: "mapping"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAssetCSVMapping(header, tc.mapping, tc.match)
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, tc.field, httpErr.Errors[0].Field)
+		})
+	}
+}
+
+// TestParseAssetCSVRow maps cells to fields and reports errors by row
+func TestParseAssetCSVRow(t *testing.T) {
+	header := []string{"Name", "Type", "Tags", "Pinned", "Disks", "Rack", "Notes"}
+	mapping := map[string]string{
+		"Name": "name", "Type": "type", "Tags": "tags", "Pinned": "pinned",
+		"Disks": "metadata.disks", "Rack": "metadata.rack", "Notes": "",
+	}
+
+	var fieldErrors fieldErrorList
+	row := parseAssetCSVRow(2, header, []string{" nas-01 ", "NAS", "Storage, prod,", "yes", "4", "A2", "ignored"}, mapping, &fieldErrors)
+
+	assert.Empty(t, fieldErrors)
+	assert.Equal(t, "nas-01", *row.Name)
+	assert.Equal(t, "nas", *row.Type)
+	assert.Equal(t, []string{"storage", "prod"}, *row.Tags)
+	assert.True(t, *row.Pinned)
+	assert.Nil(t, row.Favorite)
+	assert.JSONEq(t, `4`, string(row.Metadata["disks"]))
+	assert.JSONEq(t, `"A2"`, string(row.Metadata["rack"]))
+
+	row = parseAssetCSVRow(7, header, []string{"pve-01", "toaster", "", "maybe", "", "", ""}, mapping, &fieldErrors)
+	assert.Nil(t, row.Tags, "empty cells leave fields unset")
+	require.Len(t, fieldErrors, 2)
+	assert.Equal(t, "row 7: type", fieldErrors[0].Field)
+	assert.Equal(t, "row 7: pinned", fieldErrors[1].Field)
+}
+
+// TestMergeAssetMetadata keeps existing keys the CSV does not set
+func TestMergeAssetMetadata(t *testing.T) {
+	merged, err := mergeAssetMetadata(json.RawMessage(`{"rack": "A1", "owner": "sam"}`), map[string]json.RawMessage{
+		"rack": json.RawMessage(`"A2"`),
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rack": "A2", "owner": "sam"}`, string(merged))
+}
+
+// TestDiffAssetCSVRow only updates fields that differ from the asset
+func TestDiffAssetCSVRow(t *testing.T) {
+	asset := &model.Asset{
+		ID:       uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Name:     "nas-01",
+		Type:     stringPtr("nas"),
+		Tags:     []string{"storage", "prod"},
+		Metadata: json.RawMessage(`{"rack": "A2", "disks": 4}`),
+		Pinned:   true,
+	}
+	tags := []string{"storage", "prod"}
+	pinned := true
+
+	// Test 1: a re-imported export changes nothing
+	update, err := diffAssetCSVRow(asset, &assetCSVRow{
+		Name: stringPtr("nas-01"), Type: stringPtr("nas"), Tags: &tags, Pinned: &pinned,
+		Metadata: map[string]json.RawMessage{"disks": json.RawMessage(`4`), "rack": json.RawMessage(`"A2"`)},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, update)
+
+	// Test 2: only differing fields are set
+	update, err = diffAssetCSVRow(asset, &assetCSVRow{
+		Name: stringPtr("nas-01"), Hostname: stringPtr("nas-01.lan"),
+		Metadata: map[string]json.RawMessage{"rack": json.RawMessage(`"B1"`)},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	assert.Nil(t, update.Name)
+	assert.Equal(t, "nas-01.lan", *update.Hostname)
+	assert.Nil(t, update.Tags)
+	require.NotNil(t, update.Metadata)
+	assert.JSONEq(t, `{"rack": "B1", "disks": 4}`, string(*update.Metadata))
+}
+
+// TestAssetCSVService_Import_DuplicateRows rejects rows that match the same asset before touching the database
+func TestAssetCSVService_Import_DuplicateRows(t *testing.T) {
+	service := NewAssetCSVService(nil, nil, nil)
+	csv := "name,type\nnas-01,nas\nNAS-01,vm\npve-01,\n"
+
+	_, err := service.Import(context.Background(), "user-123", strings.NewReader(csv), nil, &model.AssetCSVImportParams{})
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Len(t, httpErr.Errors, 1)
+	assert.Equal(t, "row 3: name", httpErr.Errors[0].Field)
+	assert.Equal(t, "duplicates row 2", httpErr.Errors[0].Error)
+}
@@ -11,18 +11,25 @@ import (
 )
 
 type AssetService struct {
-	repo *repository.AssetRepository
+	repo  *repository.AssetRepository
+	stats *StatsService
 }
 
-func NewAssetService(repo *repository.AssetRepository) *AssetService {
+func NewAssetService(repo *repository.AssetRepository, stats *StatsService) *AssetService {
 	return &AssetService{
-		repo: repo,
+		repo:  repo,
+		stats: stats,
 	}
 }
 
 func (s *AssetService) List(ctx context.Context, userID string, params *model.AssetQueryParams) (*model.AssetListResponse, error) {
 	params.SetDefaults()
 
+	// Filter tags follow the same rules as stored tags
+	if params.Tags != nil {
+		params.Tags = processTags(params.Tags)
+	}
+
 	assets, err := s.repo.List(ctx, userID, params)
 	if err != nil {
 		return nil, err
@@ -55,10 +62,16 @@ func (s *AssetService) Create(ctx context.Context, userID string, req *model.Cre
 		return nil, err
 	}
 
+	// Process tags
+	if req.Tags != nil {
+		req.Tags = processTags(req.Tags)
+	}
+
 	asset, err := s.repo.Create(ctx, userID, req)
 	if err != nil {
 		return nil, err
 	}
+	s.stats.Invalidate(ctx, userID)
 
 	return model.NewAssetResponse(asset), nil
 }
@@ -73,14 +86,25 @@ func (s *AssetService) Update(ctx context.Context, userID string, assetID uuid.U
 		return nil, err
 	}
 
+	// Process tags if present
+	if req.Tags != nil {
+		processed := processTags(*req.Tags)
+		req.Tags = &processed
+	}
+
 	asset, err := s.repo.Update(ctx, userID, assetID, req)
 	if err != nil {
 		return nil, err
 	}
+	s.stats.Invalidate(ctx, userID)
 
 	return model.NewAssetResponse(asset), nil
 }
 
 func (s *AssetService) Delete(ctx context.Context, userID string, assetID uuid.UUID) error {
-	return s.repo.Delete(ctx, userID, assetID)
+	if err := s.repo.Delete(ctx, userID, assetID); err != nil {
+		return err
+	}
+	s.stats.Invalidate(ctx, userID)
+	return nil
 }
@@ -14,7 +14,7 @@ func TestAssetService_List_ReturnsAssetListResponse(t *testing.T) {
 	// This test verifies the return type signature
 	// We're not testing the actual business logic, just the type contract
 
-	service := NewAssetService(nil) // nil is okay for type checking
+	service := NewAssetService(nil, nil) // nil is okay for type checking
 
 	// Verify the method exists and returns the correct type
 	var result *model.AssetListResponse
@@ -32,7 +32,7 @@ func TestAssetService_List_ReturnsAssetListResponse(t *testing.T) {
 
 // TestAssetService_GetByID_ReturnsAssetResponse verifies GetByID returns AssetResponse DTO
 func TestAssetService_GetByID_ReturnsAssetResponse(t *testing.T) {
-	service := NewAssetService(nil)
+	service := NewAssetService(nil, nil)
 
 	var result *model.AssetResponse
 	var err error
@@ -48,7 +48,7 @@ func TestAssetService_GetByID_ReturnsAssetResponse(t *testing.T) {
 
 // TestAssetService_Create_ReturnsAssetResponse verifies Create returns AssetResponse DTO
 func TestAssetService_Create_ReturnsAssetResponse(t *testing.T) {
-	service := NewAssetService(nil)
+	service := NewAssetService(nil, nil)
 
 	var result *model.AssetResponse
 	var err error
@@ -64,7 +64,7 @@ func TestAssetService_Create_ReturnsAssetResponse(t *testing.T) {
 
 // TestAssetService_Update_ReturnsAssetResponse verifies Update returns AssetResponse DTO
 func TestAssetService_Update_ReturnsAssetResponse(t *testing.T) {
-	service := NewAssetService(nil)
+	service := NewAssetService(nil, nil)
 
 	var result *model.AssetResponse
 	var err error
@@ -80,7 +80,7 @@ func TestAssetService_Update_ReturnsAssetResponse(t *testing.T) {
 
 // TestAssetService_Delete_ReturnsError verifies Delete returns error
 func TestAssetService_Delete_ReturnsError(t *testing.T) {
-	service := NewAssetService(nil)
+	service := NewAssetService(nil, nil)
 
 	var err error
 
@@ -95,7 +95,7 @@ func TestAssetService_Delete_ReturnsError(t *testing.T) {
 // TestAssetService_Constructor verifies NewAssetService works correctly
 func TestAssetService_Constructor(t *testing.T) {
 	repo := &repository.AssetRepository{}
-	service := NewAssetService(repo)
+	service := NewAssetService(repo, nil)
 
 	assert.NotNil(t, service)
 	assert.IsType(t, &AssetService{}, service)
@@ -1,9 +1,13 @@
 package service
 
 import (
+	"context"
+	"fmt"
+
 	"ark/internal/server"
 
 	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/user"
 )
 
 type AuthService struct {
@@ -17,3 +21,27 @@ func NewAuthService(s *server.Server) *AuthService {
 		server: s,
 	}
 }
+
+// GetUserEmail looks up the user's primary email address in Clerk.
+// Used by background jobs, which have no request context to read it from.
+func (a *AuthService) GetUserEmail(ctx context.Context, userID string) (string, error) {
+	u, err := user.Get(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("get clerk user: %w", err)
+	}
+
+	return primaryEmail(u)
+}
+
+// primaryEmail returns the user's primary email, falling back to the first address
+func primaryEmail(u *clerk.User) (string, error) {
+	for _, address := range u.EmailAddresses {
+		if u.PrimaryEmailAddressID != nil && address.ID == *u.PrimaryEmailAddressID {
+			return address.EmailAddress, nil
+		}
+	}
+	if len(u.EmailAddresses) > 0 {
+		return u.EmailAddresses[0].EmailAddress, nil
+	}
+	return "", fmt.Errorf("user %s has no email address", u.ID)
+}
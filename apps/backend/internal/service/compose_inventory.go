@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"ark/internal/model"
+)
+
+// composeFile is the part of a docker-compose.yml an import reads
+type composeFile struct {
+	Name     string                    `yaml:"name"`
+	Services map[string]composeService `yaml:"services"`
+}
+
+// composeService is one service of a compose file. Ports, volumes and networks
+// accept both the short and the long syntax.
+type composeService struct {
+	Image         string `yaml:"image"`
+	ContainerName string `yaml:"container_name"`
+	Hostname      string `yaml:"hostname"`
+	Ports         []any  `yaml:"ports"`
+	Volumes       []any  `yaml:"volumes"`
+	Networks      any    `yaml:"networks"`
+}
+
+// composeProjectName returns the project a compose file belongs to: its
+// top-level name, or else the file name with "docker-compose" and "compose"
+// removed, as in "media.docker-compose.yml"
+func composeProjectName(file *composeFile, filename string) string {
+	if name := strings.TrimSpace(file.Name); name != "" {
+		return name
+	}
+
+	name := strings.ToLower(path.Base(filename))
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".yml"), ".yaml")
+	for _, suffix := range []string{"docker-compose", "compose"} {
+		name = strings.TrimSuffix(name, suffix)
+		name = strings.TrimPrefix(name, suffix)
+	}
+	return strings.Trim(name, ".-_ ")
+}
+
+// composePort writes a port in the short syntax. Long syntax ports become
+// "[host_ip:]published:target[/protocol]".
+func composePort(port any) (string, bool) {
+	switch p := port.(type) {
+	case string:
+		return p, p != ""
+	case int:
+		return strconv.Itoa(p), true
+	case map[string]any:
+		target := composeScalar(p["target"])
+		if target == "" {
+			return "", false
+		}
+		spec := target
+		if published := composeScalar(p["published"]); published != "" {
+			spec = published + ":" + spec
+			if hostIP := composeScalar(p["host_ip"]); hostIP != "" {
+				spec = hostIP + ":" + spec
+			}
+		}
+		if protocol := composeScalar(p["protocol"]); protocol != "" && protocol != "tcp" {
+			spec += "/" + protocol
+		}
+		return spec, true
+	}
+	return "", false
+}
+
+// composeVolume writes a volume in the short syntax. Long syntax volumes
+// become "source:target[:ro]".
+func composeVolume(volume any) (string, bool) {
+	switch v := volume.(type) {
+	case string:
+		return v, v != ""
+	case map[string]any:
+		target := composeScalar(v["target"])
+		if target == "" {
+			return "", false
+		}
+		spec := target
+		if source := composeScalar(v["source"]); source != "" {
+			spec = source + ":" + spec
+		}
+		if readOnly, _ := v["read_only"].(bool); readOnly {
+			spec += ":ro"
+		}
+		return spec, true
+	}
+	return "", false
+}
+
+// composeNetworks lists the networks of a service, given as a list or as a
+// map of network names to settings
+func composeNetworks(networks any) []string {
+	names := make([]string, 0)
+	switch n := networks.(type) {
+	case []any:
+		for _, network := range n {
+			if name := composeScalar(network); name != "" {
+				names = append(names, name)
+			}
+		}
+	case map[string]any:
+		for name := range n {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	return names
+}
+
+// composeScalar writes a YAML string or number, or "" for anything else
+func composeScalar(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	}
+	return ""
+}
+
+// composeKey identifies a container across imports to the same host
+func composeKey(project, service string) string {
+	return project + "/" + service
+}
+
+// parseComposeFiles reads the services of compose files as container assets
+// on the given host. A project and service may only appear once.
+func parseComposeFiles(files []model.InventoryFile, hostAssetID uuid.UUID) ([]inventoryAsset, error) {
+	containerType := model.AssetTypeContainer
+	var items []inventoryAsset
+	seen := make(map[string]string)
+
+	for _, f := range files {
+		var file composeFile
+		if err := yaml.Unmarshal(f.Data, &file); err != nil {
+			return nil, inventoryFileError(f.Name, "is not a valid compose file: "+err.Error())
+		}
+		if len(file.Services) == 0 {
+			return nil, inventoryFileError(f.Name, "has no services")
+		}
+
+		project := composeProjectName(&file, f.Name)
+		if project == "" {
+			return nil, inventoryFileError(f.Name, "needs a top-level name, or a file name such as media.docker-compose.yml, to identify its project")
+		}
+
+		services := make([]string, 0, len(file.Services))
+		for name := range file.Services {
+			services = append(services, name)
+		}
+		sort.Strings(services)
+
+		for _, name := range services {
+			service := file.Services[name]
+			source := f.Name + ": " + name
+
+			key := composeKey(project, name)
+			if other, ok := seen[key]; ok {
+				return nil, inventoryFileError(source, fmt.Sprintf("service %s of project %s is also defined in %s", name, project, other))
+			}
+			seen[key] = f.Name
+
+			metadata := map[string]any{
+				model.ComposeMetadataHostAssetID: hostAssetID.String(),
+				model.ComposeMetadataProject:     project,
+				model.ComposeMetadataService:     name,
+			}
+			if service.Image != "" {
+				metadata["image"] = service.Image
+			}
+
+			ports := make([]string, 0, len(service.Ports))
+			for _, port := range service.Ports {
+				spec, ok := composePort(port)
+				if !ok {
+					return nil, inventoryFileError(source, "has a port that is neither a string nor a mapping with a target")
+				}
+				ports = append(ports, spec)
+			}
+			metadata["ports"] = ports
+
+			volumes := make([]string, 0, len(service.Volumes))
+			for _, volume := range service.Volumes {
+				spec, ok := composeVolume(volume)
+				if !ok {
+					return nil, inventoryFileError(source, "has a volume that is neither a string nor a mapping with a target")
+				}
+				volumes = append(volumes, spec)
+			}
+			metadata["volumes"] = volumes
+			metadata["networks"] = composeNetworks(service.Networks)
+
+			assetName := service.ContainerName
+			if assetName == "" {
+				assetName = project + "-" + name
+			}
+			if utf8.RuneCountInString(assetName) > 100 {
+				return nil, inventoryFileError(source, "container name must be at most 100 characters")
+			}
+
+			item := inventoryAsset{
+				Source:   source,
+				Key:      key,
+				Name:     assetName,
+				Type:     &containerType,
+				Metadata: metadata,
+			}
+			if utf8.RuneCountInString(service.Hostname) > 255 {
+				return nil, inventoryFileError(source, "hostname must be at most 255 characters")
+			}
+			if service.Hostname != "" {
+				hostname := service.Hostname
+				item.Hostname = &hostname
+			}
+			items = append(items, item)
+
+			if len(items) > model.MaxInventoryAssets {
+				return nil, inventoryFileError(f.Name, fmt.Sprintf("imports must not describe more than %d assets", model.MaxInventoryAssets))
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// ImportCompose imports the services of docker-compose files as container
+// assets linked to a host asset. Containers imported to the same host before
+// are matched by project and service and updated in place, with a change log
+// describing what differed.
+func (s *InventoryService) ImportCompose(ctx context.Context, userID string, hostAssetID uuid.UUID, files []model.InventoryFile, dryRun bool) (*model.InventoryImportResult, error) {
+	// The host must exist and belong to the user
+	host, err := s.assetRepo.GetByID(ctx, userID, hostAssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := parseComposeFiles(files, host.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := s.assetRepo.FindByMetadata(ctx, userID, map[string]any{
+		model.ComposeMetadataHostAssetID: host.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*model.Asset, len(containers))
+	for _, container := range containers {
+		metadata := decodeAssetMetadata(container.Metadata)
+		key := composeKey(metadataCell(metadata[model.ComposeMetadataProject]), metadataCell(metadata[model.ComposeMetadataService]))
+		// The oldest asset wins if several claim the same service
+		if _, ok := existing[key]; !ok {
+			existing[key] = container
+		}
+	}
+
+	writes, writeResults, result, err := planInventoryImport(items, existing, inventorySource{
+		Label: func(item inventoryAsset) string {
+			return fmt.Sprintf("docker-compose project %s on %s", item.Metadata[model.ComposeMetadataProject], host.Name)
+		},
+		Links: []uuid.UUID{host.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyInventoryImport(ctx, userID, writes, writeResults, result, dryRun)
+}
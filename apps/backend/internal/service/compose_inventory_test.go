@@ -0,0 +1,143 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+const testComposeFile = `
+name: media
+services:
+  jellyfin:
+    image: jellyfin/jellyfin:10.9
+    container_name: jellyfin
+    hostname: jellyfin.lan
+    ports:
+      - "8096:8096"
+      - target: 7359
+        published: 7359
+        protocol: udp
+    volumes:
+      - ./config:/config
+      - type: bind
+        source: /mnt/media
+        target: /media
+        read_only: true
+    networks:
+      proxy: {}
+      default: {}
+    environment:
+      - TZ=Europe/Berlin
+  sonarr:
+    image: linuxserver/sonarr
+    networks: [proxy]
+`
+
+// TestParseComposeFiles reads services as containers with normalized metadata
+func TestParseComposeFiles(t *testing.T) {
+	hostID := uuid.MustParse("11111111-2222-3333-4444-555555555555")
+
+	items, err := parseComposeFiles([]model.InventoryFile{{Name: "docker-compose.yml", Data: []byte(testComposeFile)}}, hostID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	jellyfin := items[0]
+	assert.Equal(t, "docker-compose.yml: jellyfin", jellyfin.Source)
+	assert.Equal(t, "media/jellyfin", jellyfin.Key)
+	assert.Equal(t, "jellyfin", jellyfin.Name)
+	assert.Equal(t, "container", *jellyfin.Type)
+	assert.Equal(t, "jellyfin.lan", *jellyfin.Hostname)
+	assert.Equal(t, map[string]any{
+		"host_asset_id":   hostID.String(),
+		"compose_project": "media",
+		"compose_service": "jellyfin",
+		"image":           "jellyfin/jellyfin:10.9",
+		"ports":           []string{"8096:8096", "7359:7359/udp"},
+		"volumes":         []string{"./config:/config", "/mnt/media:/media:ro"},
+		"networks":        []string{"default", "proxy"},
+	}, jellyfin.Metadata)
+
+	sonarr := items[1]
+	assert.Equal(t, "media-sonarr", sonarr.Name)
+	assert.Nil(t, sonarr.Hostname)
+	assert.Equal(t, []string{}, sonarr.Metadata["ports"])
+	assert.Equal(t, []string{"proxy"}, sonarr.Metadata["networks"])
+}
+
+// TestComposeProjectName falls back to the file name
+func TestComposeProjectName(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"media.docker-compose.yml", "media"},
+		{"docker-compose.media.yaml", "media"},
+		{"monitoring/compose.yml", ""},
+		{"Grafana.yml", "grafana"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			assert.Equal(t, tt.want, composeProjectName(&composeFile{}, tt.filename))
+		})
+	}
+
+	assert.Equal(t, "media", composeProjectName(&composeFile{Name: "media"}, "docker-compose.yml"))
+}
+
+// TestParseComposeFiles_Errors names the file and service at fault
+func TestParseComposeFiles_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []model.InventoryFile
+		field string
+	}{
+		{
+			name:  "invalid YAML",
+			files: []model.InventoryFile{{Name: "media.yml", Data: []byte("services: [")}},
+			field: "media.yml",
+		},
+		{
+			name:  "no services",
+			files: []model.InventoryFile{{Name: "media.yml", Data: []byte("name: media\n")}},
+			field: "media.yml",
+		},
+		{
+			name:  "no project name",
+			files: []model.InventoryFile{{Name: "docker-compose.yml", Data: []byte("services:\n  web:\n    image: nginx\n")}},
+			field: "docker-compose.yml",
+		},
+		{
+			name:  "invalid port",
+			files: []model.InventoryFile{{Name: "web.yml", Data: []byte("services:\n  web:\n    ports:\n      - [80]\n")}},
+			field: "web.yml: web",
+		},
+		{
+			name: "duplicate service",
+			files: []model.InventoryFile{
+				{Name: "web.yml", Data: []byte("services:\n  nginx:\n    image: nginx\n")},
+				{Name: "web.compose.yml", Data: []byte("services:\n  nginx:\n    image: nginx\n")},
+			},
+			field: "web.compose.yml: nginx",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseComposeFiles(tt.files, uuid.New())
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			require.Len(t, httpErr.Errors, 1)
+			assert.Equal(t, tt.field, httpErr.Errors[0].Field)
+		})
+	}
+}
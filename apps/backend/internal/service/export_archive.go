@@ -0,0 +1,525 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// Account archive layout (version 2):
+//
+//	manifest.json                      format, version, export time and counts
+//	assets/<slug>-<id8>/asset.json     the asset as JSON
+//	assets/<slug>-<id8>/asset.md       the asset as markdown with YAML front matter
+//	assets/<slug>-<id8>/logs/<created>-<id8>.md
+//	                                   one file per log: YAML front matter with every
+//	                                   field except the content, which is the body
+//
+// A log lives in the folder of its primary asset; linked assets are listed by
+// ID. Version 2 adds the timeline of incident logs to their front matter;
+// version 1 archives are read as logs without timelines.
+const (
+	archiveManifestFile = "manifest.json"
+	archiveAssetsDir    = "assets"
+	archiveAssetJSON    = "asset.json"
+	archiveAssetMD      = "asset.md"
+	archiveLogsDir      = "logs"
+
+	// archiveLogTimeLayout names log files so they sort chronologically
+	archiveLogTimeLayout = "20060102T150405Z"
+)
+
+// archiveManifest describes an account archive
+type archiveManifest struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	AssetCount int       `json:"asset_count"`
+	LogCount   int       `json:"log_count"`
+}
+
+// archiveAsset is an asset as stored in an archive. Metadata is only kept in
+// asset.json; asset.md shows it as a code block.
+type archiveAsset struct {
+	ID        uuid.UUID       `json:"id" yaml:"id"`
+	Name      string          `json:"name" yaml:"name"`
+	Type      *string         `json:"type,omitempty" yaml:"type,omitempty"`
+	Hostname  *string         `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Tags      []string        `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Metadata  json.RawMessage `json:"metadata,omitempty" yaml:"-"`
+	Pinned    bool            `json:"pinned" yaml:"pinned"`
+	Favorite  bool            `json:"favorite" yaml:"favorite"`
+	CreatedAt time.Time       `json:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" yaml:"updated_at"`
+}
+
+func newArchiveAsset(asset *model.Asset) archiveAsset {
+	return archiveAsset{
+		ID:        asset.ID,
+		Name:      asset.Name,
+		Type:      asset.Type,
+		Hostname:  asset.Hostname,
+		Tags:      asset.Tags,
+		Metadata:  asset.Metadata,
+		Pinned:    asset.Pinned,
+		Favorite:  asset.Favorite,
+		CreatedAt: asset.CreatedAt,
+		UpdatedAt: asset.UpdatedAt,
+	}
+}
+
+// archiveTimelineEntry is an incident timeline entry in a log's front matter.
+// Entries are imported under new IDs.
+type archiveTimelineEntry struct {
+	Status     *string   `yaml:"status,omitempty"`
+	Note       *string   `yaml:"note,omitempty"`
+	OccurredAt time.Time `yaml:"occurred_at"`
+	CreatedAt  time.Time `yaml:"created_at"`
+}
+
+// archiveLog is the front matter of a log file in an archive
+type archiveLog struct {
+	ID              uuid.UUID   `yaml:"id"`
+	AssetID         uuid.UUID   `yaml:"asset_id"`
+	Kind            string      `yaml:"kind"`
+	Tags            []string    `yaml:"tags,omitempty"`
+	Severity        *string     `yaml:"severity,omitempty"`
+	StartedAt       *time.Time  `yaml:"started_at,omitempty"`
+	ResolvedAt      *time.Time  `yaml:"resolved_at,omitempty"`
+	RootCause       *string     `yaml:"root_cause,omitempty"`
+	IncidentStatus  *string     `yaml:"incident_status,omitempty"`
+	MitigatedAt     *time.Time  `yaml:"mitigated_at,omitempty"`
+	Planned         *bool       `yaml:"planned,omitempty"`
+	RollbackNotes   *string     `yaml:"rollback_notes,omitempty"`
+	DurationMinutes *int        `yaml:"duration_minutes,omitempty"`
+	LinkedAssetIDs  []uuid.UUID `yaml:"linked_asset_ids,omitempty"`
+	ParentLogID     *uuid.UUID  `yaml:"parent_log_id,omitempty"`
+	ThreadRootID    *uuid.UUID  `yaml:"thread_root_id,omitempty"`
+	Pinned          bool        `yaml:"pinned"`
+	Favorite        bool        `yaml:"favorite"`
+	CreatedAt       time.Time   `yaml:"created_at"`
+	UpdatedAt       time.Time   `yaml:"updated_at"`
+
+	Timeline []archiveTimelineEntry `yaml:"timeline,omitempty"`
+}
+
+func newArchiveLog(log *model.AssetLog, timeline []model.IncidentTimelineEntry) archiveLog {
+	var entries []archiveTimelineEntry
+	for _, entry := range timeline {
+		entries = append(entries, archiveTimelineEntry{
+			Status:     entry.Status,
+			Note:       entry.Note,
+			OccurredAt: entry.OccurredAt,
+			CreatedAt:  entry.CreatedAt,
+		})
+	}
+
+	return archiveLog{
+		ID:              log.ID,
+		AssetID:         log.AssetID,
+		Kind:            log.Kind,
+		Tags:            log.Tags,
+		Severity:        log.Severity,
+		StartedAt:       log.StartedAt,
+		ResolvedAt:      log.ResolvedAt,
+		RootCause:       log.RootCause,
+		IncidentStatus:  log.IncidentStatus,
+		MitigatedAt:     log.MitigatedAt,
+		Planned:         log.Planned,
+		RollbackNotes:   log.RollbackNotes,
+		DurationMinutes: log.DurationMinutes,
+		LinkedAssetIDs:  log.LinkedAssetIDs,
+		ParentLogID:     log.ParentLogID,
+		ThreadRootID:    log.ThreadRootID,
+		Pinned:          log.Pinned,
+		Favorite:        log.Favorite,
+		CreatedAt:       log.CreatedAt,
+		UpdatedAt:       log.UpdatedAt,
+		Timeline:        entries,
+	}
+}
+
+// archiveSlug turns a name into a lowercase, dash-separated path segment
+func archiveSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 50 {
+			break
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if slug == "" {
+		return "asset"
+	}
+	return slug
+}
+
+// shortID is the first eight hex digits of an ID, used to keep paths unique
+func shortID(id uuid.UUID) string {
+	return id.String()[:8]
+}
+
+// writeFrontMatter writes YAML front matter followed by a markdown body
+func writeFrontMatter(w io.Writer, frontMatter any, body string) error {
+	data, err := yaml.Marshal(frontMatter)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "---\n%s---\n\n%s\n", data, strings.TrimRight(body, "\n"))
+	return err
+}
+
+// assetMarkdownBody renders the asset heading and its metadata as a code block
+func assetMarkdownBody(asset archiveAsset) string {
+	body := "# " + asset.Name + "\n"
+
+	var metadata bytes.Buffer
+	if len(asset.Metadata) > 0 && json.Indent(&metadata, asset.Metadata, "", "  ") == nil {
+		body += "\n```json\n" + metadata.String() + "\n```\n"
+	}
+	return body
+}
+
+// exportArchiveWriter writes an account archive as a zip. Assets must be
+// written before their logs.
+type exportArchiveWriter struct {
+	zw        *zip.Writer
+	assetDirs map[uuid.UUID]string
+	assets    int
+	logs      int
+}
+
+func newExportArchiveWriter(w io.Writer) *exportArchiveWriter {
+	return &exportArchiveWriter{
+		zw:        zip.NewWriter(w),
+		assetDirs: make(map[uuid.UUID]string),
+	}
+}
+
+// create adds a file with the given modification time
+func (a *exportArchiveWriter) create(name string, modified time.Time) (io.Writer, error) {
+	return a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified.UTC(),
+	})
+}
+
+// WriteAsset adds an asset's folder with its JSON and markdown files
+func (a *exportArchiveWriter) WriteAsset(asset *model.Asset) error {
+	dir := path.Join(archiveAssetsDir, archiveSlug(asset.Name)+"-"+shortID(asset.ID))
+	a.assetDirs[asset.ID] = dir
+	archived := newArchiveAsset(asset)
+
+	w, err := a.create(path.Join(dir, archiveAssetJSON), asset.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("write asset json: %w", err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(archived); err != nil {
+		return fmt.Errorf("write asset json: %w", err)
+	}
+
+	w, err = a.create(path.Join(dir, archiveAssetMD), asset.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("write asset markdown: %w", err)
+	}
+	if err := writeFrontMatter(w, archived, assetMarkdownBody(archived)); err != nil {
+		return fmt.Errorf("write asset markdown: %w", err)
+	}
+
+	a.assets++
+	return nil
+}
+
+// WriteLog adds a log file to the folder of its primary asset, with the
+// timeline of an incident log
+func (a *exportArchiveWriter) WriteLog(log *model.AssetLog, timeline []model.IncidentTimelineEntry) error {
+	dir, ok := a.assetDirs[log.AssetID]
+	if !ok {
+		return fmt.Errorf("write log %s: asset %s not in archive", log.ID, log.AssetID)
+	}
+
+	name := log.CreatedAt.UTC().Format(archiveLogTimeLayout) + "-" + shortID(log.ID) + ".md"
+	w, err := a.create(path.Join(dir, archiveLogsDir, name), log.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("write log: %w", err)
+	}
+	if err := writeFrontMatter(w, newArchiveLog(log, timeline), log.Content); err != nil {
+		return fmt.Errorf("write log: %w", err)
+	}
+
+	a.logs++
+	return nil
+}
+
+// Close writes the manifest and finishes the zip
+func (a *exportArchiveWriter) Close(exportedAt time.Time) error {
+	w, err := a.create(archiveManifestFile, exportedAt)
+	if err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(archiveManifest{
+		Format:     model.ExportArchiveFormat,
+		Version:    model.ExportArchiveVersion,
+		ExportedAt: exportedAt.UTC(),
+		AssetCount: a.assets,
+		LogCount:   a.logs,
+	})
+	if err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+
+	return a.zw.Close()
+}
+
+const (
+	// maxArchiveEntryBytes bounds the uncompressed size of a single archive file
+	maxArchiveEntryBytes = 1 << 20
+	// maxArchiveBytes bounds the uncompressed size of all files read from an archive
+	maxArchiveBytes = 256 << 20
+	// maxArchiveEntries bounds the number of files in an archive: the manifest,
+	// two files per asset and one per log of the largest account exported
+	maxArchiveEntries = 1 + 2*model.MaxExportAssets + model.MaxExportLogs
+	// maxArchiveLogBodyBytes bounds the body of a log file: the longest content
+	// in UTF-8 and the newlines around it
+	maxArchiveLogBodyBytes = 4*maxLogContentLength + 2
+)
+
+// errArchiveTooLarge is returned once more than maxArchiveBytes are read
+var errArchiveTooLarge = errors.New("archive too large")
+
+// archiveFileReader reads a zip entry, charging every byte to the budget shared
+// by all files of an archive
+type archiveFileReader struct {
+	io.ReadCloser
+	budget *int64
+}
+
+func (r *archiveFileReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	*r.budget -= int64(n)
+	if *r.budget < 0 {
+		return n, errArchiveTooLarge
+	}
+	return n, err
+}
+
+// archiveAssetEntry is an asset read back from an archive
+type archiveAssetEntry struct {
+	archiveAsset
+	Path string
+}
+
+// archiveLogEntry is a log read back from an archive
+type archiveLogEntry struct {
+	archiveLog
+	Content string
+	Path    string
+}
+
+// parsedArchive is the content of an account archive
+type parsedArchive struct {
+	Manifest archiveManifest
+	Assets   []archiveAssetEntry
+	Logs     []archiveLogEntry
+}
+
+// archiveError reports a problem with one file of an uploaded archive
+func archiveError(file, message string) error {
+	return errs.NewBadRequestError("Invalid archive", false, nil, []errs.FieldError{
+		{Field: file, Error: message},
+	}, nil)
+}
+
+// openArchiveFile opens a zip entry for reading against the archive's budget
+func openArchiveFile(f *zip.File, budget *int64) (io.ReadCloser, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, archiveError(f.Name, "cannot be read")
+	}
+	return &archiveFileReader{ReadCloser: rc, budget: budget}, nil
+}
+
+// archiveReadError reports a failed read of a zip entry
+func archiveReadError(f *zip.File, err error) error {
+	if errors.Is(err, errArchiveTooLarge) {
+		return archiveError("file", fmt.Sprintf("must not be larger than %d MB uncompressed", maxArchiveBytes>>20))
+	}
+	return archiveError(f.Name, "cannot be read")
+}
+
+// readArchiveFile reads a zip entry, refusing entries over maxArchiveEntryBytes
+func readArchiveFile(f *zip.File, budget *int64) ([]byte, error) {
+	rc, err := openArchiveFile(f, budget)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxArchiveEntryBytes+1))
+	if err != nil {
+		return nil, archiveReadError(f, err)
+	}
+	if len(data) > maxArchiveEntryBytes {
+		return nil, archiveError(f.Name, "is too large")
+	}
+	return data, nil
+}
+
+// readArchiveLog reads a log file written by writeFrontMatter and returns its
+// YAML front matter and markdown body. The file is refused as soon as the
+// body exceeds the longest log content.
+func readArchiveLog(f *zip.File, budget *int64) (string, string, error) {
+	rc, err := openArchiveFile(f, budget)
+	if err != nil {
+		return "", "", err
+	}
+	defer rc.Close()
+
+	limited := &io.LimitedReader{R: rc, N: maxArchiveEntryBytes + 1}
+	br := bufio.NewReader(limited)
+
+	var frontMatter strings.Builder
+	for first := true; ; first = false {
+		line, err := br.ReadString('\n')
+		switch {
+		case err == io.EOF && limited.N <= 0:
+			return "", "", archiveError(f.Name, "is too large")
+		case err == io.EOF:
+			return "", "", archiveError(f.Name, "has no front matter")
+		case err != nil:
+			return "", "", archiveReadError(f, err)
+		}
+
+		if line == "---\n" {
+			if first {
+				continue
+			}
+			break
+		}
+		if first {
+			return "", "", archiveError(f.Name, "has no front matter")
+		}
+		frontMatter.WriteString(line)
+	}
+
+	tooLong := archiveError(f.Name, fmt.Sprintf("content must be at most %d characters", maxLogContentLength))
+	data, err := io.ReadAll(io.LimitReader(br, maxArchiveLogBodyBytes+1))
+	if err != nil {
+		return "", "", archiveReadError(f, err)
+	}
+	if len(data) > maxArchiveLogBodyBytes {
+		return "", "", tooLong
+	}
+
+	body := strings.TrimPrefix(string(data), "\n")
+	body = strings.TrimSuffix(body, "\n")
+	if utf8.RuneCountInString(body) > maxLogContentLength {
+		return "", "", tooLong
+	}
+	return frontMatter.String(), body, nil
+}
+
+// readExportArchive parses an account archive. Only asset.json and log files
+// are read; asset.md is a human-readable copy of asset.json.
+func readExportArchive(r io.ReaderAt, size int64) (*parsedArchive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, archiveError("file", "is not a zip archive")
+	}
+	if len(zr.File) > maxArchiveEntries {
+		return nil, archiveError("file", fmt.Sprintf("must not have more than %d files", maxArchiveEntries))
+	}
+
+	// Refuse archives that claim to be too large before reading anything; the
+	// budget then catches entries that inflate past their declared size
+	var declared uint64
+	for _, f := range zr.File {
+		if f.UncompressedSize64 > maxArchiveBytes-declared {
+			return nil, archiveError("file", fmt.Sprintf("must not be larger than %d MB uncompressed", maxArchiveBytes>>20))
+		}
+		declared += f.UncompressedSize64
+	}
+	budget := int64(maxArchiveBytes)
+
+	archive := &parsedArchive{}
+	hasManifest := false
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		name := path.Clean(f.Name)
+		switch {
+		case name == archiveManifestFile:
+			data, err := readArchiveFile(f, &budget)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(data, &archive.Manifest); err != nil {
+				return nil, archiveError(f.Name, "is not valid JSON")
+			}
+			hasManifest = true
+
+		case strings.HasPrefix(name, archiveAssetsDir+"/") && path.Base(name) == archiveAssetJSON:
+			data, err := readArchiveFile(f, &budget)
+			if err != nil {
+				return nil, err
+			}
+			asset := archiveAssetEntry{Path: f.Name}
+			if err := json.Unmarshal(data, &asset.archiveAsset); err != nil {
+				return nil, archiveError(f.Name, "is not a valid asset")
+			}
+			archive.Assets = append(archive.Assets, asset)
+
+		case strings.HasPrefix(name, archiveAssetsDir+"/") && path.Base(path.Dir(name)) == archiveLogsDir && path.Ext(name) == ".md":
+			frontMatter, body, err := readArchiveLog(f, &budget)
+			if err != nil {
+				return nil, err
+			}
+			entry := archiveLogEntry{Content: body, Path: f.Name}
+			if err := yaml.Unmarshal([]byte(frontMatter), &entry.archiveLog); err != nil {
+				return nil, archiveError(f.Name, "has invalid front matter")
+			}
+			archive.Logs = append(archive.Logs, entry)
+		}
+	}
+
+	if !hasManifest || archive.Manifest.Format != model.ExportArchiveFormat {
+		return nil, archiveError("file", "is not an Ark export archive")
+	}
+	if archive.Manifest.Version < 1 || archive.Manifest.Version > model.ExportArchiveVersion {
+		return nil, archiveError(archiveManifestFile, fmt.Sprintf("archive version %d is not supported", archive.Manifest.Version))
+	}
+
+	return archive, nil
+}
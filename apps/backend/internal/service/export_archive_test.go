@@ -0,0 +1,261 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// readZip returns the files of a zip archive by name
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+// TestArchiveSlug keeps folder names readable and path-safe
+func TestArchiveSlug(t *testing.T) {
+	assert.Equal(t, "nas-01", archiveSlug("NAS 01"))
+	assert.Equal(t, "proxmox-host", archiveSlug("  Proxmox / Host!! "))
+	assert.Equal(t, "asset", archiveSlug("../.."))
+	assert.LessOrEqual(t, len(archiveSlug(strings.Repeat("a", 80))), 50)
+}
+
+// TestExportArchiveWriter writes assets and logs in the documented layout
+func TestExportArchiveWriter(t *testing.T) {
+	created := time.Date(2024, 5, 30, 12, 30, 0, 0, time.UTC)
+	asset := &model.Asset{
+		ID:        uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Name:      "NAS 01",
+		Type:      stringPtr("nas"),
+		Tags:      []string{"storage"},
+		Metadata:  json.RawMessage(`{"disks":4}`),
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+	log := &model.AssetLog{
+		ID:             uuid.MustParse("aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"),
+		AssetID:        asset.ID,
+		Kind:           model.LogKindNote,
+		Content:        "Replaced disk 3\n\n---\nwith a spare",
+		Tags:           []string{"zfs", "hardware"},
+		LinkedAssetIDs: []uuid.UUID{},
+		CreatedAt:      created,
+		UpdatedAt:      created,
+	}
+
+	var buf bytes.Buffer
+	archive := newExportArchiveWriter(&buf)
+	require.NoError(t, archive.WriteAsset(asset))
+	require.NoError(t, archive.WriteLog(log, nil))
+	require.NoError(t, archive.Close(created))
+
+	files := readZip(t, buf.Bytes())
+	dir := "assets/nas-01-11111111/"
+
+	var manifest archiveManifest
+	require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifest))
+	assert.Equal(t, model.ExportArchiveFormat, manifest.Format)
+	assert.Equal(t, 1, manifest.AssetCount)
+	assert.Equal(t, 1, manifest.LogCount)
+
+	var assetJSON archiveAsset
+	require.NoError(t, json.Unmarshal([]byte(files[dir+"asset.json"]), &assetJSON))
+	assert.Equal(t, asset.ID, assetJSON.ID)
+	assert.JSONEq(t, `{"disks":4}`, string(assetJSON.Metadata))
+
+	assert.Contains(t, files[dir+"asset.md"], "# NAS 01")
+	assert.Contains(t, files[dir+"asset.md"], "\"disks\": 4")
+
+	logFile, ok := files[dir+"logs/20240530T123000Z-aaaaaaaa.md"]
+	require.True(t, ok, "log file should be named after its creation time and ID")
+
+	// Front matter round-trips; the body is the content
+	parts := strings.SplitN(logFile, "---\n", 3)
+	require.Len(t, parts, 3)
+	var frontMatter archiveLog
+	require.NoError(t, yaml.Unmarshal([]byte(parts[1]), &frontMatter))
+	assert.Equal(t, log.ID, frontMatter.ID)
+	assert.Equal(t, []string{"zfs", "hardware"}, frontMatter.Tags)
+	assert.True(t, created.Equal(frontMatter.CreatedAt))
+	assert.Equal(t, log.Content+"\n", strings.TrimPrefix(parts[2], "\n"))
+}
+
+// TestExportArchiveWriter_UnknownAsset rejects logs written before their asset
+func TestExportArchiveWriter_UnknownAsset(t *testing.T) {
+	archive := newExportArchiveWriter(io.Discard)
+
+	err := archive.WriteLog(&model.AssetLog{ID: uuid.New(), AssetID: uuid.New()}, nil)
+	assert.Error(t, err)
+}
+
+// writeTestArchive builds an archive with one asset and its logs; timelines
+// are keyed by log ID
+func writeTestArchive(t *testing.T, asset *model.Asset, timelines map[uuid.UUID][]model.IncidentTimelineEntry, logs ...*model.AssetLog) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := newExportArchiveWriter(&buf)
+	require.NoError(t, archive.WriteAsset(asset))
+	for _, log := range logs {
+		require.NoError(t, archive.WriteLog(log, timelines[log.ID]))
+	}
+	require.NoError(t, archive.Close(time.Now()))
+	return buf.Bytes()
+}
+
+// TestReadExportArchive reads back what the writer wrote
+func TestReadExportArchive(t *testing.T) {
+	created := time.Date(2024, 5, 30, 12, 30, 0, 0, time.UTC)
+	asset := &model.Asset{
+		ID:        uuid.New(),
+		Name:      "NAS 01",
+		Metadata:  json.RawMessage(`{"disks":4}`),
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+	log := &model.AssetLog{
+		ID:        uuid.New(),
+		AssetID:   asset.ID,
+		Kind:      model.LogKindIncident,
+		Content:   "Pool degraded\n\n---\n\nResilvered overnight",
+		Severity:  stringPtr(model.SeverityHigh),
+		CreatedAt: created,
+		UpdatedAt: created.Add(time.Hour),
+	}
+
+	timeline := []model.IncidentTimelineEntry{
+		{LogID: log.ID, Status: stringPtr(model.IncidentStatusOpen), OccurredAt: created, CreatedAt: created},
+		{LogID: log.ID, Note: stringPtr("Resilver started"), OccurredAt: created.Add(time.Hour), CreatedAt: created.Add(time.Hour)},
+	}
+
+	data := writeTestArchive(t, asset, map[uuid.UUID][]model.IncidentTimelineEntry{log.ID: timeline}, log)
+	archive, err := readExportArchive(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	require.Len(t, archive.Assets, 1)
+	assert.Equal(t, asset.ID, archive.Assets[0].ID)
+	assert.JSONEq(t, `{"disks":4}`, string(archive.Assets[0].Metadata))
+
+	require.Len(t, archive.Logs, 1)
+	assert.Equal(t, log.Content, archive.Logs[0].Content)
+	assert.Equal(t, model.SeverityHigh, *archive.Logs[0].Severity)
+	assert.True(t, log.UpdatedAt.Equal(archive.Logs[0].UpdatedAt))
+
+	require.Len(t, archive.Logs[0].Timeline, 2)
+	assert.Equal(t, model.IncidentStatusOpen, *archive.Logs[0].Timeline[0].Status)
+	assert.Nil(t, archive.Logs[0].Timeline[0].Note)
+	assert.Equal(t, "Resilver started", *archive.Logs[0].Timeline[1].Note)
+	assert.True(t, created.Add(time.Hour).Equal(archive.Logs[0].Timeline[1].OccurredAt))
+}
+
+// TestReadExportArchive_Invalid rejects files that are not Ark archives
+func TestReadExportArchive_Invalid(t *testing.T) {
+	zipWith := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			w, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+
+	tests := map[string][]byte{
+		"not a zip":       []byte("hello"),
+		"no manifest":     zipWith(map[string]string{"notes.txt": "hi"}),
+		"other format":    zipWith(map[string]string{"manifest.json": `{"format": "other", "version": 1}`}),
+		"future version":  zipWith(map[string]string{"manifest.json": `{"format": "ark-export", "version": 3}`}),
+		"no front matter": zipWith(map[string]string{"manifest.json": `{"format": "ark-export", "version": 1}`, "assets/a-1/logs/x.md": "hello"}),
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := readExportArchive(bytes.NewReader(data), int64(len(data)))
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		})
+	}
+}
+
+// TestReadExportArchive_Limits refuses archives that inflate past the limits
+func TestReadExportArchive_Limits(t *testing.T) {
+	manifest := `{"format": "ark-export", "version": 1}`
+	frontMatter := "---\nid: 550e8400-e29b-41d4-a716-446655440000\n---\n\n"
+
+	// Test 1: log bodies over the content limit are refused while reading
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"manifest.json":        manifest,
+		"assets/a-1/logs/x.md": frontMatter + strings.Repeat("x", maxLogContentLength+1) + "\n",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	_, err := readExportArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Len(t, httpErr.Errors, 1)
+	assert.Equal(t, "assets/a-1/logs/x.md", httpErr.Errors[0].Field)
+	assert.Equal(t, "content must be at most 10000 characters", httpErr.Errors[0].Error)
+
+	// Test 2: declared sizes over the total are refused before reading
+	buf.Reset()
+	zw = zip.NewWriter(&buf)
+	for i := 0; i < 3; i++ {
+		w, err := zw.CreateRaw(&zip.FileHeader{
+			Name:               "assets/a-1/logs/" + strings.Repeat("x", i+1) + ".md",
+			Method:             zip.Store,
+			UncompressedSize64: maxArchiveBytes / 2,
+			CompressedSize64:   1,
+		})
+		require.NoError(t, err)
+		_, err = w.Write([]byte("x"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	_, err = readExportArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, "must not be larger than 256 MB uncompressed", httpErr.Errors[0].Error)
+
+	// Test 3: bytes actually read are charged to the shared budget
+	budget := int64(5)
+	r := &archiveFileReader{ReadCloser: io.NopCloser(strings.NewReader("0123456789")), budget: &budget}
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, errArchiveTooLarge)
+}
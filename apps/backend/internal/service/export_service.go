@@ -0,0 +1,280 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+
+	"ark/internal/errs"
+	"ark/internal/lib/job"
+	"ark/internal/model"
+	"ark/internal/repository"
+	"ark/internal/server"
+)
+
+// exportCleanupSchedule is when expired export archives are deleted
+const exportCleanupSchedule = "0 3 * * *"
+
+type ExportService struct {
+	server     *server.Server
+	exportRepo *repository.ExportRepository
+	views      *SavedViewService
+}
+
+func NewExportService(s *server.Server, exportRepo *repository.ExportRepository, views *SavedViewService) *ExportService {
+	return &ExportService{
+		server:     s,
+		exportRepo: exportRepo,
+		views:      views,
+	}
+}
+
+// RegisterJobs registers the export and cleanup handlers and schedules the cleanup daily
+func (s *ExportService) RegisterJobs(j *job.JobService) error {
+	j.HandleFunc(job.TaskAccountExport, s.handleAccountExportTask)
+	j.HandleFunc(job.TaskExportCleanup, s.handleExportCleanupTask)
+
+	// Unique keeps the cleanup from running once per API instance
+	_, err := j.Schedule(exportCleanupSchedule, job.NewExportCleanupTask(), asynq.Unique(time.Hour))
+	return err
+}
+
+// exportFilter resolves the saved asset view an export is limited to. A nil
+// viewID exports every asset.
+func (s *ExportService) exportFilter(ctx context.Context, userID string, viewID *uuid.UUID) (*model.AssetQueryParams, error) {
+	if viewID == nil {
+		return nil, nil
+	}
+	return s.views.AssetFilter(ctx, userID, *viewID)
+}
+
+// buildArchive writes the user's assets that match filter and their logs,
+// with incident timelines, to a zip archive
+func (s *ExportService) buildArchive(ctx context.Context, userID string, filter *model.AssetQueryParams) ([]byte, *exportArchiveWriter, error) {
+	assets, err := s.exportRepo.ListAssets(ctx, userID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := newExportArchiveWriter(&buf)
+	for _, asset := range assets {
+		if err := archive.WriteAsset(asset); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	timelines, err := s.exportRepo.ListTimelines(ctx, userID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.exportRepo.ForEachLog(ctx, userID, filter, func(log *model.AssetLog) error {
+		return archive.WriteLog(log, timelines[log.ID])
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := archive.Close(time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	return buf.Bytes(), archive, nil
+}
+
+// checkExportSize refuses exports of more than maxLogs logs or
+// model.MaxExportAssets assets
+func (s *ExportService) checkExportSize(ctx context.Context, userID string, filter *model.AssetQueryParams, maxLogs int64, hint string) error {
+	code := "EXPORT_TOO_LARGE"
+
+	assets, err := s.exportRepo.CountAssets(ctx, userID, filter)
+	if err != nil {
+		return err
+	}
+	if assets > model.MaxExportAssets {
+		return errs.NewBadRequestError(fmt.Sprintf("account has more than %d assets", model.MaxExportAssets), false, &code, nil, nil)
+	}
+
+	logs, err := s.exportRepo.CountLogs(ctx, userID, filter)
+	if err != nil {
+		return err
+	}
+	if logs > maxLogs {
+		return errs.NewBadRequestError(fmt.Sprintf("account has more than %d logs%s", maxLogs, hint), false, &code, nil, nil)
+	}
+
+	return nil
+}
+
+// Export builds the user's archive within the request, limited to the assets
+// of the saved asset view viewID when it is set. Exports of more than
+// model.MaxSyncExportLogs logs must use a background export instead.
+func (s *ExportService) Export(ctx context.Context, userID string, viewID *uuid.UUID) ([]byte, error) {
+	filter, err := s.exportFilter(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkExportSize(ctx, userID, filter, model.MaxSyncExportLogs, ", start a background export with POST /api/v1/exports")
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := s.buildArchive(ctx, userID, filter)
+	return data, err
+}
+
+// Start records a pending export and enqueues the job that builds it,
+// limited to the assets of the saved asset view viewID when it is set.
+// Exports over model.MaxExportLogs logs or model.MaxExportAssets assets are
+// refused, as their archives could not be imported again.
+func (s *ExportService) Start(ctx context.Context, userID string, viewID *uuid.UUID) (*model.AccountExport, error) {
+	filter, err := s.exportFilter(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkExportSize(ctx, userID, filter, model.MaxExportLogs, ""); err != nil {
+		return nil, err
+	}
+
+	export, err := s.exportRepo.Create(ctx, userID, time.Now().Add(model.ExportRetention))
+	if err != nil {
+		return nil, err
+	}
+
+	var view string
+	if viewID != nil {
+		view = viewID.String()
+	}
+
+	task, err := job.NewAccountExportTask(export.ID.String(), userID, view)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil {
+		if failErr := s.exportRepo.Fail(ctx, userID, export.ID, "could not be queued"); failErr != nil {
+			s.server.Logger.Error().Err(failErr).Str("export_id", export.ID.String()).Msg("Failed to mark export as failed")
+		}
+		return nil, fmt.Errorf("enqueue export: %w", err)
+	}
+
+	return export, nil
+}
+
+func (s *ExportService) List(ctx context.Context, userID string) (*model.ExportListResponse, error) {
+	exports, err := s.exportRepo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewExportListResponse(exports), nil
+}
+
+func (s *ExportService) GetByID(ctx context.Context, userID string, exportID uuid.UUID) (*model.AccountExport, error) {
+	return s.exportRepo.GetByID(ctx, userID, exportID)
+}
+
+// Download returns the archive of a completed export
+func (s *ExportService) Download(ctx context.Context, userID string, exportID uuid.UUID) ([]byte, error) {
+	export, err := s.exportRepo.GetByID(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.Status != model.ExportStatusCompleted {
+		code := "EXPORT_NOT_READY"
+		return nil, errs.NewBadRequestError("export is "+export.Status, false, &code, nil, nil)
+	}
+
+	return s.exportRepo.GetArchive(ctx, userID, exportID)
+}
+
+func (s *ExportService) Delete(ctx context.Context, userID string, exportID uuid.UUID) error {
+	return s.exportRepo.Delete(ctx, userID, exportID)
+}
+
+// handleAccountExportTask builds an archive and stores it on the export. The
+// export is marked failed once the task has run out of retries.
+func (s *ExportService) handleAccountExportTask(ctx context.Context, t *asynq.Task) error {
+	var p job.AccountExportPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal account export payload: %w", err)
+	}
+
+	exportID, err := uuid.Parse(p.ExportID)
+	if err != nil {
+		return fmt.Errorf("invalid export id %q: %w", p.ExportID, asynq.SkipRetry)
+	}
+
+	var viewID *uuid.UUID
+	if p.ViewID != "" {
+		id, err := uuid.Parse(p.ViewID)
+		if err != nil {
+			return fmt.Errorf("invalid view id %q: %w", p.ViewID, asynq.SkipRetry)
+		}
+		viewID = &id
+	}
+
+	logger := s.server.Logger.With().Str("export_id", p.ExportID).Str("user_id", p.UserID).Logger()
+
+	ok, err := s.exportRepo.MarkRunning(ctx, p.UserID, exportID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logger.Info().Msg("Export was deleted or already finished, skipping")
+		return nil
+	}
+
+	filter, err := s.exportFilter(ctx, p.UserID, viewID)
+	if err != nil {
+		var httpErr *errs.HTTPError
+		if !errors.As(err, &httpErr) {
+			return err
+		}
+
+		// The view was deleted or retargeted after the export was started
+		if failErr := s.exportRepo.Fail(ctx, p.UserID, exportID, "saved view is no longer available"); failErr != nil {
+			logger.Error().Err(failErr).Msg("Failed to mark export as failed")
+		}
+		return fmt.Errorf("resolve saved view: %w", asynq.SkipRetry)
+	}
+
+	data, archive, err := s.buildArchive(ctx, p.UserID, filter)
+	if err == nil {
+		err = s.exportRepo.Complete(ctx, p.UserID, exportID, data, archive.assets, archive.logs)
+	}
+	if err != nil {
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if retried >= maxRetry {
+			if failErr := s.exportRepo.Fail(ctx, p.UserID, exportID, "archive could not be built"); failErr != nil {
+				logger.Error().Err(failErr).Msg("Failed to mark export as failed")
+			}
+		}
+		return err
+	}
+
+	logger.Info().Int("assets", archive.assets).Int("logs", archive.logs).Int("size_bytes", len(data)).Msg("Account export complete")
+	return nil
+}
+
+// handleExportCleanupTask deletes expired export archives
+func (s *ExportService) handleExportCleanupTask(ctx context.Context, _ *asynq.Task) error {
+	deleted, err := s.exportRepo.DeleteExpired(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.server.Logger.Info().Int64("deleted", deleted).Msg("Expired exports cleaned up")
+	return nil
+}
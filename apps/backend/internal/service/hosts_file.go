@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"ark/internal/model"
+)
+
+// Markers around a generated hosts file block, so a script can replace the
+// block in /etc/hosts and keep the rest
+const (
+	hostsFileBegin = "# BEGIN ark"
+	hostsFileEnd   = "# END ark"
+)
+
+// hostsFileName turns an asset name or hostname into a hosts file name:
+// lowercase letters, digits, dots and dashes, or "" when nothing is left
+func hostsFileName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), ".-")
+}
+
+// hostsFileAddress returns the address an asset is listed under: its hostname
+// when that is an IP address, or else its ip_address metadata key
+func hostsFileAddress(asset *model.Asset) (netip.Addr, bool) {
+	if asset.Hostname != nil {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(*asset.Hostname)); err == nil {
+			return addr, true
+		}
+	}
+	if raw, ok := decodeAssetMetadata(asset.Metadata)[model.MetadataIPAddress]; ok {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(metadataCell(raw))); err == nil {
+			return addr, true
+		}
+	}
+	return netip.Addr{}, false
+}
+
+// renderHostsFile writes a hosts file block mapping the address of each asset
+// that has one to the asset's name and, when it is a DNS name, its hostname.
+// Assets sharing an address share a line; a name is only given to the first
+// address that claims it. Lines are sorted by address.
+func renderHostsFile(assets []*model.Asset) []byte {
+	names := make(map[netip.Addr][]string)
+	claimed := make(map[string]bool)
+	for _, asset := range assets {
+		addr, ok := hostsFileAddress(asset)
+		if !ok {
+			continue
+		}
+
+		candidates := []string{hostsFileName(asset.Name)}
+		if asset.Hostname != nil && !strings.EqualFold(strings.TrimSpace(*asset.Hostname), addr.String()) {
+			candidates = append(candidates, hostsFileName(*asset.Hostname))
+		}
+		for _, name := range candidates {
+			if name == "" || claimed[name] {
+				continue
+			}
+			claimed[name] = true
+			names[addr] = append(names[addr], name)
+		}
+	}
+
+	addrs := make([]netip.Addr, 0, len(names))
+	for addr := range names {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Less(addrs[j]) })
+
+	var b strings.Builder
+	b.WriteString(hostsFileBegin + "\n")
+	b.WriteString("# Generated by Ark from the asset inventory; edits are overwritten.\n")
+	for _, addr := range addrs {
+		fmt.Fprintf(&b, "%s\t%s\n", addr, strings.Join(names[addr], " "))
+	}
+	b.WriteString(hostsFileEnd + "\n")
+
+	return []byte(b.String())
+}
+
+// HostsFile renders the user's assets with an address, narrowed by req, as an
+// /etc/hosts block
+func (s *IntegrationService) HostsFile(ctx context.Context, userID string, req *model.GeneratedFileRequest) ([]byte, error) {
+	assets, err := s.listAssets(ctx, userID, req.Type, req.Tags, req.TagMode, model.MaxGeneratedFileHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderHostsFile(assets), nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/model"
+)
+
+// TestHostsFileName keeps names to lowercase letters, digits, dots and dashes
+func TestHostsFileName(t *testing.T) {
+	assert.Equal(t, "web01.lan", hostsFileName("web01.lan"))
+	assert.Equal(t, "living-room-pi", hostsFileName("Living Room Pi"))
+	assert.Equal(t, "", hostsFileName("__"))
+}
+
+// TestRenderHostsFile lists assets by address between markers
+func TestRenderHostsFile(t *testing.T) {
+	assets := []*model.Asset{
+		{ID: uuid.New(), Name: "web01", Hostname: stringPtr("10.0.0.11")},
+		{ID: uuid.New(), Name: "NAS", Hostname: stringPtr("nas.lan"), Metadata: json.RawMessage(`{"ip_address": "10.0.0.2"}`)},
+		{ID: uuid.New(), Name: "web", Hostname: stringPtr("10.0.0.11")},
+		{ID: uuid.New(), Name: "router", Hostname: stringPtr("fd00::1")},
+		{ID: uuid.New(), Name: "laptop", Hostname: stringPtr("laptop.lan")},
+		{ID: uuid.New(), Name: "web01", Hostname: stringPtr("10.0.0.12")},
+	}
+
+	assert.Equal(t, `# BEGIN ark
+# Generated by Ark from the asset inventory; edits are overwritten.
+10.0.0.2	nas nas.lan
+10.0.0.11	web01 web
+fd00::1	router
+# END ark
+`, string(renderHostsFile(assets)))
+}
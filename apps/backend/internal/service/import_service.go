@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// maxImportFieldErrors bounds the field errors reported for an invalid archive
+const maxImportFieldErrors = 100
+
+type ImportService struct {
+	importRepo *repository.ImportRepository
+	stats      *StatsService
+}
+
+func NewImportService(importRepo *repository.ImportRepository, stats *StatsService) *ImportService {
+	return &ImportService{
+		importRepo: importRepo,
+		stats:      stats,
+	}
+}
+
+// Import recreates the assets and logs of an account archive. Rows keep their
+// archived IDs and timestamps unless the ID is taken; params.Conflict decides
+// what happens to IDs the user already has. A dry run only reports the plan.
+func (s *ImportService) Import(ctx context.Context, userID string, r io.ReaderAt, size int64, params *model.ImportParams) (*model.ImportResult, error) {
+	params.SetDefaults()
+	if !model.IsValidImportConflict(params.Conflict) {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "conflict", Error: "must be one of: skip overwrite duplicate"},
+		}, nil)
+	}
+
+	archive, err := readExportArchive(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateImportArchive(archive); err != nil {
+		return nil, err
+	}
+
+	assetIDs := make([]uuid.UUID, 0, len(archive.Assets))
+	for _, asset := range archive.Assets {
+		assetIDs = append(assetIDs, asset.ID)
+	}
+	logIDs := make([]uuid.UUID, 0, len(archive.Logs))
+	for _, log := range archive.Logs {
+		logIDs = append(logIDs, log.ID)
+	}
+
+	owners, err := s.importRepo.ExistingIDs(ctx, userID, assetIDs, logIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, result := planImport(archive, owners, params.Conflict, time.Now(), uuid.New)
+	result.DryRun = params.DryRun
+	if params.DryRun {
+		return result, nil
+	}
+
+	if err := s.importRepo.Apply(ctx, userID, rows); err != nil {
+		return nil, err
+	}
+	s.stats.Invalidate(ctx, userID)
+
+	return result, nil
+}
+
+// validateImportArchive applies the rules of the asset and log services to
+// every archived row. Field errors are prefixed with the archive file.
+func validateImportArchive(archive *parsedArchive) error {
+	var fieldErrors []errs.FieldError
+	fail := func(file, field, message string) {
+		if len(fieldErrors) >= maxImportFieldErrors {
+			return
+		}
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: file + ": " + field, Error: message})
+	}
+
+	assets := make(map[uuid.UUID]bool, len(archive.Assets))
+	for _, asset := range archive.Assets {
+		switch {
+		case asset.ID == uuid.Nil:
+			fail(asset.Path, "id", "is required")
+		case assets[asset.ID]:
+			fail(asset.Path, "id", "is used by another asset in the archive")
+		}
+		assets[asset.ID] = true
+
+		if strings.TrimSpace(asset.Name) == "" {
+			fail(asset.Path, "name", "is required")
+		} else if utf8.RuneCountInString(asset.Name) > 100 {
+			fail(asset.Path, "name", "must be at most 100 characters")
+		}
+		if asset.Type != nil && !model.IsValidAssetType(*asset.Type) {
+			fail(asset.Path, "type", "must be one of: server vm nas container network other")
+		}
+		if asset.Hostname != nil && utf8.RuneCountInString(*asset.Hostname) > 255 {
+			fail(asset.Path, "hostname", "must be at most 255 characters")
+		}
+		if len(asset.Metadata) > 0 && !isJSONObject(asset.Metadata) {
+			fail(asset.Path, "metadata", "must be a JSON object")
+		}
+	}
+
+	logs := make(map[uuid.UUID]bool, len(archive.Logs))
+	for _, log := range archive.Logs {
+		switch {
+		case log.ID == uuid.Nil:
+			fail(log.Path, "id", "is required")
+		case logs[log.ID]:
+			fail(log.Path, "id", "is used by another log in the archive")
+		}
+		logs[log.ID] = true
+
+		if !assets[log.AssetID] {
+			fail(log.Path, "asset_id", "must be an asset in the archive")
+		}
+		if strings.TrimSpace(log.Content) == "" {
+			fail(log.Path, "content", "is required")
+		} else if utf8.RuneCountInString(log.Content) > 10000 {
+			fail(log.Path, "content", "must be at most 10000 characters")
+		}
+
+		err := validateLogKind(log.Kind, logKindFields{
+			Severity:        log.Severity,
+			StartedAt:       log.StartedAt,
+			ResolvedAt:      log.ResolvedAt,
+			RootCause:       log.RootCause,
+			Planned:         log.Planned,
+			RollbackNotes:   log.RollbackNotes,
+			DurationMinutes: log.DurationMinutes,
+		})
+		var httpErr *errs.HTTPError
+		if errors.As(err, &httpErr) {
+			if len(httpErr.Errors) == 0 {
+				fail(log.Path, "kind", httpErr.Message)
+			}
+			for _, fieldErr := range httpErr.Errors {
+				fail(log.Path, fieldErr.Field, fieldErr.Error)
+			}
+		}
+
+		if log.IncidentStatus != nil {
+			if log.Kind != model.LogKindIncident {
+				fail(log.Path, "incident_status", "only allowed for incident logs")
+			} else if !model.IsValidIncidentStatus(*log.IncidentStatus) {
+				fail(log.Path, "incident_status", "must be one of: open mitigated resolved")
+			}
+		}
+
+		if len(log.Timeline) > 0 && log.Kind != model.LogKindIncident {
+			fail(log.Path, "timeline", "only allowed for incident logs")
+		}
+		for i, entry := range log.Timeline {
+			field := fmt.Sprintf("timeline[%d]", i)
+			if entry.Status == nil && entry.Note == nil {
+				fail(log.Path, field, "requires a status or a note")
+			}
+			if entry.Status != nil && !model.IsValidIncidentStatus(*entry.Status) {
+				fail(log.Path, field+".status", "must be one of: open mitigated resolved")
+			}
+			if entry.Note != nil && utf8.RuneCountInString(*entry.Note) > 5000 {
+				fail(log.Path, field+".note", "must be at most 5000 characters")
+			}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return errs.NewBadRequestError("Invalid archive", false, nil, fieldErrors, nil)
+	}
+
+	return nil
+}
+
+// isJSONObject reports whether data is a JSON object
+func isJSONObject(data json.RawMessage) bool {
+	var object map[string]json.RawMessage
+	return json.Unmarshal(data, &object) == nil && object != nil
+}
+
+// importPlanner maps archived IDs to the IDs rows are imported under
+type importPlanner struct {
+	policy string
+	newID  func() uuid.UUID
+	result *model.ImportResult
+}
+
+// assign decides the action and ID for an archived row. owned and exists
+// describe the ID in the database: IDs of other users always get a new ID,
+// the user's own IDs follow the conflict policy.
+func (p *importPlanner) assign(summary *model.ImportEntitySummary, id uuid.UUID, owned, exists bool) (string, uuid.UUID) {
+	switch {
+	case !exists:
+		summary.Created++
+		return model.ImportActionCreate, id
+	case !owned || p.policy == model.ImportConflictDuplicate:
+		summary.Created++
+		summary.NewIDs++
+		return model.ImportActionCreate, p.newID()
+	case p.policy == model.ImportConflictOverwrite:
+		summary.Updated++
+		return model.ImportActionUpdate, id
+	default:
+		summary.Skipped++
+		return model.ImportActionSkip, id
+	}
+}
+
+// record adds a change to the result, up to model.MaxImportChanges
+func (p *importPlanner) record(kind, action string, id, importID uuid.UUID, name string) {
+	if len(p.result.Changes) >= model.MaxImportChanges {
+		p.result.ChangesTruncated = true
+		return
+	}
+
+	change := model.ImportChange{Type: kind, Action: action, ID: id, Name: name}
+	if importID != id {
+		change.NewID = &importID
+	}
+	p.result.Changes = append(p.result.Changes, change)
+}
+
+// importTimes fills in missing timestamps
+func importTimes(createdAt, updatedAt, now time.Time) (time.Time, time.Time) {
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
+	return createdAt, updatedAt
+}
+
+// logExcerpt names a log in the change list by the start of its first line
+func logExcerpt(content string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	if utf8.RuneCountInString(line) > 80 {
+		line = string([]rune(line)[:80]) + "…"
+	}
+	return line
+}
+
+// planImport decides what happens to every row of a validated archive and
+// builds the rows to write. References to linked assets, parents and thread
+// roots follow rows that get a new ID; references that cannot be resolved
+// are dropped, and a reply whose thread cannot be resolved becomes a root log.
+func planImport(archive *parsedArchive, owners *repository.ImportOwnership, policy string, now time.Time, newID func() uuid.UUID) (*repository.ImportRows, *model.ImportResult) {
+	result := &model.ImportResult{
+		Conflict: policy,
+		Changes:  []model.ImportChange{},
+	}
+	planner := &importPlanner{policy: policy, newID: newID, result: result}
+	rows := &repository.ImportRows{
+		Assets:    []*model.Asset{},
+		Logs:      []*model.AssetLog{},
+		Overwrite: make(map[uuid.UUID]bool),
+	}
+
+	assetIDs := make(map[uuid.UUID]uuid.UUID, len(archive.Assets))
+	for _, a := range archive.Assets {
+		owned, exists := owners.Assets[a.ID]
+		action, id := planner.assign(&result.Assets, a.ID, owned, exists)
+		assetIDs[a.ID] = id
+		planner.record(model.ImportTypeAsset, action, a.ID, id, a.Name)
+		if action == model.ImportActionSkip {
+			continue
+		}
+
+		rows.Overwrite[id] = action == model.ImportActionUpdate
+		createdAt, updatedAt := importTimes(a.CreatedAt, a.UpdatedAt, now)
+		rows.Assets = append(rows.Assets, &model.Asset{
+			ID:        id,
+			Name:      a.Name,
+			Type:      a.Type,
+			Hostname:  a.Hostname,
+			Tags:      processTags(a.Tags),
+			Metadata:  a.Metadata,
+			Pinned:    a.Pinned,
+			Favorite:  a.Favorite,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		})
+	}
+
+	// Map every log first so replies can point at logs later in the archive
+	actions := make([]string, len(archive.Logs))
+	logIDs := make(map[uuid.UUID]uuid.UUID, len(archive.Logs))
+	for i, l := range archive.Logs {
+		owned, exists := owners.Logs[l.ID]
+		actions[i], logIDs[l.ID] = planner.assign(&result.Logs, l.ID, owned, exists)
+	}
+
+	for i, l := range archive.Logs {
+		id := logIDs[l.ID]
+		planner.record(model.ImportTypeLog, actions[i], l.ID, id, logExcerpt(l.Content))
+		if actions[i] == model.ImportActionSkip {
+			continue
+		}
+
+		assetID := assetIDs[l.AssetID]
+		var linked []uuid.UUID
+		for _, linkedID := range l.LinkedAssetIDs {
+			if mapped, ok := assetIDs[linkedID]; ok {
+				linked = append(linked, mapped)
+			}
+		}
+
+		var parentID, rootID *uuid.UUID
+		if l.ParentLogID != nil && l.ThreadRootID != nil {
+			parent, parentOK := logIDs[*l.ParentLogID]
+			root, rootOK := logIDs[*l.ThreadRootID]
+			if parentOK && rootOK {
+				parentID, rootID = &parent, &root
+			}
+		}
+
+		rows.Overwrite[id] = actions[i] == model.ImportActionUpdate
+		createdAt, updatedAt := importTimes(l.CreatedAt, l.UpdatedAt, now)
+		rows.Logs = append(rows.Logs, &model.AssetLog{
+			ID:              id,
+			AssetID:         assetID,
+			Kind:            l.Kind,
+			Content:         l.Content,
+			Tags:            processTags(l.Tags),
+			Severity:        l.Severity,
+			StartedAt:       l.StartedAt,
+			ResolvedAt:      l.ResolvedAt,
+			RootCause:       l.RootCause,
+			IncidentStatus:  l.IncidentStatus,
+			MitigatedAt:     l.MitigatedAt,
+			Planned:         l.Planned,
+			RollbackNotes:   l.RollbackNotes,
+			DurationMinutes: l.DurationMinutes,
+			LinkedAssetIDs:  processLinkedAssetIDs(assetID, linked),
+			ParentLogID:     parentID,
+			ThreadRootID:    rootID,
+			Pinned:          l.Pinned,
+			Favorite:        l.Favorite,
+			CreatedAt:       createdAt,
+			UpdatedAt:       updatedAt,
+		})
+
+		for _, entry := range l.Timeline {
+			occurredAt, entryCreatedAt := importTimes(entry.OccurredAt, entry.CreatedAt, createdAt)
+			rows.Timeline = append(rows.Timeline, model.IncidentTimelineEntry{
+				LogID:      id,
+				Status:     entry.Status,
+				Note:       entry.Note,
+				OccurredAt: occurredAt,
+				CreatedAt:  entryCreatedAt,
+			})
+		}
+	}
+
+	return rows, result
+}
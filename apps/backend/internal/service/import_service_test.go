@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// TestImportService_Constructor verifies NewImportService works correctly
+func TestImportService_Constructor(t *testing.T) {
+	service := NewImportService(nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestImportService_InvalidConflict rejects unknown conflict policies before reading the archive
+func TestImportService_InvalidConflict(t *testing.T) {
+	service := NewImportService(nil, nil)
+
+	_, err := service.Import(context.Background(), "user-123", bytes.NewReader(nil), 0, &model.ImportParams{Conflict: "merge"})
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.Equal(t, "conflict", httpErr.Errors[0].Field)
+}
+
+// testImportArchive is an asset with a root log and a reply
+func testImportArchive() (*parsedArchive, uuid.UUID, uuid.UUID, uuid.UUID) {
+	assetID, rootID, replyID := uuid.New(), uuid.New(), uuid.New()
+	created := time.Date(2024, 5, 30, 12, 0, 0, 0, time.UTC)
+
+	archive := &parsedArchive{
+		Assets: []archiveAssetEntry{{
+			archiveAsset: archiveAsset{ID: assetID, Name: "nas-01", Tags: []string{" Storage "}, CreatedAt: created, UpdatedAt: created},
+			Path:         "assets/nas-01/asset.json",
+		}},
+		Logs: []archiveLogEntry{
+			{
+				archiveLog: archiveLog{ID: rootID, AssetID: assetID, Kind: model.LogKindNote, CreatedAt: created, UpdatedAt: created},
+				Content:    "Replaced disk",
+				Path:       "assets/nas-01/logs/root.md",
+			},
+			{
+				archiveLog: archiveLog{
+					ID: replyID, AssetID: assetID, Kind: model.LogKindNote,
+					LinkedAssetIDs: []uuid.UUID{assetID, uuid.New()},
+					ParentLogID:    &rootID, ThreadRootID: &rootID,
+					CreatedAt: created.Add(time.Hour),
+				},
+				Content: "Resilver finished",
+				Path:    "assets/nas-01/logs/reply.md",
+			},
+		},
+	}
+	return archive, assetID, rootID, replyID
+}
+
+// TestValidateImportArchive applies the asset and log rules to archived rows
+func TestValidateImportArchive(t *testing.T) {
+	archive, _, _, _ := testImportArchive()
+	require.NoError(t, validateImportArchive(archive))
+
+	archive.Assets[0].Type = stringPtr("toaster")
+	archive.Logs[0].Kind = model.LogKindIncident
+	archive.Logs[1].AssetID = uuid.New()
+
+	err := validateImportArchive(archive)
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+
+	fields := make([]string, 0, len(httpErr.Errors))
+	for _, fieldErr := range httpErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	assert.Contains(t, fields, "assets/nas-01/asset.json: type")
+	assert.Contains(t, fields, "assets/nas-01/logs/root.md: severity")
+	assert.Contains(t, fields, "assets/nas-01/logs/reply.md: asset_id")
+
+	// Timelines are only imported on incidents, with valid entries
+	archive, _, _, _ = testImportArchive()
+	archive.Logs[0].Timeline = []archiveTimelineEntry{{}}
+	archive.Logs[1].Kind = model.LogKindIncident
+	archive.Logs[1].Severity = stringPtr(model.SeverityLow)
+	archive.Logs[1].Timeline = []archiveTimelineEntry{{Status: stringPtr("closed")}}
+
+	err = validateImportArchive(archive)
+	require.ErrorAs(t, err, &httpErr)
+	fields = fields[:0]
+	for _, fieldErr := range httpErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"assets/nas-01/logs/root.md: timeline",
+		"assets/nas-01/logs/root.md: timeline[0]",
+		"assets/nas-01/logs/reply.md: timeline[0].status",
+	}, fields)
+}
+
+// TestPlanImport_NewAccount keeps archived IDs, timestamps and threads
+func TestPlanImport_NewAccount(t *testing.T) {
+	archive, assetID, rootID, replyID := testImportArchive()
+	owners := &repository.ImportOwnership{Assets: map[uuid.UUID]bool{}, Logs: map[uuid.UUID]bool{}}
+
+	rows, result := planImport(archive, owners, model.ImportConflictSkip, time.Now(), uuid.New)
+
+	assert.Equal(t, model.ImportEntitySummary{Created: 1}, result.Assets)
+	assert.Equal(t, model.ImportEntitySummary{Created: 2}, result.Logs)
+	assert.Len(t, result.Changes, 3)
+
+	require.Len(t, rows.Assets, 1)
+	assert.Equal(t, assetID, rows.Assets[0].ID)
+	assert.Equal(t, []string{"storage"}, rows.Assets[0].Tags)
+
+	require.Len(t, rows.Logs, 2)
+	reply := rows.Logs[1]
+	assert.Equal(t, replyID, reply.ID)
+	assert.Equal(t, rootID, *reply.ParentLogID)
+	assert.Equal(t, rootID, *reply.ThreadRootID)
+	assert.Empty(t, reply.LinkedAssetIDs, "the primary asset and assets outside the archive are dropped")
+	assert.True(t, reply.CreatedAt.Equal(reply.UpdatedAt), "a missing updated_at falls back to created_at")
+	assert.False(t, rows.Overwrite[replyID])
+}
+
+// TestPlanImport_ConflictPolicies covers IDs the user already has
+func TestPlanImport_ConflictPolicies(t *testing.T) {
+	tests := []struct {
+		policy   string
+		assets   model.ImportEntitySummary
+		logs     model.ImportEntitySummary
+		rows     int
+		newIDs   bool
+		override bool
+	}{
+		{policy: model.ImportConflictSkip, assets: model.ImportEntitySummary{Skipped: 1}, logs: model.ImportEntitySummary{Created: 1, Skipped: 1}, rows: 1},
+		{policy: model.ImportConflictOverwrite, assets: model.ImportEntitySummary{Updated: 1}, logs: model.ImportEntitySummary{Created: 1, Updated: 1}, rows: 2, override: true},
+		{policy: model.ImportConflictDuplicate, assets: model.ImportEntitySummary{Created: 1, NewIDs: 1}, logs: model.ImportEntitySummary{Created: 2, NewIDs: 1}, rows: 2, newIDs: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.policy, func(t *testing.T) {
+			archive, assetID, rootID, replyID := testImportArchive()
+			archive.Logs[1].Kind = model.LogKindIncident
+			archive.Logs[1].Timeline = []archiveTimelineEntry{{Note: stringPtr("Paged on-call")}}
+			owners := &repository.ImportOwnership{
+				Assets: map[uuid.UUID]bool{assetID: true},
+				Logs:   map[uuid.UUID]bool{rootID: true},
+			}
+
+			rows, result := planImport(archive, owners, tc.policy, time.Now(), uuid.New)
+
+			assert.Equal(t, tc.assets, result.Assets)
+			assert.Equal(t, tc.logs, result.Logs)
+			require.Len(t, rows.Logs, tc.rows)
+			assert.Equal(t, tc.override, rows.Overwrite[rootID])
+
+			// The reply always follows the asset and root it was imported with
+			reply := rows.Logs[len(rows.Logs)-1]
+			assert.Equal(t, replyID, reply.ID)
+
+			// Timeline entries follow their log's imported ID
+			require.Len(t, rows.Timeline, 1)
+			assert.Equal(t, reply.ID, rows.Timeline[0].LogID)
+			if tc.newIDs {
+				assert.NotEqual(t, assetID, reply.AssetID)
+				assert.NotEqual(t, rootID, *reply.ParentLogID)
+				assert.Equal(t, rows.Logs[0].ID, *reply.ThreadRootID)
+			} else {
+				assert.Equal(t, assetID, reply.AssetID)
+				assert.Equal(t, rootID, *reply.ParentLogID)
+			}
+		})
+	}
+}
+
+// TestPlanImport_OtherUsersIDs imports rows under new IDs when the ID belongs to another account
+func TestPlanImport_OtherUsersIDs(t *testing.T) {
+	archive, assetID, _, _ := testImportArchive()
+	owners := &repository.ImportOwnership{
+		Assets: map[uuid.UUID]bool{assetID: false},
+		Logs:   map[uuid.UUID]bool{},
+	}
+
+	rows, result := planImport(archive, owners, model.ImportConflictOverwrite, time.Now(), uuid.New)
+
+	assert.Equal(t, model.ImportEntitySummary{Created: 1, NewIDs: 1}, result.Assets)
+	require.Len(t, rows.Assets, 1)
+	assert.NotEqual(t, assetID, rows.Assets[0].ID)
+	assert.False(t, rows.Overwrite[rows.Assets[0].ID])
+	require.NotNil(t, result.Changes[0].NewID)
+	assert.Equal(t, rows.Assets[0].ID, *result.Changes[0].NewID)
+}
+
+// TestPlanImport_UnresolvedThread turns a reply whose thread is missing into a root log
+func TestPlanImport_UnresolvedThread(t *testing.T) {
+	archive, _, _, _ := testImportArchive()
+	missing := uuid.New()
+	archive.Logs[1].ParentLogID = &missing
+	owners := &repository.ImportOwnership{Assets: map[uuid.UUID]bool{}, Logs: map[uuid.UUID]bool{}}
+
+	rows, _ := planImport(archive, owners, model.ImportConflictSkip, time.Now(), uuid.New)
+
+	assert.Nil(t, rows.Logs[1].ParentLogID)
+	assert.Nil(t, rows.Logs[1].ThreadRootID)
+}
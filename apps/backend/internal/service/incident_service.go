@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// maxIncidentBuckets caps the number of intervals returned by Stats
+const maxIncidentBuckets = 366
+
+type IncidentService struct {
+	incidentRepo *repository.IncidentRepository
+	logRepo      *repository.LogRepository
+	assetRepo    *repository.AssetRepository
+	stats        *StatsService
+}
+
+func NewIncidentService(incidentRepo *repository.IncidentRepository, logRepo *repository.LogRepository, assetRepo *repository.AssetRepository, stats *StatsService) *IncidentService {
+	return &IncidentService{
+		incidentRepo: incidentRepo,
+		logRepo:      logRepo,
+		assetRepo:    assetRepo,
+		stats:        stats,
+	}
+}
+
+// getIncident loads a log and verifies that it is an incident
+func (s *IncidentService) getIncident(ctx context.Context, userID string, logID uuid.UUID) (*model.AssetLog, error) {
+	log, err := s.logRepo.GetByID(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.Kind != model.LogKindIncident {
+		return nil, errs.NewBadRequestError("log is not an incident", false, nil, nil, nil)
+	}
+
+	return log, nil
+}
+
+func (s *IncidentService) GetTimeline(ctx context.Context, userID string, logID uuid.UUID) (*model.IncidentTimelineResponse, error) {
+	incident, err := s.getIncident(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.incidentRepo.ListTimeline(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.IncidentTimelineResponse{
+		Incident: model.NewLogResponse(incident),
+		Entries:  entries,
+	}, nil
+}
+
+// validateTimelineEntry checks a timeline entry against the incident's current state
+func validateTimelineEntry(incident *model.AssetLog, req *model.CreateTimelineEntryRequest, occurredAt time.Time) error {
+	if req.Status == nil && req.Note == nil {
+		return errs.NewBadRequestError("status or note is required", false, nil, nil, nil)
+	}
+
+	if req.Status != nil {
+		current := model.IncidentStatusOpen
+		if incident.IncidentStatus != nil {
+			current = *incident.IncidentStatus
+		}
+		if !model.IsValidIncidentStatus(*req.Status) || !model.CanTransitionIncident(current, *req.Status) {
+			return errs.NewBadRequestError("cannot move incident from "+current+" to "+*req.Status, false, nil, nil, nil)
+		}
+	}
+
+	if incident.StartedAt != nil && occurredAt.Before(*incident.StartedAt) {
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "occurred_at", Error: "must not be before the incident started"},
+		}, nil)
+	}
+
+	return nil
+}
+
+func (s *IncidentService) AddTimelineEntry(ctx context.Context, userID string, logID uuid.UUID, req *model.CreateTimelineEntryRequest) (*model.IncidentTimelineResponse, error) {
+	incident, err := s.getIncident(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurredAt := time.Now()
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+
+	if err := validateTimelineEntry(incident, req, occurredAt); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.incidentRepo.AddTimelineEntry(ctx, userID, logID, req, occurredAt); err != nil {
+		return nil, err
+	}
+	s.stats.Invalidate(ctx, userID)
+
+	return s.GetTimeline(ctx, userID, logID)
+}
+
+func (s *IncidentService) Stats(ctx context.Context, userID string, params *model.IncidentStatsQueryParams) (*model.IncidentStatsResponse, error) {
+	now := time.Now().UTC()
+	params.SetDefaults(now)
+
+	start, end := params.StartDate.UTC(), params.EndDate.UTC()
+	if !end.After(start) {
+		return nil, errs.NewBadRequestError("end_date must be after start_date", false, nil, nil, nil)
+	}
+	if len(incidentBucketStarts(start, end, params.Interval)) > maxIncidentBuckets {
+		return nil, errs.NewBadRequestError("too many intervals, use a larger interval or a shorter window", false, nil, nil, nil)
+	}
+
+	var assetID *uuid.UUID
+	var assetCount int64
+	if params.AssetID != nil {
+		id, err := uuid.Parse(*params.AssetID)
+		if err != nil {
+			return nil, errs.NewBadRequestError("invalid asset id", false, nil, nil, nil)
+		}
+		// Verify asset ownership
+		if _, err := s.assetRepo.GetByID(ctx, userID, id); err != nil {
+			return nil, err
+		}
+		assetID = &id
+		assetCount = 1
+	} else {
+		count, err := s.assetRepo.Count(ctx, userID, &model.AssetQueryParams{Type: params.Type})
+		if err != nil {
+			return nil, err
+		}
+		assetCount = count
+	}
+
+	incidents, err := s.incidentRepo.ListIncidents(ctx, userID, start, end, assetID, params.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.MinSeverity != nil {
+		incidents = filterIncidentsBySeverity(incidents, *params.MinSeverity)
+	}
+
+	return computeIncidentStats(incidents, assetCount, start, end, now, params.Interval), nil
+}
+
+// filterIncidentsBySeverity keeps incidents at or above the given severity
+func filterIncidentsBySeverity(incidents []model.IncidentRecord, minSeverity string) []model.IncidentRecord {
+	minRank := model.SeverityRank(minSeverity)
+	filtered := make([]model.IncidentRecord, 0, len(incidents))
+	for _, incident := range incidents {
+		if incident.Severity != nil && model.SeverityRank(*incident.Severity) >= minRank {
+			filtered = append(filtered, incident)
+		}
+	}
+	return filtered
+}
+
+// truncateToInterval returns the start of the interval containing t (UTC).
+// Weeks start on Monday, matching Postgres date_trunc('week').
+func truncateToInterval(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case model.StatsIntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case model.StatsIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// nextInterval returns the start of the interval following start
+func nextInterval(start time.Time, interval string) time.Time {
+	switch interval {
+	case model.StatsIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case model.StatsIntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// incidentBucketStarts lists the interval starts covering [start, end]
+func incidentBucketStarts(start, end time.Time, interval string) []time.Time {
+	var starts []time.Time
+	for b := truncateToInterval(start, interval); !b.After(end); b = nextInterval(b, interval) {
+		starts = append(starts, b)
+		if len(starts) > maxIncidentBuckets {
+			break
+		}
+	}
+	return starts
+}
+
+// timeSpan is a half-open [start, end) interval
+type timeSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+// unionDuration returns the total duration covered by spans, counting overlaps once
+func unionDuration(spans []timeSpan) time.Duration {
+	if len(spans) == 0 {
+		return 0
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	var total time.Duration
+	current := spans[0]
+	for _, span := range spans[1:] {
+		if span.start.After(current.end) {
+			total += current.end.Sub(current.start)
+			current = span
+			continue
+		}
+		if span.end.After(current.end) {
+			current.end = span.end
+		}
+	}
+	total += current.end.Sub(current.start)
+
+	return total
+}
+
+// meanSeconds returns the mean of the durations in seconds, or nil when there are none
+func meanSeconds(durations []time.Duration) *float64 {
+	if len(durations) == 0 {
+		return nil
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	mean := total.Seconds() / float64(len(durations))
+	return &mean
+}
+
+// availabilityPercent converts downtime within a window into an availability percentage
+func availabilityPercent(downtimeSeconds, windowSeconds float64) float64 {
+	if windowSeconds <= 0 {
+		return 100
+	}
+	return 100 * (1 - downtimeSeconds/windowSeconds)
+}
+
+// computeIncidentStats aggregates incidents overlapping [start, end].
+//   - MTTR uses incidents resolved inside the window (resolved_at - started_at)
+//   - Downtime is the union of incident spans clipped to the window; open incidents run until now
+//   - Availability across all assets assumes assets without incidents were up the whole window
+func computeIncidentStats(incidents []model.IncidentRecord, assetCount int64, start, end, now time.Time, interval string) *model.IncidentStatsResponse {
+	window := end.Sub(start)
+
+	type assetAccumulator struct {
+		stats     model.AssetIncidentStats
+		repairs   []time.Duration
+		downtimes []timeSpan
+	}
+	type typeAccumulator struct {
+		count   int
+		repairs []time.Duration
+	}
+
+	assets := make(map[uuid.UUID]*assetAccumulator)
+	assetOrder := make([]uuid.UUID, 0)
+	types := make(map[string]*typeAccumulator)
+	buckets := make(map[time.Time]*model.IncidentBucket)
+	var allRepairs []time.Duration
+
+	response := &model.IncidentStatsResponse{
+		StartDate: start,
+		EndDate:   end,
+		Interval:  interval,
+	}
+
+	for _, incident := range incidents {
+		acc, ok := assets[incident.AssetID]
+		if !ok {
+			acc = &assetAccumulator{stats: model.AssetIncidentStats{
+				AssetID:   incident.AssetID,
+				AssetName: incident.AssetName,
+				AssetType: incident.AssetType,
+			}}
+			assets[incident.AssetID] = acc
+			assetOrder = append(assetOrder, incident.AssetID)
+		}
+
+		typeKey := "untyped"
+		if incident.AssetType != nil {
+			typeKey = *incident.AssetType
+		}
+		typeAcc, ok := types[typeKey]
+		if !ok {
+			typeAcc = &typeAccumulator{}
+			types[typeKey] = typeAcc
+		}
+
+		if incident.Status != model.IncidentStatusResolved {
+			response.OpenIncidents++
+		}
+
+		// Counts cover incidents that started inside the window
+		if !incident.StartedAt.Before(start) {
+			response.TotalIncidents++
+			acc.stats.IncidentCount++
+			typeAcc.count++
+
+			bucketStart := truncateToInterval(incident.StartedAt, interval)
+			bucket, ok := buckets[bucketStart]
+			if !ok {
+				bucket = &model.IncidentBucket{Start: bucketStart, ByType: map[string]int{}}
+				buckets[bucketStart] = bucket
+			}
+			bucket.Total++
+			bucket.ByType[typeKey]++
+		}
+
+		// Repair times cover incidents resolved inside the window
+		if incident.ResolvedAt != nil && !incident.ResolvedAt.Before(start) && !incident.ResolvedAt.After(end) {
+			repair := incident.ResolvedAt.Sub(incident.StartedAt)
+			acc.stats.ResolvedCount++
+			acc.repairs = append(acc.repairs, repair)
+			typeAcc.repairs = append(typeAcc.repairs, repair)
+			allRepairs = append(allRepairs, repair)
+		}
+
+		// Downtime is clipped to the window
+		spanEnd := now
+		if incident.ResolvedAt != nil {
+			spanEnd = *incident.ResolvedAt
+		}
+		spanStart := incident.StartedAt
+		if spanStart.Before(start) {
+			spanStart = start
+		}
+		if spanEnd.After(end) {
+			spanEnd = end
+		}
+		if spanEnd.After(spanStart) {
+			acc.downtimes = append(acc.downtimes, timeSpan{start: spanStart, end: spanEnd})
+		}
+	}
+
+	var totalDowntime time.Duration
+	response.ByAsset = make([]model.AssetIncidentStats, 0, len(assetOrder))
+	for _, id := range assetOrder {
+		acc := assets[id]
+		downtime := unionDuration(acc.downtimes)
+		totalDowntime += downtime
+		acc.stats.MTTRSeconds = meanSeconds(acc.repairs)
+		acc.stats.DowntimeSeconds = downtime.Seconds()
+		acc.stats.AvailabilityPercent = availabilityPercent(downtime.Seconds(), window.Seconds())
+		response.ByAsset = append(response.ByAsset, acc.stats)
+	}
+	sort.SliceStable(response.ByAsset, func(i, j int) bool {
+		return response.ByAsset[i].IncidentCount > response.ByAsset[j].IncidentCount
+	})
+
+	response.ByType = make([]model.TypeIncidentStats, 0, len(types))
+	for key, acc := range types {
+		response.ByType = append(response.ByType, model.TypeIncidentStats{
+			AssetType:     key,
+			IncidentCount: acc.count,
+			MTTRSeconds:   meanSeconds(acc.repairs),
+		})
+	}
+	sort.Slice(response.ByType, func(i, j int) bool {
+		if response.ByType[i].IncidentCount != response.ByType[j].IncidentCount {
+			return response.ByType[i].IncidentCount > response.ByType[j].IncidentCount
+		}
+		return response.ByType[i].AssetType < response.ByType[j].AssetType
+	})
+
+	// Emit every interval so charts don't have gaps
+	bucketStarts := incidentBucketStarts(start, end, interval)
+	response.OverTime = make([]model.IncidentBucket, 0, len(bucketStarts))
+	for _, b := range bucketStarts {
+		if bucket, ok := buckets[b]; ok {
+			response.OverTime = append(response.OverTime, *bucket)
+		} else {
+			response.OverTime = append(response.OverTime, model.IncidentBucket{Start: b, ByType: map[string]int{}})
+		}
+	}
+
+	response.MTTRSeconds = meanSeconds(allRepairs)
+
+	// assetCount comes from a separate query; it can never be lower than the assets seen here
+	if assetCount < int64(len(assetOrder)) {
+		assetCount = int64(len(assetOrder))
+	}
+	response.AvailabilityPercent = 100
+	if assetCount > 0 {
+		response.AvailabilityPercent = availabilityPercent(totalDowntime.Seconds(), window.Seconds()*float64(assetCount))
+	}
+
+	return response
+}
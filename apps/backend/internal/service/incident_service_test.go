@@ -0,0 +1,165 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestIncidentService_Stats_ReturnsIncidentStatsResponse verifies Stats returns IncidentStatsResponse DTO
+func TestIncidentService_Stats_ReturnsIncidentStatsResponse(t *testing.T) {
+	service := NewIncidentService(nil, nil, nil, nil)
+
+	_ = func() (*model.IncidentStatsResponse, error) {
+		return service.Stats(nil, "", nil)
+	}
+
+	assert.NotNil(t, service)
+}
+
+func TestValidateTimelineEntry(t *testing.T) {
+	started := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		status     *string
+		current    *string
+		note       *string
+		occurredAt time.Time
+		wantErr    bool
+	}{
+		{name: "note only", note: stringPtr("investigating"), current: stringPtr(model.IncidentStatusOpen), occurredAt: started.Add(time.Minute)},
+		{name: "open to mitigated", status: stringPtr(model.IncidentStatusMitigated), current: stringPtr(model.IncidentStatusOpen), occurredAt: started.Add(time.Hour)},
+		{name: "nil status treated as open", status: stringPtr(model.IncidentStatusResolved), occurredAt: started.Add(time.Hour)},
+		{name: "reopen resolved", status: stringPtr(model.IncidentStatusOpen), current: stringPtr(model.IncidentStatusResolved), occurredAt: started.Add(time.Hour)},
+		{name: "empty entry", current: stringPtr(model.IncidentStatusOpen), occurredAt: started, wantErr: true},
+		{name: "resolved to mitigated", status: stringPtr(model.IncidentStatusMitigated), current: stringPtr(model.IncidentStatusResolved), occurredAt: started.Add(time.Hour), wantErr: true},
+		{name: "invalid status", status: stringPtr("closed"), current: stringPtr(model.IncidentStatusOpen), occurredAt: started.Add(time.Hour), wantErr: true},
+		{name: "before start", note: stringPtr("early"), current: stringPtr(model.IncidentStatusOpen), occurredAt: started.Add(-time.Minute), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incident := &model.AssetLog{Kind: model.LogKindIncident, StartedAt: timePtr(started), IncidentStatus: tt.current}
+			req := &model.CreateTimelineEntryRequest{Status: tt.status, Note: tt.note}
+
+			err := validateTimelineEntry(incident, req, tt.occurredAt)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			httpErr, ok := err.(*errs.HTTPError)
+			require.True(t, ok, "error should be *errs.HTTPError")
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		})
+	}
+}
+
+func TestTruncateToInterval(t *testing.T) {
+	// Wednesday 2025-03-12 15:30 UTC
+	ts := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, model.StatsIntervalDay))
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, model.StatsIntervalWeek))
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, model.StatsIntervalMonth))
+
+	// Sunday belongs to the week starting the previous Monday
+	sunday := time.Date(2025, 3, 16, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), truncateToInterval(sunday, model.StatsIntervalWeek))
+}
+
+func TestUnionDuration(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+
+	assert.Equal(t, time.Duration(0), unionDuration(nil))
+
+	// Overlapping [0,3) and [2,5), nested [1,2), disjoint [7,8)
+	spans := []timeSpan{
+		{start: at(7), end: at(8)},
+		{start: at(0), end: at(3)},
+		{start: at(2), end: at(5)},
+		{start: at(1), end: at(2)},
+	}
+	assert.Equal(t, 6*time.Hour, unionDuration(spans))
+}
+
+func TestComputeIncidentStats(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 10)
+	now := end.Add(time.Hour)
+
+	server := uuid.New()
+	nas := uuid.New()
+	serverType := "server"
+
+	incidents := []model.IncidentRecord{
+		{
+			// Started before the window, resolved inside it: contributes MTTR and clipped downtime but no count
+			LogID: uuid.New(), AssetID: server, AssetName: "web-01", AssetType: &serverType,
+			Status: model.IncidentStatusResolved, StartedAt: start.Add(-2 * time.Hour), ResolvedAt: timePtr(start.Add(2 * time.Hour)),
+		},
+		{
+			LogID: uuid.New(), AssetID: server, AssetName: "web-01", AssetType: &serverType,
+			Status: model.IncidentStatusResolved, StartedAt: start.AddDate(0, 0, 1), ResolvedAt: timePtr(start.AddDate(0, 0, 1).Add(4 * time.Hour)),
+		},
+		{
+			// Still open: runs until the end of the window
+			LogID: uuid.New(), AssetID: nas, AssetName: "nas",
+			Status: model.IncidentStatusOpen, StartedAt: end.Add(-12 * time.Hour),
+		},
+	}
+
+	stats := computeIncidentStats(incidents, 4, start, end, now, model.StatsIntervalDay)
+
+	assert.Equal(t, 2, stats.TotalIncidents)
+	assert.Equal(t, 1, stats.OpenIncidents)
+
+	// MTTR over both resolved incidents: (4h + 4h) / 2
+	require.NotNil(t, stats.MTTRSeconds)
+	assert.InDelta(t, (4 * time.Hour).Seconds(), *stats.MTTRSeconds, 0.001)
+
+	require.Len(t, stats.ByAsset, 2)
+	assert.Equal(t, server, stats.ByAsset[0].AssetID)
+	assert.Equal(t, 1, stats.ByAsset[0].IncidentCount)
+	assert.Equal(t, 2, stats.ByAsset[0].ResolvedCount)
+	assert.InDelta(t, (6 * time.Hour).Seconds(), stats.ByAsset[0].DowntimeSeconds, 0.001)
+	assert.Nil(t, stats.ByAsset[1].MTTRSeconds)
+	assert.InDelta(t, (12 * time.Hour).Seconds(), stats.ByAsset[1].DowntimeSeconds, 0.001)
+
+	// 18h of downtime across 4 assets over 10 days
+	window := (10 * 24 * time.Hour).Seconds()
+	assert.InDelta(t, 100*(1-(18*time.Hour).Seconds()/(4*window)), stats.AvailabilityPercent, 0.0001)
+
+	require.Len(t, stats.ByType, 2)
+	assert.ElementsMatch(t, []string{"server", "untyped"}, []string{stats.ByType[0].AssetType, stats.ByType[1].AssetType})
+
+	// One zero-filled bucket per day in [start, end]
+	require.Len(t, stats.OverTime, 11)
+	assert.Equal(t, 1, stats.OverTime[1].Total)
+	assert.Equal(t, 1, stats.OverTime[1].ByType["server"])
+	assert.Equal(t, 0, stats.OverTime[0].Total)
+	assert.Equal(t, 1, stats.OverTime[9].Total)
+}
+
+func TestComputeIncidentStats_NoIncidents(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	stats := computeIncidentStats(nil, 3, start, end, end, model.StatsIntervalWeek)
+
+	assert.Equal(t, 0, stats.TotalIncidents)
+	assert.Nil(t, stats.MTTRSeconds)
+	assert.Equal(t, float64(100), stats.AvailabilityPercent)
+	assert.NotNil(t, stats.ByAsset)
+	assert.NotEmpty(t, stats.OverTime)
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// IntegrationService builds the views of the inventory that outside tools
+// such as Ansible and Prometheus read
+type IntegrationService struct {
+	assetRepo *repository.AssetRepository
+	portRepo  *repository.PortRepository
+}
+
+func NewIntegrationService(assetRepo *repository.AssetRepository, portRepo *repository.PortRepository) *IntegrationService {
+	return &IntegrationService{
+		assetRepo: assetRepo,
+		portRepo:  portRepo,
+	}
+}
+
+// listAssets returns the user's assets matching the filters, oldest first,
+// refusing to list more than max
+func (s *IntegrationService) listAssets(ctx context.Context, userID string, assetType *string, tags []string, tagMode string, max int) ([]*model.Asset, error) {
+	query := &model.AssetQueryParams{
+		Type:      assetType,
+		Tags:      tags,
+		TagMode:   tagMode,
+		SortBy:    "created_at",
+		SortOrder: "asc",
+	}
+	query.SetDefaults()
+	if query.Tags != nil {
+		query.Tags = processTags(query.Tags)
+	}
+
+	total, err := s.assetRepo.Count(ctx, userID, query)
+	if err != nil {
+		return nil, err
+	}
+	if total > int64(max) {
+		code := "INVENTORY_TOO_LARGE"
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("%d assets match, narrow the filters to at most %d", total, max),
+			false, &code, nil, nil)
+	}
+
+	query.Limit = max
+	query.Offset = 0
+	return s.assetRepo.List(ctx, userID, query)
+}
+
+// ansibleGroupName turns a type or tag into a valid Ansible group name:
+// lowercase letters, digits and underscores
+func ansibleGroupName(prefix, value string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// buildAnsibleInventory lists assets as Ansible hosts named after the asset.
+// Each host is in the group type_<type> and a group tag_<tag> per tag, or in
+// ungrouped without either. Metadata keys become host variables, along with
+// ansible_host for the hostname and ark_id, ark_type and ark_tags.
+func buildAnsibleInventory(assets []*model.Asset) *model.AnsibleInventory {
+	inv := &model.AnsibleInventory{
+		Groups:   map[string]*model.AnsibleInventoryGroup{"all": {}},
+		HostVars: make(map[string]map[string]any, len(assets)),
+	}
+
+	addHost := func(group, host string) {
+		if inv.Groups[group] == nil {
+			inv.Groups[group] = &model.AnsibleInventoryGroup{}
+		}
+		inv.Groups[group].Hosts = append(inv.Groups[group].Hosts, host)
+	}
+
+	for _, asset := range assets {
+		// Hosts must be unique; later assets sharing a name get their ID appended
+		host := asset.Name
+		if _, taken := inv.HostVars[host]; taken {
+			host = fmt.Sprintf("%s-%s", asset.Name, asset.ID.String()[:8])
+		}
+
+		vars := make(map[string]any)
+		for key, value := range decodeAssetMetadata(asset.Metadata) {
+			vars[key] = value
+		}
+		if asset.Hostname != nil && *asset.Hostname != "" {
+			vars["ansible_host"] = *asset.Hostname
+		}
+		vars["ark_id"] = asset.ID
+		vars["ark_tags"] = asset.Tags
+		if asset.Tags == nil {
+			vars["ark_tags"] = []string{}
+		}
+		if asset.Type != nil {
+			vars["ark_type"] = *asset.Type
+		}
+		inv.HostVars[host] = vars
+
+		grouped := false
+		if asset.Type != nil && *asset.Type != "" {
+			addHost(ansibleGroupName("type_", *asset.Type), host)
+			grouped = true
+		}
+		for _, tag := range asset.Tags {
+			addHost(ansibleGroupName("tag_", tag), host)
+			grouped = true
+		}
+		if !grouped {
+			addHost("ungrouped", host)
+		}
+	}
+
+	for name, group := range inv.Groups {
+		if name != "all" {
+			inv.Groups["all"].Children = append(inv.Groups["all"].Children, name)
+			sort.Strings(group.Hosts)
+		}
+	}
+	sort.Strings(inv.Groups["all"].Children)
+
+	return inv
+}
+
+// AnsibleInventory returns the user's assets, narrowed by params, in Ansible's
+// dynamic inventory format
+func (s *IntegrationService) AnsibleInventory(ctx context.Context, userID string, params *model.AnsibleInventoryParams) (*model.AnsibleInventory, error) {
+	assets, err := s.listAssets(ctx, userID, params.Type, params.Tags, params.TagMode, model.MaxAnsibleInventoryHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAnsibleInventory(assets), nil
+}
+
+// prometheusTagsLabel joins tags the way Prometheus' own service discovery
+// does, with a separator on both ends so a regex can match ",tag,"
+func prometheusTagsLabel(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "," + strings.Join(tags, ",") + ","
+}
+
+// buildPrometheusTargets lists one target group per TCP port of an asset with
+// a hostname, targeting hostname:port. Service, when set, keeps only ports of
+// that service. Groups are labelled with the asset's name, type and tags and
+// the port's service.
+func buildPrometheusTargets(assets []*model.Asset, ports []*model.AssetPort, service *string) []model.PrometheusTargetGroup {
+	byAsset := make(map[uuid.UUID][]*model.AssetPort, len(assets))
+	for _, port := range ports {
+		byAsset[port.AssetID] = append(byAsset[port.AssetID], port)
+	}
+
+	groups := make([]model.PrometheusTargetGroup, 0, len(ports))
+	for _, asset := range assets {
+		if asset.Hostname == nil || *asset.Hostname == "" {
+			continue
+		}
+
+		for _, port := range byAsset[asset.ID] {
+			if port.Protocol != model.PortProtocolTCP {
+				continue
+			}
+			portService := ""
+			if port.Service != nil {
+				portService = *port.Service
+			}
+			if service != nil && !strings.EqualFold(portService, strings.TrimSpace(*service)) {
+				continue
+			}
+
+			labels := map[string]string{
+				"asset":               asset.Name,
+				"tags":                prometheusTagsLabel(asset.Tags),
+				"__meta_ark_asset_id": asset.ID.String(),
+			}
+			if asset.Type != nil {
+				labels["asset_type"] = *asset.Type
+			}
+			if portService != "" {
+				labels["service"] = portService
+			}
+
+			groups = append(groups, model.PrometheusTargetGroup{
+				Targets: []string{net.JoinHostPort(*asset.Hostname, strconv.Itoa(port.Port))},
+				Labels:  labels,
+			})
+		}
+	}
+
+	return groups
+}
+
+// PrometheusTargets returns the ports of the user's assets, narrowed by
+// params, in Prometheus' HTTP service discovery format
+func (s *IntegrationService) PrometheusTargets(ctx context.Context, userID string, params *model.PrometheusSDParams) ([]model.PrometheusTargetGroup, error) {
+	assets, err := s.listAssets(ctx, userID, params.Type, params.Tags, params.TagMode, model.MaxPrometheusSDAssets)
+	if err != nil {
+		return nil, err
+	}
+	if len(assets) == 0 {
+		return []model.PrometheusTargetGroup{}, nil
+	}
+
+	assetIDs := make([]uuid.UUID, 0, len(assets))
+	for _, asset := range assets {
+		assetIDs = append(assetIDs, asset.ID)
+	}
+	ports, err := s.portRepo.ListByAssets(ctx, userID, assetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPrometheusTargets(assets, ports, params.Service), nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/model"
+)
+
+// TestIntegrationService_Constructor verifies NewIntegrationService works correctly
+func TestIntegrationService_Constructor(t *testing.T) {
+	service := NewIntegrationService(nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestAnsibleGroupName keeps group names to letters, digits and underscores
+func TestAnsibleGroupName(t *testing.T) {
+	assert.Equal(t, "tag_prod", ansibleGroupName("tag_", "prod"))
+	assert.Equal(t, "tag_eu_west_1", ansibleGroupName("tag_", "eu-west 1"))
+	assert.Equal(t, "type_vm", ansibleGroupName("type_", "VM"))
+}
+
+// TestBuildAnsibleInventory groups hosts by type and tag and exposes metadata as hostvars
+func TestBuildAnsibleInventory(t *testing.T) {
+	webID := uuid.MustParse("11111111-2222-3333-4444-555555555555")
+	dupID := uuid.MustParse("66666666-7777-8888-9999-000000000000")
+	assets := []*model.Asset{
+		{
+			ID:       webID,
+			Name:     "web01",
+			Type:     stringPtr("server"),
+			Hostname: stringPtr("10.0.0.11"),
+			Tags:     []string{"prod", "eu-west"},
+			Metadata: json.RawMessage(`{"http_port": 8080}`),
+		},
+		{ID: uuid.New(), Name: "printer"},
+		{ID: dupID, Name: "web01", Type: stringPtr("vm")},
+	}
+
+	inv := buildAnsibleInventory(assets)
+
+	assert.Equal(t, []string{"tag_eu_west", "tag_prod", "type_server", "type_vm", "ungrouped"}, inv.Groups["all"].Children)
+	assert.Equal(t, []string{"web01"}, inv.Groups["tag_prod"].Hosts)
+	assert.Equal(t, []string{"printer"}, inv.Groups["ungrouped"].Hosts)
+	assert.Equal(t, []string{"web01-66666666"}, inv.Groups["type_vm"].Hosts)
+
+	data, err := json.Marshal(inv)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	hostvars := decoded["_meta"].(map[string]any)["hostvars"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"http_port":    float64(8080),
+		"ansible_host": "10.0.0.11",
+		"ark_id":       webID.String(),
+		"ark_type":     "server",
+		"ark_tags":     []any{"prod", "eu-west"},
+	}, hostvars["web01"])
+	assert.Equal(t, []any{}, hostvars["printer"].(map[string]any)["ark_tags"])
+}
+
+// TestPrometheusTagsLabel wraps tags in separators so regexes can match ",tag,"
+func TestPrometheusTagsLabel(t *testing.T) {
+	assert.Equal(t, "", prometheusTagsLabel(nil))
+	assert.Equal(t, ",prod,dns,", prometheusTagsLabel([]string{"prod", "dns"}))
+}
+
+// TestBuildPrometheusTargets lists TCP ports of assets with a hostname, labelled by asset
+func TestBuildPrometheusTargets(t *testing.T) {
+	webID := uuid.MustParse("11111111-2222-3333-4444-555555555555")
+	v6ID := uuid.New()
+	noHostID := uuid.New()
+	assets := []*model.Asset{
+		{ID: webID, Name: "web01", Type: stringPtr("server"), Hostname: stringPtr("10.0.0.11"), Tags: []string{"prod"}},
+		{ID: v6ID, Name: "nas", Hostname: stringPtr("fd00::2")},
+		{ID: noHostID, Name: "switch"},
+	}
+	ports := []*model.AssetPort{
+		{AssetID: webID, Port: 9100, Protocol: model.PortProtocolTCP, Service: stringPtr("node_exporter")},
+		{AssetID: webID, Port: 161, Protocol: model.PortProtocolUDP, Service: stringPtr("snmp")},
+		{AssetID: v6ID, Port: 9100, Protocol: model.PortProtocolTCP, Service: stringPtr("Node_Exporter")},
+		{AssetID: v6ID, Port: 8080, Protocol: model.PortProtocolTCP},
+		{AssetID: noHostID, Port: 9116, Protocol: model.PortProtocolTCP},
+	}
+
+	groups := buildPrometheusTargets(assets, ports, nil)
+	require.Len(t, groups, 3)
+	assert.Equal(t, model.PrometheusTargetGroup{
+		Targets: []string{"10.0.0.11:9100"},
+		Labels: map[string]string{
+			"asset":               "web01",
+			"asset_type":          "server",
+			"tags":                ",prod,",
+			"service":             "node_exporter",
+			"__meta_ark_asset_id": webID.String(),
+		},
+	}, groups[0])
+	assert.Equal(t, []string{"[fd00::2]:9100"}, groups[1].Targets)
+	assert.Equal(t, []string{"[fd00::2]:8080"}, groups[2].Targets)
+	assert.NotContains(t, groups[2].Labels, "service")
+	assert.NotContains(t, groups[2].Labels, "asset_type")
+
+	// Service filter ignores case
+	groups = buildPrometheusTargets(assets, ports, stringPtr("node_exporter"))
+	require.Len(t, groups, 2)
+	assert.Equal(t, []string{"[fd00::2]:9100"}, groups[1].Targets)
+
+	// No matching ports encode as an empty list, not null
+	data, err := json.Marshal(buildPrometheusTargets(nil, nil, nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(data))
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+type InventoryService struct {
+	assetRepo *repository.AssetRepository
+	portRepo  *repository.PortRepository
+	stats     *StatsService
+}
+
+func NewInventoryService(assetRepo *repository.AssetRepository, portRepo *repository.PortRepository, stats *StatsService) *InventoryService {
+	return &InventoryService{
+		assetRepo: assetRepo,
+		portRepo:  portRepo,
+		stats:     stats,
+	}
+}
+
+// inventoryAsset is an asset described by an inventory file. Key identifies
+// the asset across imports; Metadata holds the keys the import manages, other
+// keys of an existing asset are kept.
+type inventoryAsset struct {
+	Source   string
+	Key      string
+	Name     string
+	Type     *string
+	Hostname *string
+	Tags     []string
+	Metadata map[string]any
+}
+
+// inventorySource describes where imported assets came from, for change logs
+type inventorySource struct {
+	// Label completes "Created from ..." and "Updated from ...", e.g.
+	// "docker-compose project media"
+	Label func(item inventoryAsset) string
+	// Links are the assets change logs are linked to besides the imported one
+	Links []uuid.UUID
+}
+
+// inventoryFileError reports a problem with one uploaded inventory file
+func inventoryFileError(file, message string) error {
+	return errs.NewBadRequestError("Invalid inventory", false, nil, []errs.FieldError{
+		{Field: file, Error: message},
+	}, nil)
+}
+
+// inventoryValue shows a field value in a change description
+func inventoryValue(s *string) string {
+	if s == nil || *s == "" {
+		return "(none)"
+	}
+	return *s
+}
+
+// inventoryMetadataValue shows a metadata value in a change description
+func inventoryMetadataValue(value json.RawMessage) string {
+	if cell := metadataCell(value); cell != "" {
+		return cell
+	}
+	return "(none)"
+}
+
+// jsonEqual compares two JSON values ignoring formatting and key order
+func jsonEqual(a, b json.RawMessage) bool {
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// diffInventoryAsset compares an existing asset with its inventory entry. It
+// returns the update to apply, or nil when nothing differs, and one change
+// description per differing field. Tags are only ever added.
+func diffInventoryAsset(asset *model.Asset, item inventoryAsset) (*model.UpdateAssetRequest, []string, error) {
+	update := &model.UpdateAssetRequest{}
+	var changes []string
+
+	if item.Name != asset.Name {
+		update.Name = &item.Name
+		changes = append(changes, fmt.Sprintf("name: %s → %s", asset.Name, item.Name))
+	}
+	if item.Type != nil && (asset.Type == nil || *asset.Type != *item.Type) {
+		update.Type = item.Type
+		changes = append(changes, fmt.Sprintf("type: %s → %s", inventoryValue(asset.Type), *item.Type))
+	}
+	if item.Hostname != nil && (asset.Hostname == nil || *asset.Hostname != *item.Hostname) {
+		update.Hostname = item.Hostname
+		changes = append(changes, fmt.Sprintf("hostname: %s → %s", inventoryValue(asset.Hostname), *item.Hostname))
+	}
+
+	existingTags := make(map[string]bool, len(asset.Tags))
+	for _, tag := range asset.Tags {
+		existingTags[tag] = true
+	}
+	// processTags may drop added tags over the limit
+	tags := processTags(append(append([]string{}, asset.Tags...), item.Tags...))
+	var added []string
+	for _, tag := range tags {
+		if !existingTags[tag] {
+			added = append(added, tag)
+		}
+	}
+	if len(added) > 0 {
+		update.Tags = &tags
+		changes = append(changes, "tags: added "+strings.Join(added, ", "))
+	}
+
+	metadata := decodeAssetMetadata(asset.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]json.RawMessage, len(item.Metadata))
+	}
+	keys := make([]string, 0, len(item.Metadata))
+	for key := range item.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	metadataChanged := false
+	for _, key := range keys {
+		value, err := json.Marshal(item.Metadata[key])
+		if err != nil {
+			return nil, nil, err
+		}
+		if old, ok := metadata[key]; ok && jsonEqual(old, value) {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", key, inventoryMetadataValue(metadata[key]), inventoryMetadataValue(value)))
+		metadata[key] = value
+		metadataChanged = true
+	}
+	if metadataChanged {
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, nil, err
+		}
+		update.Metadata = (*json.RawMessage)(&data)
+	}
+
+	if len(changes) == 0 {
+		return nil, nil, nil
+	}
+	return update, changes, nil
+}
+
+// inventoryLogContent describes an import in a change log, within the log
+// content limit
+func inventoryLogContent(action, label string, changes []string) string {
+	content := action + " from " + label
+	if len(changes) > 0 {
+		content += ":\n\n- " + strings.Join(changes, "\n- ")
+	}
+	if utf8.RuneCountInString(content) > 10000 {
+		content = string([]rune(content)[:9999]) + "…"
+	}
+	return content
+}
+
+// planInventoryImport compares inventory assets with the existing assets that
+// share their key. New assets are created, differing ones updated with a
+// change log describing what differed, and the rest reported unchanged.
+func planInventoryImport(items []inventoryAsset, existing map[string]*model.Asset, source inventorySource) ([]repository.AssetWrite, []int, *model.InventoryImportResult, error) {
+	result := &model.InventoryImportResult{Assets: make([]model.InventoryAssetResult, 0, len(items))}
+	writes := make([]repository.AssetWrite, 0, len(items))
+	// writeResults maps each write to its entry in result.Assets
+	writeResults := make([]int, 0, len(items))
+
+	for _, item := range items {
+		asset, found := existing[item.Key]
+		if !found {
+			create := &model.CreateAssetRequest{
+				Name:     item.Name,
+				Type:     item.Type,
+				Hostname: item.Hostname,
+				Tags:     processTags(item.Tags),
+			}
+			if len(item.Metadata) > 0 {
+				data, err := json.Marshal(item.Metadata)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				create.Metadata = (*json.RawMessage)(&data)
+			}
+
+			result.Created++
+			writeResults = append(writeResults, len(result.Assets))
+			result.Assets = append(result.Assets, model.InventoryAssetResult{Source: item.Source, Action: model.InventoryActionCreate, Name: item.Name})
+			writes = append(writes, repository.AssetWrite{
+				Create: create,
+				Log: &model.CreateLogRequest{
+					Kind:           model.LogKindChange,
+					Content:        inventoryLogContent("Created", source.Label(item), nil),
+					LinkedAssetIDs: source.Links,
+				},
+			})
+			continue
+		}
+
+		update, changes, err := diffInventoryAsset(asset, item)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		entry := model.InventoryAssetResult{Source: item.Source, ID: &asset.ID, Name: item.Name, Changes: changes}
+		if update == nil {
+			result.Unchanged++
+			entry.Action = model.InventoryActionUnchanged
+			result.Assets = append(result.Assets, entry)
+			continue
+		}
+
+		result.Updated++
+		entry.Action = model.InventoryActionUpdate
+		writeResults = append(writeResults, len(result.Assets))
+		result.Assets = append(result.Assets, entry)
+		writes = append(writes, repository.AssetWrite{
+			ID:     asset.ID,
+			Update: update,
+			Log: &model.CreateLogRequest{
+				Kind:           model.LogKindChange,
+				Content:        inventoryLogContent("Updated", source.Label(item), changes),
+				LinkedAssetIDs: source.Links,
+			},
+		})
+	}
+
+	return writes, writeResults, result, nil
+}
+
+// applyInventoryImport writes a planned import unless it is a dry run
+func (s *InventoryService) applyInventoryImport(ctx context.Context, userID string, writes []repository.AssetWrite, writeResults []int, result *model.InventoryImportResult, dryRun bool) (*model.InventoryImportResult, error) {
+	result.DryRun = dryRun
+	if dryRun || len(writes) == 0 {
+		return result, nil
+	}
+
+	assets, err := s.assetRepo.BulkWrite(ctx, userID, writes)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.Invalidate(ctx, userID)
+
+	for i, asset := range assets {
+		result.Assets[writeResults[i]].ID = &asset.ID
+	}
+
+	return result, nil
+}
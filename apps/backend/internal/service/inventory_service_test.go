@@ -0,0 +1,132 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/model"
+)
+
+// TestInventoryService_Constructor verifies NewInventoryService works correctly
+func TestInventoryService_Constructor(t *testing.T) {
+	service := NewInventoryService(nil, nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// testInventorySource labels change logs by item key
+var testInventorySource = inventorySource{
+	Label: func(item inventoryAsset) string { return "test " + item.Key },
+}
+
+// TestPlanInventoryImport_Create creates assets without a match, with a change log
+func TestPlanInventoryImport_Create(t *testing.T) {
+	hostID := uuid.New()
+	source := testInventorySource
+	source.Links = []uuid.UUID{hostID}
+
+	writes, writeResults, result, err := planInventoryImport([]inventoryAsset{{
+		Source:   "media.yml: jellyfin",
+		Key:      "media/jellyfin",
+		Name:     "jellyfin",
+		Type:     stringPtr("container"),
+		Tags:     []string{"Media"},
+		Metadata: map[string]any{"image": "jellyfin/jellyfin"},
+	}}, nil, source)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, []int{0}, writeResults)
+	require.Len(t, writes, 1)
+	require.NotNil(t, writes[0].Create)
+	assert.Equal(t, "jellyfin", writes[0].Create.Name)
+	assert.Equal(t, []string{"media"}, writes[0].Create.Tags)
+	assert.JSONEq(t, `{"image": "jellyfin/jellyfin"}`, string(*writes[0].Create.Metadata))
+	require.NotNil(t, writes[0].Log)
+	assert.Equal(t, model.LogKindChange, writes[0].Log.Kind)
+	assert.Equal(t, "Created from test media/jellyfin", writes[0].Log.Content)
+	assert.Equal(t, []uuid.UUID{hostID}, writes[0].Log.LinkedAssetIDs)
+	assert.Equal(t, model.InventoryActionCreate, result.Assets[0].Action)
+	assert.Nil(t, result.Assets[0].ID)
+}
+
+// TestPlanInventoryImport_Update describes what differed and keeps unmanaged metadata
+func TestPlanInventoryImport_Update(t *testing.T) {
+	existing := &model.Asset{
+		ID:       uuid.New(),
+		Name:     "jellyfin",
+		Type:     stringPtr("container"),
+		Tags:     []string{"media"},
+		Metadata: json.RawMessage(`{"image": "jellyfin/jellyfin:10.8", "ports": ["8096:8096"], "owner": "sam"}`),
+	}
+
+	writes, _, result, err := planInventoryImport([]inventoryAsset{{
+		Key:  "media/jellyfin",
+		Name: "jellyfin",
+		Type: stringPtr("container"),
+		Tags: []string{"media", "prod"},
+		Metadata: map[string]any{
+			"image": "jellyfin/jellyfin:10.9",
+			"ports": []string{"8096:8096"},
+		},
+	}}, map[string]*model.Asset{"media/jellyfin": existing}, testInventorySource)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, []string{
+		"tags: added prod",
+		"image: jellyfin/jellyfin:10.8 → jellyfin/jellyfin:10.9",
+	}, result.Assets[0].Changes)
+	assert.Equal(t, &existing.ID, result.Assets[0].ID)
+
+	require.Len(t, writes, 1)
+	assert.Equal(t, existing.ID, writes[0].ID)
+	require.NotNil(t, writes[0].Update)
+	assert.Nil(t, writes[0].Update.Name)
+	assert.Equal(t, []string{"media", "prod"}, *writes[0].Update.Tags)
+	assert.JSONEq(t, `{"image": "jellyfin/jellyfin:10.9", "ports": ["8096:8096"], "owner": "sam"}`, string(*writes[0].Update.Metadata))
+	assert.Equal(t, "Updated from test media/jellyfin:\n\n- tags: added prod\n- image: jellyfin/jellyfin:10.8 → jellyfin/jellyfin:10.9", writes[0].Log.Content)
+}
+
+// TestPlanInventoryImport_Unchanged writes nothing when an asset already matches
+func TestPlanInventoryImport_Unchanged(t *testing.T) {
+	existing := &model.Asset{
+		ID:       uuid.New(),
+		Name:     "jellyfin",
+		Type:     stringPtr("container"),
+		Tags:     []string{"media", "prod"},
+		Metadata: json.RawMessage(`{"ports": ["8096:8096"], "networks": []}`),
+	}
+
+	writes, writeResults, result, err := planInventoryImport([]inventoryAsset{{
+		Key:      "media/jellyfin",
+		Name:     "jellyfin",
+		Type:     stringPtr("container"),
+		Tags:     []string{"media"},
+		Metadata: map[string]any{"ports": []string{"8096:8096"}, "networks": []string{}},
+	}}, map[string]*model.Asset{"media/jellyfin": existing}, testInventorySource)
+	require.NoError(t, err)
+
+	assert.Empty(t, writes)
+	assert.Empty(t, writeResults)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, model.InventoryActionUnchanged, result.Assets[0].Action)
+	assert.Empty(t, result.Assets[0].Changes)
+}
+
+// TestInventoryLogContent_Truncated keeps change logs within the content limit
+func TestInventoryLogContent_Truncated(t *testing.T) {
+	changes := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		changes = append(changes, fmt.Sprintf("key%d: old value → new value", i))
+	}
+
+	content := inventoryLogContent("Updated", "test", changes)
+
+	assert.Len(t, []rune(content), 10000)
+}
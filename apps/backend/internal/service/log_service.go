@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"slices"
 	"strings"
+	"time"
 
+	"ark/internal/errs"
+	"ark/internal/lib/markdown"
 	"ark/internal/model"
 	"ark/internal/repository"
 
@@ -11,17 +15,26 @@ import (
 )
 
 type LogService struct {
-	logRepo   *repository.LogRepository
-	assetRepo *repository.AssetRepository
+	logRepo      *repository.LogRepository
+	assetRepo    *repository.AssetRepository
+	templateRepo *repository.LogTemplateRepository
+	stats        *StatsService
 }
 
-func NewLogService(logRepo *repository.LogRepository, assetRepo *repository.AssetRepository) *LogService {
+func NewLogService(logRepo *repository.LogRepository, assetRepo *repository.AssetRepository, templateRepo *repository.LogTemplateRepository, stats *StatsService) *LogService {
 	return &LogService{
-		logRepo:   logRepo,
-		assetRepo: assetRepo,
+		logRepo:      logRepo,
+		assetRepo:    assetRepo,
+		templateRepo: templateRepo,
+		stats:        stats,
 	}
 }
 
+// normalizeTag trims and lowercases a tag
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
 // processTags cleans up tags: trim, lowercase, deduplicate, and limit to 20
 func processTags(tags []string) []string {
 	if tags == nil {
@@ -32,7 +45,7 @@ func processTags(tags []string) []string {
 	var processed []string
 
 	for _, tag := range tags {
-		cleanTag := strings.ToLower(strings.TrimSpace(tag))
+		cleanTag := normalizeTag(tag)
 		if cleanTag != "" && !uniqueTags[cleanTag] {
 			uniqueTags[cleanTag] = true
 			processed = append(processed, cleanTag)
@@ -47,6 +60,156 @@ func processTags(tags []string) []string {
 	return processed
 }
 
+// processLinkedAssetIDs de-duplicates linked assets, preserving order, and drops
+// the primary asset, which is always linked through asset_id
+func processLinkedAssetIDs(primaryID uuid.UUID, ids []uuid.UUID) []uuid.UUID {
+	seen := map[uuid.UUID]bool{primaryID: true}
+	processed := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id != uuid.Nil && !seen[id] {
+			seen[id] = true
+			processed = append(processed, id)
+		}
+	}
+	return processed
+}
+
+// logKindFields holds the kind-specific fields shared by create and update requests
+type logKindFields struct {
+	Severity        *string
+	StartedAt       *time.Time
+	ResolvedAt      *time.Time
+	RootCause       *string
+	Planned         *bool
+	RollbackNotes   *string
+	DurationMinutes *int
+}
+
+// validateLogKind enforces the per-kind rules for log fields:
+//   - kind must be one of note, change, incident or maintenance
+//   - fields that belong to a different kind are rejected
+//   - incidents require a severity, and resolved_at must not precede started_at
+func validateLogKind(kind string, f logKindFields) error {
+	if !model.IsValidLogKind(kind) {
+		return errs.NewBadRequestError("invalid log kind: "+kind, false, nil, nil, nil)
+	}
+
+	var fieldErrors []errs.FieldError
+	reject := func(field string, set bool, owner string) {
+		if set && kind != owner {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: field,
+				Error: "only allowed for " + owner + " logs",
+			})
+		}
+	}
+
+	reject("severity", f.Severity != nil, model.LogKindIncident)
+	reject("started_at", f.StartedAt != nil, model.LogKindIncident)
+	reject("resolved_at", f.ResolvedAt != nil, model.LogKindIncident)
+	reject("root_cause", f.RootCause != nil, model.LogKindIncident)
+	reject("planned", f.Planned != nil, model.LogKindChange)
+	reject("rollback_notes", f.RollbackNotes != nil, model.LogKindChange)
+	reject("duration_minutes", f.DurationMinutes != nil, model.LogKindMaintenance)
+
+	switch kind {
+	case model.LogKindIncident:
+		if f.Severity == nil {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "severity", Error: "is required"})
+		} else if !model.IsValidSeverity(*f.Severity) {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "severity", Error: "must be one of: low medium high critical"})
+		}
+		if f.StartedAt != nil && f.ResolvedAt != nil && f.ResolvedAt.Before(*f.StartedAt) {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "resolved_at", Error: "must not be before started_at"})
+		}
+	case model.LogKindMaintenance:
+		if f.DurationMinutes != nil && *f.DurationMinutes < 0 {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "duration_minutes", Error: "must be at least 0"})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	return nil
+}
+
+// mergeLogKindFields overlays the fields set in an update request onto the
+// existing log. When the kind changes, the existing kind-specific fields are
+// dropped because the repository clears them. A log converted to an incident
+// without a started_at started when it was first logged.
+func mergeLogKindFields(existing *model.AssetLog, req *model.UpdateLogRequest) (string, logKindFields) {
+	kind := existing.Kind
+	merged := logKindFields{
+		Severity:        existing.Severity,
+		StartedAt:       existing.StartedAt,
+		ResolvedAt:      existing.ResolvedAt,
+		RootCause:       existing.RootCause,
+		Planned:         existing.Planned,
+		RollbackNotes:   existing.RollbackNotes,
+		DurationMinutes: existing.DurationMinutes,
+	}
+	if req.Kind != nil && *req.Kind != existing.Kind {
+		kind = *req.Kind
+		merged = logKindFields{}
+	}
+
+	if req.Severity != nil {
+		merged.Severity = req.Severity
+	}
+	if req.StartedAt != nil {
+		merged.StartedAt = req.StartedAt
+	}
+	if req.ResolvedAt != nil {
+		merged.ResolvedAt = req.ResolvedAt
+	}
+	if req.RootCause != nil {
+		merged.RootCause = req.RootCause
+	}
+	if req.Planned != nil {
+		merged.Planned = req.Planned
+	}
+	if req.RollbackNotes != nil {
+		merged.RollbackNotes = req.RollbackNotes
+	}
+	if req.DurationMinutes != nil {
+		merged.DurationMinutes = req.DurationMinutes
+	}
+	if kind == model.LogKindIncident && merged.StartedAt == nil {
+		merged.StartedAt = &existing.CreatedAt
+	}
+
+	return kind, merged
+}
+
+// validateIncidentUpdate rejects resolving an existing incident through a log
+// update. Status changes go through the incident timeline, which checks the
+// transition and records it; a log converted to an incident may still start
+// out resolved, as on create.
+func validateIncidentUpdate(existing *model.AssetLog, kind string, req *model.UpdateLogRequest) error {
+	if existing.Kind == model.LogKindIncident && kind == model.LogKindIncident && req.ResolvedAt != nil {
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "resolved_at", Error: "resolve the incident through its timeline"},
+		}, nil)
+	}
+
+	return nil
+}
+
+// validateParentLog checks that a reply is created on the asset of its parent
+// log, either the parent's primary asset or one it is linked to, so a thread
+// never spans unrelated assets
+func validateParentLog(parent *model.AssetLog, assetID uuid.UUID) error {
+	if parent.AssetID == assetID || slices.Contains(parent.LinkedAssetIDs, assetID) {
+		return nil
+	}
+
+	return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+		{Field: "parent_log_id", Error: "must be a log of the same asset"},
+	}, nil)
+}
+
 func (s *LogService) ListByAsset(ctx context.Context, userID string, assetID uuid.UUID, params *model.LogQueryParams) (*model.LogListResponse, error) {
 	// Verify asset ownership
 	_, err := s.assetRepo.GetByID(ctx, userID, assetID)
@@ -56,6 +219,12 @@ func (s *LogService) ListByAsset(ctx context.Context, userID string, assetID uui
 
 	params.SetDefaults()
 
+	// Filter tags follow the same rules as stored tags
+	params.Tags = processTags(params.Tags)
+	params.TagsAll = processTags(params.TagsAll)
+	params.TagsAny = processTags(params.TagsAny)
+	params.TagsNone = processTags(params.TagsNone)
+
 	logs, err := s.logRepo.ListByAsset(ctx, userID, assetID, params)
 	if err != nil {
 		return nil, err
@@ -66,22 +235,99 @@ func (s *LogService) ListByAsset(ctx context.Context, userID string, assetID uui
 		return nil, err
 	}
 
-	return model.NewLogListResponse(logs, total, params.Limit, params.Offset), nil
+	response := model.NewLogListResponse(logs, total, params.Limit, params.Offset)
+	if err := renderLogs(params.Render, logResponsePtrs(response.Logs)...); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
-func (s *LogService) GetByID(ctx context.Context, userID string, logID uuid.UUID) (*model.LogResponse, error) {
+func (s *LogService) GetByID(ctx context.Context, userID string, logID uuid.UUID, params *model.LogRenderParams) (*model.LogResponse, error) {
 	log, err := s.logRepo.GetByID(ctx, userID, logID)
 	if err != nil {
 		return nil, err
 	}
 
-	return model.NewLogResponse(log), nil
+	response := model.NewLogResponse(log)
+	if err := renderLogs(params.Render, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// renderLogs fills content_html and code_blocks on each log when render is
+// "html", the only mode the validate tag of the render parameter allows.
+// A nil render leaves the logs untouched.
+func renderLogs(render *string, logs ...*model.LogResponse) error {
+	if render == nil || *render == "" {
+		return nil
+	}
+
+	for _, log := range logs {
+		rendered, err := markdown.Render(log.Content)
+		if err != nil {
+			return err
+		}
+
+		blocks := make([]model.CodeBlock, len(rendered.CodeBlocks))
+		for i, block := range rendered.CodeBlocks {
+			blocks[i] = model.CodeBlock{Language: block.Language, Code: block.Code}
+		}
+
+		log.ContentHTML = &rendered.HTML
+		log.CodeBlocks = blocks
+	}
+
+	return nil
+}
+
+// logResponsePtrs returns pointers into logs so they can be updated in place
+func logResponsePtrs(logs []model.LogResponse) []*model.LogResponse {
+	ptrs := make([]*model.LogResponse, len(logs))
+	for i := range logs {
+		ptrs[i] = &logs[i]
+	}
+	return ptrs
 }
 
 func (s *LogService) Create(ctx context.Context, userID string, assetID uuid.UUID, req *model.CreateLogRequest) (*model.LogResponse, error) {
-	// Verify asset ownership
-	_, err := s.assetRepo.GetByID(ctx, userID, assetID)
-	if err != nil {
+	// Verify ownership of the primary asset and every linked asset
+	req.LinkedAssetIDs = processLinkedAssetIDs(assetID, req.LinkedAssetIDs)
+	if err := s.assetRepo.VerifyOwned(ctx, userID, append([]uuid.UUID{assetID}, req.LinkedAssetIDs...)); err != nil {
+		return nil, err
+	}
+
+	// A reply must follow up on one of the user's logs about the same asset
+	if req.ParentLogID != nil {
+		parent, err := s.logRepo.GetByID(ctx, userID, *req.ParentLogID)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateParentLog(parent, assetID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate kind-specific fields, with incidents starting when they are
+	// logged unless told otherwise
+	if req.Kind == "" {
+		req.Kind = model.LogKindNote
+	}
+	if req.Kind == model.LogKindIncident && req.StartedAt == nil {
+		now := time.Now()
+		req.StartedAt = &now
+	}
+	if err := validateLogKind(req.Kind, logKindFields{
+		Severity:        req.Severity,
+		StartedAt:       req.StartedAt,
+		ResolvedAt:      req.ResolvedAt,
+		RootCause:       req.RootCause,
+		Planned:         req.Planned,
+		RollbackNotes:   req.RollbackNotes,
+		DurationMinutes: req.DurationMinutes,
+	}); err != nil {
 		return nil, err
 	}
 
@@ -94,25 +340,112 @@ func (s *LogService) Create(ctx context.Context, userID string, assetID uuid.UUI
 	if err != nil {
 		return nil, err
 	}
+	s.stats.Invalidate(ctx, userID)
 
 	return model.NewLogResponse(log), nil
 }
 
+// GetThread returns the thread a log belongs to: its root and every reply
+func (s *LogService) GetThread(ctx context.Context, userID string, logID uuid.UUID, params *model.LogRenderParams) (*model.LogThreadResponse, error) {
+	root, err := s.logRepo.GetByID(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	if root.ThreadRootID != nil {
+		root, err = s.logRepo.GetByID(ctx, userID, *root.ThreadRootID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	replies, err := s.logRepo.ListThread(ctx, userID, root.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := model.NewLogThreadResponse(root, replies)
+	logs := append([]*model.LogResponse{&response.Root}, logResponsePtrs(response.Replies)...)
+	if err := renderLogs(params.Render, logs...); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CreateFromTemplate renders a log template with req.Values and creates the log.
+// The rendered content replaces req.Content, the template's kind is used unless
+// req.Kind is set, and the template's tags are added to req.Tags.
+func (s *LogService) CreateFromTemplate(ctx context.Context, userID string, assetID uuid.UUID, templateID uuid.UUID, req *model.CreateLogRequest) (*model.LogResponse, error) {
+	template, err := s.templateRepo.GetByID(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := renderLogTemplate(template, req.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Content = content
+	if req.Kind == "" {
+		req.Kind = template.Kind
+	}
+	if len(template.Tags) > 0 {
+		req.Tags = append(append([]string{}, template.Tags...), req.Tags...)
+	}
+
+	return s.Create(ctx, userID, assetID, req)
+}
+
 func (s *LogService) Update(ctx context.Context, userID string, logID uuid.UUID, req *model.UpdateLogRequest) (*model.LogResponse, error) {
+	// Load the existing log so kind-specific fields can be validated as a whole
+	existing, err := s.logRepo.GetByID(ctx, userID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, merged := mergeLogKindFields(existing, req)
+	if err := validateLogKind(kind, merged); err != nil {
+		return nil, err
+	}
+	if err := validateIncidentUpdate(existing, kind, req); err != nil {
+		return nil, err
+	}
+
+	// Store the started_at default of a log converted to an incident
+	if kind == model.LogKindIncident && req.StartedAt == nil {
+		req.StartedAt = merged.StartedAt
+	}
+
 	// Process tags if present
 	if req.Tags != nil {
 		processed := processTags(*req.Tags)
 		req.Tags = &processed
 	}
 
+	// Verify ownership of every linked asset
+	if req.LinkedAssetIDs != nil {
+		processed := processLinkedAssetIDs(existing.AssetID, *req.LinkedAssetIDs)
+		if err := s.assetRepo.VerifyOwned(ctx, userID, processed); err != nil {
+			return nil, err
+		}
+		req.LinkedAssetIDs = &processed
+	}
+
 	log, err := s.logRepo.Update(ctx, userID, logID, req)
 	if err != nil {
 		return nil, err
 	}
+	s.stats.Invalidate(ctx, userID)
 
 	return model.NewLogResponse(log), nil
 }
 
 func (s *LogService) Delete(ctx context.Context, userID string, logID uuid.UUID) error {
-	return s.logRepo.Delete(ctx, userID, logID)
+	if err := s.logRepo.Delete(ctx, userID, logID); err != nil {
+		return err
+	}
+	s.stats.Invalidate(ctx, userID)
+	return nil
 }
@@ -1,10 +1,15 @@
 package service
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"ark/internal/errs"
 	"ark/internal/model"
 )
 
@@ -13,7 +18,7 @@ func TestLogService_ListByAsset_ReturnsLogListResponse(t *testing.T) {
 	// This test verifies the return type signature
 	// We're not testing the actual business logic, just the type contract
 
-	service := NewLogService(nil, nil) // nil is okay for type checking
+	service := NewLogService(nil, nil, nil, nil) // nil is okay for type checking
 
 	// Verify the method exists and returns the correct type
 	var result *model.LogListResponse
@@ -31,23 +36,34 @@ func TestLogService_ListByAsset_ReturnsLogListResponse(t *testing.T) {
 
 // TestLogService_GetByID_ReturnsLogResponse verifies GetByID returns LogResponse DTO
 func TestLogService_GetByID_ReturnsLogResponse(t *testing.T) {
-	service := NewLogService(nil, nil)
+	service := NewLogService(nil, nil, nil, nil)
 
 	var result *model.LogResponse
 	var err error
 
 	// Type assertion to verify the signature
 	_ = func() (*model.LogResponse, error) {
-		return service.GetByID(nil, "", [16]byte{})
+		return service.GetByID(nil, "", [16]byte{}, nil)
 	}
 
 	assert.IsType(t, result, (*model.LogResponse)(nil))
 	assert.IsType(t, err, error(nil))
 }
 
+// TestLogService_GetThread_ReturnsLogThreadResponse verifies GetThread returns LogThreadResponse DTO
+func TestLogService_GetThread_ReturnsLogThreadResponse(t *testing.T) {
+	service := NewLogService(nil, nil, nil, nil)
+
+	_ = func() (*model.LogThreadResponse, error) {
+		return service.GetThread(nil, "", uuid.UUID{}, nil)
+	}
+
+	assert.NotNil(t, service)
+}
+
 // TestLogService_Create_ReturnsLogResponse verifies Create returns LogResponse DTO
 func TestLogService_Create_ReturnsLogResponse(t *testing.T) {
-	service := NewLogService(nil, nil)
+	service := NewLogService(nil, nil, nil, nil)
 
 	var result *model.LogResponse
 	var err error
@@ -63,7 +79,7 @@ func TestLogService_Create_ReturnsLogResponse(t *testing.T) {
 
 // TestLogService_Update_ReturnsLogResponse verifies Update returns LogResponse DTO
 func TestLogService_Update_ReturnsLogResponse(t *testing.T) {
-	service := NewLogService(nil, nil)
+	service := NewLogService(nil, nil, nil, nil)
 
 	var result *model.LogResponse
 	var err error
@@ -79,7 +95,7 @@ func TestLogService_Update_ReturnsLogResponse(t *testing.T) {
 
 // TestLogService_Delete_ReturnsError verifies Delete returns error
 func TestLogService_Delete_ReturnsError(t *testing.T) {
-	service := NewLogService(nil, nil)
+	service := NewLogService(nil, nil, nil, nil)
 
 	var err error
 
@@ -93,8 +109,216 @@ func TestLogService_Delete_ReturnsError(t *testing.T) {
 
 // TestLogService_Constructor verifies NewLogService works correctly
 func TestLogService_Constructor(t *testing.T) {
-	service := NewLogService(nil, nil)
+	service := NewLogService(nil, nil, nil, nil)
 
 	assert.NotNil(t, service)
 	assert.IsType(t, &LogService{}, service)
 }
+
+// TestValidateLogKind covers the per-kind field rules
+func TestValidateLogKind(t *testing.T) {
+	started := time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC)
+	before := started.Add(-time.Hour)
+	after := started.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		kind    string
+		fields  logKindFields
+		wantErr bool
+		field   string
+	}{
+		{name: "plain note", kind: model.LogKindNote},
+		{name: "unknown kind", kind: "outage", wantErr: true},
+		{
+			name:    "note with severity",
+			kind:    model.LogKindNote,
+			fields:  logKindFields{Severity: stringPtr(model.SeverityLow)},
+			wantErr: true,
+			field:   "severity",
+		},
+		{
+			name:    "incident without severity",
+			kind:    model.LogKindIncident,
+			wantErr: true,
+			field:   "severity",
+		},
+		{
+			name:   "resolved incident",
+			kind:   model.LogKindIncident,
+			fields: logKindFields{Severity: stringPtr(model.SeverityHigh), StartedAt: &started, ResolvedAt: &after},
+		},
+		{
+			name:    "resolved before started",
+			kind:    model.LogKindIncident,
+			fields:  logKindFields{Severity: stringPtr(model.SeverityHigh), StartedAt: &started, ResolvedAt: &before},
+			wantErr: true,
+			field:   "resolved_at",
+		},
+		{
+			name:   "planned change",
+			kind:   model.LogKindChange,
+			fields: logKindFields{Planned: boolPtr(true), RollbackNotes: stringPtr("revert config")},
+		},
+		{
+			name:    "change with duration",
+			kind:    model.LogKindChange,
+			fields:  logKindFields{DurationMinutes: intPtr(10)},
+			wantErr: true,
+			field:   "duration_minutes",
+		},
+		{
+			name:   "maintenance duration",
+			kind:   model.LogKindMaintenance,
+			fields: logKindFields{DurationMinutes: intPtr(30)},
+		},
+		{
+			name:    "negative duration",
+			kind:    model.LogKindMaintenance,
+			fields:  logKindFields{DurationMinutes: intPtr(-1)},
+			wantErr: true,
+			field:   "duration_minutes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateLogKind(tc.kind, tc.fields)
+			if !tc.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			if tc.field != "" {
+				require.NotEmpty(t, httpErr.Errors)
+				assert.Equal(t, tc.field, httpErr.Errors[0].Field)
+			}
+		})
+	}
+}
+
+// TestMergeLogKindFields_SameKind keeps existing fields and overlays the request
+func TestMergeLogKindFields_SameKind(t *testing.T) {
+	existing := &model.AssetLog{
+		Kind:      model.LogKindIncident,
+		Severity:  stringPtr(model.SeverityLow),
+		RootCause: stringPtr("unknown"),
+	}
+	req := &model.UpdateLogRequest{Severity: stringPtr(model.SeverityCritical)}
+
+	kind, merged := mergeLogKindFields(existing, req)
+
+	assert.Equal(t, model.LogKindIncident, kind)
+	assert.Equal(t, model.SeverityCritical, *merged.Severity)
+	assert.Equal(t, "unknown", *merged.RootCause)
+}
+
+// TestMergeLogKindFields_KindChange drops fields owned by the previous kind
+func TestMergeLogKindFields_KindChange(t *testing.T) {
+	existing := &model.AssetLog{
+		Kind:            model.LogKindMaintenance,
+		DurationMinutes: intPtr(20),
+	}
+	req := &model.UpdateLogRequest{
+		Kind:     stringPtr(model.LogKindIncident),
+		Severity: stringPtr(model.SeverityMedium),
+	}
+
+	kind, merged := mergeLogKindFields(existing, req)
+
+	assert.Equal(t, model.LogKindIncident, kind)
+	assert.Nil(t, merged.DurationMinutes)
+	assert.NoError(t, validateLogKind(kind, merged))
+}
+
+// TestMergeLogKindFields_IncidentStartedAt defaults started_at of a log
+// converted to an incident to its creation time, before validation
+func TestMergeLogKindFields_IncidentStartedAt(t *testing.T) {
+	created := time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC)
+	existing := &model.AssetLog{
+		Kind:      model.LogKindNote,
+		CreatedAt: created,
+	}
+	resolved := created.Add(-time.Hour)
+	req := &model.UpdateLogRequest{
+		Kind:       stringPtr(model.LogKindIncident),
+		Severity:   stringPtr(model.SeverityHigh),
+		ResolvedAt: &resolved,
+	}
+
+	kind, merged := mergeLogKindFields(existing, req)
+
+	require.NotNil(t, merged.StartedAt)
+	assert.Equal(t, created, *merged.StartedAt)
+
+	err := validateLogKind(kind, merged)
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.Equal(t, "resolved_at", httpErr.Errors[0].Field)
+}
+
+// TestValidateIncidentUpdate rejects resolved_at on an existing incident but
+// allows it when a log is converted to an incident
+func TestValidateIncidentUpdate(t *testing.T) {
+	resolved := time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC)
+	incident := &model.AssetLog{Kind: model.LogKindIncident}
+	note := &model.AssetLog{Kind: model.LogKindNote}
+
+	err := validateIncidentUpdate(incident, model.LogKindIncident, &model.UpdateLogRequest{ResolvedAt: &resolved})
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.Equal(t, "resolved_at", httpErr.Errors[0].Field)
+
+	assert.NoError(t, validateIncidentUpdate(incident, model.LogKindIncident, &model.UpdateLogRequest{RootCause: stringPtr("disk full")}))
+	assert.NoError(t, validateIncidentUpdate(note, model.LogKindIncident, &model.UpdateLogRequest{
+		Kind:       stringPtr(model.LogKindIncident),
+		ResolvedAt: &resolved,
+	}))
+}
+
+// TestValidateParentLog accepts replies on the parent's primary or linked
+// assets and rejects replies on other assets
+func TestValidateParentLog(t *testing.T) {
+	primary := uuid.New()
+	linked := uuid.New()
+	parent := &model.AssetLog{AssetID: primary, LinkedAssetIDs: []uuid.UUID{linked}}
+
+	assert.NoError(t, validateParentLog(parent, primary))
+	assert.NoError(t, validateParentLog(parent, linked))
+
+	err := validateParentLog(parent, uuid.New())
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.Equal(t, "parent_log_id", httpErr.Errors[0].Field)
+}
+
+// TestProcessLinkedAssetIDs drops duplicates, nil IDs and the primary asset
+func TestProcessLinkedAssetIDs(t *testing.T) {
+	primary := uuid.New()
+	a := uuid.New()
+	b := uuid.New()
+
+	got := processLinkedAssetIDs(primary, []uuid.UUID{a, primary, b, a, uuid.Nil})
+
+	assert.Equal(t, []uuid.UUID{a, b}, got)
+	assert.Empty(t, processLinkedAssetIDs(primary, nil))
+}
+
+// TestRenderLogs fills content_html and code_blocks only when asked for html
+func TestRenderLogs(t *testing.T) {
+	log := &model.LogResponse{Content: "Ran:\n\n```bash\nzpool status\n```\n<script>alert(1)</script>"}
+
+	require.NoError(t, renderLogs(nil, log))
+	assert.Nil(t, log.ContentHTML)
+
+	require.NoError(t, renderLogs(stringPtr(model.LogRenderHTML), log))
+	require.NotNil(t, log.ContentHTML)
+	assert.NotContains(t, *log.ContentHTML, "<script")
+	assert.Equal(t, []model.CodeBlock{{Language: "bash", Code: "zpool status\n"}}, log.CodeBlocks)
+}
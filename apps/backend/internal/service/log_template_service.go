@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// maxLogContentLength mirrors the max length of log content
+const maxLogContentLength = 10000
+
+var (
+	// placeholderPattern matches {{name}} references in template content; inner spaces are allowed
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	// placeholderNamePattern is the allowed form of a placeholder name
+	placeholderNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+type LogTemplateService struct {
+	templateRepo *repository.LogTemplateRepository
+}
+
+func NewLogTemplateService(templateRepo *repository.LogTemplateRepository) *LogTemplateService {
+	return &LogTemplateService{
+		templateRepo: templateRepo,
+	}
+}
+
+// validateLogTemplate checks the placeholder declarations and that the content
+// only references declared placeholders
+func validateLogTemplate(content string, placeholders []model.TemplatePlaceholder) error {
+	var fieldErrors []errs.FieldError
+
+	declared := make(map[string]bool, len(placeholders))
+	for i, p := range placeholders {
+		field := fmt.Sprintf("placeholders[%d]", i)
+		switch {
+		case !placeholderNamePattern.MatchString(p.Name):
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: field + ".name",
+				Error: "must start with a letter or underscore and contain only letters, digits and underscores",
+			})
+		case declared[p.Name]:
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: field + ".name", Error: "is declared more than once"})
+		}
+		declared[p.Name] = true
+
+		if !model.IsValidPlaceholderType(p.Type) {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: field + ".type",
+				Error: "must be one of: string int number bool date",
+			})
+		} else if p.Default != nil {
+			if _, err := formatPlaceholderValue(p.Type, *p.Default); err != nil {
+				fieldErrors = append(fieldErrors, errs.FieldError{Field: field + ".default", Error: err.Error()})
+			}
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, match := range placeholderPattern.FindAllStringSubmatch(content, -1) {
+		name := match[1]
+		if !declared[name] && !reported[name] {
+			reported[name] = true
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: "content",
+				Error: fmt.Sprintf("uses undeclared placeholder {{%s}}", name),
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	return nil
+}
+
+// formatPlaceholderValue checks a JSON-decoded value against a placeholder type
+// and returns its text form. Strings are accepted for every type so defaults and
+// form input can be parsed.
+func formatPlaceholderValue(placeholderType string, value any) (string, error) {
+	switch placeholderType {
+	case model.PlaceholderTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return "", errors.New("must be a string")
+		}
+		return s, nil
+
+	case model.PlaceholderTypeInt:
+		switch v := value.(type) {
+		case float64:
+			if v != math.Trunc(v) || math.IsInf(v, 0) {
+				return "", errors.New("must be an integer")
+			}
+			return strconv.FormatInt(int64(v), 10), nil
+		case string:
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return "", errors.New("must be an integer")
+			}
+			return strconv.FormatInt(n, 10), nil
+		}
+		return "", errors.New("must be an integer")
+
+	case model.PlaceholderTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+				return "", errors.New("must be a number")
+			}
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+		return "", errors.New("must be a number")
+
+	case model.PlaceholderTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return strconv.FormatBool(v), nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return "", errors.New("must be true or false")
+			}
+			return strconv.FormatBool(b), nil
+		}
+		return "", errors.New("must be true or false")
+
+	case model.PlaceholderTypeDate:
+		s, ok := value.(string)
+		if !ok {
+			return "", errors.New("must be a date (YYYY-MM-DD or RFC 3339)")
+		}
+		s = strings.TrimSpace(s)
+		if d, err := time.Parse(time.DateOnly, s); err == nil {
+			return d.Format(time.DateOnly), nil
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t.Format(time.RFC3339), nil
+		}
+		return "", errors.New("must be a date (YYYY-MM-DD or RFC 3339)")
+	}
+
+	return "", fmt.Errorf("has unknown type %q", placeholderType)
+}
+
+// renderLogTemplate substitutes values into the template content. Every value
+// is checked against its placeholder type, missing values fall back to the
+// default, and values for undeclared placeholders are rejected.
+func renderLogTemplate(template *model.LogTemplate, values map[string]any) (string, error) {
+	var fieldErrors []errs.FieldError
+
+	rendered := make(map[string]string, len(template.Placeholders))
+	for _, p := range template.Placeholders {
+		field := "values." + p.Name
+
+		var raw any
+		if v, ok := values[p.Name]; ok && v != nil {
+			raw = v
+		} else if p.Default != nil {
+			raw = *p.Default
+		} else if p.Required {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: field, Error: "is required"})
+			continue
+		} else {
+			rendered[p.Name] = ""
+			continue
+		}
+
+		text, err := formatPlaceholderValue(p.Type, raw)
+		if err != nil {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: field, Error: err.Error()})
+			continue
+		}
+		if p.Required && strings.TrimSpace(text) == "" {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: field, Error: "is required"})
+			continue
+		}
+		rendered[p.Name] = text
+	}
+
+	var unknown []string
+	for name := range values {
+		if !templateDeclares(template, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: "values." + name, Error: "is not a placeholder of this template"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return "", errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	content := placeholderPattern.ReplaceAllStringFunc(template.Content, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if text, ok := rendered[name]; ok {
+			return text
+		}
+		return match
+	})
+
+	if len(content) > maxLogContentLength {
+		return "", errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "content", Error: fmt.Sprintf("rendered content must not exceed %d characters", maxLogContentLength)},
+		}, nil)
+	}
+
+	return content, nil
+}
+
+// templateDeclares reports whether the template has a placeholder with the given name
+func templateDeclares(template *model.LogTemplate, name string) bool {
+	for _, p := range template.Placeholders {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *LogTemplateService) List(ctx context.Context, userID string) (*model.LogTemplateListResponse, error) {
+	templates, err := s.templateRepo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewLogTemplateListResponse(templates), nil
+}
+
+func (s *LogTemplateService) GetByID(ctx context.Context, userID string, templateID uuid.UUID) (*model.LogTemplate, error) {
+	return s.templateRepo.GetByID(ctx, userID, templateID)
+}
+
+func (s *LogTemplateService) Create(ctx context.Context, userID string, req *model.CreateLogTemplateRequest) (*model.LogTemplate, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "name", Error: "is required"},
+		}, nil)
+	}
+
+	if req.Kind == "" {
+		req.Kind = model.LogKindNote
+	}
+	if !model.IsValidLogKind(req.Kind) {
+		return nil, errs.NewBadRequestError("invalid log kind: "+req.Kind, false, nil, nil, nil)
+	}
+
+	if err := validateLogTemplate(req.Content, req.Placeholders); err != nil {
+		return nil, err
+	}
+
+	if req.Tags != nil {
+		req.Tags = processTags(req.Tags)
+	}
+
+	return s.templateRepo.Create(ctx, userID, req)
+}
+
+func (s *LogTemplateService) Update(ctx context.Context, userID string, templateID uuid.UUID, req *model.UpdateLogTemplateRequest) (*model.LogTemplate, error) {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+				{Field: "name", Error: "must not be empty"},
+			}, nil)
+		}
+		req.Name = &name
+	}
+
+	if req.Kind != nil && !model.IsValidLogKind(*req.Kind) {
+		return nil, errs.NewBadRequestError("invalid log kind: "+*req.Kind, false, nil, nil, nil)
+	}
+
+	// Content and placeholders are validated together against the stored template
+	if req.Content != nil || req.Placeholders != nil {
+		existing, err := s.templateRepo.GetByID(ctx, userID, templateID)
+		if err != nil {
+			return nil, err
+		}
+
+		content := existing.Content
+		if req.Content != nil {
+			content = *req.Content
+		}
+		placeholders := existing.Placeholders
+		if req.Placeholders != nil {
+			placeholders = *req.Placeholders
+		}
+
+		if err := validateLogTemplate(content, placeholders); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Tags != nil {
+		processed := processTags(*req.Tags)
+		req.Tags = &processed
+	}
+
+	return s.templateRepo.Update(ctx, userID, templateID, req)
+}
+
+func (s *LogTemplateService) Delete(ctx context.Context, userID string, templateID uuid.UUID) error {
+	return s.templateRepo.Delete(ctx, userID, templateID)
+}
+
+// Render previews a template with values without creating a log
+func (s *LogTemplateService) Render(ctx context.Context, userID string, templateID uuid.UUID, req *model.RenderLogTemplateRequest) (*model.RenderLogTemplateResponse, error) {
+	template, err := s.templateRepo.GetByID(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := renderLogTemplate(template, req.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RenderLogTemplateResponse{
+		Content: content,
+		Kind:    template.Kind,
+		Tags:    template.Tags,
+	}, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestLogTemplateService_Render_ReturnsRenderResponse verifies Render returns RenderLogTemplateResponse DTO
+func TestLogTemplateService_Render_ReturnsRenderResponse(t *testing.T) {
+	service := NewLogTemplateService(nil)
+
+	_ = func() (*model.RenderLogTemplateResponse, error) {
+		return service.Render(nil, "", uuid.UUID{}, nil)
+	}
+
+	assert.NotNil(t, service)
+}
+
+// TestLogService_CreateFromTemplate_ReturnsLogResponse verifies CreateFromTemplate returns LogResponse DTO
+func TestLogService_CreateFromTemplate_ReturnsLogResponse(t *testing.T) {
+	service := NewLogService(nil, nil, nil, nil)
+
+	_ = func() (*model.LogResponse, error) {
+		return service.CreateFromTemplate(nil, "", uuid.UUID{}, uuid.UUID{}, nil)
+	}
+
+	assert.NotNil(t, service)
+}
+
+func templateFieldErrors(t *testing.T, err error) []errs.FieldError {
+	t.Helper()
+	require.Error(t, err)
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok, "error should be *errs.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	return httpErr.Errors
+}
+
+func TestValidateLogTemplate(t *testing.T) {
+	placeholders := []model.TemplatePlaceholder{
+		{Name: "disk_serial", Type: model.PlaceholderTypeString, Required: true},
+		{Name: "bay", Type: model.PlaceholderTypeInt, Default: stringPtr("1")},
+	}
+	assert.NoError(t, validateLogTemplate("Replaced disk {{ disk_serial }} in bay {{bay}}", placeholders))
+	assert.NoError(t, validateLogTemplate("Static content", nil))
+
+	errors := templateFieldErrors(t, validateLogTemplate("Upgraded from {{old_version}} to {{new_version}} ({{old_version}})", nil))
+	require.Len(t, errors, 2)
+	assert.Equal(t, "content", errors[0].Field)
+	assert.Contains(t, errors[0].Error, "{{old_version}}")
+	assert.Contains(t, errors[1].Error, "{{new_version}}")
+
+	errors = templateFieldErrors(t, validateLogTemplate("x", []model.TemplatePlaceholder{
+		{Name: "1bad", Type: model.PlaceholderTypeString},
+		{Name: "dup", Type: model.PlaceholderTypeString},
+		{Name: "dup", Type: model.PlaceholderTypeString},
+		{Name: "kind", Type: "uuid"},
+		{Name: "count", Type: model.PlaceholderTypeInt, Default: stringPtr("many")},
+	}))
+	fields := make([]string, 0, len(errors))
+	for _, e := range errors {
+		fields = append(fields, e.Field)
+	}
+	assert.Equal(t, []string{"placeholders[0].name", "placeholders[2].name", "placeholders[3].type", "placeholders[4].default"}, fields)
+}
+
+func TestFormatPlaceholderValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{"string", model.PlaceholderTypeString, "WD-123", "WD-123", false},
+		{"string rejects number", model.PlaceholderTypeString, float64(3), "", true},
+		{"int from JSON number", model.PlaceholderTypeInt, float64(3), "3", false},
+		{"int rejects fraction", model.PlaceholderTypeInt, 3.5, "", true},
+		{"int from string", model.PlaceholderTypeInt, " 42 ", "42", false},
+		{"int rejects text", model.PlaceholderTypeInt, "four", "", true},
+		{"number", model.PlaceholderTypeNumber, 2.5, "2.5", false},
+		{"number from string", model.PlaceholderTypeNumber, "1e3", "1000", false},
+		{"number rejects bool", model.PlaceholderTypeNumber, true, "", true},
+		{"bool", model.PlaceholderTypeBool, false, "false", false},
+		{"bool from string", model.PlaceholderTypeBool, "true", "true", false},
+		{"bool rejects text", model.PlaceholderTypeBool, "yes please", "", true},
+		{"date", model.PlaceholderTypeDate, "2024-03-15", "2024-03-15", false},
+		{"datetime", model.PlaceholderTypeDate, "2024-03-15T14:30:00Z", "2024-03-15T14:30:00Z", false},
+		{"date rejects text", model.PlaceholderTypeDate, "last tuesday", "", true},
+		{"unknown type", "uuid", "x", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatPlaceholderValue(tt.typ, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderLogTemplate(t *testing.T) {
+	template := &model.LogTemplate{
+		Content: "Upgraded {{package}} from {{old_version}} to {{new_version}}.{{notes}} Reboot: {{reboot}}",
+		Placeholders: []model.TemplatePlaceholder{
+			{Name: "package", Type: model.PlaceholderTypeString, Default: stringPtr("kernel")},
+			{Name: "old_version", Type: model.PlaceholderTypeString, Required: true},
+			{Name: "new_version", Type: model.PlaceholderTypeString, Required: true},
+			{Name: "notes", Type: model.PlaceholderTypeString},
+			{Name: "reboot", Type: model.PlaceholderTypeBool, Default: stringPtr("false")},
+		},
+	}
+
+	content, err := renderLogTemplate(template, map[string]any{
+		"old_version": "6.1",
+		"new_version": "6.5",
+		"reboot":      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Upgraded kernel from 6.1 to 6.5. Reboot: true", content)
+
+	_, err = renderLogTemplate(template, map[string]any{
+		"new_version": "",
+		"reboot":      "maybe",
+		"zzz":         1,
+		"aaa":         1,
+	})
+	errors := templateFieldErrors(t, err)
+	fields := make([]string, 0, len(errors))
+	for _, e := range errors {
+		fields = append(fields, e.Field)
+	}
+	assert.Equal(t, []string{"values.old_version", "values.new_version", "values.reboot", "values.aaa", "values.zzz"}, fields)
+}
+
+func TestRenderLogTemplate_ContentTooLong(t *testing.T) {
+	template := &model.LogTemplate{
+		Content:      "{{body}}",
+		Placeholders: []model.TemplatePlaceholder{{Name: "body", Type: model.PlaceholderTypeString}},
+	}
+
+	long := strings.Repeat("x", maxLogContentLength+1)
+
+	_, err := renderLogTemplate(template, map[string]any{"body": long})
+	errors := templateFieldErrors(t, err)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "content", errors[0].Field)
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+
+	"ark/internal/errs"
+	"ark/internal/lib/email"
+	"ark/internal/lib/job"
+	"ark/internal/lib/rrule"
+	"ark/internal/model"
+	"ark/internal/repository"
+	"ark/internal/server"
+)
+
+// maintenanceScanSchedule is how often overdue tasks are checked for reminders
+const maintenanceScanSchedule = "*/15 * * * *"
+
+type MaintenanceService struct {
+	server          *server.Server
+	maintenanceRepo *repository.MaintenanceRepository
+	assetRepo       *repository.AssetRepository
+	auth            *AuthService
+	stats           *StatsService
+}
+
+func NewMaintenanceService(s *server.Server, maintenanceRepo *repository.MaintenanceRepository, assetRepo *repository.AssetRepository, auth *AuthService, stats *StatsService) *MaintenanceService {
+	return &MaintenanceService{
+		server:          s,
+		maintenanceRepo: maintenanceRepo,
+		assetRepo:       assetRepo,
+		auth:            auth,
+		stats:           stats,
+	}
+}
+
+// RegisterJobs registers the reminder scan handler and schedules it periodically
+func (s *MaintenanceService) RegisterJobs(j *job.JobService) error {
+	j.HandleFunc(job.TaskMaintenanceScan, s.handleMaintenanceScanTask)
+
+	// Unique keeps the scan from running once per API instance
+	_, err := j.Schedule(maintenanceScanSchedule, job.NewMaintenanceScanTask(), asynq.Unique(10*time.Minute))
+	return err
+}
+
+// parseRecurrence validates an RRULE and returns it with its canonical form
+func parseRecurrence(recurrence string) (*rrule.Rule, error) {
+	rule, err := rrule.Parse(recurrence)
+	if err != nil {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "recurrence", Error: err.Error()},
+		}, nil)
+	}
+	return rule, nil
+}
+
+// firstDueAt returns when a task with the given schedule is next due.
+// A task that has never been completed is due at startsAt; otherwise it is due at
+// the first occurrence after its last completion. Nil means the rule has ended.
+func firstDueAt(rule *rrule.Rule, startsAt time.Time, lastCompletedAt *time.Time) *time.Time {
+	if lastCompletedAt == nil || startsAt.After(*lastCompletedAt) {
+		if rule.Until != nil && startsAt.After(*rule.Until) {
+			return nil
+		}
+		return &startsAt
+	}
+
+	next, ok := rule.Next(startsAt, *lastCompletedAt)
+	if !ok {
+		return nil
+	}
+	return &next
+}
+
+// nextDueAfterCompletion returns the due date following a completion at completedAt.
+// Completing early (before the task is due) still moves past the current due date.
+func nextDueAfterCompletion(rule *rrule.Rule, task *model.MaintenanceTask, completedAt time.Time) *time.Time {
+	if task.NextDueAt == nil {
+		return nil
+	}
+
+	after := completedAt
+	if task.NextDueAt.After(after) {
+		after = *task.NextDueAt
+	}
+
+	next, ok := rule.Next(task.StartsAt, after)
+	if !ok {
+		return nil
+	}
+	return &next
+}
+
+// maintenanceLogContent builds the content of the log recorded when a task is completed
+func maintenanceLogContent(task *model.MaintenanceTask, notes *string) string {
+	content := "Completed maintenance: " + task.Title
+	if notes != nil && strings.TrimSpace(*notes) != "" {
+		content += "\n\n" + strings.TrimSpace(*notes)
+	}
+	return content
+}
+
+func (s *MaintenanceService) List(ctx context.Context, userID string, params *model.MaintenanceTaskQueryParams) (*model.MaintenanceTaskListResponse, error) {
+	now := time.Now()
+	tasks, err := s.maintenanceRepo.List(ctx, userID, nil, params, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMaintenanceTaskListResponse(tasks, now), nil
+}
+
+func (s *MaintenanceService) ListByAsset(ctx context.Context, userID string, assetID uuid.UUID, params *model.MaintenanceTaskQueryParams) (*model.MaintenanceTaskListResponse, error) {
+	// Verify asset ownership
+	_, err := s.assetRepo.GetByID(ctx, userID, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tasks, err := s.maintenanceRepo.List(ctx, userID, &assetID, params, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMaintenanceTaskListResponse(tasks, now), nil
+}
+
+func (s *MaintenanceService) GetByID(ctx context.Context, userID string, taskID uuid.UUID) (*model.MaintenanceTaskResponse, error) {
+	task, err := s.maintenanceRepo.GetByID(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMaintenanceTaskResponse(task, time.Now()), nil
+}
+
+func (s *MaintenanceService) Create(ctx context.Context, userID string, assetID uuid.UUID, req *model.CreateMaintenanceTaskRequest) (*model.MaintenanceTaskResponse, error) {
+	// Verify asset ownership
+	_, err := s.assetRepo.GetByID(ctx, userID, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "title", Error: "is required"},
+		}, nil)
+	}
+
+	rule, err := parseRecurrence(req.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	req.Recurrence = rule.String()
+
+	now := time.Now()
+	if req.StartsAt == nil {
+		req.StartsAt = &now
+	}
+
+	nextDueAt := firstDueAt(rule, *req.StartsAt, nil)
+	if nextDueAt == nil {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "recurrence", Error: "UNTIL must not be before starts_at"},
+		}, nil)
+	}
+
+	task, err := s.maintenanceRepo.Create(ctx, userID, assetID, req, nextDueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMaintenanceTaskResponse(task, now), nil
+}
+
+func (s *MaintenanceService) Update(ctx context.Context, userID string, taskID uuid.UUID, req *model.UpdateMaintenanceTaskRequest) (*model.MaintenanceTaskResponse, error) {
+	if req.Title != nil && strings.TrimSpace(*req.Title) == "" {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "title", Error: "must not be empty"},
+		}, nil)
+	}
+
+	// Recompute the due date when the schedule changes
+	var nextDueAt *time.Time
+	if req.Recurrence != nil || req.StartsAt != nil {
+		existing, err := s.maintenanceRepo.GetByID(ctx, userID, taskID)
+		if err != nil {
+			return nil, err
+		}
+
+		recurrence := existing.Recurrence
+		if req.Recurrence != nil {
+			recurrence = *req.Recurrence
+		}
+		rule, err := parseRecurrence(recurrence)
+		if err != nil {
+			return nil, err
+		}
+		if req.Recurrence != nil {
+			canonical := rule.String()
+			req.Recurrence = &canonical
+		}
+
+		startsAt := existing.StartsAt
+		if req.StartsAt != nil {
+			startsAt = *req.StartsAt
+		}
+
+		nextDueAt = firstDueAt(rule, startsAt, existing.LastCompletedAt)
+	}
+
+	task, err := s.maintenanceRepo.Update(ctx, userID, taskID, req, nextDueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewMaintenanceTaskResponse(task, time.Now()), nil
+}
+
+func (s *MaintenanceService) Delete(ctx context.Context, userID string, taskID uuid.UUID) error {
+	return s.maintenanceRepo.Delete(ctx, userID, taskID)
+}
+
+// Complete marks a task done: it records a maintenance log on the asset and
+// advances the task to its next occurrence.
+func (s *MaintenanceService) Complete(ctx context.Context, userID string, taskID uuid.UUID, req *model.CompleteMaintenanceTaskRequest) (*model.CompleteMaintenanceTaskResponse, error) {
+	task, err := s.maintenanceRepo.GetByID(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := parseRecurrence(task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	completedAt := now
+	if req.CompletedAt != nil {
+		if req.CompletedAt.After(now) {
+			return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+				{Field: "completed_at", Error: "must not be in the future"},
+			}, nil)
+		}
+		completedAt = *req.CompletedAt
+	}
+
+	if req.DurationMinutes != nil && *req.DurationMinutes < 0 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "duration_minutes", Error: "must be at least 0"},
+		}, nil)
+	}
+
+	logReq := &model.CreateLogRequest{
+		Kind:            model.LogKindMaintenance,
+		Content:         maintenanceLogContent(task, req.Notes),
+		Tags:            processTags(req.Tags),
+		DurationMinutes: req.DurationMinutes,
+	}
+
+	nextDueAt := nextDueAfterCompletion(rule, task, completedAt)
+
+	updated, log, err := s.maintenanceRepo.Complete(ctx, userID, task, logReq, completedAt, nextDueAt)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.Invalidate(ctx, userID)
+
+	return &model.CompleteMaintenanceTaskResponse{
+		Task: model.NewMaintenanceTaskResponse(updated, now),
+		Log:  model.NewLogResponse(log),
+	}, nil
+}
+
+// groupRemindersByUser groups claimed reminders into one email's worth of tasks per user
+func groupRemindersByUser(reminders []model.MaintenanceReminder) map[string][]model.MaintenanceReminder {
+	byUser := make(map[string][]model.MaintenanceReminder)
+	for _, reminder := range reminders {
+		byUser[reminder.UserID] = append(byUser[reminder.UserID], reminder)
+	}
+	return byUser
+}
+
+// reminderEmailItems lists a user's reminders for the email template
+func reminderEmailItems(reminders []model.MaintenanceReminder) []email.MaintenanceReminderItem {
+	items := make([]email.MaintenanceReminderItem, 0, len(reminders))
+	for _, reminder := range reminders {
+		items = append(items, email.MaintenanceReminderItem{
+			Title:     reminder.Title,
+			AssetName: reminder.AssetName,
+			DueAt:     reminder.NextDueAt,
+		})
+	}
+	return items
+}
+
+// remindUser enqueues the reminder email for one user's claimed tasks
+func (s *MaintenanceService) remindUser(ctx context.Context, userID string, reminders []model.MaintenanceReminder) error {
+	to, err := s.auth.GetUserEmail(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("look up email: %w", err)
+	}
+
+	task, err := job.NewMaintenanceReminderTask(to, reminderEmailItems(reminders))
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil {
+		return fmt.Errorf("enqueue reminder: %w", err)
+	}
+
+	return nil
+}
+
+// handleMaintenanceScanTask claims overdue tasks and enqueues one reminder email
+// per user. A user's claims are released when their email cannot be enqueued,
+// so the next scan tries again.
+func (s *MaintenanceService) handleMaintenanceScanTask(ctx context.Context, _ *asynq.Task) error {
+	logger := s.server.Logger
+
+	reminders, err := s.maintenanceRepo.ClaimDueReminders(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for userID, userReminders := range groupRemindersByUser(reminders) {
+		err := s.remindUser(ctx, userID, userReminders)
+		if err == nil {
+			continue
+		}
+		logger.Error().Err(err).Str("user_id", userID).Msg("Failed to send maintenance reminder")
+
+		taskIDs := make([]uuid.UUID, 0, len(userReminders))
+		for _, reminder := range userReminders {
+			taskIDs = append(taskIDs, reminder.TaskID)
+		}
+		if err := s.maintenanceRepo.ReleaseReminders(ctx, userID, taskIDs); err != nil {
+			logger.Error().Err(err).Str("user_id", userID).Msg("Failed to release maintenance reminders")
+		}
+	}
+
+	logger.Info().Int("tasks", len(reminders)).Msg("Maintenance reminder scan complete")
+	return nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/lib/rrule"
+	"ark/internal/model"
+)
+
+// TestMaintenanceService_Complete_ReturnsCompleteResponse verifies Complete returns CompleteMaintenanceTaskResponse DTO
+func TestMaintenanceService_Complete_ReturnsCompleteResponse(t *testing.T) {
+	service := NewMaintenanceService(nil, nil, nil, nil, nil)
+
+	_ = func() (*model.CompleteMaintenanceTaskResponse, error) {
+		return service.Complete(nil, "", uuid.UUID{}, nil)
+	}
+
+	assert.NotNil(t, service)
+}
+
+func mustParseRule(t *testing.T, s string) *rrule.Rule {
+	t.Helper()
+	rule, err := rrule.Parse(s)
+	require.NoError(t, err)
+	return rule
+}
+
+func TestParseRecurrence_Invalid(t *testing.T) {
+	_, err := parseRecurrence("FREQ=HOURLY")
+	require.Error(t, err)
+
+	httpErr, ok := err.(*errs.HTTPError)
+	require.True(t, ok, "error should be *errs.HTTPError")
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	require.Len(t, httpErr.Errors, 1)
+	assert.Equal(t, "recurrence", httpErr.Errors[0].Field)
+}
+
+func TestFirstDueAt(t *testing.T) {
+	startsAt := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+	rule := mustParseRule(t, "FREQ=MONTHLY")
+
+	// Never completed: due at starts_at
+	due := firstDueAt(rule, startsAt, nil)
+	require.NotNil(t, due)
+	assert.Equal(t, startsAt, *due)
+
+	// Completed on Feb 10: next occurrence is Mar 1
+	completed := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	due = firstDueAt(rule, startsAt, &completed)
+	require.NotNil(t, due)
+	assert.Equal(t, time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC), *due)
+
+	// Schedule moved past the last completion: due at the new start
+	later := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	due = firstDueAt(rule, later, &completed)
+	require.NotNil(t, due)
+	assert.Equal(t, later, *due)
+
+	// UNTIL before the start: nothing is ever due
+	ended := mustParseRule(t, "FREQ=MONTHLY;UNTIL=20241231")
+	assert.Nil(t, firstDueAt(ended, startsAt, nil))
+}
+
+func TestNextDueAfterCompletion(t *testing.T) {
+	startsAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rule := mustParseRule(t, "FREQ=MONTHLY")
+	task := &model.MaintenanceTask{StartsAt: startsAt, NextDueAt: &due}
+
+	tests := []struct {
+		name        string
+		completedAt time.Time
+		want        time.Time
+	}{
+		{"on time", due, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"early still skips current due date", due.AddDate(0, 0, -3), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"late keeps cadence", due.AddDate(0, 0, 9), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"very late skips missed occurrences", due.AddDate(0, 2, 5), time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := nextDueAfterCompletion(rule, task, tt.completedAt)
+			require.NotNil(t, next)
+			assert.Equal(t, tt.want, *next)
+		})
+	}
+
+	// The last occurrence before UNTIL finishes the task
+	final := mustParseRule(t, "FREQ=MONTHLY;UNTIL=20250315")
+	assert.Nil(t, nextDueAfterCompletion(final, task, due))
+
+	// Finished tasks stay finished
+	assert.Nil(t, nextDueAfterCompletion(rule, &model.MaintenanceTask{StartsAt: startsAt}, due))
+}
+
+func TestMaintenanceLogContent(t *testing.T) {
+	task := &model.MaintenanceTask{Title: "Scrub ZFS pool"}
+
+	assert.Equal(t, "Completed maintenance: Scrub ZFS pool", maintenanceLogContent(task, nil))
+	assert.Equal(t, "Completed maintenance: Scrub ZFS pool", maintenanceLogContent(task, stringPtr("   ")))
+	assert.Equal(t, "Completed maintenance: Scrub ZFS pool\n\n0 errors repaired", maintenanceLogContent(task, stringPtr(" 0 errors repaired\n")))
+}
+
+func TestGroupRemindersByUser(t *testing.T) {
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	reminders := []model.MaintenanceReminder{
+		{TaskID: uuid.New(), UserID: "user_a", Title: "Scrub", AssetName: "nas", NextDueAt: due},
+		{TaskID: uuid.New(), UserID: "user_b", Title: "Rotate backups", AssetName: "backup", NextDueAt: due},
+		{TaskID: uuid.New(), UserID: "user_a", Title: "Update firmware", AssetName: "router", NextDueAt: due},
+	}
+
+	byUser := groupRemindersByUser(reminders)
+
+	require.Len(t, byUser, 2)
+	require.Len(t, byUser["user_a"], 2)
+	assert.Equal(t, reminders[0].TaskID, byUser["user_a"][0].TaskID)
+	assert.Equal(t, reminders[2].TaskID, byUser["user_a"][1].TaskID)
+	assert.Len(t, byUser["user_b"], 1)
+
+	items := reminderEmailItems(byUser["user_a"])
+	require.Len(t, items, 2)
+	assert.Equal(t, "Scrub", items[0].Title)
+	assert.Equal(t, "router", items[1].AssetName)
+	assert.Equal(t, due, items[1].DueAt)
+}
+
+func TestPrimaryEmail(t *testing.T) {
+	primaryID := "idn_2"
+	u := &clerk.User{
+		ID:                    "user_1",
+		PrimaryEmailAddressID: &primaryID,
+		EmailAddresses: []*clerk.EmailAddress{
+			{ID: "idn_1", EmailAddress: "old@example.com"},
+			{ID: "idn_2", EmailAddress: "primary@example.com"},
+		},
+	}
+
+	addr, err := primaryEmail(u)
+	require.NoError(t, err)
+	assert.Equal(t, "primary@example.com", addr)
+
+	u.PrimaryEmailAddressID = nil
+	addr, err = primaryEmail(u)
+	require.NoError(t, err)
+	assert.Equal(t, "old@example.com", addr)
+
+	_, err = primaryEmail(&clerk.User{ID: "user_2"})
+	assert.Error(t, err)
+}
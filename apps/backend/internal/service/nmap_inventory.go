@@ -0,0 +1,642 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// nmapRun is the part of nmap -oX output an import reads
+type nmapRun struct {
+	XMLName xml.Name   `xml:"nmaprun"`
+	Args    string     `xml:"args,attr"`
+	Start   int64      `xml:"start,attr"`
+	Hosts   []nmapHost `xml:"host"`
+}
+
+type nmapHost struct {
+	Status struct {
+		State string `xml:"state,attr"`
+	} `xml:"status"`
+	Addresses []struct {
+		Addr     string `xml:"addr,attr"`
+		AddrType string `xml:"addrtype,attr"`
+		Vendor   string `xml:"vendor,attr"`
+	} `xml:"address"`
+	Hostnames []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"hostnames>hostname"`
+	Ports []struct {
+		Protocol string `xml:"protocol,attr"`
+		PortID   int    `xml:"portid,attr"`
+		State    struct {
+			State string `xml:"state,attr"`
+		} `xml:"state"`
+		Service struct {
+			Name string `xml:"name,attr"`
+		} `xml:"service"`
+	} `xml:"ports>port"`
+}
+
+// nmapArgsValueOptions are nmap options followed by an address that is not a
+// scan target
+var nmapArgsValueOptions = map[string]bool{
+	"--exclude":     true,
+	"--dns-servers": true,
+	"-S":            true,
+	"-D":            true,
+	"-e":            true,
+}
+
+// scannedHost is a host a scan found up, merged across files by address
+type scannedHost struct {
+	Source    string
+	File      string
+	Addr      netip.Addr
+	MAC       string
+	Vendor    string
+	Hostnames []string
+	Ports     []*model.CreateAssetPortRequest
+	SeenAt    time.Time
+}
+
+// portKey identifies a port of an asset
+func portKey(port int, protocol string) string {
+	return strconv.Itoa(port) + "/" + protocol
+}
+
+// portLabel describes a port in a change description, e.g. "22/tcp (ssh)"
+func portLabel(port *model.CreateAssetPortRequest) string {
+	label := portKey(port.Port, port.Protocol)
+	if port.Service != nil {
+		label += " (" + *port.Service + ")"
+	}
+	return label
+}
+
+// normalizeMAC uppercases a MAC address and separates it with colons, or
+// returns "" when it is not one
+func normalizeMAC(mac string) string {
+	mac = strings.ToUpper(strings.NewReplacer("-", ":", ".", "").Replace(strings.TrimSpace(mac)))
+	if len(mac) == 12 && !strings.Contains(mac, ":") {
+		parts := make([]string, 0, 6)
+		for i := 0; i < 12; i += 2 {
+			parts = append(parts, mac[i:i+2])
+		}
+		mac = strings.Join(parts, ":")
+	}
+	if len(mac) != 17 {
+		return ""
+	}
+	for i, r := range mac {
+		if i%3 == 2 {
+			if r != ':' {
+				return ""
+			}
+		} else if !strings.ContainsRune("0123456789ABCDEF", r) {
+			return ""
+		}
+	}
+	return mac
+}
+
+// nmapArgsScope reads the networks a scan covered from the targets on its
+// command line. Targets given as host names or octet ranges are skipped.
+func nmapArgsScope(args string) []netip.Prefix {
+	var scope []netip.Prefix
+	fields := strings.Fields(args)
+	for i := 1; i < len(fields); i++ {
+		field := fields[i]
+		if nmapArgsValueOptions[field] {
+			i++
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(field); err == nil {
+			scope = append(scope, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(field); err == nil {
+			scope = append(scope, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	return scope
+}
+
+// parseNmapParams reads the scope and accept query parameters. Applying a scan
+// requires the plan of its dry run and at least one accepted address.
+func parseNmapParams(params *model.NmapImportParams) ([]netip.Prefix, map[netip.Addr]bool, error) {
+	scope := make([]netip.Prefix, 0, len(params.Scope))
+	for _, value := range params.Scope {
+		if prefix, err := netip.ParsePrefix(strings.TrimSpace(value)); err == nil {
+			scope = append(scope, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(strings.TrimSpace(value)); err == nil {
+			scope = append(scope, netip.PrefixFrom(addr, addr.BitLen()))
+		} else {
+			return nil, nil, inventoryFileError("scope", fmt.Sprintf("%q is neither a CIDR nor an IP address", value))
+		}
+	}
+
+	if !params.DryRun {
+		if params.Plan == "" {
+			return nil, nil, inventoryFileError("plan", "is required, review the scan with dry_run=true first")
+		}
+		if len(params.Accept) == 0 {
+			return nil, nil, inventoryFileError("accept", "list the scanned addresses to import")
+		}
+	}
+
+	accept := make(map[netip.Addr]bool, len(params.Accept))
+	for _, value := range params.Accept {
+		addr, err := netip.ParseAddr(strings.TrimSpace(value))
+		if err != nil {
+			return nil, nil, inventoryFileError("accept", fmt.Sprintf("%q is not an IP address", value))
+		}
+		accept[addr] = true
+	}
+
+	return scope, accept, nil
+}
+
+// parseNmapFiles reads the hosts nmap found up, by IP address, along with the
+// networks the scans covered. A host scanned in several files, say once for
+// TCP and once for UDP, is merged.
+func parseNmapFiles(files []model.InventoryFile) ([]*scannedHost, []netip.Prefix, error) {
+	var hosts []*scannedHost
+	byAddr := make(map[netip.Addr]*scannedHost)
+	var scope []netip.Prefix
+
+	for _, f := range files {
+		var run nmapRun
+		if err := xml.Unmarshal(f.Data, &run); err != nil {
+			return nil, nil, inventoryFileError(f.Name, "is not nmap XML output (nmap -oX): "+err.Error())
+		}
+		scope = append(scope, nmapArgsScope(run.Args)...)
+		seenAt := time.Now().UTC()
+		if run.Start > 0 {
+			seenAt = time.Unix(run.Start, 0).UTC()
+		}
+
+		for _, h := range run.Hosts {
+			if h.Status.State != "up" {
+				continue
+			}
+
+			var addr netip.Addr
+			var mac, vendor string
+			for _, a := range h.Addresses {
+				switch a.AddrType {
+				case "ipv4", "ipv6":
+					parsed, err := netip.ParseAddr(a.Addr)
+					// Prefer IPv4 when a host has both
+					if err == nil && (!addr.IsValid() || (addr.Is6() && parsed.Is4())) {
+						addr = parsed
+					}
+				case "mac":
+					mac = normalizeMAC(a.Addr)
+					vendor = a.Vendor
+				}
+			}
+			if !addr.IsValid() {
+				continue
+			}
+
+			host, ok := byAddr[addr]
+			if !ok {
+				host = &scannedHost{Source: f.Name + ": " + addr.String(), File: f.Name, Addr: addr}
+				byAddr[addr] = host
+				hosts = append(hosts, host)
+				if len(hosts) > model.MaxInventoryAssets {
+					return nil, nil, inventoryFileError(f.Name, fmt.Sprintf("imports must not describe more than %d assets", model.MaxInventoryAssets))
+				}
+			}
+			if seenAt.After(host.SeenAt) {
+				host.SeenAt = seenAt
+			}
+			if host.MAC == "" {
+				host.MAC, host.Vendor = mac, vendor
+			}
+			for _, hostname := range h.Hostnames {
+				name := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(hostname.Name), "."))
+				if name != "" && utf8.RuneCountInString(name) <= 255 && !containsString(host.Hostnames, name) {
+					host.Hostnames = append(host.Hostnames, name)
+				}
+			}
+			for _, p := range h.Ports {
+				protocol := strings.ToLower(p.Protocol)
+				if p.State.State != "open" || !model.IsValidPortProtocol(protocol) || p.PortID < 1 || p.PortID > 65535 {
+					continue
+				}
+				port := &model.CreateAssetPortRequest{Port: p.PortID, Protocol: protocol}
+				if name := strings.TrimSpace(p.Service.Name); name != "" && utf8.RuneCountInString(name) <= 100 {
+					port.Service = &name
+				}
+				duplicate := false
+				for _, other := range host.Ports {
+					if other.Port == port.Port && other.Protocol == port.Protocol {
+						duplicate = true
+						break
+					}
+				}
+				if !duplicate {
+					host.Ports = append(host.Ports, port)
+				}
+			}
+		}
+	}
+
+	for _, host := range hosts {
+		sort.Slice(host.Ports, func(i, j int) bool {
+			if host.Ports[i].Protocol != host.Ports[j].Protocol {
+				return host.Ports[i].Protocol < host.Ports[j].Protocol
+			}
+			return host.Ports[i].Port < host.Ports[j].Port
+		})
+	}
+
+	return hosts, scope, nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// assetAddresses returns the IP addresses an asset is known by: its hostname
+// when that is one, and its ip_address metadata key
+func assetAddresses(asset *model.Asset) []netip.Addr {
+	var addrs []netip.Addr
+	if asset.Hostname != nil {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(*asset.Hostname)); err == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	if raw, ok := decodeAssetMetadata(asset.Metadata)[model.MetadataIPAddress]; ok {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(metadataCell(raw))); err == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// matchScannedHosts pairs scanned hosts with existing assets, by MAC address
+// first, then IP address, then hostname. Each asset matches one host at most;
+// the oldest asset wins when several share an address.
+func matchScannedHosts(hosts []*scannedHost, assets []*model.Asset) map[*scannedHost]*model.Asset {
+	byMAC := make(map[string]*model.Asset)
+	byAddr := make(map[netip.Addr]*model.Asset)
+	byHostname := make(map[string]*model.Asset)
+	for _, asset := range assets {
+		metadata := decodeAssetMetadata(asset.Metadata)
+		if mac := normalizeMAC(metadataCell(metadata[model.MetadataMACAddress])); mac != "" {
+			if _, ok := byMAC[mac]; !ok {
+				byMAC[mac] = asset
+			}
+		}
+		for _, addr := range assetAddresses(asset) {
+			if _, ok := byAddr[addr]; !ok {
+				byAddr[addr] = asset
+			}
+		}
+		if asset.Hostname != nil {
+			hostname := strings.ToLower(strings.TrimSpace(*asset.Hostname))
+			if _, ok := byHostname[hostname]; !ok && hostname != "" {
+				byHostname[hostname] = asset
+			}
+		}
+	}
+
+	matches := make(map[*scannedHost]*model.Asset, len(hosts))
+	claimed := make(map[*model.Asset]bool, len(hosts))
+	claim := func(host *scannedHost, asset *model.Asset) {
+		if asset != nil && !claimed[asset] && matches[host] == nil {
+			matches[host] = asset
+			claimed[asset] = true
+		}
+	}
+	for _, host := range hosts {
+		if host.MAC != "" {
+			claim(host, byMAC[host.MAC])
+		}
+	}
+	for _, host := range hosts {
+		claim(host, byAddr[host.Addr])
+	}
+	for _, host := range hosts {
+		for _, hostname := range host.Hostnames {
+			claim(host, byHostname[hostname])
+		}
+	}
+	return matches
+}
+
+// scannedHostMetadata is the network metadata a scan records about a host.
+// The address is only recorded when the hostname is not the address already.
+func scannedHostMetadata(host *scannedHost, hostname *string) map[string]any {
+	metadata := make(map[string]any)
+	if hostname == nil || *hostname != host.Addr.String() {
+		metadata[model.MetadataIPAddress] = host.Addr.String()
+	}
+	if host.MAC != "" {
+		metadata[model.MetadataMACAddress] = host.MAC
+		if host.Vendor != "" {
+			metadata[model.MetadataMACVendor] = host.Vendor
+		}
+	}
+	return metadata
+}
+
+// planNmapImport reconciles scanned hosts with existing assets. Unknown hosts
+// become new assets with their open ports. Known assets follow a changed IP
+// address when their hostname was the old one, gain the MAC address and any
+// newly open ports, and have every open port marked seen. Assets in scope
+// that no host matched are reported as not seen.
+func planNmapImport(hosts []*scannedHost, assets []*model.Asset, ports []*model.AssetPort, scope []netip.Prefix) ([]repository.AssetWrite, []int, *model.InventoryImportResult, error) {
+	matches := matchScannedHosts(hosts, assets)
+
+	recorded := make(map[string]bool, len(ports))
+	for _, port := range ports {
+		recorded[port.AssetID.String()+"/"+portKey(port.Port, port.Protocol)] = true
+	}
+
+	result := &model.InventoryImportResult{Assets: make([]model.InventoryAssetResult, 0, len(hosts))}
+	writes := make([]repository.AssetWrite, 0, len(hosts))
+	// writeResults maps each write to its entry in result.Assets
+	writeResults := make([]int, 0, len(hosts))
+
+	for _, host := range hosts {
+		label := "nmap scan " + host.File
+		asset, found := matches[host]
+		if !found {
+			hostname := host.Addr.String()
+			if len(host.Hostnames) > 0 {
+				hostname = host.Hostnames[0]
+			}
+			name := hostname
+			if utf8.RuneCountInString(name) > 100 {
+				name = host.Addr.String()
+			}
+
+			create := &model.CreateAssetRequest{Name: name, Hostname: &hostname, Tags: processTags(nil)}
+			data, err := json.Marshal(scannedHostMetadata(host, &hostname))
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			create.Metadata = (*json.RawMessage)(&data)
+
+			var changes []string
+			if len(host.Ports) > 0 {
+				labels := make([]string, 0, len(host.Ports))
+				for _, port := range host.Ports {
+					labels = append(labels, portLabel(port))
+				}
+				changes = append(changes, "ports: "+strings.Join(labels, ", "))
+			}
+
+			result.Created++
+			writeResults = append(writeResults, len(result.Assets))
+			result.Assets = append(result.Assets, model.InventoryAssetResult{
+				Source:  host.Source,
+				Action:  model.InventoryActionCreate,
+				Name:    name,
+				Address: host.Addr.String(),
+				Changes: changes,
+			})
+			writes = append(writes, repository.AssetWrite{
+				Create: create,
+				Log: &model.CreateLogRequest{
+					Kind:    model.LogKindChange,
+					Content: inventoryLogContent("Created", label, changes),
+				},
+				Ports:       host.Ports,
+				PortsSeenAt: host.SeenAt,
+			})
+			continue
+		}
+
+		item := inventoryAsset{Name: asset.Name}
+		// Follow a new address when the asset is known by its old one
+		if asset.Hostname != nil {
+			if old, err := netip.ParseAddr(*asset.Hostname); err == nil && old != host.Addr {
+				hostname := host.Addr.String()
+				item.Hostname = &hostname
+			}
+		}
+		hostname := asset.Hostname
+		if item.Hostname != nil {
+			hostname = item.Hostname
+		}
+		item.Metadata = scannedHostMetadata(host, hostname)
+		// Keep the asset's own notation of the same MAC address
+		if raw, ok := decodeAssetMetadata(asset.Metadata)[model.MetadataMACAddress]; ok && normalizeMAC(metadataCell(raw)) == host.MAC {
+			delete(item.Metadata, model.MetadataMACAddress)
+		}
+
+		update, changes, err := diffInventoryAsset(asset, item)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		var added []string
+		for _, port := range host.Ports {
+			if !recorded[asset.ID.String()+"/"+portKey(port.Port, port.Protocol)] {
+				added = append(added, portLabel(port))
+			}
+		}
+		if len(added) > 0 {
+			changes = append(changes, "ports: added "+strings.Join(added, ", "))
+		}
+
+		entry := model.InventoryAssetResult{Source: host.Source, ID: &asset.ID, Name: asset.Name, Address: host.Addr.String(), Changes: changes}
+		write := repository.AssetWrite{ID: asset.ID, Update: update, Ports: host.Ports, PortsSeenAt: host.SeenAt}
+		if len(changes) == 0 {
+			result.Unchanged++
+			entry.Action = model.InventoryActionUnchanged
+		} else {
+			result.Updated++
+			entry.Action = model.InventoryActionUpdate
+			write.Log = &model.CreateLogRequest{
+				Kind:    model.LogKindChange,
+				Content: inventoryLogContent("Updated", label, changes),
+			}
+		}
+
+		// Unchanged assets are still written to mark their ports seen
+		if write.Update != nil || write.Log != nil || len(write.Ports) > 0 {
+			writeResults = append(writeResults, len(result.Assets))
+			writes = append(writes, write)
+		}
+		result.Assets = append(result.Assets, entry)
+	}
+
+	claimed := make(map[*model.Asset]bool, len(matches))
+	for _, asset := range matches {
+		claimed[asset] = true
+	}
+	for _, asset := range assets {
+		if claimed[asset] {
+			continue
+		}
+		for _, addr := range assetAddresses(asset) {
+			if !prefixesContain(scope, addr) {
+				continue
+			}
+			result.NotSeen = append(result.NotSeen, model.InventoryAssetResult{
+				Source: addr.String(),
+				Action: model.InventoryActionNotSeen,
+				ID:     &asset.ID,
+				Name:   asset.Name,
+			})
+			break
+		}
+	}
+
+	return writes, writeResults, result, nil
+}
+
+// prefixesContain reports whether any of prefixes contains addr
+func prefixesContain(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// nmapPlanHash fingerprints what an import would do with every scanned host,
+// so applying it can check that nothing changed since the dry run
+func nmapPlanHash(result *model.InventoryImportResult) (string, error) {
+	data, err := json.Marshal(struct {
+		Assets  []model.InventoryAssetResult `json:"assets"`
+		NotSeen []model.InventoryAssetResult `json:"not_seen"`
+	}{result.Assets, result.NotSeen})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// acceptNmapHosts keeps the writes of accepted hosts and reports every other
+// host as skipped. Accepted addresses must be hosts of the scan.
+func acceptNmapHosts(writes []repository.AssetWrite, writeResults []int, result *model.InventoryImportResult, accept map[netip.Addr]bool) ([]repository.AssetWrite, []int, error) {
+	scanned := make(map[netip.Addr]bool, len(result.Assets))
+	for i := range result.Assets {
+		entry := &result.Assets[i]
+		addr := netip.MustParseAddr(entry.Address)
+		scanned[addr] = true
+		if accept[addr] {
+			continue
+		}
+
+		switch entry.Action {
+		case model.InventoryActionCreate:
+			result.Created--
+		case model.InventoryActionUpdate:
+			result.Updated--
+		case model.InventoryActionUnchanged:
+			result.Unchanged--
+		}
+		entry.Action = model.InventoryActionSkipped
+		entry.Changes = nil
+		result.Skipped++
+	}
+
+	for addr := range accept {
+		if !scanned[addr] {
+			return nil, nil, inventoryFileError("accept", fmt.Sprintf("%s is not a host of the scan", addr))
+		}
+	}
+
+	acceptedWrites := make([]repository.AssetWrite, 0, len(writes))
+	acceptedResults := make([]int, 0, len(writes))
+	for i, write := range writes {
+		if result.Assets[writeResults[i]].Action != model.InventoryActionSkipped {
+			acceptedWrites = append(acceptedWrites, write)
+			acceptedResults = append(acceptedResults, writeResults[i])
+		}
+	}
+
+	return acceptedWrites, acceptedResults, nil
+}
+
+// ImportNmap reconciles the hosts of nmap XML scans with existing assets,
+// matched by MAC address, IP address or hostname. It never deletes: assets in
+// scope the scans did not find are reported as not seen.
+//
+// A dry run returns the plan. Applying requires that plan back and imports
+// only the accepted hosts; it is refused with code PLAN_CHANGED when the scan
+// or the assets it touches changed since the dry run.
+func (s *InventoryService) ImportNmap(ctx context.Context, userID string, params *model.NmapImportParams, files []model.InventoryFile) (*model.InventoryImportResult, error) {
+	scope, accept, err := parseNmapParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, scanScope, err := parseNmapFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	if len(scope) == 0 {
+		scope = scanScope
+	}
+
+	assets, err := s.assetRepo.FindWithAddress(ctx, userID, []string{model.MetadataIPAddress, model.MetadataMACAddress})
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make([]*model.AssetPort, 0)
+	if matches := matchScannedHosts(hosts, assets); len(matches) > 0 {
+		ids := make([]uuid.UUID, 0, len(matches))
+		for _, asset := range matches {
+			ids = append(ids, asset.ID)
+		}
+		ports, err = s.portRepo.ListByAssets(ctx, userID, ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writes, writeResults, result, err := planNmapImport(hosts, assets, ports, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Plan, err = nmapPlanHash(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if !params.DryRun {
+		if params.Plan != result.Plan {
+			code := "PLAN_CHANGED"
+			return nil, errs.NewBadRequestError("the import no longer matches the reviewed plan, run the dry run again", false, &code, nil, nil)
+		}
+
+		writes, writeResults, err = acceptNmapHosts(writes, writeResults, result, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.applyInventoryImport(ctx, userID, writes, writeResults, result, params.DryRun)
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+const testNmapScan = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE nmaprun>
+<nmaprun scanner="nmap" args="nmap -sV --exclude 10.0.0.1 -oX lan.xml 10.0.0.0/24" start="1767225600">
+  <host>
+    <status state="up"/>
+    <address addr="10.0.0.5" addrtype="ipv4"/>
+    <address addr="00:11:32:aa:bb:cc" addrtype="mac" vendor="Synology"/>
+    <hostnames><hostname name="nas.lan" type="PTR"/></hostnames>
+    <ports>
+      <port protocol="tcp" portid="22"><state state="open"/><service name="ssh"/></port>
+      <port protocol="tcp" portid="445"><state state="open"/><service name="microsoft-ds"/></port>
+      <port protocol="tcp" portid="8080"><state state="closed"/></port>
+    </ports>
+  </host>
+  <host>
+    <status state="up"/>
+    <address addr="10.0.0.23" addrtype="ipv4"/>
+    <hostnames><hostname name="printer.lan." type="PTR"/></hostnames>
+    <ports><port protocol="tcp" portid="631"><state state="open"/><service name="ipp"/></port></ports>
+  </host>
+  <host>
+    <status state="down"/>
+    <address addr="10.0.0.40" addrtype="ipv4"/>
+  </host>
+</nmaprun>`
+
+const testNmapUDPScan = `<nmaprun args="nmap -sU 10.0.0.5" start="1767229200">
+  <host>
+    <status state="up"/>
+    <address addr="10.0.0.5" addrtype="ipv4"/>
+    <ports><port protocol="udp" portid="161"><state state="open"/><service name="snmp"/></port></ports>
+  </host>
+</nmaprun>`
+
+// TestNormalizeMAC accepts the common MAC address notations
+func TestNormalizeMAC(t *testing.T) {
+	assert.Equal(t, "00:11:32:AA:BB:CC", normalizeMAC("00:11:32:aa:bb:cc"))
+	assert.Equal(t, "00:11:32:AA:BB:CC", normalizeMAC("00-11-32-AA-BB-CC"))
+	assert.Equal(t, "00:11:32:AA:BB:CC", normalizeMAC("0011.32aa.bbcc"))
+	assert.Equal(t, "", normalizeMAC("not a mac"))
+	assert.Equal(t, "", normalizeMAC(""))
+}
+
+// TestNmapArgsScope reads targets from the command line, skipping option values
+func TestNmapArgsScope(t *testing.T) {
+	scope := nmapArgsScope("nmap -sV --exclude 10.0.0.1 -p 22 -oX - 10.0.0.7/24 192.168.1.10 nas.lan 10.1.0.1-20")
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("192.168.1.10/32"),
+	}, scope)
+}
+
+// TestParseNmapFiles reads up hosts with their open ports, merging hosts across scans
+func TestParseNmapFiles(t *testing.T) {
+	hosts, scope, err := parseNmapFiles([]model.InventoryFile{
+		{Name: "lan.xml", Data: []byte(testNmapScan)},
+		{Name: "udp.xml", Data: []byte(testNmapUDPScan)},
+	})
+	require.NoError(t, err)
+	require.Len(t, hosts, 2)
+
+	nas := hosts[0]
+	assert.Equal(t, "lan.xml: 10.0.0.5", nas.Source)
+	assert.Equal(t, "00:11:32:AA:BB:CC", nas.MAC)
+	assert.Equal(t, "Synology", nas.Vendor)
+	assert.Equal(t, []string{"nas.lan"}, nas.Hostnames)
+	assert.Equal(t, time.Unix(1767229200, 0).UTC(), nas.SeenAt)
+	require.Len(t, nas.Ports, 3)
+	assert.Equal(t, "22/tcp (ssh)", portLabel(nas.Ports[0]))
+	assert.Equal(t, "445/tcp (microsoft-ds)", portLabel(nas.Ports[1]))
+	assert.Equal(t, "161/udp (snmp)", portLabel(nas.Ports[2]))
+
+	assert.Equal(t, []string{"printer.lan"}, hosts[1].Hostnames)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("10.0.0.5/32"),
+	}, scope)
+
+	_, _, err = parseNmapFiles([]model.InventoryFile{{Name: "hosts.ini", Data: []byte("[web]\nweb01\n")}})
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.Equal(t, "hosts.ini", httpErr.Errors[0].Field)
+}
+
+// TestParseNmapParams rejects scope and accept values that are not addresses,
+// and applies without a reviewed plan
+func TestParseNmapParams(t *testing.T) {
+	scope, accept, err := parseNmapParams(&model.NmapImportParams{
+		Scope:  []string{"10.0.0.9/24", "fd00::1"},
+		Plan:   "3f1c9a",
+		Accept: []string{"10.0.0.23"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24"), netip.MustParsePrefix("fd00::1/128")}, scope)
+	assert.True(t, accept[netip.MustParseAddr("10.0.0.23")])
+
+	// A dry run needs neither a plan nor accepted hosts
+	_, _, err = parseNmapParams(&model.NmapImportParams{DryRun: true})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params model.NmapImportParams
+		field  string
+	}{
+		{"no plan", model.NmapImportParams{Accept: []string{"10.0.0.23"}}, "plan"},
+		{"no accepted hosts", model.NmapImportParams{Plan: "3f1c9a"}, "accept"},
+		{"accepted host name", model.NmapImportParams{Plan: "3f1c9a", Accept: []string{"nas.lan"}}, "accept"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseNmapParams(&tt.params)
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, tt.field, httpErr.Errors[0].Field)
+		})
+	}
+}
+
+// TestPlanNmapImport proposes new assets, updates known ones and flags those not seen
+func TestPlanNmapImport(t *testing.T) {
+	hosts, scope, err := parseNmapFiles([]model.InventoryFile{{Name: "lan.xml", Data: []byte(testNmapScan)}})
+	require.NoError(t, err)
+
+	// The NAS is known by its MAC address under an old IP
+	nas := &model.Asset{ID: uuid.New(), Name: "nas", Hostname: stringPtr("10.0.0.4"),
+		Metadata: json.RawMessage(`{"mac_address": "00-11-32-AA-BB-CC", "rack": "A2"}`)}
+	oldPi := &model.Asset{ID: uuid.New(), Name: "old-pi", Hostname: stringPtr("pi.lan"),
+		Metadata: json.RawMessage(`{"ip_address": "10.0.0.9"}`)}
+	elsewhere := &model.Asset{ID: uuid.New(), Name: "vps", Hostname: stringPtr("203.0.113.7")}
+	ports := []*model.AssetPort{{AssetID: nas.ID, Port: 22, Protocol: model.PortProtocolTCP}}
+
+	writes, writeResults, result, err := planNmapImport(hosts, []*model.Asset{nas, oldPi, elsewhere}, ports, scope)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	require.Len(t, result.Assets, 2)
+	require.Len(t, writes, 2)
+	assert.Equal(t, []int{0, 1}, writeResults)
+
+	update := result.Assets[0]
+	assert.Equal(t, model.InventoryActionUpdate, update.Action)
+	assert.Equal(t, &nas.ID, update.ID)
+	assert.Equal(t, []string{
+		"hostname: 10.0.0.4 → 10.0.0.5",
+		"mac_vendor: (none) → Synology",
+		"ports: added 445/tcp (microsoft-ds)",
+	}, update.Changes)
+	require.NotNil(t, writes[0].Update)
+	assert.JSONEq(t, `{"mac_address": "00-11-32-AA-BB-CC", "mac_vendor": "Synology", "rack": "A2"}`, string(*writes[0].Update.Metadata))
+	assert.Len(t, writes[0].Ports, 2)
+	assert.Equal(t, time.Unix(1767225600, 0).UTC(), writes[0].PortsSeenAt)
+	require.NotNil(t, writes[0].Log)
+	assert.Contains(t, writes[0].Log.Content, "Updated from nmap scan lan.xml")
+
+	create := result.Assets[1]
+	assert.Equal(t, model.InventoryActionCreate, create.Action)
+	assert.Equal(t, "printer.lan", create.Name)
+	assert.Equal(t, []string{"ports: 631/tcp (ipp)"}, create.Changes)
+	assert.Equal(t, "printer.lan", *writes[1].Create.Hostname)
+	assert.JSONEq(t, `{"ip_address": "10.0.0.23"}`, string(*writes[1].Create.Metadata))
+
+	// Only assets in the scanned network are flagged
+	require.Len(t, result.NotSeen, 1)
+	assert.Equal(t, model.InventoryAssetResult{Source: "10.0.0.9", Action: model.InventoryActionNotSeen, ID: &oldPi.ID, Name: "old-pi"}, result.NotSeen[0])
+}
+
+// TestPlanNmapImport_Unchanged still writes known assets to mark their ports seen
+func TestPlanNmapImport_Unchanged(t *testing.T) {
+	hosts, _, err := parseNmapFiles([]model.InventoryFile{{Name: "udp.xml", Data: []byte(testNmapUDPScan)}})
+	require.NoError(t, err)
+
+	nas := &model.Asset{ID: uuid.New(), Name: "nas", Hostname: stringPtr("10.0.0.5")}
+	ports := []*model.AssetPort{{AssetID: nas.ID, Port: 161, Protocol: model.PortProtocolUDP}}
+
+	writes, _, result, err := planNmapImport(hosts, []*model.Asset{nas}, ports, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Empty(t, result.NotSeen)
+	require.Len(t, writes, 1)
+	assert.Nil(t, writes[0].Update)
+	assert.Nil(t, writes[0].Log)
+	assert.Len(t, writes[0].Ports, 1)
+}
+
+// TestNmapPlanHash is stable for the same plan and changes with the assets
+func TestNmapPlanHash(t *testing.T) {
+	hosts, scope, err := parseNmapFiles([]model.InventoryFile{{Name: "lan.xml", Data: []byte(testNmapScan)}})
+	require.NoError(t, err)
+
+	nas := &model.Asset{ID: uuid.New(), Name: "nas", Hostname: stringPtr("10.0.0.5")}
+	plan := func() string {
+		_, _, result, err := planNmapImport(hosts, []*model.Asset{nas}, nil, scope)
+		require.NoError(t, err)
+		hash, err := nmapPlanHash(result)
+		require.NoError(t, err)
+		return hash
+	}
+
+	reviewed := plan()
+	assert.Len(t, reviewed, 64)
+	assert.Equal(t, reviewed, plan())
+
+	// The asset was renamed after the dry run
+	nas.Name = "nas-01"
+	assert.NotEqual(t, reviewed, plan())
+}
+
+// TestAcceptNmapHosts writes only the accepted hosts and skips the others
+func TestAcceptNmapHosts(t *testing.T) {
+	hosts, scope, err := parseNmapFiles([]model.InventoryFile{{Name: "lan.xml", Data: []byte(testNmapScan)}})
+	require.NoError(t, err)
+
+	nas := &model.Asset{ID: uuid.New(), Name: "nas", Hostname: stringPtr("10.0.0.4"),
+		Metadata: json.RawMessage(`{"mac_address": "00:11:32:AA:BB:CC"}`)}
+	writes, writeResults, result, err := planNmapImport(hosts, []*model.Asset{nas}, nil, scope)
+	require.NoError(t, err)
+	require.Len(t, writes, 2)
+
+	accepted, acceptedResults, err := acceptNmapHosts(writes, writeResults, result,
+		map[netip.Addr]bool{netip.MustParseAddr("10.0.0.23"): true})
+	require.NoError(t, err)
+
+	require.Len(t, accepted, 1)
+	assert.Equal(t, "printer.lan", accepted[0].Create.Name)
+	assert.Equal(t, []int{1}, acceptedResults)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 0, result.Updated)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, model.InventoryActionSkipped, result.Assets[0].Action)
+	assert.Empty(t, result.Assets[0].Changes)
+
+	// Accepted addresses must be hosts of the scan
+	writes, writeResults, result, err = planNmapImport(hosts, []*model.Asset{nas}, nil, scope)
+	require.NoError(t, err)
+	_, _, err = acceptNmapHosts(writes, writeResults, result, map[netip.Addr]bool{netip.MustParseAddr("10.0.0.99"): true})
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, "accept", httpErr.Errors[0].Field)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// PortService manages the network ports assets expose
+type PortService struct {
+	portRepo  *repository.PortRepository
+	assetRepo *repository.AssetRepository
+}
+
+func NewPortService(portRepo *repository.PortRepository, assetRepo *repository.AssetRepository) *PortService {
+	return &PortService{
+		portRepo:  portRepo,
+		assetRepo: assetRepo,
+	}
+}
+
+// normalizePortService trims a service name, dropping it when blank
+func normalizePortService(service *string) *string {
+	if service == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*service)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// validatePort checks a port number and protocol, lowercasing the protocol
+func validatePort(port *int, protocol *string) error {
+	var fieldErrors []errs.FieldError
+	if port != nil && (*port < 1 || *port > 65535) {
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: "port", Error: "must be between 1 and 65535"})
+	}
+	if protocol != nil {
+		*protocol = strings.ToLower(strings.TrimSpace(*protocol))
+		if !model.IsValidPortProtocol(*protocol) {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: "protocol", Error: "must be one of: tcp udp"})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+	return nil
+}
+
+func (s *PortService) ListByAsset(ctx context.Context, userID string, assetID uuid.UUID) (*model.AssetPortListResponse, error) {
+	// Verify asset ownership
+	_, err := s.assetRepo.GetByID(ctx, userID, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ports, err := s.portRepo.ListByAssets(ctx, userID, []uuid.UUID{assetID})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewAssetPortListResponse(ports), nil
+}
+
+func (s *PortService) Create(ctx context.Context, userID string, assetID uuid.UUID, req *model.CreateAssetPortRequest) (*model.AssetPort, error) {
+	// Verify asset ownership
+	_, err := s.assetRepo.GetByID(ctx, userID, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Protocol == "" {
+		req.Protocol = model.PortProtocolTCP
+	}
+	if err := validatePort(&req.Port, &req.Protocol); err != nil {
+		return nil, err
+	}
+	req.Service = normalizePortService(req.Service)
+
+	return s.portRepo.Create(ctx, userID, assetID, req)
+}
+
+func (s *PortService) Update(ctx context.Context, userID string, portID uuid.UUID, req *model.UpdateAssetPortRequest) (*model.AssetPort, error) {
+	if err := validatePort(req.Port, req.Protocol); err != nil {
+		return nil, err
+	}
+	if req.Service != nil {
+		// An empty service clears it
+		trimmed := strings.TrimSpace(*req.Service)
+		req.Service = &trimmed
+	}
+
+	return s.portRepo.Update(ctx, userID, portID, req)
+}
+
+func (s *PortService) Delete(ctx context.Context, userID string, portID uuid.UUID) error {
+	return s.portRepo.Delete(ctx, userID, portID)
+}
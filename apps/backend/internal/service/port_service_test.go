@@ -0,0 +1,46 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+)
+
+// TestPortService_Constructor verifies NewPortService works correctly
+func TestPortService_Constructor(t *testing.T) {
+	service := NewPortService(nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestValidatePort checks the port range and lowercases the protocol
+func TestValidatePort(t *testing.T) {
+	port := 9100
+	protocol := " TCP "
+	require.NoError(t, validatePort(&port, &protocol))
+	assert.Equal(t, "tcp", protocol)
+
+	// Partial updates validate only what is set
+	require.NoError(t, validatePort(nil, nil))
+
+	port = 0
+	protocol = "sctp"
+	err := validatePort(&port, &protocol)
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	require.Len(t, httpErr.Errors, 2)
+	assert.Equal(t, "port", httpErr.Errors[0].Field)
+	assert.Equal(t, "protocol", httpErr.Errors[1].Field)
+}
+
+// TestNormalizePortService trims service names and drops blank ones
+func TestNormalizePortService(t *testing.T) {
+	assert.Nil(t, normalizePortService(nil))
+	assert.Nil(t, normalizePortService(stringPtr("  ")))
+	assert.Equal(t, "node_exporter", *normalizePortService(stringPtr(" node_exporter ")))
+}
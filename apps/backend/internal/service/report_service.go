@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"ark/internal/errs"
+	"ark/internal/lib/email"
+	"ark/internal/lib/job"
+	"ark/internal/model"
+	"ark/internal/repository"
+	"ark/internal/server"
+)
+
+const (
+	// staleAssetScanSchedule sends the stale asset report on Monday mornings
+	staleAssetScanSchedule = "0 8 * * 1"
+
+	// staleAssetEmailLimit caps the assets listed in a report email; the rest are counted
+	staleAssetEmailLimit = 25
+)
+
+type ReportService struct {
+	server     *server.Server
+	reportRepo *repository.ReportRepository
+	views      *SavedViewService
+	auth       *AuthService
+}
+
+func NewReportService(s *server.Server, reportRepo *repository.ReportRepository, views *SavedViewService, auth *AuthService) *ReportService {
+	return &ReportService{
+		server:     s,
+		reportRepo: reportRepo,
+		views:      views,
+		auth:       auth,
+	}
+}
+
+// RegisterJobs registers the stale asset scan handler and schedules it weekly
+func (s *ReportService) RegisterJobs(j *job.JobService) error {
+	j.HandleFunc(job.TaskStaleAssetScan, s.handleStaleAssetScanTask)
+
+	// Unique keeps the scan from running once per API instance
+	_, err := j.Schedule(staleAssetScanSchedule, job.NewStaleAssetScanTask(), asynq.Unique(time.Hour))
+	return err
+}
+
+// StaleAssets lists the assets with no logs and no updates in params.Days
+// days, limited to the assets of the saved asset view params.ViewID when set
+func (s *ReportService) StaleAssets(ctx context.Context, userID string, params *model.StaleAssetQueryParams) (*model.StaleAssetReport, error) {
+	params.SetDefaults()
+
+	if params.Days < 1 || params.Days > model.MaxStaleAssetDays {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "days", Error: fmt.Sprintf("must be between 1 and %d", model.MaxStaleAssetDays)},
+		}, nil)
+	}
+	if params.Limit < 1 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "limit", Error: "must be at least 1"},
+		}, nil)
+	}
+
+	var filter *model.AssetQueryParams
+	if params.ViewID != nil {
+		var err error
+		filter, err = s.views.AssetFilter(ctx, userID, *params.ViewID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	assets, total, err := s.reportRepo.StaleAssets(ctx, userID, params.Days, params.Limit, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.StaleAssetReport{
+		Days:   params.Days,
+		Assets: assets,
+		Total:  total,
+	}, nil
+}
+
+// staleAssetEmailItems converts stale assets to the rows of the report email
+func staleAssetEmailItems(assets []model.StaleAsset) []email.StaleAssetItem {
+	items := make([]email.StaleAssetItem, 0, len(assets))
+	for _, asset := range assets {
+		item := email.StaleAssetItem{
+			Name:           asset.Name,
+			LastActivityAt: asset.LastActivityAt,
+			DaysStale:      asset.DaysStale,
+		}
+		if asset.Type != nil {
+			item.Type = *asset.Type
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// handleStaleAssetScanTask enqueues one stale asset report email per user with stale assets
+func (s *ReportService) handleStaleAssetScanTask(ctx context.Context, _ *asynq.Task) error {
+	logger := s.server.Logger
+
+	userIDs, err := s.reportRepo.ListUsersWithStaleAssets(ctx, model.DefaultStaleAssetDays)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		// A failure for one user must not fail the scan: asynq would retry it
+		// and email every user handled so far again
+		assets, total, err := s.reportRepo.StaleAssets(ctx, userID, model.DefaultStaleAssetDays, staleAssetEmailLimit, nil)
+		if err != nil {
+			logger.Error().Err(err).Str("user_id", userID).Msg("Failed to list stale assets for report")
+			continue
+		}
+		if total == 0 {
+			continue
+		}
+
+		to, err := s.auth.GetUserEmail(ctx, userID)
+		if err != nil {
+			logger.Error().Err(err).Str("user_id", userID).Msg("Failed to look up email for stale asset report")
+			continue
+		}
+
+		task, err := job.NewStaleAssetsReportTask(to, model.DefaultStaleAssetDays, int(total), staleAssetEmailItems(assets))
+		if err != nil {
+			logger.Error().Err(err).Str("user_id", userID).Msg("Failed to build stale asset report")
+			continue
+		}
+
+		if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil {
+			logger.Error().Err(err).Str("user_id", userID).Msg("Failed to enqueue stale asset report")
+			continue
+		}
+	}
+
+	logger.Info().Int("users", len(userIDs)).Msg("Stale asset report scan complete")
+	return nil
+}
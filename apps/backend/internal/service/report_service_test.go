@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestReportService_Constructor verifies NewReportService works correctly
+func TestReportService_Constructor(t *testing.T) {
+	service := NewReportService(nil, nil, nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestReportService_StaleAssets_InvalidDays rejects periods outside 1..MaxStaleAssetDays
+// before touching the repository
+func TestReportService_StaleAssets_InvalidDays(t *testing.T) {
+	service := NewReportService(nil, nil, nil, nil)
+
+	for _, days := range []int{-1, model.MaxStaleAssetDays + 1} {
+		_, err := service.StaleAssets(context.Background(), "user-123", &model.StaleAssetQueryParams{Days: days})
+
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		require.NotEmpty(t, httpErr.Errors)
+		assert.Equal(t, "days", httpErr.Errors[0].Field)
+		assert.Equal(t, fmt.Sprintf("must be between 1 and %d", model.MaxStaleAssetDays), httpErr.Errors[0].Error)
+	}
+}
+
+// TestStaleAssetEmailItems flattens optional asset types for the email template
+func TestStaleAssetEmailItems(t *testing.T) {
+	now := time.Now()
+	items := staleAssetEmailItems([]model.StaleAsset{
+		{Name: "old-nas", Type: stringPtr("nas"), LastActivityAt: now, DaysStale: 120},
+		{Name: "spare-pi", LastActivityAt: now, DaysStale: 95},
+	})
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "nas", items[0].Type)
+	assert.Equal(t, 120, items[0].DaysStale)
+	assert.Equal(t, "", items[1].Type)
+	assert.Equal(t, "spare-pi", items[1].Name)
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+type RunbookService struct {
+	runbookRepo *repository.RunbookRepository
+	assetRepo   *repository.AssetRepository
+	stats       *StatsService
+}
+
+func NewRunbookService(runbookRepo *repository.RunbookRepository, assetRepo *repository.AssetRepository, stats *StatsService) *RunbookService {
+	return &RunbookService{
+		runbookRepo: runbookRepo,
+		assetRepo:   assetRepo,
+		stats:       stats,
+	}
+}
+
+// processAssetTypes trims and de-duplicates asset types, preserving order
+func processAssetTypes(types []string) []string {
+	seen := make(map[string]bool)
+	processed := make([]string, 0, len(types))
+	for _, t := range types {
+		clean := strings.TrimSpace(t)
+		if clean != "" && !seen[clean] {
+			seen[clean] = true
+			processed = append(processed, clean)
+		}
+	}
+	return processed
+}
+
+// validateRunbookSteps checks that a runbook has between 1 and MaxRunbookSteps titled steps
+func validateRunbookSteps(steps []model.RunbookStepInput) error {
+	if len(steps) == 0 {
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "steps", Error: "at least one step is required"},
+		}, nil)
+	}
+	if len(steps) > model.MaxRunbookSteps {
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "steps", Error: fmt.Sprintf("must not have more than %d steps", model.MaxRunbookSteps)},
+		}, nil)
+	}
+
+	var fieldErrors []errs.FieldError
+	for i, step := range steps {
+		if strings.TrimSpace(step.Title) == "" {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: fmt.Sprintf("steps[%d].title", i),
+				Error: "is required",
+			})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	return nil
+}
+
+func (s *RunbookService) List(ctx context.Context, userID string, params *model.RunbookQueryParams) (*model.RunbookListResponse, error) {
+	runbooks, err := s.runbookRepo.List(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRunbookListResponse(runbooks), nil
+}
+
+// ListForAsset returns the runbooks that can be run against an asset
+func (s *RunbookService) ListForAsset(ctx context.Context, userID string, assetID uuid.UUID) (*model.RunbookListResponse, error) {
+	// Verify asset ownership
+	asset, err := s.assetRepo.GetByID(ctx, userID, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	runbooks, err := s.runbookRepo.ListForAsset(ctx, userID, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRunbookListResponse(runbooks), nil
+}
+
+func (s *RunbookService) GetByID(ctx context.Context, userID string, runbookID uuid.UUID) (*model.Runbook, error) {
+	return s.runbookRepo.GetByID(ctx, userID, runbookID)
+}
+
+func (s *RunbookService) Create(ctx context.Context, userID string, req *model.CreateRunbookRequest) (*model.Runbook, error) {
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "title", Error: "is required"},
+		}, nil)
+	}
+
+	if err := validateRunbookSteps(req.Steps); err != nil {
+		return nil, err
+	}
+
+	if err := s.assetRepo.VerifyOwned(ctx, userID, req.AssetIDs); err != nil {
+		return nil, err
+	}
+
+	req.AssetTypes = processAssetTypes(req.AssetTypes)
+
+	return s.runbookRepo.Create(ctx, userID, req)
+}
+
+func (s *RunbookService) Update(ctx context.Context, userID string, runbookID uuid.UUID, req *model.UpdateRunbookRequest) (*model.Runbook, error) {
+	if req.Title != nil && strings.TrimSpace(*req.Title) == "" {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "title", Error: "must not be empty"},
+		}, nil)
+	}
+
+	if req.Steps != nil {
+		if err := validateRunbookSteps(*req.Steps); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.AssetIDs != nil {
+		if err := s.assetRepo.VerifyOwned(ctx, userID, *req.AssetIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.AssetTypes != nil {
+		processed := processAssetTypes(*req.AssetTypes)
+		req.AssetTypes = &processed
+	}
+
+	return s.runbookRepo.Update(ctx, userID, runbookID, req)
+}
+
+func (s *RunbookService) Delete(ctx context.Context, userID string, runbookID uuid.UUID) error {
+	return s.runbookRepo.Delete(ctx, userID, runbookID)
+}
+
+// StartRun starts executing a runbook against an asset it applies to
+func (s *RunbookService) StartRun(ctx context.Context, userID string, runbookID uuid.UUID, req *model.StartRunbookRunRequest) (*model.RunbookRun, error) {
+	if req.AssetID == uuid.Nil {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "asset_id", Error: "is required"},
+		}, nil)
+	}
+
+	runbook, err := s.runbookRepo.GetByID(ctx, userID, runbookID)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := s.assetRepo.GetByID(ctx, userID, req.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !runbook.AppliesTo(asset) {
+		return nil, errs.NewBadRequestError("runbook does not apply to this asset", false, nil, nil, nil)
+	}
+
+	return s.runbookRepo.StartRun(ctx, userID, runbook, asset.ID)
+}
+
+func (s *RunbookService) GetRun(ctx context.Context, userID string, runID uuid.UUID) (*model.RunbookRun, error) {
+	return s.runbookRepo.GetRun(ctx, userID, runID)
+}
+
+// UpdateRunStep checks off a step and/or records notes on it
+func (s *RunbookService) UpdateRunStep(ctx context.Context, userID string, runID uuid.UUID, position int, req *model.UpdateRunbookRunStepRequest) (*model.RunbookRun, error) {
+	run, err := s.runbookRepo.GetRun(ctx, userID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.Status != model.RunbookRunStatusInProgress {
+		return nil, errs.NewBadRequestError("runbook run is already finished", false, nil, nil, nil)
+	}
+
+	if position < 1 || position > len(run.Steps) {
+		return nil, errs.NewNotFoundError("runbook run step not found", false, nil)
+	}
+
+	if req.Checked == nil && req.Notes == nil {
+		return nil, errs.NewBadRequestError("checked or notes is required", false, nil, nil, nil)
+	}
+
+	if err := s.runbookRepo.UpdateRunStep(ctx, userID, runID, position, req); err != nil {
+		return nil, err
+	}
+
+	return s.runbookRepo.GetRun(ctx, userID, runID)
+}
+
+// Finish completes a run and records it as a single maintenance log
+func (s *RunbookService) Finish(ctx context.Context, userID string, runID uuid.UUID, req *model.CloseRunbookRunRequest) (*model.CloseRunbookRunResponse, error) {
+	return s.closeRun(ctx, userID, runID, model.RunbookRunStatusCompleted, req)
+}
+
+// Abort stops a run early and records what was done as a single maintenance log
+func (s *RunbookService) Abort(ctx context.Context, userID string, runID uuid.UUID, req *model.CloseRunbookRunRequest) (*model.CloseRunbookRunResponse, error) {
+	return s.closeRun(ctx, userID, runID, model.RunbookRunStatusAborted, req)
+}
+
+func (s *RunbookService) closeRun(ctx context.Context, userID string, runID uuid.UUID, status string, req *model.CloseRunbookRunRequest) (*model.CloseRunbookRunResponse, error) {
+	run, err := s.runbookRepo.GetRun(ctx, userID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.Status != model.RunbookRunStatusInProgress {
+		return nil, errs.NewBadRequestError("runbook run is already finished", false, nil, nil, nil)
+	}
+
+	finishedAt := time.Now()
+	duration := int(finishedAt.Sub(run.StartedAt).Minutes())
+
+	logReq := &model.CreateLogRequest{
+		Kind:            model.LogKindMaintenance,
+		Content:         runbookRunLogContent(run, status, req.Notes),
+		Tags:            []string{"runbook"},
+		DurationMinutes: &duration,
+	}
+
+	closed, log, err := s.runbookRepo.CloseRun(ctx, userID, run, status, req.Notes, logReq, finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.Invalidate(ctx, userID)
+
+	return &model.CloseRunbookRunResponse{
+		Run: closed,
+		Log: model.NewLogResponse(log),
+	}, nil
+}
+
+// runbookRunLogContent renders a run as a markdown checklist for its consolidated log
+func runbookRunLogContent(run *model.RunbookRun, status string, notes *string) string {
+	var b strings.Builder
+
+	checked := 0
+	for _, step := range run.Steps {
+		if step.Checked {
+			checked++
+		}
+	}
+
+	outcome := "Completed"
+	if status == model.RunbookRunStatusAborted {
+		outcome = "Aborted"
+	}
+	fmt.Fprintf(&b, "%s runbook: %s (%d/%d steps)\n\n", outcome, run.Title, checked, len(run.Steps))
+
+	for _, step := range run.Steps {
+		mark := " "
+		if step.Checked {
+			mark = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s\n", mark, step.Title)
+		if step.Notes != nil && strings.TrimSpace(*step.Notes) != "" {
+			for _, line := range strings.Split(strings.TrimSpace(*step.Notes), "\n") {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+	}
+
+	if notes != nil && strings.TrimSpace(*notes) != "" {
+		label := "Notes"
+		if status == model.RunbookRunStatusAborted {
+			label = "Abort reason"
+		}
+		fmt.Fprintf(&b, "\n%s: %s\n", label, strings.TrimSpace(*notes))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestRunbookService_Finish_ReturnsCloseResponse verifies Finish returns CloseRunbookRunResponse DTO
+func TestRunbookService_Finish_ReturnsCloseResponse(t *testing.T) {
+	service := NewRunbookService(nil, nil, nil)
+
+	_ = func() (*model.CloseRunbookRunResponse, error) {
+		return service.Finish(nil, "", uuid.UUID{}, nil)
+	}
+
+	assert.NotNil(t, service)
+}
+
+func TestValidateRunbookSteps(t *testing.T) {
+	assert.NoError(t, validateRunbookSteps([]model.RunbookStepInput{{Title: "Drain node"}}))
+
+	err := validateRunbookSteps(nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*errs.HTTPError).Status)
+
+	tooMany := make([]model.RunbookStepInput, model.MaxRunbookSteps+1)
+	for i := range tooMany {
+		tooMany[i].Title = "step"
+	}
+	assert.Error(t, validateRunbookSteps(tooMany))
+
+	err = validateRunbookSteps([]model.RunbookStepInput{{Title: "ok"}, {Title: "  "}})
+	require.Error(t, err)
+	httpErr := err.(*errs.HTTPError)
+	require.Len(t, httpErr.Errors, 1)
+	assert.Equal(t, "steps[1].title", httpErr.Errors[0].Field)
+}
+
+func TestProcessAssetTypes(t *testing.T) {
+	assert.Equal(t, []string{"nas", "server"}, processAssetTypes([]string{" nas", "server", "nas ", ""}))
+	assert.Equal(t, []string{}, processAssetTypes(nil))
+}
+
+func TestRunbookRunLogContent(t *testing.T) {
+	run := &model.RunbookRun{
+		Title: "Replace failed disk",
+		Steps: []model.RunbookRunStep{
+			{Position: 1, Title: "Identify the failed disk", Checked: true, Notes: stringPtr("sdc, serial ZA1234\nSMART: 48 reallocated")},
+			{Position: 2, Title: "Swap the disk", Checked: true},
+			{Position: 3, Title: "Resilver"},
+		},
+	}
+
+	content := runbookRunLogContent(run, model.RunbookRunStatusCompleted, stringPtr("Resilver running overnight"))
+	expected := strings.Join([]string{
+		"Completed runbook: Replace failed disk (2/3 steps)",
+		"",
+		"- [x] Identify the failed disk",
+		"  sdc, serial ZA1234",
+		"  SMART: 48 reallocated",
+		"- [x] Swap the disk",
+		"- [ ] Resilver",
+		"",
+		"Notes: Resilver running overnight",
+	}, "\n")
+	assert.Equal(t, expected, content)
+
+	aborted := runbookRunLogContent(run, model.RunbookRunStatusAborted, stringPtr("wrong disk size"))
+	assert.True(t, strings.HasPrefix(aborted, "Aborted runbook: Replace failed disk"))
+	assert.True(t, strings.HasSuffix(aborted, "Abort reason: wrong disk size"))
+
+	noNotes := runbookRunLogContent(run, model.RunbookRunStatusCompleted, nil)
+	assert.True(t, strings.HasSuffix(noNotes, "- [ ] Resilver"))
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// viewParamsValidator checks stored view params against the validate tags of
+// the list query params, reporting fields by their JSON name
+var viewParamsValidator = func() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.Split(f.Tag.Get("json"), ",")[0]
+	})
+	return v
+}()
+
+// SavedViewService manages saved views and runs them through the asset and
+// log services, so a view returns exactly what the list endpoint would.
+type SavedViewService struct {
+	viewRepo     *repository.SavedViewRepository
+	assetRepo    *repository.AssetRepository
+	assetService *AssetService
+	logService   *LogService
+}
+
+func NewSavedViewService(viewRepo *repository.SavedViewRepository, assetRepo *repository.AssetRepository, assetService *AssetService, logService *LogService) *SavedViewService {
+	return &SavedViewService{
+		viewRepo:     viewRepo,
+		assetRepo:    assetRepo,
+		assetService: assetService,
+		logService:   logService,
+	}
+}
+
+// decodeViewParams decodes raw view params into dst, rejecting unknown keys,
+// and validates them. Empty params decode to the zero value.
+func decodeViewParams(raw json.RawMessage, dst any) error {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "params", Error: "must be an object of list filters: " + err.Error()},
+		}, nil)
+	}
+
+	err := viewParamsValidator.Struct(dst)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fmt.Errorf("validate view params: %w", err)
+	}
+
+	fieldErrors := make([]errs.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		msg := "is invalid"
+		switch fe.Tag() {
+		case "oneof":
+			msg = "must be one of: " + fe.Param()
+		case "min":
+			msg = "must be at least " + fe.Param()
+		case "max":
+			msg = "must not exceed " + fe.Param()
+		}
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: "params." + fe.Field(), Error: msg})
+	}
+	return errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+}
+
+// normalizeViewParams validates raw params for the target and returns them
+// re-encoded, so stored params only hold known filters
+func normalizeViewParams(target string, raw json.RawMessage) (json.RawMessage, error) {
+	var params any
+	switch target {
+	case model.SavedViewTargetAssets:
+		params = &model.AssetQueryParams{}
+	case model.SavedViewTargetLogs:
+		params = &model.LogQueryParams{}
+	default:
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "target", Error: "must be one of: assets logs"},
+		}, nil)
+	}
+
+	if err := decodeViewParams(raw, params); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal view params: %w", err)
+	}
+	return data, nil
+}
+
+// validateViewAsset checks that log views name an asset and asset views don't
+func validateViewAsset(target string, assetID *uuid.UUID) error {
+	if target == model.SavedViewTargetLogs && assetID == nil {
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "asset_id", Error: "is required for log views"},
+		}, nil)
+	}
+	if target == model.SavedViewTargetAssets && assetID != nil {
+		return errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "asset_id", Error: "only applies to log views"},
+		}, nil)
+	}
+	return nil
+}
+
+func (s *SavedViewService) List(ctx context.Context, userID string) (*model.SavedViewListResponse, error) {
+	views, err := s.viewRepo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewSavedViewListResponse(views), nil
+}
+
+func (s *SavedViewService) GetByID(ctx context.Context, userID string, viewID uuid.UUID) (*model.SavedView, error) {
+	return s.viewRepo.GetByID(ctx, userID, viewID)
+}
+
+func (s *SavedViewService) Create(ctx context.Context, userID string, req *model.CreateSavedViewRequest) (*model.SavedView, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "name", Error: "is required"},
+		}, nil)
+	}
+
+	params, err := normalizeViewParams(req.Target, req.Params)
+	if err != nil {
+		return nil, err
+	}
+	req.Params = params
+
+	if err := validateViewAsset(req.Target, req.AssetID); err != nil {
+		return nil, err
+	}
+
+	// Verify asset ownership
+	if req.AssetID != nil {
+		if _, err := s.assetRepo.GetByID(ctx, userID, *req.AssetID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.viewRepo.Create(ctx, userID, req)
+}
+
+func (s *SavedViewService) Update(ctx context.Context, userID string, viewID uuid.UUID, req *model.UpdateSavedViewRequest) (*model.SavedView, error) {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+				{Field: "name", Error: "must not be empty"},
+			}, nil)
+		}
+		req.Name = &name
+	}
+
+	// Params and asset are validated against the stored target
+	if req.Params != nil || req.AssetID != nil {
+		existing, err := s.viewRepo.GetByID(ctx, userID, viewID)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Params != nil {
+			params, err := normalizeViewParams(existing.Target, *req.Params)
+			if err != nil {
+				return nil, err
+			}
+			req.Params = &params
+		}
+
+		if req.AssetID != nil {
+			if err := validateViewAsset(existing.Target, req.AssetID); err != nil {
+				return nil, err
+			}
+			if _, err := s.assetRepo.GetByID(ctx, userID, *req.AssetID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.viewRepo.Update(ctx, userID, viewID, req)
+}
+
+func (s *SavedViewService) Delete(ctx context.Context, userID string, viewID uuid.UUID) error {
+	return s.viewRepo.Delete(ctx, userID, viewID)
+}
+
+// Run executes a saved view and returns the list response of its target:
+// *model.AssetListResponse for asset views and *model.LogListResponse for log
+// views. Non-zero page values override the limit and offset stored in the view.
+func (s *SavedViewService) Run(ctx context.Context, userID string, viewID uuid.UUID, page *model.RunSavedViewParams) (any, error) {
+	view, err := s.viewRepo.GetByID(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch view.Target {
+	case model.SavedViewTargetAssets:
+		params, err := assetViewParams(view, page)
+		if err != nil {
+			return nil, err
+		}
+		return s.assetService.List(ctx, userID, params)
+	case model.SavedViewTargetLogs:
+		params, err := logViewParams(view, page)
+		if err != nil {
+			return nil, err
+		}
+		return s.logService.ListByAsset(ctx, userID, *view.AssetID, params)
+	default:
+		return nil, fmt.Errorf("saved view %s has unknown target %q", view.ID, view.Target)
+	}
+}
+
+// AssetFilter returns the filters of an asset view for endpoints that select
+// assets by view_id: the asset CSV export, the stale asset report and the
+// account export. Limit, offset and sorting are left to the caller.
+func (s *SavedViewService) AssetFilter(ctx context.Context, userID string, viewID uuid.UUID) (*model.AssetQueryParams, error) {
+	view, err := s.viewRepo.GetByID(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	return assetViewFilter(view)
+}
+
+// assetViewFilter decodes the filters of a view given as view_id, which must
+// target assets
+func assetViewFilter(view *model.SavedView) (*model.AssetQueryParams, error) {
+	if view.Target != model.SavedViewTargetAssets {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "view_id", Error: "must be an asset view"},
+		}, nil)
+	}
+
+	params, err := assetViewParams(view, nil)
+	if err != nil {
+		return nil, err
+	}
+	params.SetDefaults()
+	params.Tags = processTags(params.Tags)
+	return params, nil
+}
+
+// assetViewParams decodes the filters of an asset view
+func assetViewParams(view *model.SavedView, page *model.RunSavedViewParams) (*model.AssetQueryParams, error) {
+	var params model.AssetQueryParams
+	if err := decodeViewParams(view.Params, &params); err != nil {
+		return nil, err
+	}
+	if page != nil {
+		applyViewPage(&params.Limit, &params.Offset, page)
+	}
+	return &params, nil
+}
+
+// logViewParams decodes the filters of a log view; the logs belong to view.AssetID
+func logViewParams(view *model.SavedView, page *model.RunSavedViewParams) (*model.LogQueryParams, error) {
+	var params model.LogQueryParams
+	if err := decodeViewParams(view.Params, &params); err != nil {
+		return nil, err
+	}
+	if page != nil {
+		applyViewPage(&params.Limit, &params.Offset, page)
+	}
+	return &params, nil
+}
+
+// applyViewPage overrides a stored limit and offset with non-zero page values
+func applyViewPage(limit, offset *int, page *model.RunSavedViewParams) {
+	if page.Limit > 0 {
+		*limit = page.Limit
+	}
+	if page.Offset > 0 {
+		*offset = page.Offset
+	}
+}
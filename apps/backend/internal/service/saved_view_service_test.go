@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestSavedViewService_Constructor verifies NewSavedViewService works correctly
+func TestSavedViewService_Constructor(t *testing.T) {
+	service := NewSavedViewService(nil, nil, nil, nil)
+
+	assert.NotNil(t, service)
+
+	_ = func() (any, error) {
+		return service.Run(nil, "", uuid.UUID{}, nil)
+	}
+}
+
+// TestNormalizeViewParams covers decoding, validation and re-encoding of view params
+func TestNormalizeViewParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		params  string
+		want    string
+		field   string
+		wantErr bool
+	}{
+		{name: "empty params", target: model.SavedViewTargetAssets, params: "", want: `{}`},
+		{
+			name:   "asset filters",
+			target: model.SavedViewTargetAssets,
+			params: `{"type": "vm", "tags": ["prod"], "updated_within_days": 30}`,
+			want:   `{"type":"vm","tags":["prod"],"updated_within_days":30}`,
+		},
+		{
+			name:   "log filters",
+			target: model.SavedViewTargetLogs,
+			params: `{"tags_any": ["zfs", "btrfs"], "tags_none": ["resolved"]}`,
+			want:   `{"tags_any":["zfs","btrfs"],"tags_none":["resolved"]}`,
+		},
+		{name: "unknown key", target: model.SavedViewTargetAssets, params: `{"kind": "note"}`, wantErr: true, field: "params"},
+		{name: "not an object", target: model.SavedViewTargetLogs, params: `["zfs"]`, wantErr: true, field: "params"},
+		{name: "invalid sort", target: model.SavedViewTargetAssets, params: `{"sort_by": "hostname"}`, wantErr: true, field: "params.sort_by"},
+		{name: "empty window", target: model.SavedViewTargetLogs, params: `{"created_within_days": 0}`, wantErr: true, field: "params.created_within_days"},
+		{name: "unknown target", target: "incidents", params: `{}`, wantErr: true, field: "target"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := normalizeViewParams(tc.target, json.RawMessage(tc.params))
+			if !tc.wantErr {
+				require.NoError(t, err)
+				assert.JSONEq(t, tc.want, string(got))
+				return
+			}
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			require.NotEmpty(t, httpErr.Errors)
+			assert.Equal(t, tc.field, httpErr.Errors[0].Field)
+		})
+	}
+}
+
+// TestValidateViewAsset requires an asset for log views only
+func TestValidateViewAsset(t *testing.T) {
+	assetID := uuid.New()
+
+	assert.NoError(t, validateViewAsset(model.SavedViewTargetAssets, nil))
+	assert.NoError(t, validateViewAsset(model.SavedViewTargetLogs, &assetID))
+	assert.Error(t, validateViewAsset(model.SavedViewTargetLogs, nil))
+	assert.Error(t, validateViewAsset(model.SavedViewTargetAssets, &assetID))
+}
+
+// TestAssetViewParams applies non-zero page values over the stored ones
+func TestAssetViewParams(t *testing.T) {
+	view := &model.SavedView{
+		Target: model.SavedViewTargetAssets,
+		Params: json.RawMessage(`{"type": "vm", "limit": 10, "offset": 20}`),
+	}
+
+	params, err := assetViewParams(view, &model.RunSavedViewParams{Limit: 50})
+	require.NoError(t, err)
+	assert.Equal(t, "vm", *params.Type)
+	assert.Equal(t, 50, params.Limit)
+	assert.Equal(t, 20, params.Offset)
+}
+
+// TestAssetViewFilter resolves view_id to asset filters and refuses log views
+func TestAssetViewFilter(t *testing.T) {
+	view := &model.SavedView{
+		Target: model.SavedViewTargetAssets,
+		Params: json.RawMessage(`{"type": "vm", "tags": [" Prod ", "prod"], "tag_mode": "any"}`),
+	}
+
+	params, err := assetViewFilter(view)
+	require.NoError(t, err)
+	assert.Equal(t, "vm", *params.Type)
+	assert.Equal(t, []string{"prod"}, params.Tags)
+	assert.Equal(t, model.TagModeAny, params.TagMode)
+
+	assetID := uuid.New()
+	_, err = assetViewFilter(&model.SavedView{Target: model.SavedViewTargetLogs, AssetID: &assetID})
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Len(t, httpErr.Errors, 1)
+	assert.Equal(t, "view_id", httpErr.Errors[0].Field)
+	assert.Equal(t, "must be an asset view", httpErr.Errors[0].Error)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"fmt"
+
 	"ark/internal/lib/job"
 	"ark/internal/repository"
 	"ark/internal/server"
@@ -8,23 +10,82 @@ import (
 
 // Services holds all service layer instances
 type Services struct {
-	Auth  *AuthService
-	Job   *job.JobService
-	Asset *AssetService
-	Log   *LogService
+	Auth        *AuthService
+	Job         *job.JobService
+	Asset       *AssetService
+	AssetCSV    *AssetCSVService
+	Log         *LogService
+	LogTemplate *LogTemplateService
+	Incident    *IncidentService
+	Maintenance *MaintenanceService
+	Runbook     *RunbookService
+	Tag         *TagService
+	SavedView   *SavedViewService
+	Report      *ReportService
+	Stats       *StatsService
+	Activity    *ActivityService
+	Export      *ExportService
+	Import      *ImportService
+	Inventory   *InventoryService
+	APIToken    *APITokenService
+	Integration *IntegrationService
+	Port        *PortService
 }
 
 // NewServices creates and initializes all services with their dependencies
 func NewServices(s *server.Server, repos *repository.Repositories) (*Services, error) {
 	// Initialize core services
 	authService := NewAuthService(s)
-	assetService := NewAssetService(repos.Asset)
-	logService := NewLogService(repos.Log, repos.Asset)
+	statsService := NewStatsService(s, repos.Stats, repos.Tag)
+	assetService := NewAssetService(repos.Asset, statsService)
+	logService := NewLogService(repos.Log, repos.Asset, repos.LogTemplate, statsService)
+	logTemplateService := NewLogTemplateService(repos.LogTemplate)
+	incidentService := NewIncidentService(repos.Incident, repos.Log, repos.Asset, statsService)
+	maintenanceService := NewMaintenanceService(s, repos.Maintenance, repos.Asset, authService, statsService)
+	runbookService := NewRunbookService(repos.Runbook, repos.Asset, statsService)
+	tagService := NewTagService(repos.Tag, statsService)
+	savedViewService := NewSavedViewService(repos.SavedView, repos.Asset, assetService, logService)
+	assetCSVService := NewAssetCSVService(repos.Asset, savedViewService, statsService)
+	reportService := NewReportService(s, repos.Report, savedViewService, authService)
+	activityService := NewActivityService(repos.Activity, repos.Asset)
+	exportService := NewExportService(s, repos.Export, savedViewService)
+	importService := NewImportService(repos.Import, statsService)
+	inventoryService := NewInventoryService(repos.Asset, repos.Port, statsService)
+	apiTokenService := NewAPITokenService(repos.APIToken)
+	integrationService := NewIntegrationService(repos.Asset, repos.Port)
+	portService := NewPortService(repos.Port, repos.Asset)
+
+	// Register background jobs owned by services
+	if err := maintenanceService.RegisterJobs(s.Job); err != nil {
+		return nil, fmt.Errorf("register maintenance jobs: %w", err)
+	}
+	if err := reportService.RegisterJobs(s.Job); err != nil {
+		return nil, fmt.Errorf("register report jobs: %w", err)
+	}
+	if err := exportService.RegisterJobs(s.Job); err != nil {
+		return nil, fmt.Errorf("register export jobs: %w", err)
+	}
 
 	return &Services{
-		Job:   s.Job,
-		Auth:  authService,
-		Asset: assetService,
-		Log:   logService,
+		Job:         s.Job,
+		Auth:        authService,
+		Asset:       assetService,
+		AssetCSV:    assetCSVService,
+		Log:         logService,
+		LogTemplate: logTemplateService,
+		Incident:    incidentService,
+		Maintenance: maintenanceService,
+		Runbook:     runbookService,
+		Tag:         tagService,
+		SavedView:   savedViewService,
+		Report:      reportService,
+		Stats:       statsService,
+		Activity:    activityService,
+		Export:      exportService,
+		Import:      importService,
+		Inventory:   inventoryService,
+		APIToken:    apiTokenService,
+		Integration: integrationService,
+		Port:        portService,
 	}, nil
 }
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"ark/internal/model"
+)
+
+// sshConfigHeader opens a generated SSH config
+const sshConfigHeader = "# Generated by Ark from the asset inventory; edits are overwritten.\n"
+
+// sshFallbackVars are the Ansible host variables read when an asset has no
+// ssh_* metadata key of the same meaning
+var sshFallbackVars = map[string]string{
+	model.SSHMetadataUser: "ansible_user",
+	model.SSHMetadataPort: "ansible_port",
+}
+
+// sshAlias turns an asset name into a Host alias, replacing whitespace, %
+// and the pattern characters * ? ! , with dashes. Leading dashes are dropped.
+func sshAlias(name string) string {
+	return strings.TrimLeft(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`*?!,"%`, r) {
+			return '-'
+		}
+		return r
+	}, name), "-")
+}
+
+// sshSafe reports whether a value can be written as a single ssh_config
+// argument: non-empty, without whitespace, quotes or control characters. A
+// leading dash could be read as a command line option when ssh passes the
+// value on, as with ProxyJump, and ssh expands % tokens, so both are refused.
+func sshSafe(value string) bool {
+	if value == "" || strings.HasPrefix(value, "-") {
+		return false
+	}
+	for _, r := range value {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == '"' || r == '%' {
+			return false
+		}
+	}
+	return true
+}
+
+// sshMetadataValue reads an SSH setting from asset metadata, falling back to
+// the matching Ansible host variable. Values sshSafe rejects are ignored.
+func sshMetadataValue(metadata map[string]json.RawMessage, key string) string {
+	keys := []string{key}
+	if fallback, ok := sshFallbackVars[key]; ok {
+		keys = append(keys, fallback)
+	}
+	for _, k := range keys {
+		raw, ok := metadata[k]
+		if !ok {
+			continue
+		}
+		if value := metadataCell(raw); sshSafe(value) {
+			return value
+		}
+	}
+	return ""
+}
+
+// renderSSHConfig writes a Host block per asset with a hostname, aliased by
+// the asset's name. Metadata sets User, Port and ProxyJump; a jump host naming
+// another asset is written as that asset's alias. Hosts are sorted by alias.
+func renderSSHConfig(assets []*model.Asset) []byte {
+	type sshHost struct {
+		alias    string
+		hostname string
+		metadata map[string]json.RawMessage
+	}
+
+	// Aliases are unique; later assets sharing a name get their ID appended
+	aliases := make(map[string]string, len(assets))
+	taken := make(map[string]bool, len(assets))
+	hosts := make([]sshHost, 0, len(assets))
+	for _, asset := range assets {
+		if asset.Hostname == nil || !sshSafe(*asset.Hostname) {
+			continue
+		}
+		alias := sshAlias(asset.Name)
+		if alias == "" {
+			alias = "asset-" + asset.ID.String()[:8]
+		}
+		if taken[alias] {
+			alias = fmt.Sprintf("%s-%s", alias, asset.ID.String()[:8])
+		}
+		taken[alias] = true
+		if _, ok := aliases[asset.Name]; !ok {
+			aliases[asset.Name] = alias
+		}
+		hosts = append(hosts, sshHost{alias: alias, hostname: *asset.Hostname, metadata: decodeAssetMetadata(asset.Metadata)})
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].alias < hosts[j].alias })
+
+	var b strings.Builder
+	b.WriteString(sshConfigHeader)
+	for _, host := range hosts {
+		fmt.Fprintf(&b, "\nHost %s\n    HostName %s\n", host.alias, host.hostname)
+		if user := sshMetadataValue(host.metadata, model.SSHMetadataUser); user != "" {
+			fmt.Fprintf(&b, "    User %s\n", user)
+		}
+		if port, err := strconv.Atoi(sshMetadataValue(host.metadata, model.SSHMetadataPort)); err == nil && port >= 1 && port <= 65535 {
+			fmt.Fprintf(&b, "    Port %d\n", port)
+		}
+		// The jump host may name an asset, spaces and all
+		jump := strings.TrimSpace(metadataCell(host.metadata[model.SSHMetadataJumpHost]))
+		if alias, ok := aliases[jump]; ok {
+			jump = alias
+		}
+		if sshSafe(jump) && jump != host.alias {
+			fmt.Fprintf(&b, "    ProxyJump %s\n", jump)
+		}
+	}
+
+	return []byte(b.String())
+}
+
+// SSHConfig renders the user's assets with a hostname, narrowed by req, as an
+// ~/.ssh/config snippet
+func (s *IntegrationService) SSHConfig(ctx context.Context, userID string, req *model.GeneratedFileRequest) ([]byte, error) {
+	assets, err := s.listAssets(ctx, userID, req.Type, req.Tags, req.TagMode, model.MaxGeneratedFileHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderSSHConfig(assets), nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"ark/internal/model"
+)
+
+// TestSSHAlias replaces characters ssh_config treats as separators or patterns
+func TestSSHAlias(t *testing.T) {
+	assert.Equal(t, "web01", sshAlias("web01"))
+	assert.Equal(t, "Living-Room-Pi", sshAlias("Living Room Pi"))
+	assert.Equal(t, "db--", sshAlias("db*?"))
+	assert.Equal(t, "oProxyCommand=sh", sshAlias("-oProxyCommand=sh"))
+	assert.Equal(t, "web-h", sshAlias("web%h"))
+}
+
+// TestSSHMetadataValue prefers ssh_* keys, falls back to Ansible variables and skips unsafe values
+func TestSSHMetadataValue(t *testing.T) {
+	metadata := decodeAssetMetadata(json.RawMessage(`{"ssh_user": "deploy", "ansible_user": "root", "ansible_port": 2222, "ssh_port": "22 22"}`))
+
+	assert.Equal(t, "deploy", sshMetadataValue(metadata, model.SSHMetadataUser))
+	// An unsafe ssh_port falls back to ansible_port
+	assert.Equal(t, "2222", sshMetadataValue(metadata, model.SSHMetadataPort))
+	assert.Equal(t, "", sshMetadataValue(metadata, model.SSHMetadataJumpHost))
+}
+
+// TestSSHSafe refuses values ssh could read as options or expand
+func TestSSHSafe(t *testing.T) {
+	assert.True(t, sshSafe("deploy"))
+	assert.True(t, sshSafe("bastion.example.com"))
+	assert.False(t, sshSafe(""))
+	assert.False(t, sshSafe("-oProxyCommand=sh"))
+	assert.False(t, sshSafe("host%d"))
+	assert.False(t, sshSafe("two words"))
+	assert.False(t, sshSafe("line\nbreak"))
+}
+
+// TestRenderSSHConfig_HostileMetadata leaves out settings that could inject options
+func TestRenderSSHConfig_HostileMetadata(t *testing.T) {
+	assets := []*model.Asset{
+		{ID: uuid.New(), Name: "web01", Hostname: stringPtr("10.0.0.11"),
+			Metadata: json.RawMessage(`{"ssh_user": "%u-evil", "ssh_jump_host": "-oProxyCommand=touch /tmp/pwned", "ssh_port": "-1"}`)},
+		{ID: uuid.New(), Name: "web02", Hostname: stringPtr("-oProxyCommand=sh")},
+		{ID: uuid.New(), Name: "web03", Hostname: stringPtr("%h.example.com")},
+	}
+
+	assert.Equal(t, sshConfigHeader+`
+Host web01
+    HostName 10.0.0.11
+`, string(renderSSHConfig(assets)))
+}
+
+// TestRenderSSHConfig writes a sorted Host block per asset with a hostname
+func TestRenderSSHConfig(t *testing.T) {
+	dupID := uuid.MustParse("66666666-7777-8888-9999-000000000000")
+	assets := []*model.Asset{
+		{ID: uuid.New(), Name: "web01", Hostname: stringPtr("10.0.0.11"),
+			Metadata: json.RawMessage(`{"ssh_user": "deploy", "ssh_port": "2222", "ssh_jump_host": "Bastion Host"}`)},
+		{ID: uuid.New(), Name: "Bastion Host", Hostname: stringPtr("bastion.example.com"),
+			Metadata: json.RawMessage(`{"ssh_port": 70000}`)},
+		{ID: uuid.New(), Name: "printer"},
+		{ID: dupID, Name: "web01", Hostname: stringPtr("10.0.0.12"),
+			Metadata: json.RawMessage(`{"ssh_jump_host": "web01-66666666"}`)},
+	}
+
+	assert.Equal(t, sshConfigHeader+`
+Host Bastion-Host
+    HostName bastion.example.com
+
+Host web01
+    HostName 10.0.0.11
+    User deploy
+    Port 2222
+    ProxyJump Bastion-Host
+
+Host web01-66666666
+    HostName 10.0.0.12
+`, string(renderSSHConfig(assets)))
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+	"ark/internal/server"
+)
+
+// statsCacheTTL bounds how long cached statistics are served. Writes through the
+// services invalidate the cache sooner; the TTL covers anything they miss, such
+// as logs aging out of the window.
+const statsCacheTTL = 10 * time.Minute
+
+// statsCacheKey is the Redis hash holding a user's cached statistics, one field
+// per days/limit combination, so that one DEL invalidates all of them
+func statsCacheKey(userID string) string {
+	return "stats:" + userID
+}
+
+// statsCacheField identifies one days/limit combination in the cache hash
+func statsCacheField(params *model.StatsQueryParams) string {
+	return strconv.Itoa(params.Days) + ":" + strconv.Itoa(params.Limit)
+}
+
+type StatsService struct {
+	server    *server.Server
+	statsRepo *repository.StatsRepository
+	tagRepo   *repository.TagRepository
+}
+
+func NewStatsService(s *server.Server, statsRepo *repository.StatsRepository, tagRepo *repository.TagRepository) *StatsService {
+	return &StatsService{
+		server:    s,
+		statsRepo: statsRepo,
+		tagRepo:   tagRepo,
+	}
+}
+
+// redis returns the Redis client, or nil when the statistics are not cached
+func (s *StatsService) redis() *redis.Client {
+	if s == nil || s.server == nil {
+		return nil
+	}
+	return s.server.Redis
+}
+
+// Get returns the user's dashboard statistics, from cache when possible
+func (s *StatsService) Get(ctx context.Context, userID string, params *model.StatsQueryParams) (*model.DashboardStats, error) {
+	params.SetDefaults()
+
+	if params.Days < 1 || params.Days > model.MaxStatsDays {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "days", Error: "must be between 1 and 365"},
+		}, nil)
+	}
+	if params.Limit < 1 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "limit", Error: "must be at least 1"},
+		}, nil)
+	}
+
+	if stats := s.cached(ctx, userID, params); stats != nil {
+		return stats, nil
+	}
+
+	stats, err := s.compute(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(ctx, userID, params, stats)
+	return stats, nil
+}
+
+// compute runs the statistics queries
+func (s *StatsService) compute(ctx context.Context, userID string, params *model.StatsQueryParams) (*model.DashboardStats, error) {
+	assets, err := s.statsRepo.AssetCounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	logsPerDay, err := s.statsRepo.LogsPerDay(ctx, userID, params.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	topTags, err := s.tagRepo.List(ctx, userID, &model.TagQueryParams{Limit: params.Limit})
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.statsRepo.MostActiveAssets(ctx, userID, params.Days, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := s.statsRepo.Storage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DashboardStats{
+		Days:             params.Days,
+		Assets:           *assets,
+		LogsPerDay:       logsPerDay,
+		TopTags:          topTags,
+		MostActiveAssets: active,
+		Storage:          *storage,
+		GeneratedAt:      time.Now().UTC(),
+	}, nil
+}
+
+// cached returns the cached statistics, or nil on a miss. Cache errors are
+// logged and treated as a miss so that Redis is never required.
+func (s *StatsService) cached(ctx context.Context, userID string, params *model.StatsQueryParams) *model.DashboardStats {
+	client := s.redis()
+	if client == nil {
+		return nil
+	}
+
+	data, err := client.HGet(ctx, statsCacheKey(userID), statsCacheField(params)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			s.server.Logger.Warn().Err(err).Str("user_id", userID).Msg("Failed to read stats cache")
+		}
+		return nil
+	}
+
+	var stats model.DashboardStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		s.server.Logger.Warn().Err(err).Str("user_id", userID).Msg("Failed to decode stats cache")
+		return nil
+	}
+
+	return &stats
+}
+
+// store caches the statistics; failures are logged
+func (s *StatsService) store(ctx context.Context, userID string, params *model.StatsQueryParams, stats *model.DashboardStats) {
+	client := s.redis()
+	if client == nil {
+		return
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		s.server.Logger.Warn().Err(err).Str("user_id", userID).Msg("Failed to encode stats cache")
+		return
+	}
+
+	key := statsCacheKey(userID)
+	pipe := client.TxPipeline()
+	pipe.HSet(ctx, key, statsCacheField(params), data)
+	pipe.Expire(ctx, key, statsCacheTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.server.Logger.Warn().Err(err).Str("user_id", userID).Msg("Failed to write stats cache")
+	}
+}
+
+// Invalidate drops the user's cached statistics. Services call it after every
+// write that can change them. It is safe to call on a nil StatsService.
+func (s *StatsService) Invalidate(ctx context.Context, userID string) {
+	client := s.redis()
+	if client == nil {
+		return
+	}
+
+	if err := client.Del(ctx, statsCacheKey(userID)).Err(); err != nil {
+		s.server.Logger.Warn().Err(err).Str("user_id", userID).Msg("Failed to invalidate stats cache")
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestStatsService_Constructor verifies NewStatsService works correctly
+func TestStatsService_Constructor(t *testing.T) {
+	service := NewStatsService(nil, nil, nil)
+
+	assert.NotNil(t, service)
+}
+
+// TestStatsService_Get_InvalidDays rejects windows outside 1..MaxStatsDays
+// before touching the cache or the repository
+func TestStatsService_Get_InvalidDays(t *testing.T) {
+	service := NewStatsService(nil, nil, nil)
+
+	for _, days := range []int{-1, model.MaxStatsDays + 1} {
+		_, err := service.Get(context.Background(), "user-123", &model.StatsQueryParams{Days: days})
+
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		require.NotEmpty(t, httpErr.Errors)
+		assert.Equal(t, "days", httpErr.Errors[0].Field)
+	}
+}
+
+// TestStatsService_Invalidate_NoCache is a no-op without a service or Redis client
+func TestStatsService_Invalidate_NoCache(t *testing.T) {
+	var nilService *StatsService
+
+	assert.NotPanics(t, func() {
+		nilService.Invalidate(context.Background(), "user-123")
+		NewStatsService(nil, nil, nil).Invalidate(context.Background(), "user-123")
+	})
+}
+
+// TestStatsCacheField keys cache entries by every parameter that changes the result
+func TestStatsCacheField(t *testing.T) {
+	assert.Equal(t, "stats:user-123", statsCacheKey("user-123"))
+	assert.Equal(t, "30:10", statsCacheField(&model.StatsQueryParams{Days: 30, Limit: 10}))
+	assert.NotEqual(t,
+		statsCacheField(&model.StatsQueryParams{Days: 30, Limit: 10}),
+		statsCacheField(&model.StatsQueryParams{Days: 7, Limit: 10}))
+}
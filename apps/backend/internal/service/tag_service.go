@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+	"ark/internal/repository"
+)
+
+// maxTagLength mirrors the max length of a single tag on logs
+const maxTagLength = 50
+
+type TagService struct {
+	tagRepo *repository.TagRepository
+	stats   *StatsService
+}
+
+func NewTagService(tagRepo *repository.TagRepository, stats *StatsService) *TagService {
+	return &TagService{
+		tagRepo: tagRepo,
+		stats:   stats,
+	}
+}
+
+// validateTag checks a normalized tag used as a rename or merge argument
+func validateTag(field, tag string) *errs.FieldError {
+	if tag == "" {
+		return &errs.FieldError{Field: field, Error: "is required"}
+	}
+	if len(tag) > maxTagLength {
+		return &errs.FieldError{Field: field, Error: fmt.Sprintf("must not exceed %d characters", maxTagLength)}
+	}
+	return nil
+}
+
+// processMergeSources normalizes and de-duplicates source tags, dropping the target
+func processMergeSources(sources []string, target string) []string {
+	seen := map[string]bool{target: true}
+	processed := make([]string, 0, len(sources))
+	for _, tag := range sources {
+		clean := normalizeTag(tag)
+		if clean != "" && !seen[clean] {
+			seen[clean] = true
+			processed = append(processed, clean)
+		}
+	}
+	return processed
+}
+
+func (s *TagService) List(ctx context.Context, userID string, params *model.TagQueryParams) (*model.TagListResponse, error) {
+	params.SetDefaults()
+
+	if params.Prefix != nil {
+		prefix := normalizeTag(*params.Prefix)
+		params.Prefix = &prefix
+	}
+
+	tags, err := s.tagRepo.List(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewTagListResponse(tags), nil
+}
+
+// Rename renames a tag on every log, asset and template. Renaming onto a tag that is
+// already in use merges the two.
+func (s *TagService) Rename(ctx context.Context, userID string, req *model.RenameTagRequest) (*model.TagOperationResponse, error) {
+	from := normalizeTag(req.From)
+	to := normalizeTag(req.To)
+
+	var fieldErrors []errs.FieldError
+	if fe := validateTag("from", from); fe != nil {
+		fieldErrors = append(fieldErrors, *fe)
+	}
+	if fe := validateTag("to", to); fe != nil {
+		fieldErrors = append(fieldErrors, *fe)
+	}
+	if len(fieldErrors) == 0 && from == to {
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: "to", Error: "must differ from from"})
+	}
+	if len(fieldErrors) > 0 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, fieldErrors, nil)
+	}
+
+	return s.merge(ctx, userID, []string{from}, to)
+}
+
+// Merge folds several tags into a target tag on every log, asset and template
+func (s *TagService) Merge(ctx context.Context, userID string, req *model.MergeTagsRequest) (*model.TagOperationResponse, error) {
+	target := normalizeTag(req.Target)
+	if fe := validateTag("target", target); fe != nil {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{*fe}, nil)
+	}
+
+	sources := processMergeSources(req.Sources, target)
+	if len(sources) == 0 {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "sources", Error: "must contain at least one tag other than target"},
+		}, nil)
+	}
+	if len(sources) > model.MaxMergeSourceTags {
+		return nil, errs.NewBadRequestError("Validation failed", false, nil, []errs.FieldError{
+			{Field: "sources", Error: fmt.Sprintf("must not have more than %d tags", model.MaxMergeSourceTags)},
+		}, nil)
+	}
+
+	return s.merge(ctx, userID, sources, target)
+}
+
+func (s *TagService) merge(ctx context.Context, userID string, sources []string, target string) (*model.TagOperationResponse, error) {
+	response, err := s.tagRepo.Merge(ctx, userID, sources, target)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.Invalidate(ctx, userID)
+	return response, nil
+}
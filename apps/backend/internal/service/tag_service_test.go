@@ -0,0 +1,72 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ark/internal/errs"
+	"ark/internal/model"
+)
+
+// TestTagService_Merge_ReturnsTagOperationResponse verifies Merge returns TagOperationResponse DTO
+func TestTagService_Merge_ReturnsTagOperationResponse(t *testing.T) {
+	service := NewTagService(nil, nil)
+
+	_ = func() (*model.TagOperationResponse, error) {
+		return service.Merge(nil, "", nil)
+	}
+
+	assert.NotNil(t, service)
+}
+
+func TestProcessMergeSources(t *testing.T) {
+	got := processMergeSources([]string{" K8s", "kube", "kubernetes", "k8s", ""}, "kubernetes")
+	assert.Equal(t, []string{"k8s", "kube"}, got)
+
+	assert.Empty(t, processMergeSources([]string{"Nginx "}, "nginx"))
+}
+
+// TestTagService_Rename_Validation rejects empty, oversized and identical tags before touching the repository
+func TestTagService_Rename_Validation(t *testing.T) {
+	service := NewTagService(nil, nil)
+
+	tests := []struct {
+		name  string
+		req   model.RenameTagRequest
+		field string
+	}{
+		{"empty from", model.RenameTagRequest{From: " ", To: "nginx"}, "from"},
+		{"empty to", model.RenameTagRequest{From: "ngnix", To: ""}, "to"},
+		{"same after normalizing", model.RenameTagRequest{From: "Nginx", To: " nginx"}, "to"},
+		{"too long", model.RenameTagRequest{From: "ngnix", To: strings.Repeat("n", maxTagLength+1)}, "to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Rename(nil, "user-123", &tt.req)
+			require.Error(t, err)
+			httpErr, ok := err.(*errs.HTTPError)
+			require.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			require.Len(t, httpErr.Errors, 1)
+			assert.Equal(t, tt.field, httpErr.Errors[0].Field)
+		})
+	}
+}
+
+// TestTagService_Merge_Validation rejects a merge without a source other than the target
+func TestTagService_Merge_Validation(t *testing.T) {
+	service := NewTagService(nil, nil)
+
+	_, err := service.Merge(nil, "user-123", &model.MergeTagsRequest{Sources: []string{"NGINX"}, Target: "nginx"})
+	require.Error(t, err)
+	assert.Equal(t, "sources", err.(*errs.HTTPError).Errors[0].Field)
+
+	_, err = service.Merge(nil, "user-123", &model.MergeTagsRequest{Sources: []string{"ngnix"}, Target: " "})
+	require.Error(t, err)
+	assert.Equal(t, "target", err.(*errs.HTTPError).Errors[0].Field)
+}
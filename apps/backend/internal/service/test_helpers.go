@@ -17,6 +17,10 @@ func float64Ptr(f float64) *float64 {
 	return &f
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
@@ -41,6 +41,15 @@ func BindAndValidate(c echo.Context, payload Validatable) error {
 	return nil
 }
 
+// validate is shared by the Validate methods of request models; it caches
+// the validate tags of each struct type it sees
+var validate = validator.New()
+
+// Struct checks v against its validate tags
+func Struct(v any) error {
+	return validate.Struct(v)
+}
+
 func validateStruct(v Validatable) (string, []errs.FieldError) {
 	if err := v.Validate(); err != nil {
 		return extractValidationErrors(err)
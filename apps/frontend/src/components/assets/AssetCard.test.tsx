@@ -12,6 +12,8 @@ const mockAsset: Asset = {
     type: "server",
     hostname: "server.local",
     metadata: {},
+    pinned: false,
+    favorite: false,
     created_at: "2023-01-01T00:00:00Z",
     updated_at: "2023-01-02T00:00:00Z",
 };
@@ -7,8 +7,12 @@ describe("LogEntry", () => {
         id: "log-1",
         asset_id: "asset-1",
         user_id: "user-1",
+        kind: "note" as const,
         content: "Test log content",
         tags: ["tag1", "tag2"],
+        linked_asset_ids: [],
+        pinned: false,
+        favorite: false,
         created_at: new Date().toISOString(),
         updated_at: new Date().toISOString(),
     };
@@ -40,8 +40,12 @@ describe("LogFormModal", () => {
             id: "log-1",
             asset_id: assetId,
             user_id: "user-1",
+            kind: "note" as const,
             content: "Test content",
             tags: ["tag1"],
+            linked_asset_ids: [],
+            pinned: false,
+            favorite: false,
             created_at: new Date().toISOString(),
             updated_at: new Date().toISOString(),
         };
@@ -24,6 +24,8 @@ describe("TypeScript Types", () => {
                 type: "server",
                 hostname: "test.example.com",
                 metadata: { cpu: "Intel i7", ram: "16GB" },
+                pinned: false,
+                favorite: false,
                 created_at: "2024-01-01T00:00:00Z",
                 updated_at: "2024-01-01T00:00:00Z",
             };
@@ -38,8 +40,12 @@ describe("TypeScript Types", () => {
                 id: "123e4567-e89b-12d3-a456-426614174001",
                 asset_id: "123e4567-e89b-12d3-a456-426614174000",
                 user_id: "user_123",
+                kind: "maintenance",
                 content: "Server maintenance completed",
                 tags: ["maintenance", "server"],
+                linked_asset_ids: [],
+                pinned: false,
+                favorite: false,
                 created_at: "2024-01-01T00:00:00Z",
                 updated_at: "2024-01-01T00:00:00Z",
             };
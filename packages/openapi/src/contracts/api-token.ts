@@ -0,0 +1,59 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZAPITokenListResponse,
+    ZCreateAPITokenRequest,
+    ZCreatedAPIToken,
+    ZErrorResponse,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const apiTokenContract = c.router(
+    {
+        listApiTokens: {
+            summary: "List API tokens",
+            path: "/tokens",
+            method: "GET",
+            description: "Get the API tokens of the authenticated user, newest first. The tokens themselves are never listed",
+            responses: {
+                200: ZAPITokenListResponse,
+            },
+            metadata: metadata,
+        },
+
+        createApiToken: {
+            summary: "Create a new API token",
+            path: "/tokens",
+            method: "POST",
+            description: "Create a bearer token for the integration endpoints. The token is only returned by this request",
+            body: ZCreateAPITokenRequest,
+            responses: {
+                201: ZCreatedAPIToken,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        deleteApiToken: {
+            summary: "Revoke API token",
+            path: "/tokens/:id",
+            method: "DELETE",
+            description: "Revoke an API token. It stops working immediately",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                204: z.void(),
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
@@ -1,10 +1,14 @@
 import { getSecurityMetadata } from "../utils.js";
 import {
     ZAsset,
+    ZAssetCSVImportQueryParams,
+    ZAssetCSVImportResult,
+    ZAssetCSVPreview,
     ZAssetListResponse,
     ZCreateAssetRequest,
     ZUpdateAssetRequest,
     ZErrorResponse,
+    ZFile,
     ZUuid,
 } from "@ark/zod";
 import { schemaWithPagination } from "@ark/zod";
@@ -29,6 +33,10 @@ export const assetContract = c.router(
                 search: z.string().max(100).optional(),
                 sort_by: z.enum(["name", "created_at", "updated_at"]).optional(),
                 sort_order: z.enum(["asc", "desc"]).optional(),
+                tags: z.array(z.string().max(50)).optional(),
+                tag_mode: z.enum(["any", "all"]).optional(),
+                favorite: z.boolean().optional(),
+                updated_within_days: z.coerce.number().int().min(1).max(3650).optional(),
             }),
             responses: {
                 200: ZAssetListResponse,
@@ -94,6 +102,68 @@ export const assetContract = c.router(
             },
             metadata: metadata,
         },
+
+        exportAssetsCsv: {
+            summary: "Export assets as CSV",
+            path: "/assets/csv",
+            method: "GET",
+            description: "Export the assets matching the list filters, or the assets of a saved asset view, as a CSV file of at most 5000 rows",
+            query: z.object({
+                type: z.string().max(50).optional(),
+                search: z.string().max(100).optional(),
+                sort_by: z.enum(["name", "created_at", "updated_at"]).optional(),
+                sort_order: z.enum(["asc", "desc"]).optional(),
+                tags: z.array(z.string().max(50)).optional(),
+                tag_mode: z.enum(["any", "all"]).optional(),
+                favorite: z.boolean().optional(),
+                updated_within_days: z.coerce.number().int().min(1).max(3650).optional(),
+                view_id: ZUuid.optional(),
+            }),
+            responses: {
+                200: c.otherResponse({
+                    contentType: "text/csv",
+                    body: z.string(),
+                }),
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        previewAssetsCsv: {
+            summary: "Preview asset CSV import",
+            path: "/assets/csv/preview",
+            method: "POST",
+            description: "Parse an uploaded CSV file and return its columns, a suggested column mapping and the first rows",
+            contentType: "multipart/form-data",
+            body: z.object({
+                file: ZFile,
+            }),
+            responses: {
+                200: ZAssetCSVPreview,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        importAssetsCsv: {
+            summary: "Import assets from CSV",
+            path: "/assets/csv",
+            method: "POST",
+            description: "Create or update assets from an uploaded CSV file using a JSON mapping of CSV column to asset field",
+            contentType: "multipart/form-data",
+            query: ZAssetCSVImportQueryParams,
+            body: z.object({
+                file: ZFile,
+                mapping: z.string().optional(),
+            }),
+            responses: {
+                200: ZAssetCSVImportResult,
+                400: ZErrorResponse,
+                413: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
     },
     {
         pathPrefix: "/api/v1",
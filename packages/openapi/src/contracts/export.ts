@@ -0,0 +1,133 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZAccountExport,
+    ZErrorResponse,
+    ZExportListResponse,
+    ZExportQueryParams,
+    ZFile,
+    ZImportQueryParams,
+    ZImportResult,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const exportContract = c.router(
+    {
+        exportAccount: {
+            summary: "Export account",
+            path: "/export",
+            method: "GET",
+            description: "Download the assets and logs of the account, or of a saved asset view, as a zip archive. Only for accounts small enough to export directly",
+            query: ZExportQueryParams,
+            responses: {
+                200: c.otherResponse({
+                    contentType: "application/zip",
+                    body: ZFile,
+                }),
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        startExport: {
+            summary: "Start account export",
+            path: "/exports",
+            method: "POST",
+            description: "Start building an export archive in the background. Poll the export until it completes, then download it",
+            query: ZExportQueryParams,
+            body: c.noBody(),
+            responses: {
+                202: ZAccountExport,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        listExports: {
+            summary: "List exports",
+            path: "/exports",
+            method: "GET",
+            description: "Get the unexpired exports of the authenticated user, newest first",
+            responses: {
+                200: ZExportListResponse,
+            },
+            metadata: metadata,
+        },
+
+        getExportById: {
+            summary: "Get export by ID",
+            path: "/exports/:id",
+            method: "GET",
+            description: "Get the status of a single export",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZAccountExport,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        downloadExport: {
+            summary: "Download export",
+            path: "/exports/:id/download",
+            method: "GET",
+            description: "Download the archive of a completed export",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: c.otherResponse({
+                    contentType: "application/zip",
+                    body: ZFile,
+                }),
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        deleteExport: {
+            summary: "Delete export",
+            path: "/exports/:id",
+            method: "DELETE",
+            description: "Delete an export and its archive",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                204: z.void(),
+            },
+            metadata: metadata,
+        },
+
+        importAccount: {
+            summary: "Import account archive",
+            path: "/imports",
+            method: "POST",
+            description: "Recreate the assets, logs and incident timelines of an export archive in the account",
+            contentType: "multipart/form-data",
+            query: ZImportQueryParams,
+            body: z.object({
+                file: ZFile,
+            }),
+            responses: {
+                200: ZImportResult,
+                400: ZErrorResponse,
+                413: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
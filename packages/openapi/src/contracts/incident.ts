@@ -0,0 +1,69 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZCreateTimelineEntryRequest,
+    ZErrorResponse,
+    ZIncidentStatsQueryParams,
+    ZIncidentStatsResponse,
+    ZIncidentTimelineResponse,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const incidentContract = c.router(
+    {
+        getIncidentTimeline: {
+            summary: "Get incident timeline",
+            path: "/logs/:id/timeline",
+            method: "GET",
+            description: "Get an incident log with its status changes and notes, oldest first",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZIncidentTimelineResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        addIncidentTimelineEntry: {
+            summary: "Add incident timeline entry",
+            path: "/logs/:id/timeline",
+            method: "POST",
+            description: "Add a status change, a note or both to an incident. Status changes must follow open, mitigated, resolved and may reopen the incident",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZCreateTimelineEntryRequest,
+            responses: {
+                201: ZIncidentTimelineResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getIncidentStats: {
+            summary: "Get incident statistics",
+            path: "/stats/incidents",
+            method: "GET",
+            description: "Get incident counts, MTTR and availability over a time window, per asset, per asset type and over time",
+            query: ZIncidentStatsQueryParams,
+            responses: {
+                200: ZIncidentStatsResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
@@ -2,6 +2,18 @@ import { initContract } from "@ts-rest/core";
 import { healthContract } from "./health.js";
 import { assetContract } from "./asset.js";
 import { logContract } from "./log.js";
+import { incidentContract } from "./incident.js";
+import { maintenanceContract } from "./maintenance.js";
+import { runbookContract } from "./runbook.js";
+import { logTemplateContract } from "./log-template.js";
+import { tagContract } from "./tag.js";
+import { savedViewContract } from "./saved-view.js";
+import { statsContract } from "./stats.js";
+import { exportContract } from "./export.js";
+import { inventoryContract } from "./inventory.js";
+import { apiTokenContract } from "./api-token.js";
+import { portContract } from "./port.js";
+import { integrationContract } from "./integration.js";
 
 const c = initContract();
 
@@ -9,4 +21,16 @@ export const apiContract = c.router({
   System: healthContract,
   Assets: assetContract,
   Logs: logContract,
+  Incidents: incidentContract,
+  Maintenance: maintenanceContract,
+  Runbooks: runbookContract,
+  LogTemplates: logTemplateContract,
+  Tags: tagContract,
+  Views: savedViewContract,
+  Stats: statsContract,
+  Exports: exportContract,
+  Inventory: inventoryContract,
+  Tokens: apiTokenContract,
+  Ports: portContract,
+  Integrations: integrationContract,
 });
@@ -0,0 +1,80 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZAnsibleInventory,
+    ZErrorResponse,
+    ZIntegrationQueryParams,
+    ZPrometheusSDQueryParams,
+    ZPrometheusTargetGroup,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+// Integration endpoints accept an API token as well as a session
+const metadata = getSecurityMetadata();
+
+export const integrationContract = c.router(
+    {
+        getAnsibleInventory: {
+            summary: "Get Ansible dynamic inventory",
+            path: "/integrations/ansible/inventory",
+            method: "GET",
+            description: "Get the assets as an Ansible dynamic inventory, grouped by type and tag, with their metadata as host variables",
+            query: ZIntegrationQueryParams,
+            responses: {
+                200: ZAnsibleInventory,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getPrometheusTargets: {
+            summary: "Get Prometheus service discovery targets",
+            path: "/integrations/prometheus/sd",
+            method: "GET",
+            description: "Get the asset ports as Prometheus HTTP service discovery target groups",
+            query: ZPrometheusSDQueryParams,
+            responses: {
+                200: z.array(ZPrometheusTargetGroup),
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getSshConfig: {
+            summary: "Get SSH config",
+            path: "/integrations/ssh/config",
+            method: "GET",
+            description: "Get an ssh_config file with a Host entry for each asset with a hostname",
+            query: ZIntegrationQueryParams,
+            responses: {
+                200: c.otherResponse({
+                    contentType: "text/plain",
+                    body: z.string(),
+                }),
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getHostsFile: {
+            summary: "Get hosts file",
+            path: "/integrations/hosts",
+            method: "GET",
+            description: "Get an /etc/hosts block for the assets with an IP address as hostname or ip_address metadata",
+            query: ZIntegrationQueryParams,
+            responses: {
+                200: c.otherResponse({
+                    contentType: "text/plain",
+                    body: z.string(),
+                }),
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
@@ -0,0 +1,76 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZAnsibleImportQueryParams,
+    ZComposeImportQueryParams,
+    ZErrorResponse,
+    ZFile,
+    ZInventoryImportResult,
+    ZNmapImportQueryParams,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+// Up to 20 inventory files of at most 1 MB each
+const ZInventoryFiles = z.object({
+    files: z.array(ZFile).min(1).max(20),
+});
+
+export const inventoryContract = c.router(
+    {
+        importCompose: {
+            summary: "Import docker-compose files",
+            path: "/inventory/compose",
+            method: "POST",
+            description: "Create or update a container asset for each service of the uploaded compose files, running on a host asset",
+            contentType: "multipart/form-data",
+            query: ZComposeImportQueryParams,
+            body: ZInventoryFiles,
+            responses: {
+                200: ZInventoryImportResult,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+                413: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        importAnsible: {
+            summary: "Import Ansible inventories",
+            path: "/inventory/ansible",
+            method: "POST",
+            description: "Create or update an asset for each host of the uploaded INI or YAML inventories, with their groups as tags",
+            contentType: "multipart/form-data",
+            query: ZAnsibleImportQueryParams,
+            body: ZInventoryFiles,
+            responses: {
+                200: ZInventoryImportResult,
+                400: ZErrorResponse,
+                413: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        importNmap: {
+            summary: "Import nmap scans",
+            path: "/inventory/nmap",
+            method: "POST",
+            description: "Reconcile the hosts of uploaded nmap XML scans with existing assets. Run with dry_run first, then apply the returned plan with the accepted addresses",
+            contentType: "multipart/form-data",
+            query: ZNmapImportQueryParams,
+            body: ZInventoryFiles,
+            responses: {
+                200: ZInventoryImportResult,
+                400: ZErrorResponse,
+                413: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
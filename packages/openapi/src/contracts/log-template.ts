@@ -0,0 +1,111 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZCreateLogTemplateRequest,
+    ZErrorResponse,
+    ZLogTemplate,
+    ZLogTemplateListResponse,
+    ZRenderLogTemplateRequest,
+    ZRenderLogTemplateResponse,
+    ZUpdateLogTemplateRequest,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const logTemplateContract = c.router(
+    {
+        listLogTemplates: {
+            summary: "List log templates",
+            path: "/log-templates",
+            method: "GET",
+            description: "Get the log templates of the authenticated user, ordered by name",
+            responses: {
+                200: ZLogTemplateListResponse,
+            },
+            metadata: metadata,
+        },
+
+        createLogTemplate: {
+            summary: "Create a new log template",
+            path: "/log-templates",
+            method: "POST",
+            description: "Create a log template whose content references typed placeholders as {{name}}",
+            body: ZCreateLogTemplateRequest,
+            responses: {
+                201: ZLogTemplate,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getLogTemplateById: {
+            summary: "Get log template by ID",
+            path: "/log-templates/:id",
+            method: "GET",
+            description: "Get a single log template by its ID",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZLogTemplate,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        updateLogTemplate: {
+            summary: "Update log template",
+            path: "/log-templates/:id",
+            method: "PATCH",
+            description: "Update an existing log template (partial update)",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZUpdateLogTemplateRequest,
+            responses: {
+                200: ZLogTemplate,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        deleteLogTemplate: {
+            summary: "Delete log template",
+            path: "/log-templates/:id",
+            method: "DELETE",
+            description: "Delete a log template",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                204: z.void(),
+            },
+            metadata: metadata,
+        },
+
+        renderLogTemplate: {
+            summary: "Render log template",
+            path: "/log-templates/:id/render",
+            method: "POST",
+            description: "Render a log template with placeholder values without creating a log",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZRenderLogTemplateRequest,
+            responses: {
+                200: ZRenderLogTemplateResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
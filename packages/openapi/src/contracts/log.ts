@@ -1,12 +1,15 @@
 import { getSecurityMetadata } from "../utils.js";
 import {
     ZAssetLog,
+    ZCreateLogQuery,
     ZCreateLogRequest,
+    ZLogListResponse,
+    ZLogRenderQuery,
+    ZLogThreadResponse,
     ZUpdateLogRequest,
     ZErrorResponse,
     ZUuid,
 } from "@ark/zod";
-import { schemaWithPagination } from "@ark/zod";
 import { initContract } from "@ts-rest/core";
 import { z } from "zod";
 
@@ -33,9 +36,17 @@ export const logContract = c.router(
                 end_date: z.string().datetime().optional(),
                 sort_by: z.enum(["created_at", "updated_at"]).optional(),
                 sort_order: z.enum(["asc", "desc"]).optional(),
+                tags_all: z.array(z.string().max(50)).optional(),
+                tags_any: z.array(z.string().max(50)).optional(),
+                tags_none: z.array(z.string().max(50)).optional(),
+                kind: z.enum(["note", "change", "incident", "maintenance"]).optional(),
+                created_within_days: z.coerce.number().int().min(1).max(3650).optional(),
+                collapse_threads: z.boolean().optional(),
+                favorite: z.boolean().optional(),
+                render: z.enum(["html"]).optional(),
             }),
             responses: {
-                200: schemaWithPagination(ZAssetLog),
+                200: ZLogListResponse,
                 404: ZErrorResponse,
             },
             metadata: metadata,
@@ -45,10 +56,11 @@ export const logContract = c.router(
             summary: "Create a new log for asset",
             path: "/assets/:id/logs",
             method: "POST",
-            description: "Create a new log entry for a specific asset",
+            description: "Create a new log entry for a specific asset, optionally rendered from a log template with placeholder values",
             pathParams: z.object({
                 id: ZUuid,
             }),
+            query: ZCreateLogQuery,
             body: ZCreateLogRequest,
             responses: {
                 201: ZAssetLog,
@@ -66,6 +78,7 @@ export const logContract = c.router(
             pathParams: z.object({
                 id: ZUuid,
             }),
+            query: ZLogRenderQuery,
             responses: {
                 200: ZAssetLog,
                 404: ZErrorResponse,
@@ -73,6 +86,23 @@ export const logContract = c.router(
             metadata: metadata,
         },
 
+        getLogThread: {
+            summary: "Get log thread",
+            path: "/logs/:id/thread",
+            method: "GET",
+            description: "Get the thread a log belongs to: its root log and all replies, oldest first",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            query: ZLogRenderQuery,
+            responses: {
+                200: ZLogThreadResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
         updateLog: {
             summary: "Update log",
             path: "/logs/:id",
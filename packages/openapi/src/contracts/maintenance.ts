@@ -0,0 +1,135 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZCompleteMaintenanceTaskRequest,
+    ZCompleteMaintenanceTaskResponse,
+    ZCreateMaintenanceTaskRequest,
+    ZErrorResponse,
+    ZMaintenanceTask,
+    ZMaintenanceTaskListResponse,
+    ZMaintenanceTaskQueryParams,
+    ZUpdateMaintenanceTaskRequest,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const maintenanceContract = c.router(
+    {
+        listMaintenanceTasks: {
+            summary: "List maintenance tasks",
+            path: "/maintenance-tasks",
+            method: "GET",
+            description: "Get the maintenance tasks of all assets, ordered by next due date",
+            query: ZMaintenanceTaskQueryParams,
+            responses: {
+                200: ZMaintenanceTaskListResponse,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        listMaintenanceTasksByAsset: {
+            summary: "List maintenance tasks for asset",
+            path: "/assets/:id/maintenance-tasks",
+            method: "GET",
+            description: "Get the maintenance tasks of a specific asset, ordered by next due date",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            query: ZMaintenanceTaskQueryParams,
+            responses: {
+                200: ZMaintenanceTaskListResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        createMaintenanceTask: {
+            summary: "Create a maintenance task for asset",
+            path: "/assets/:id/maintenance-tasks",
+            method: "POST",
+            description: "Create a recurring maintenance task with an RFC 5545 RRULE schedule for a specific asset",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZCreateMaintenanceTaskRequest,
+            responses: {
+                201: ZMaintenanceTask,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getMaintenanceTaskById: {
+            summary: "Get maintenance task by ID",
+            path: "/maintenance-tasks/:id",
+            method: "GET",
+            description: "Get a single maintenance task by its ID",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZMaintenanceTask,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        updateMaintenanceTask: {
+            summary: "Update maintenance task",
+            path: "/maintenance-tasks/:id",
+            method: "PATCH",
+            description: "Update an existing maintenance task (partial update)",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZUpdateMaintenanceTaskRequest,
+            responses: {
+                200: ZMaintenanceTask,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        deleteMaintenanceTask: {
+            summary: "Delete maintenance task",
+            path: "/maintenance-tasks/:id",
+            method: "DELETE",
+            description: "Delete a maintenance task",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                204: z.void(),
+            },
+            metadata: metadata,
+        },
+
+        completeMaintenanceTask: {
+            summary: "Complete maintenance task",
+            path: "/maintenance-tasks/:id/complete",
+            method: "POST",
+            description: "Record a maintenance log for the task's asset and schedule the next occurrence",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZCompleteMaintenanceTaskRequest,
+            responses: {
+                200: ZCompleteMaintenanceTaskResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
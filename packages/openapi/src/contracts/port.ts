@@ -0,0 +1,86 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZAssetPort,
+    ZAssetPortListResponse,
+    ZCreateAssetPortRequest,
+    ZErrorResponse,
+    ZUpdateAssetPortRequest,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const portContract = c.router(
+    {
+        listPortsByAsset: {
+            summary: "List ports for asset",
+            path: "/assets/:id/ports",
+            method: "GET",
+            description: "Get the ports of a specific asset, ordered by port and protocol",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZAssetPortListResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        createPort: {
+            summary: "Create a port for asset",
+            path: "/assets/:id/ports",
+            method: "POST",
+            description: "Record a port an asset exposes, with the service behind it",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZCreateAssetPortRequest,
+            responses: {
+                201: ZAssetPort,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        updatePort: {
+            summary: "Update port",
+            path: "/ports/:id",
+            method: "PATCH",
+            description: "Update an existing port (partial update)",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZUpdateAssetPortRequest,
+            responses: {
+                200: ZAssetPort,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        deletePort: {
+            summary: "Delete port",
+            path: "/ports/:id",
+            method: "DELETE",
+            description: "Delete a port",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                204: z.void(),
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
@@ -0,0 +1,201 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZCloseRunbookRunRequest,
+    ZCloseRunbookRunResponse,
+    ZCreateRunbookRequest,
+    ZErrorResponse,
+    ZRunbook,
+    ZRunbookListResponse,
+    ZRunbookQueryParams,
+    ZRunbookRun,
+    ZStartRunbookRunRequest,
+    ZUpdateRunbookRequest,
+    ZUpdateRunbookRunStepRequest,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const runbookContract = c.router(
+    {
+        listRunbooks: {
+            summary: "List runbooks",
+            path: "/runbooks",
+            method: "GET",
+            description: "Get the runbooks of the authenticated user without their steps",
+            query: ZRunbookQueryParams,
+            responses: {
+                200: ZRunbookListResponse,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        listRunbooksByAsset: {
+            summary: "List runbooks for asset",
+            path: "/assets/:id/runbooks",
+            method: "GET",
+            description: "Get the runbooks that apply to a specific asset, by asset ID or asset type",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZRunbookListResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        createRunbook: {
+            summary: "Create a new runbook",
+            path: "/runbooks",
+            method: "POST",
+            description: "Create a runbook with ordered steps, attached to asset types, assets or neither",
+            body: ZCreateRunbookRequest,
+            responses: {
+                201: ZRunbook,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getRunbookById: {
+            summary: "Get runbook by ID",
+            path: "/runbooks/:id",
+            method: "GET",
+            description: "Get a single runbook with its steps",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZRunbook,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        updateRunbook: {
+            summary: "Update runbook",
+            path: "/runbooks/:id",
+            method: "PATCH",
+            description: "Update an existing runbook (partial update). Steps, asset types and asset IDs are replaced as a whole",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZUpdateRunbookRequest,
+            responses: {
+                200: ZRunbook,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        deleteRunbook: {
+            summary: "Delete runbook",
+            path: "/runbooks/:id",
+            method: "DELETE",
+            description: "Delete a runbook. Its runs are kept",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                204: z.void(),
+            },
+            metadata: metadata,
+        },
+
+        startRunbookRun: {
+            summary: "Start runbook run",
+            path: "/runbooks/:id/runs",
+            method: "POST",
+            description: "Start a run of the runbook against an asset it applies to, with a copy of its steps",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZStartRunbookRunRequest,
+            responses: {
+                201: ZRunbookRun,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getRunbookRun: {
+            summary: "Get runbook run",
+            path: "/runbook-runs/:id",
+            method: "GET",
+            description: "Get a runbook run with the state of each step",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZRunbookRun,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        updateRunbookRunStep: {
+            summary: "Update runbook run step",
+            path: "/runbook-runs/:id/steps/:position",
+            method: "PATCH",
+            description: "Check or uncheck a step of an in-progress run and set its notes",
+            pathParams: z.object({
+                id: ZUuid,
+                position: z.coerce.number().int(),
+            }),
+            body: ZUpdateRunbookRunStepRequest,
+            responses: {
+                200: ZRunbookRun,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        finishRunbookRun: {
+            summary: "Finish runbook run",
+            path: "/runbook-runs/:id/finish",
+            method: "POST",
+            description: "Complete a run and record it as a single maintenance log on the asset",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZCloseRunbookRunRequest,
+            responses: {
+                200: ZCloseRunbookRunResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        abortRunbookRun: {
+            summary: "Abort runbook run",
+            path: "/runbook-runs/:id/abort",
+            method: "POST",
+            description: "Abort a run and record the steps done so far as a single log on the asset",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZCloseRunbookRunRequest,
+            responses: {
+                200: ZCloseRunbookRunResponse,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
@@ -0,0 +1,113 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZAssetListResponse,
+    ZCreateSavedViewRequest,
+    ZErrorResponse,
+    ZLogListResponse,
+    ZRunSavedViewParams,
+    ZSavedView,
+    ZSavedViewListResponse,
+    ZUpdateSavedViewRequest,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const savedViewContract = c.router(
+    {
+        listSavedViews: {
+            summary: "List saved views",
+            path: "/views",
+            method: "GET",
+            description: "Get the saved views of the authenticated user, ordered by name",
+            responses: {
+                200: ZSavedViewListResponse,
+            },
+            metadata: metadata,
+        },
+
+        createSavedView: {
+            summary: "Create a new saved view",
+            path: "/views",
+            method: "POST",
+            description: "Save the filters and sort of the asset list, or of an asset's log list, under a name",
+            body: ZCreateSavedViewRequest,
+            responses: {
+                201: ZSavedView,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getSavedViewById: {
+            summary: "Get saved view by ID",
+            path: "/views/:id",
+            method: "GET",
+            description: "Get a single saved view by its ID",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                200: ZSavedView,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        updateSavedView: {
+            summary: "Update saved view",
+            path: "/views/:id",
+            method: "PATCH",
+            description: "Update an existing saved view (partial update). The target cannot change",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            body: ZUpdateSavedViewRequest,
+            responses: {
+                200: ZSavedView,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        deleteSavedView: {
+            summary: "Delete saved view",
+            path: "/views/:id",
+            method: "DELETE",
+            description: "Delete a saved view",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            responses: {
+                204: z.void(),
+            },
+            metadata: metadata,
+        },
+
+        runSavedView: {
+            summary: "Run saved view",
+            path: "/views/:id/results",
+            method: "GET",
+            description: "Run a saved view and return an asset list for asset views or a log list for log views",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            query: ZRunSavedViewParams,
+            responses: {
+                200: z.union([ZAssetListResponse, ZLogListResponse]),
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
@@ -0,0 +1,97 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZActivityHeatmap,
+    ZActivityHeatmapQueryParams,
+    ZActivityTimeline,
+    ZActivityTimelineQueryParams,
+    ZDashboardStats,
+    ZErrorResponse,
+    ZStaleAssetQueryParams,
+    ZStaleAssetReport,
+    ZStatsQueryParams,
+    ZUuid,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+import { z } from "zod";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const statsContract = c.router(
+    {
+        getDashboardStats: {
+            summary: "Get dashboard statistics",
+            path: "/stats",
+            method: "GET",
+            description: "Get asset counts, logs per day, top tags, most active assets and storage used, cached per user for up to ten minutes",
+            query: ZStatsQueryParams,
+            responses: {
+                200: ZDashboardStats,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getStaleAssetReport: {
+            summary: "Get stale asset report",
+            path: "/reports/stale-assets",
+            method: "GET",
+            description: "Get the assets without logs or updates for a number of days, most stale first",
+            query: ZStaleAssetQueryParams,
+            responses: {
+                200: ZStaleAssetReport,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getActivityHeatmap: {
+            summary: "Get activity heatmap",
+            path: "/activity/heatmap",
+            method: "GET",
+            description: "Get the number of logs per day over a calendar year or the last 365 days",
+            query: ZActivityHeatmapQueryParams,
+            responses: {
+                200: ZActivityHeatmap,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getAssetActivityHeatmap: {
+            summary: "Get activity heatmap for asset",
+            path: "/assets/:id/activity/heatmap",
+            method: "GET",
+            description: "Get the number of logs per day for a specific asset, counting logs linked to it",
+            pathParams: z.object({
+                id: ZUuid,
+            }),
+            query: ZActivityHeatmapQueryParams,
+            responses: {
+                200: ZActivityHeatmap,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        getActivityTimeline: {
+            summary: "Get activity timeline",
+            path: "/activity/timeline",
+            method: "GET",
+            description: "Get the number of logs per interval, grouped by tag or asset type",
+            query: ZActivityTimelineQueryParams,
+            responses: {
+                200: ZActivityTimeline,
+                400: ZErrorResponse,
+                404: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
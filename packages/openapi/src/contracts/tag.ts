@@ -0,0 +1,60 @@
+import { getSecurityMetadata } from "../utils.js";
+import {
+    ZErrorResponse,
+    ZMergeTagsRequest,
+    ZRenameTagRequest,
+    ZTagListResponse,
+    ZTagOperationResponse,
+    ZTagQueryParams,
+} from "@ark/zod";
+import { initContract } from "@ts-rest/core";
+
+const c = initContract();
+
+const metadata = getSecurityMetadata();
+
+export const tagContract = c.router(
+    {
+        listTags: {
+            summary: "List tags",
+            path: "/tags",
+            method: "GET",
+            description: "Get the tags used on logs, assets and log templates with usage counts, most used first",
+            query: ZTagQueryParams,
+            responses: {
+                200: ZTagListResponse,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        renameTag: {
+            summary: "Rename tag",
+            path: "/tags/rename",
+            method: "POST",
+            description: "Rename a tag on all logs, assets and log templates. Renaming onto a tag in use merges the two",
+            body: ZRenameTagRequest,
+            responses: {
+                200: ZTagOperationResponse,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+
+        mergeTags: {
+            summary: "Merge tags",
+            path: "/tags/merge",
+            method: "POST",
+            description: "Replace several tags with a target tag on all logs, assets and log templates",
+            body: ZMergeTagsRequest,
+            responses: {
+                200: ZTagOperationResponse,
+                400: ZErrorResponse,
+            },
+            metadata: metadata,
+        },
+    },
+    {
+        pathPrefix: "/api/v1",
+    }
+);
@@ -0,0 +1,34 @@
+import { z } from "zod";
+import { ZTimestamp, ZUuid } from "./common.js";
+
+/**
+ * API token Zod schemas matching Go models
+ */
+
+// API token - matches Go model.APIToken
+export const ZAPIToken = z.object({
+    id: ZUuid,
+    user_id: z.string(),
+    name: z.string(),
+    prefix: z.string(),
+    last_used_at: ZTimestamp.optional(),
+    expires_at: ZTimestamp.optional(),
+    created_at: ZTimestamp,
+});
+
+// Create API token request - matches Go model.CreateAPITokenRequest
+export const ZCreateAPITokenRequest = z.object({
+    name: z.string().min(1).max(100),
+    expires_in_days: z.number().int().min(1).max(3650).optional(),
+});
+
+// Created API token, the only response that carries the token - matches Go model.CreatedAPIToken
+export const ZCreatedAPIToken = ZAPIToken.extend({
+    token: z.string(),
+});
+
+// API token list response - matches Go model.APITokenListResponse
+export const ZAPITokenListResponse = z.object({
+    tokens: z.array(ZAPIToken),
+    total: z.number().int(),
+});
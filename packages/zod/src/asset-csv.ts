@@ -0,0 +1,39 @@
+import { z } from "zod";
+import { ZUuid } from "./common.js";
+
+/**
+ * Asset CSV import Zod schemas matching Go models
+ */
+
+// CSV preview - matches Go model.AssetCSVPreview
+export const ZAssetCSVPreview = z.object({
+    columns: z.array(z.string()),
+    // Suggested mapping of CSV column to asset field
+    mapping: z.record(z.string()),
+    rows: z.array(z.array(z.string())),
+    total_rows: z.number().int(),
+});
+
+// CSV import query parameters - matches Go model.AssetCSVImportParams
+export const ZAssetCSVImportQueryParams = z.object({
+    match: z.enum(["name", "hostname"]).optional(),
+    dry_run: z.boolean().optional(),
+});
+
+// Imported row - matches Go model.AssetCSVRowResult
+export const ZAssetCSVRowResult = z.object({
+    row: z.number().int(),
+    action: z.enum(["create", "update", "unchanged"]),
+    id: ZUuid.optional(),
+    name: z.string(),
+});
+
+// CSV import result - matches Go model.AssetCSVImportResult
+export const ZAssetCSVImportResult = z.object({
+    dry_run: z.boolean(),
+    match: z.enum(["name", "hostname"]),
+    created: z.number().int(),
+    updated: z.number().int(),
+    unchanged: z.number().int(),
+    rows: z.array(ZAssetCSVRowResult),
+});
@@ -18,7 +18,10 @@ export const ZAsset = ZBase.extend({
     name: z.string().min(1).max(100),
     type: ZAssetType.nullable().optional(),
     hostname: z.string().max(255).nullable().optional(),
+    tags: z.array(z.string().max(50)).nullable().optional(),
     metadata: ZAssetMetadata.nullable().optional(),
+    pinned: z.boolean(),
+    favorite: z.boolean(),
 });
 
 export type Asset = z.infer<typeof ZAsset>;
@@ -28,7 +31,10 @@ export const ZCreateAssetRequest = z.object({
     name: z.string().min(1).max(100),
     type: ZAssetType.optional(),
     hostname: z.string().max(255).optional(),
+    tags: z.array(z.string().max(50)).optional(),
     metadata: ZAssetMetadata.optional(),
+    pinned: z.boolean().optional(),
+    favorite: z.boolean().optional(),
 });
 
 // Update Asset request - matches Go model.UpdateAssetRequest (all fields optional for PATCH)
@@ -36,7 +42,10 @@ export const ZUpdateAssetRequest = z.object({
     name: z.string().min(1).max(100).optional(),
     type: ZAssetType.optional(),
     hostname: z.string().max(255).optional(),
+    tags: z.array(z.string().max(50)).optional(),
     metadata: ZAssetMetadata.optional(),
+    pinned: z.boolean().optional(),
+    favorite: z.boolean().optional(),
 });
 
 // Asset query parameters - matches Go model.AssetQueryParams
@@ -47,6 +56,10 @@ export const ZAssetQueryParams = z.object({
     search: z.string().max(100).optional(),
     sort_by: z.enum(["name", "created_at", "updated_at"]).optional(),
     sort_order: z.enum(["asc", "desc"]).optional(),
+    tags: z.array(z.string().max(50)).optional(),
+    tag_mode: z.enum(["any", "all"]).optional(),
+    favorite: z.boolean().optional(),
+    updated_within_days: z.coerce.number().int().min(1).max(3650).optional(),
 });
 
 // Asset list response - matches Go model.AssetListResponse
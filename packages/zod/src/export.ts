@@ -0,0 +1,68 @@
+import { z } from "zod";
+import { ZTimestamp, ZUuid } from "./common.js";
+
+/**
+ * Account export and import Zod schemas matching Go models
+ */
+
+// Export status enum - matches Go model.ExportStatus* constants
+export const ZExportStatus = z.enum(["pending", "running", "completed", "failed"]);
+
+// Account export - matches Go model.AccountExport
+export const ZAccountExport = z.object({
+    id: ZUuid,
+    user_id: z.string(),
+    status: ZExportStatus,
+    error: z.string().optional(),
+    size_bytes: z.number().int().optional(),
+    asset_count: z.number().int().optional(),
+    log_count: z.number().int().optional(),
+    created_at: ZTimestamp,
+    updated_at: ZTimestamp,
+    completed_at: ZTimestamp.optional(),
+    expires_at: ZTimestamp,
+});
+
+// Export query parameters - matches Go model.ExportRequest
+export const ZExportQueryParams = z.object({
+    view_id: ZUuid.optional(),
+});
+
+// Export list response - matches Go model.ExportListResponse
+export const ZExportListResponse = z.object({
+    exports: z.array(ZAccountExport),
+    total: z.number().int(),
+});
+
+// Import query parameters - matches Go model.ImportParams
+export const ZImportQueryParams = z.object({
+    conflict: z.enum(["skip", "overwrite", "duplicate"]).optional(),
+    dry_run: z.boolean().optional(),
+});
+
+// Imported entity - matches Go model.ImportChange
+export const ZImportChange = z.object({
+    type: z.enum(["asset", "log"]),
+    action: z.enum(["create", "update", "skip"]),
+    id: ZUuid,
+    new_id: ZUuid.optional(),
+    name: z.string().optional(),
+});
+
+// Per-type import counts - matches Go model.ImportEntitySummary
+export const ZImportEntitySummary = z.object({
+    created: z.number().int(),
+    updated: z.number().int(),
+    skipped: z.number().int(),
+    new_ids: z.number().int(),
+});
+
+// Import result - matches Go model.ImportResult
+export const ZImportResult = z.object({
+    dry_run: z.boolean(),
+    conflict: z.enum(["skip", "overwrite", "duplicate"]),
+    assets: ZImportEntitySummary,
+    logs: ZImportEntitySummary,
+    changes: z.array(ZImportChange),
+    changes_truncated: z.boolean(),
+});
@@ -0,0 +1,82 @@
+import { z } from "zod";
+import { ZTimestamp, ZUuid } from "./common.js";
+import { ZAssetLog, ZIncidentStatus, ZSeverity } from "./log.js";
+
+/**
+ * Incident timeline and statistics Zod schemas matching Go models
+ */
+
+// Timeline entry - matches Go model.IncidentTimelineEntry
+export const ZIncidentTimelineEntry = z.object({
+    id: ZUuid,
+    log_id: ZUuid,
+    user_id: z.string(),
+    status: ZIncidentStatus.optional(),
+    note: z.string().optional(),
+    occurred_at: ZTimestamp,
+    created_at: ZTimestamp,
+});
+
+// Create timeline entry request - matches Go model.CreateTimelineEntryRequest
+// At least one of status or note is required
+export const ZCreateTimelineEntryRequest = z.object({
+    status: ZIncidentStatus.optional(),
+    note: z.string().min(1).max(5000).optional(),
+    occurred_at: ZTimestamp.optional(),
+});
+
+// Timeline response - matches Go model.IncidentTimelineResponse
+export const ZIncidentTimelineResponse = z.object({
+    incident: ZAssetLog,
+    entries: z.array(ZIncidentTimelineEntry),
+});
+
+// Incident stats query parameters - matches Go model.IncidentStatsQueryParams
+export const ZIncidentStatsQueryParams = z.object({
+    start_date: z.string().datetime().optional(),
+    end_date: z.string().datetime().optional(),
+    interval: z.enum(["day", "week", "month"]).optional(),
+    asset_id: ZUuid.optional(),
+    type: z.string().max(50).optional(),
+    min_severity: ZSeverity.optional(),
+});
+
+// Per-asset incident stats - matches Go model.AssetIncidentStats
+export const ZAssetIncidentStats = z.object({
+    asset_id: ZUuid,
+    asset_name: z.string(),
+    asset_type: z.string().optional(),
+    incident_count: z.number().int(),
+    resolved_count: z.number().int(),
+    mttr_seconds: z.number().optional(),
+    downtime_seconds: z.number(),
+    availability_percent: z.number(),
+});
+
+// Per-type incident stats - matches Go model.TypeIncidentStats
+export const ZTypeIncidentStats = z.object({
+    asset_type: z.string(),
+    incident_count: z.number().int(),
+    mttr_seconds: z.number().optional(),
+});
+
+// Incident count bucket - matches Go model.IncidentBucket
+export const ZIncidentBucket = z.object({
+    start: ZTimestamp,
+    total: z.number().int(),
+    by_type: z.record(z.number().int()),
+});
+
+// Incident stats response - matches Go model.IncidentStatsResponse
+export const ZIncidentStatsResponse = z.object({
+    start_date: ZTimestamp,
+    end_date: ZTimestamp,
+    interval: z.enum(["day", "week", "month"]),
+    total_incidents: z.number().int(),
+    open_incidents: z.number().int(),
+    mttr_seconds: z.number().optional(),
+    availability_percent: z.number(),
+    by_asset: z.array(ZAssetIncidentStats),
+    by_type: z.array(ZTypeIncidentStats),
+    over_time: z.array(ZIncidentBucket),
+});
@@ -13,4 +13,17 @@ export * from "./utils.js";
 export * from "./common.js";
 export * from "./health.js";
 export * from "./asset.js";
-export * from "./log.js";
\ No newline at end of file
+export * from "./log.js";
+export * from "./incident.js";
+export * from "./maintenance.js";
+export * from "./runbook.js";
+export * from "./log-template.js";
+export * from "./tag.js";
+export * from "./saved-view.js";
+export * from "./stats.js";
+export * from "./export.js";
+export * from "./asset-csv.js";
+export * from "./inventory.js";
+export * from "./api-token.js";
+export * from "./port.js";
+export * from "./integration.js";
\ No newline at end of file
@@ -0,0 +1,26 @@
+import { z } from "zod";
+
+/**
+ * Integration Zod schemas matching Go models
+ */
+
+// Asset selection shared by the integration endpoints - matches Go model.GeneratedFileRequest
+export const ZIntegrationQueryParams = z.object({
+    type: z.string().optional(),
+    tags: z.array(z.string()).optional(),
+    tag_mode: z.enum(["any", "all"]).optional(),
+});
+
+// Prometheus service discovery query parameters - matches Go model.PrometheusSDParams
+export const ZPrometheusSDQueryParams = ZIntegrationQueryParams.extend({
+    service: z.string().optional(),
+});
+
+// Ansible dynamic inventory: groups by name plus _meta.hostvars - matches Go model.AnsibleInventory
+export const ZAnsibleInventory = z.record(z.any());
+
+// Prometheus HTTP SD target group - matches Go model.PrometheusTargetGroup
+export const ZPrometheusTargetGroup = z.object({
+    targets: z.array(z.string()),
+    labels: z.record(z.string()),
+});
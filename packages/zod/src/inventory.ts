@@ -0,0 +1,49 @@
+import { z } from "zod";
+import { ZUuid } from "./common.js";
+
+/**
+ * Inventory import Zod schemas matching Go models
+ */
+
+// Compose import query parameters - matches Go model.ComposeImportParams
+export const ZComposeImportQueryParams = z.object({
+    host_asset_id: ZUuid,
+    dry_run: z.boolean().optional(),
+});
+
+// Ansible import query parameters - matches Go model.AnsibleImportParams
+export const ZAnsibleImportQueryParams = z.object({
+    type: z.string().max(50).optional(),
+    dry_run: z.boolean().optional(),
+});
+
+// Nmap import query parameters - matches Go model.NmapImportParams
+// plan and accept are required unless dry_run is set
+export const ZNmapImportQueryParams = z.object({
+    dry_run: z.boolean().optional(),
+    scope: z.array(z.string()).optional(),
+    plan: z.string().optional(),
+    accept: z.array(z.string()).optional(),
+});
+
+// Imported asset - matches Go model.InventoryAssetResult
+export const ZInventoryAssetResult = z.object({
+    source: z.string(),
+    action: z.enum(["create", "update", "unchanged", "not_seen", "skipped"]),
+    id: ZUuid.optional(),
+    name: z.string(),
+    address: z.string().optional(),
+    changes: z.array(z.string()).optional(),
+});
+
+// Inventory import result - matches Go model.InventoryImportResult
+export const ZInventoryImportResult = z.object({
+    dry_run: z.boolean(),
+    created: z.number().int(),
+    updated: z.number().int(),
+    unchanged: z.number().int(),
+    skipped: z.number().int().optional(),
+    assets: z.array(ZInventoryAssetResult),
+    not_seen: z.array(ZInventoryAssetResult).optional(),
+    plan: z.string().optional(),
+});
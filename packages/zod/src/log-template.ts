@@ -0,0 +1,68 @@
+import { z } from "zod";
+import { ZBase } from "./common.js";
+import { ZLogKind } from "./log.js";
+
+/**
+ * Log template Zod schemas matching Go models
+ */
+
+// Placeholder type enum - matches Go model.PlaceholderType* constants
+export const ZPlaceholderType = z.enum(["string", "int", "number", "bool", "date"]);
+
+// Template placeholder, referenced as {{name}} in content - matches Go model.TemplatePlaceholder
+export const ZTemplatePlaceholder = z.object({
+    name: z.string().min(1).max(50),
+    type: ZPlaceholderType,
+    required: z.boolean(),
+    default: z.string().max(1000).optional(),
+    description: z.string().max(500).optional(),
+});
+
+// Log template - matches Go model.LogTemplate
+export const ZLogTemplate = ZBase.extend({
+    user_id: z.string(),
+    name: z.string().max(100),
+    description: z.string().max(1000).optional(),
+    kind: ZLogKind,
+    content: z.string().min(2).max(10000),
+    tags: z.array(z.string().max(50)).optional(),
+    placeholders: z.array(ZTemplatePlaceholder),
+});
+
+// Create log template request - matches Go model.CreateLogTemplateRequest
+export const ZCreateLogTemplateRequest = z.object({
+    name: z.string().min(1).max(100),
+    description: z.string().max(1000).optional(),
+    kind: ZLogKind.optional(),
+    content: z.string().min(2).max(10000),
+    tags: z.array(z.string().max(50)).optional(),
+    placeholders: z.array(ZTemplatePlaceholder).max(50).optional(),
+});
+
+// Update log template request - matches Go model.UpdateLogTemplateRequest (all fields optional for PATCH)
+export const ZUpdateLogTemplateRequest = z.object({
+    name: z.string().min(1).max(100).optional(),
+    description: z.string().max(1000).optional(),
+    kind: ZLogKind.optional(),
+    content: z.string().min(2).max(10000).optional(),
+    tags: z.array(z.string().max(50)).optional(),
+    placeholders: z.array(ZTemplatePlaceholder).max(50).optional(),
+});
+
+// Render log template request - matches Go model.RenderLogTemplateRequest
+export const ZRenderLogTemplateRequest = z.object({
+    values: z.record(z.any()).optional(),
+});
+
+// Render log template response - matches Go model.RenderLogTemplateResponse
+export const ZRenderLogTemplateResponse = z.object({
+    content: z.string(),
+    kind: ZLogKind,
+    tags: z.array(z.string()).optional(),
+});
+
+// Log template list response - matches Go model.LogTemplateListResponse
+export const ZLogTemplateListResponse = z.object({
+    templates: z.array(ZLogTemplate),
+    total: z.number().int(),
+});
@@ -1,28 +1,102 @@
 import { z } from "zod";
-import { ZBase, ZUuid } from "./common.js";
+import { ZBase, ZTimestamp, ZUuid } from "./common.js";
 
 /**
  * Asset Log Zod schemas matching Go models
  */
 
-// Core AssetLog schema - matches Go model.AssetLog struct
+// Log kind enum - matches Go model.LogKind* constants
+export const ZLogKind = z.enum(["note", "change", "incident", "maintenance"]);
+
+// Severity enum for incidents - matches Go model.Severity* constants
+export const ZSeverity = z.enum(["low", "medium", "high", "critical"]);
+
+// Incident status enum - matches Go model.IncidentStatus* constants
+export const ZIncidentStatus = z.enum(["open", "mitigated", "resolved"]);
+
+// Fenced code block extracted from rendered content - matches Go model.CodeBlock
+export const ZCodeBlock = z.object({
+    language: z.string(),
+    code: z.string(),
+});
+
+// Core AssetLog schema - matches Go model.LogResponse struct
 export const ZAssetLog = ZBase.extend({
     asset_id: ZUuid,
     user_id: z.string(),
+    kind: ZLogKind,
     content: z.string().min(2).max(10000),
     tags: z.array(z.string().max(50)).max(20).nullable().optional(),
+    // Incident fields
+    severity: ZSeverity.optional(),
+    started_at: ZTimestamp.optional(),
+    resolved_at: ZTimestamp.optional(),
+    root_cause: z.string().optional(),
+    incident_status: ZIncidentStatus.optional(),
+    mitigated_at: ZTimestamp.optional(),
+    // Change fields
+    planned: z.boolean().optional(),
+    rollback_notes: z.string().optional(),
+    // Maintenance fields
+    duration_minutes: z.number().int().optional(),
+    linked_asset_ids: z.array(ZUuid),
+    // Thread fields, reply_count and last_activity_at only on collapsed listings
+    parent_log_id: ZUuid.optional(),
+    thread_root_id: ZUuid.optional(),
+    reply_count: z.number().int().optional(),
+    last_activity_at: ZTimestamp.optional(),
+    pinned: z.boolean(),
+    favorite: z.boolean(),
+    // Only with render=html
+    content_html: z.string().optional(),
+    code_blocks: z.array(ZCodeBlock).optional(),
 });
 
 // Create Log request - matches Go model.CreateLogRequest
 export const ZCreateLogRequest = z.object({
+    kind: ZLogKind.optional(),
     content: z.string().min(2).max(10000),
     tags: z.array(z.string().max(50)).max(20).optional(),
+    severity: ZSeverity.optional(),
+    started_at: ZTimestamp.optional(),
+    resolved_at: ZTimestamp.optional(),
+    root_cause: z.string().max(5000).optional(),
+    planned: z.boolean().optional(),
+    rollback_notes: z.string().max(5000).optional(),
+    duration_minutes: z.number().int().min(0).optional(),
+    linked_asset_ids: z.array(ZUuid).max(100).optional(),
+    parent_log_id: ZUuid.optional(),
+    pinned: z.boolean().optional(),
+    favorite: z.boolean().optional(),
+    // Placeholder values, only with the template query parameter
+    values: z.record(z.any()).optional(),
 });
 
 // Update Log request - matches Go model.UpdateLogRequest (all fields optional for PATCH)
 export const ZUpdateLogRequest = z.object({
+    kind: ZLogKind.optional(),
     content: z.string().min(2).max(10000).optional(),
     tags: z.array(z.string().max(50)).max(20).nullable().optional(),
+    severity: ZSeverity.optional(),
+    started_at: ZTimestamp.optional(),
+    resolved_at: ZTimestamp.optional(),
+    root_cause: z.string().max(5000).optional(),
+    planned: z.boolean().optional(),
+    rollback_notes: z.string().max(5000).optional(),
+    duration_minutes: z.number().int().min(0).optional(),
+    linked_asset_ids: z.array(ZUuid).max(100).optional(),
+    pinned: z.boolean().optional(),
+    favorite: z.boolean().optional(),
+});
+
+// Log render query parameter - matches Go model.LogRenderParams
+export const ZLogRenderQuery = z.object({
+    render: z.enum(["html"]).optional(),
+});
+
+// Create log query parameters - template renders a log template into the new log
+export const ZCreateLogQuery = z.object({
+    template: ZUuid.optional(),
 });
 
 // Log query parameters - matches Go model.LogQueryParams
@@ -30,11 +104,19 @@ export const ZLogQueryParams = z.object({
     limit: z.coerce.number().int().min(1).max(200).optional(),
     offset: z.coerce.number().int().min(0).optional(),
     tags: z.array(z.string().max(50)).optional(),
+    tags_all: z.array(z.string().max(50)).optional(),
+    tags_any: z.array(z.string().max(50)).optional(),
+    tags_none: z.array(z.string().max(50)).optional(),
+    kind: ZLogKind.optional(),
     search: z.string().max(100).optional(),
     start_date: z.string().datetime().optional(),
     end_date: z.string().datetime().optional(),
+    created_within_days: z.coerce.number().int().min(1).max(3650).optional(),
     sort_by: z.enum(["created_at", "updated_at"]).optional(),
     sort_order: z.enum(["asc", "desc"]).optional(),
+    collapse_threads: z.boolean().optional(),
+    favorite: z.boolean().optional(),
+    render: z.enum(["html"]).optional(),
 });
 
 // Log list response - matches Go model.LogListResponse
@@ -44,3 +126,11 @@ export const ZLogListResponse = z.object({
     limit: z.number().int(),
     offset: z.number().int(),
 });
+
+// Log thread response - matches Go model.LogThreadResponse
+export const ZLogThreadResponse = z.object({
+    root: ZAssetLog,
+    replies: z.array(ZAssetLog),
+    reply_count: z.number().int(),
+    last_activity_at: ZTimestamp,
+});
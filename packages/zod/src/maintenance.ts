@@ -0,0 +1,66 @@
+import { z } from "zod";
+import { ZTimestamp, ZUuid } from "./common.js";
+import { ZAssetLog } from "./log.js";
+
+/**
+ * Maintenance task Zod schemas matching Go models
+ */
+
+// Maintenance task - matches Go model.MaintenanceTaskResponse
+export const ZMaintenanceTask = z.object({
+    id: ZUuid,
+    asset_id: ZUuid,
+    user_id: z.string(),
+    title: z.string().max(200),
+    description: z.string().max(5000).optional(),
+    // RFC 5545 RRULE such as FREQ=MONTHLY;BYMONTHDAY=1
+    recurrence: z.string().max(200),
+    starts_at: ZTimestamp,
+    next_due_at: ZTimestamp.optional(),
+    last_completed_at: ZTimestamp.optional(),
+    overdue: z.boolean(),
+    created_at: ZTimestamp,
+    updated_at: ZTimestamp,
+});
+
+// Create maintenance task request - matches Go model.CreateMaintenanceTaskRequest
+export const ZCreateMaintenanceTaskRequest = z.object({
+    title: z.string().min(1).max(200),
+    description: z.string().max(5000).optional(),
+    recurrence: z.string().min(1).max(200),
+    starts_at: ZTimestamp.optional(),
+});
+
+// Update maintenance task request - matches Go model.UpdateMaintenanceTaskRequest (all fields optional for PATCH)
+export const ZUpdateMaintenanceTaskRequest = z.object({
+    title: z.string().min(1).max(200).optional(),
+    description: z.string().max(5000).optional(),
+    recurrence: z.string().min(1).max(200).optional(),
+    starts_at: ZTimestamp.optional(),
+});
+
+// Complete maintenance task request - matches Go model.CompleteMaintenanceTaskRequest
+export const ZCompleteMaintenanceTaskRequest = z.object({
+    notes: z.string().max(10000).optional(),
+    completed_at: ZTimestamp.optional(),
+    duration_minutes: z.number().int().min(0).optional(),
+    tags: z.array(z.string().max(50)).optional(),
+});
+
+// Maintenance task query parameters - matches Go model.MaintenanceTaskQueryParams
+export const ZMaintenanceTaskQueryParams = z.object({
+    overdue: z.boolean().optional(),
+    due_by: z.string().datetime().optional(),
+});
+
+// Maintenance task list response - matches Go model.MaintenanceTaskListResponse
+export const ZMaintenanceTaskListResponse = z.object({
+    tasks: z.array(ZMaintenanceTask),
+    total: z.number().int(),
+});
+
+// Complete maintenance task response - matches Go model.CompleteMaintenanceTaskResponse
+export const ZCompleteMaintenanceTaskResponse = z.object({
+    task: ZMaintenanceTask,
+    log: ZAssetLog,
+});
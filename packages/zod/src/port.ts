@@ -0,0 +1,42 @@
+import { z } from "zod";
+import { ZBase, ZTimestamp, ZUuid } from "./common.js";
+
+/**
+ * Asset port Zod schemas matching Go models
+ */
+
+// Port protocol enum - matches Go model.PortProtocol* constants
+export const ZPortProtocol = z.enum(["tcp", "udp"]);
+
+// Asset port - matches Go model.AssetPort
+export const ZAssetPort = ZBase.extend({
+    asset_id: ZUuid,
+    user_id: z.string(),
+    port: z.number().int().min(1).max(65535),
+    protocol: ZPortProtocol,
+    service: z.string().max(100).optional(),
+    description: z.string().max(1000).optional(),
+    last_seen_at: ZTimestamp.optional(),
+});
+
+// Create asset port request - matches Go model.CreateAssetPortRequest
+export const ZCreateAssetPortRequest = z.object({
+    port: z.number().int().min(1).max(65535),
+    protocol: ZPortProtocol.optional(),
+    service: z.string().max(100).optional(),
+    description: z.string().max(1000).optional(),
+});
+
+// Update asset port request - matches Go model.UpdateAssetPortRequest (all fields optional for PATCH)
+export const ZUpdateAssetPortRequest = z.object({
+    port: z.number().int().min(1).max(65535).optional(),
+    protocol: ZPortProtocol.optional(),
+    service: z.string().max(100).optional(),
+    description: z.string().max(1000).optional(),
+});
+
+// Asset port list response - matches Go model.AssetPortListResponse
+export const ZAssetPortListResponse = z.object({
+    ports: z.array(ZAssetPort),
+    total: z.number().int(),
+});
@@ -0,0 +1,118 @@
+import { z } from "zod";
+import { ZTimestamp, ZUuid } from "./common.js";
+import { ZAssetLog } from "./log.js";
+
+/**
+ * Runbook Zod schemas matching Go models
+ */
+
+// Runbook step - matches Go model.RunbookStep
+export const ZRunbookStep = z.object({
+    id: ZUuid,
+    runbook_id: ZUuid,
+    position: z.number().int(),
+    title: z.string(),
+    body: z.string().optional(),
+});
+
+// Runbook - matches Go model.Runbook, steps are omitted in list responses
+export const ZRunbook = z.object({
+    id: ZUuid,
+    user_id: z.string(),
+    title: z.string(),
+    description: z.string().optional(),
+    asset_types: z.array(z.string()),
+    asset_ids: z.array(ZUuid),
+    step_count: z.number().int(),
+    steps: z.array(ZRunbookStep).optional(),
+    created_at: ZTimestamp,
+    updated_at: ZTimestamp,
+});
+
+// Runbook step input - matches Go model.RunbookStepInput
+export const ZRunbookStepInput = z.object({
+    title: z.string().min(1).max(200),
+    body: z.string().max(10000).optional(),
+});
+
+// Create runbook request - matches Go model.CreateRunbookRequest
+export const ZCreateRunbookRequest = z.object({
+    title: z.string().min(1).max(200),
+    description: z.string().max(50000).optional(),
+    asset_types: z.array(z.string().max(50)).optional(),
+    asset_ids: z.array(ZUuid).optional(),
+    steps: z.array(ZRunbookStepInput).min(1).max(100),
+});
+
+// Update runbook request - matches Go model.UpdateRunbookRequest (all fields optional for PATCH)
+export const ZUpdateRunbookRequest = z.object({
+    title: z.string().min(1).max(200).optional(),
+    description: z.string().max(50000).optional(),
+    asset_types: z.array(z.string().max(50)).optional(),
+    asset_ids: z.array(ZUuid).optional(),
+    steps: z.array(ZRunbookStepInput).min(1).max(100).optional(),
+});
+
+// Runbook query parameters - matches Go model.RunbookQueryParams
+export const ZRunbookQueryParams = z.object({
+    asset_type: z.string().max(50).optional(),
+    search: z.string().max(100).optional(),
+});
+
+// Runbook list response - matches Go model.RunbookListResponse
+export const ZRunbookListResponse = z.object({
+    runbooks: z.array(ZRunbook),
+    total: z.number().int(),
+});
+
+// Runbook run status enum - matches Go model.RunbookRunStatus* constants
+export const ZRunbookRunStatus = z.enum(["in_progress", "completed", "aborted"]);
+
+// Runbook run step - matches Go model.RunbookRunStep
+export const ZRunbookRunStep = z.object({
+    id: ZUuid,
+    run_id: ZUuid,
+    position: z.number().int(),
+    title: z.string(),
+    body: z.string().optional(),
+    checked: z.boolean(),
+    checked_at: ZTimestamp.optional(),
+    notes: z.string().optional(),
+});
+
+// Runbook run - matches Go model.RunbookRun
+export const ZRunbookRun = z.object({
+    id: ZUuid,
+    runbook_id: ZUuid.optional(),
+    asset_id: ZUuid,
+    user_id: z.string(),
+    title: z.string(),
+    status: ZRunbookRunStatus,
+    notes: z.string().optional(),
+    log_id: ZUuid.optional(),
+    started_at: ZTimestamp,
+    finished_at: ZTimestamp.optional(),
+    steps: z.array(ZRunbookRunStep),
+});
+
+// Start runbook run request - matches Go model.StartRunbookRunRequest
+export const ZStartRunbookRunRequest = z.object({
+    asset_id: ZUuid,
+});
+
+// Update runbook run step request - matches Go model.UpdateRunbookRunStepRequest
+export const ZUpdateRunbookRunStepRequest = z.object({
+    checked: z.boolean().optional(),
+    notes: z.string().max(5000).optional(),
+});
+
+// Finish or abort runbook run request - matches Go model.CloseRunbookRunRequest
+export const ZCloseRunbookRunRequest = z.object({
+    notes: z.string().max(5000).optional(),
+});
+
+// Finish or abort runbook run response - matches Go model.CloseRunbookRunResponse
+export const ZCloseRunbookRunResponse = z.object({
+    run: ZRunbookRun,
+    log: ZAssetLog,
+});
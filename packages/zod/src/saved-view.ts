@@ -0,0 +1,52 @@
+import { z } from "zod";
+import { ZBase, ZUuid } from "./common.js";
+
+/**
+ * Saved view Zod schemas matching Go models
+ */
+
+// Saved view target enum - matches Go model.SavedViewTarget* constants
+export const ZSavedViewTarget = z.enum(["assets", "logs"]);
+
+// Saved view params - the query parameters of GET /assets or GET /assets/:id/logs
+export const ZSavedViewParams = z.record(z.any());
+
+// Saved view - matches Go model.SavedView
+export const ZSavedView = ZBase.extend({
+    user_id: z.string(),
+    name: z.string().max(100),
+    description: z.string().max(1000).optional(),
+    target: ZSavedViewTarget,
+    // Required for log views, the asset whose logs are listed
+    asset_id: ZUuid.optional(),
+    params: ZSavedViewParams,
+});
+
+// Create saved view request - matches Go model.CreateSavedViewRequest
+export const ZCreateSavedViewRequest = z.object({
+    name: z.string().min(1).max(100),
+    description: z.string().max(1000).optional(),
+    target: ZSavedViewTarget,
+    asset_id: ZUuid.optional(),
+    params: ZSavedViewParams.optional(),
+});
+
+// Update saved view request - matches Go model.UpdateSavedViewRequest (all fields optional for PATCH)
+export const ZUpdateSavedViewRequest = z.object({
+    name: z.string().min(1).max(100).optional(),
+    description: z.string().max(1000).optional(),
+    asset_id: ZUuid.optional(),
+    params: ZSavedViewParams.optional(),
+});
+
+// Run saved view query parameters - matches Go model.RunSavedViewParams
+export const ZRunSavedViewParams = z.object({
+    limit: z.coerce.number().int().min(1).optional(),
+    offset: z.coerce.number().int().min(0).optional(),
+});
+
+// Saved view list response - matches Go model.SavedViewListResponse
+export const ZSavedViewListResponse = z.object({
+    views: z.array(ZSavedView),
+    total: z.number().int(),
+});
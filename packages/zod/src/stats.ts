@@ -0,0 +1,128 @@
+import { z } from "zod";
+import { ZTimestamp, ZUuid } from "./common.js";
+import { ZTag } from "./tag.js";
+
+/**
+ * Dashboard statistics, report and activity Zod schemas matching Go models
+ */
+
+// Stats query parameters - matches Go model.StatsQueryParams
+export const ZStatsQueryParams = z.object({
+    days: z.coerce.number().int().min(1).max(365).optional(),
+    limit: z.coerce.number().int().min(1).max(50).optional(),
+});
+
+// Count per key - matches Go model.CountBucket
+export const ZCountBucket = z.object({
+    key: z.string(),
+    count: z.number().int(),
+});
+
+// Asset counts - matches Go model.AssetCountStats
+export const ZAssetCountStats = z.object({
+    total: z.number().int(),
+    by_type: z.array(ZCountBucket),
+    by_status: z.array(ZCountBucket),
+});
+
+// Count per day as YYYY-MM-DD - matches Go model.DailyCount
+export const ZDailyCount = z.object({
+    date: z.string(),
+    count: z.number().int(),
+});
+
+// Asset by recent log count - matches Go model.ActiveAsset
+export const ZActiveAsset = z.object({
+    id: ZUuid,
+    name: z.string(),
+    type: z.string().optional(),
+    log_count: z.number().int(),
+    last_log_at: ZTimestamp,
+});
+
+// Storage used - matches Go model.StorageStats
+export const ZStorageStats = z.object({
+    asset_bytes: z.number().int(),
+    log_bytes: z.number().int(),
+    total_bytes: z.number().int(),
+});
+
+// Dashboard statistics - matches Go model.DashboardStats
+export const ZDashboardStats = z.object({
+    days: z.number().int(),
+    assets: ZAssetCountStats,
+    logs_per_day: z.array(ZDailyCount),
+    top_tags: z.array(ZTag),
+    most_active_assets: z.array(ZActiveAsset),
+    storage: ZStorageStats,
+    generated_at: ZTimestamp,
+});
+
+// Stale asset report query parameters - matches Go model.StaleAssetQueryParams
+export const ZStaleAssetQueryParams = z.object({
+    days: z.coerce.number().int().min(1).max(3650).optional(),
+    limit: z.coerce.number().int().min(1).max(1000).optional(),
+    view_id: ZUuid.optional(),
+});
+
+// Stale asset - matches Go model.StaleAsset
+export const ZStaleAsset = z.object({
+    id: ZUuid,
+    name: z.string(),
+    type: z.string().optional(),
+    hostname: z.string().optional(),
+    updated_at: ZTimestamp,
+    last_log_at: ZTimestamp.optional(),
+    last_activity_at: ZTimestamp,
+    days_stale: z.number().int(),
+});
+
+// Stale asset report - matches Go model.StaleAssetReport
+export const ZStaleAssetReport = z.object({
+    days: z.number().int(),
+    assets: z.array(ZStaleAsset),
+    total: z.number().int(),
+});
+
+// Activity heatmap query parameters - matches Go model.ActivityHeatmapQueryParams
+export const ZActivityHeatmapQueryParams = z.object({
+    year: z.coerce.number().int().min(1970).max(9999).optional(),
+    tz: z.string().max(64).optional(),
+});
+
+// Activity heatmap - matches Go model.ActivityHeatmap
+export const ZActivityHeatmap = z.object({
+    tz: z.string(),
+    start: z.string(),
+    end: z.string(),
+    total: z.number().int(),
+    max: z.number().int(),
+    days: z.array(ZDailyCount),
+});
+
+// Activity timeline query parameters - matches Go model.ActivityTimelineQueryParams
+export const ZActivityTimelineQueryParams = z.object({
+    start: z.string().datetime().optional(),
+    end: z.string().datetime().optional(),
+    interval: z.enum(["hour", "day", "week", "month"]).optional(),
+    group_by: z.enum(["tag", "asset_type"]).optional(),
+    asset_id: ZUuid.optional(),
+    tz: z.string().max(64).optional(),
+});
+
+// Activity timeline bucket - matches Go model.ActivityBucket
+export const ZActivityBucket = z.object({
+    start: ZTimestamp,
+    total: z.number().int(),
+    groups: z.record(z.number().int()),
+});
+
+// Activity timeline - matches Go model.ActivityTimeline
+export const ZActivityTimeline = z.object({
+    start: ZTimestamp,
+    end: ZTimestamp,
+    interval: z.enum(["hour", "day", "week", "month"]),
+    group_by: z.enum(["tag", "asset_type"]),
+    tz: z.string(),
+    buckets: z.array(ZActivityBucket),
+});
@@ -0,0 +1,47 @@
+import { z } from "zod";
+import { ZTimestamp } from "./common.js";
+
+/**
+ * Tag Zod schemas matching Go models
+ */
+
+// Tag with usage counts across logs and assets - matches Go model.Tag
+export const ZTag = z.object({
+    name: z.string(),
+    count: z.number().int(),
+    log_count: z.number().int(),
+    asset_count: z.number().int(),
+    last_used_at: ZTimestamp,
+});
+
+// Tag query parameters - matches Go model.TagQueryParams
+export const ZTagQueryParams = z.object({
+    prefix: z.string().max(50).optional(),
+    limit: z.coerce.number().int().min(1).max(1000).optional(),
+});
+
+// Tag list response - matches Go model.TagListResponse
+export const ZTagListResponse = z.object({
+    tags: z.array(ZTag),
+    total: z.number().int(),
+});
+
+// Rename tag request - matches Go model.RenameTagRequest
+export const ZRenameTagRequest = z.object({
+    from: z.string().min(1).max(50),
+    to: z.string().min(1).max(50),
+});
+
+// Merge tags request - matches Go model.MergeTagsRequest
+export const ZMergeTagsRequest = z.object({
+    sources: z.array(z.string().max(50)).min(1).max(50),
+    target: z.string().min(1).max(50),
+});
+
+// Rename or merge result - matches Go model.TagOperationResponse
+export const ZTagOperationResponse = z.object({
+    tag: z.string(),
+    logs_updated: z.number().int(),
+    assets_updated: z.number().int(),
+    templates_updated: z.number().int(),
+});
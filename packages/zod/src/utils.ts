@@ -18,3 +18,8 @@ export const schemaWithPagination = <T>(
     limit: z.number(),
     totalPages: z.number(),
   });
+
+// File upload field - the OpenAPI generator rewrites it to a binary string
+export const ZFile = z.object({
+  type: z.literal("file"),
+});